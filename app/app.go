@@ -2,12 +2,14 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -36,6 +38,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	"github.com/cosmos/cosmos-sdk/x/staking"
@@ -47,8 +50,12 @@ import (
 	"github.com/cosmos/ibc-go/v8/modules/apps/transfer"
 	ibc "github.com/cosmos/ibc-go/v8/modules/core"
 
+	ingestadmin "github.com/osmosis-labs/osmosis/v30/ingest/common/admin"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/checkpoint"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/lifecycle"
 	"github.com/osmosis-labs/osmosis/v30/ingest/common/poolextractor"
 	"github.com/osmosis-labs/osmosis/v30/ingest/common/pooltracker"
+	ingeststatus "github.com/osmosis-labs/osmosis/v30/ingest/common/status"
 	"github.com/osmosis-labs/osmosis/v30/ingest/common/writelistener"
 	"github.com/osmosis-labs/osmosis/v30/ingest/indexer"
 	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
@@ -56,12 +63,15 @@ import (
 	indexerwritelistener "github.com/osmosis-labs/osmosis/v30/ingest/indexer/service/writelistener"
 	"github.com/osmosis-labs/osmosis/v30/ingest/sqs"
 	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	poolsapr "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/apr"
+	poolsfilter "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/filter"
 	poolstransformer "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/transformer"
 
 	sqsservice "github.com/osmosis-labs/osmosis/v30/ingest/sqs/service"
 	concentratedtypes "github.com/osmosis-labs/osmosis/v30/x/concentrated-liquidity/types"
 	cosmwasmpooltypes "github.com/osmosis-labs/osmosis/v30/x/cosmwasmpool/types"
 	gammtypes "github.com/osmosis-labs/osmosis/v30/x/gamm/types"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
 
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
 	commonservice "github.com/osmosis-labs/osmosis/v30/ingest/common/service"
@@ -93,6 +103,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/rakyll/statik/fs"
 	"github.com/spf13/cast"
+	"google.golang.org/grpc"
 
 	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/cosmos/cosmos-sdk/baseapp"
@@ -222,6 +233,19 @@ type OsmosisApp struct {
 	homePath     string
 
 	checkTxHandler checktx.CheckTx
+
+	// ingestServices are the ingest streaming services shut down on Close.
+	ingestServices []lifecycle.Shutdowner
+	// ingestShutdownTimeout is the time the ingest services are given to shut down on Close.
+	ingestShutdownTimeout time.Duration
+	// cancelIngest cancels the lifecycle context of the ingest services.
+	cancelIngest context.CancelFunc
+	// ingestStatusServer is the status server of the ingest sinks. Nil if disabled.
+	ingestStatusServer *http.Server
+	// ingestAdminServer is the gRPC server of the ingest admin service. Nil if disabled.
+	ingestAdminServer *grpc.Server
+	// ingestCheckpointStore is the checkpoint store of the ingest services. Nil if no service checkpoints.
+	ingestCheckpointStore commondomain.CheckpointStore
 }
 
 // init sets DefaultNodeHome to default osmosisd install location.
@@ -360,6 +384,49 @@ func NewOsmosisApp(
 		nodeStatusChecker = commonservice.NewNodeStatusChecker(rpcAddress)
 	}
 
+	// Open the checkpoint store shared by the ingest services that checkpoint
+	// their progress for warm restart. It also persists the volume snapshots of the SQS pool APR computers.
+	var checkpointStore commondomain.CheckpointStore
+	if (sqsConfig.IsEnabled && (sqsConfig.CheckpointEnabled || sqsConfig.APREnabled)) || (indexerConfig.IsEnabled && indexerConfig.CheckpointEnabled) {
+		var err error
+		checkpointStore, err = checkpoint.OpenStore(dataDir)
+		if err != nil {
+			panic(err)
+		}
+		app.ingestCheckpointStore = checkpointStore
+	}
+
+	// Start the status server of the ingest sinks if it is enabled.
+	ingestStatusConfig := ingeststatus.NewConfigFromOptions(appOpts)
+	var ingestStatusRegistry *ingeststatus.Registry
+	if ingestStatusConfig.StatusAddress != "" && (sqsConfig.IsEnabled || indexerConfig.IsEnabled) {
+		ingestStatusRegistry = ingeststatus.NewRegistry(ingestStatusConfig.StatusMaxLagBlocks, ingestStatusConfig.StatusMaxConsecutiveFailures)
+		statusServer, err := ingeststatus.StartServer(ingestStatusConfig.StatusAddress, ingestStatusRegistry, logger)
+		if err != nil {
+			panic(fmt.Sprintf("failed to start ingest status server: %s", err))
+		}
+		app.ingestStatusServer = statusServer
+	}
+
+	// Start the admin server of the ingest services if it is enabled.
+	// The block process strategy managers are registered as the services are created.
+	ingestAdminConfig := ingestadmin.NewConfigFromOptions(appOpts)
+	var ingestAdminServer *ingestadmin.Server
+	if ingestAdminConfig.AdminAddress != "" && (sqsConfig.IsEnabled || indexerConfig.IsEnabled) {
+		ingestAdminServer = ingestadmin.NewServer()
+		adminGRPCServer, err := ingestAdminServer.Start(ingestAdminConfig.AdminAddress, logger)
+		if err != nil {
+			panic(fmt.Sprintf("failed to start ingest admin server: %s", err))
+		}
+		app.ingestAdminServer = adminGRPCServer
+	}
+
+	// The lifecycle context of the ingest services is cancelled on Close, once they are
+	// shut down or the shutdown timeout elapsed, aborting the publishes still in flight.
+	ingestCtx, cancelIngest := context.WithCancel(context.Background())
+	app.cancelIngest = cancelIngest
+	app.ingestShutdownTimeout = lifecycle.NewConfigFromOptions(appOpts).ShutdownTimeout()
+
 	streamingServices := []storetypes.ABCIListener{}
 
 	// Initialize the SQS ingester if it is enabled.
@@ -374,13 +441,32 @@ func NewOsmosisApp(
 			ConcentratedKeeper: app.ConcentratedLiquidityKeeper,
 		}
 
+		// Create the filter of the pools pushed to SQS.
+		poolFilter, err := poolsfilter.New(sqsConfig.PoolFilterConfig())
+		if err != nil {
+			panic(fmt.Sprintf("failed to create sqs pool filter: %s", err))
+		}
+
 		// Create sqs grpc client
 		sqsGRPCClients := make([]domain.SQSGRPClient, len(sqsConfig.GRPCIngestAddress))
 		for i, grpcIngestAddress := range sqsConfig.GRPCIngestAddress {
-			sqsGRPCClients[i] = sqsservice.NewGRPCCLient(grpcIngestAddress, sqsConfig.GRPCIngestMaxCallSizeBytes, appCodec)
+			// Each sink is checkpointed separately since they may fall behind independently.
+			var checkpointer commondomain.Checkpointer
+			if sqsConfig.CheckpointEnabled {
+				checkpointer, err = checkpoint.NewCheckpointer(checkpointStore, "sqs/"+grpcIngestAddress, sqsConfig.CheckpointMaxAgeBlocks)
+				if err != nil {
+					panic(fmt.Sprintf("failed to load sqs checkpoint for %s: %s", grpcIngestAddress, err))
+				}
+			}
+
+			sqsGRPCClient, err := sqsservice.NewGRPCCLient(grpcIngestAddress, sqsConfig, appCodec, checkpointer)
+			if err != nil {
+				panic(fmt.Sprintf("failed to create sqs grpc client for %s: %s", grpcIngestAddress, err))
+			}
+			sqsGRPCClients[i] = sqsGRPCClient
 		}
 
-		for _, grpcClient := range sqsGRPCClients {
+		for i, grpcClient := range sqsGRPCClients {
 			// Create pool tracker that tracks pool updates
 			// made by the write listenetrs.
 			poolTracker := pooltracker.NewMemory()
@@ -388,8 +474,27 @@ func NewOsmosisApp(
 			// Create pool extractor
 			poolExtractor := poolextractor.New(sqsKeepers, poolTracker)
 
+			// Create the computer of the pool APR and fees data if enabled.
+			// Each transformer has its own computer since the computer tracks the refresh height.
+			// Its volume snapshots are persisted per sink so that the volume windows survive restarts.
+			var poolAPRComputer domain.PoolAPRComputer
+			if sqsConfig.APREnabled {
+				poolAPRComputer, err = poolsapr.NewWithVolumeSnapshotStore(commondomain.PoolAPRKeepers{
+					PoolManagerKeeper:    app.PoolManagerKeeper,
+					IncentivesKeeper:     app.IncentivesKeeper,
+					PoolIncentivesKeeper: app.PoolIncentivesKeeper,
+					SuperfluidKeeper:     app.SuperfluidKeeper,
+					MintKeeper:           app.MintKeeper,
+					EpochsKeeper:         app.EpochsKeeper,
+					StakingKeeper:        app.StakingKeeper,
+				}, sqsConfig.APRRefreshIntervalBlocks, checkpointStore, "sqs/"+sqsConfig.GRPCIngestAddress[i])
+				if err != nil {
+					panic(fmt.Sprintf("failed to create sqs pool APR computer: %s", err))
+				}
+			}
+
 			// Create pools ingester
-			poolsTransformer := poolstransformer.NewPoolTransformer(sqsKeepers, sqs.DefaultUSDCUOSMOPool)
+			poolsTransformer := poolstransformer.NewPoolTransformer(sqsKeepers, sqs.DefaultUSDCUOSMOPool, poolFilter, poolAPRComputer)
 
 			blockProcessStrategyManager := commondomain.NewBlockProcessStrategyManager()
 			// Create write listeners for the SQS service.
@@ -402,17 +507,33 @@ func NewOsmosisApp(
 				StoreKeyMap:    storeKeyMap,
 			}
 
-			sqsStreamingService := sqsservice.New(blockUpdatesProcessUtils, poolExtractor, poolsTransformer, poolTracker, grpcClient, blockProcessStrategyManager, nodeStatusChecker)
+			sinkName := "sqs/" + sqsConfig.GRPCIngestAddress[i]
+
+			var statusRecorder commondomain.StatusRecorder
+			if ingestStatusRegistry != nil {
+				statusRecorder = ingestStatusRegistry.Sink(sinkName)
+			}
+
+			if ingestAdminServer != nil {
+				ingestAdminServer.Register(ingestadmin.TargetSQS, sinkName, blockProcessStrategyManager)
+			}
+
+			sqsStreamingService := sqsservice.New(blockUpdatesProcessUtils, poolExtractor, poolsTransformer, poolTracker, grpcClient, blockProcessStrategyManager, nodeStatusChecker, statusRecorder, logger)
 			streamingServices = append(streamingServices, sqsStreamingService)
+			app.ingestServices = append(app.ingestServices, sqsStreamingService)
 		}
 	}
 
 	// initialize indexer if enabled
 	if indexerConfig.IsEnabled {
-		indexerPublisher := indexerConfig.Initialize()
+		if indexerConfig.PubSubSpoolDir == "" {
+			indexerConfig.PubSubSpoolDir = filepath.Join(dataDir, "indexer-pubsub-spool")
+		}
 
-		// TODO: handle graceful shutdown
-		pubSubCtx := context.Background()
+		indexerPublisher, err := indexerConfig.Initialize()
+		if err != nil {
+			panic(fmt.Sprintf("failed to initialize indexer publisher: %s", err))
+		}
 
 		// Create cold start manager
 		blockProcessStrategyManager := commondomain.NewBlockProcessStrategyManager()
@@ -422,7 +543,7 @@ func NewOsmosisApp(
 		poolTracker := pooltracker.NewMemory()
 
 		// Create write listeners for the indexer service.
-		writeListeners, storeKeyMap := getIndexerServiceWriteListeners(pubSubCtx, app, appCodec, poolTracker, app.WasmKeeper, indexerPublisher, blockProcessStrategyManager)
+		writeListeners, storeKeyMap := getIndexerServiceWriteListeners(ingestCtx, app, appCodec, poolTracker, app.WasmKeeper, indexerPublisher, blockProcessStrategyManager)
 
 		// Create keepers for the indexer service.
 		keepers := indexerdomain.Keepers{
@@ -446,10 +567,40 @@ func NewOsmosisApp(
 			StoreKeyMap:    storeKeyMap,
 		}
 		poolExtractor := poolextractor.New(poolKeepers, poolTracker)
-		indexerStreamingService := indexerservice.New(blockUpdatesProcessUtils, blockProcessStrategyManager, indexerPublisher, storeKeyMap, poolExtractor, poolTracker, keepers, app.GetTxConfig().TxDecoder(), nodeStatusChecker, logger)
+
+		var checkpointer commondomain.Checkpointer
+		if indexerConfig.CheckpointEnabled {
+			checkpointer, err = checkpoint.NewCheckpointer(checkpointStore, "indexer", indexerConfig.CheckpointMaxAgeBlocks)
+			if err != nil {
+				panic(fmt.Sprintf("failed to load indexer checkpoint: %s", err))
+			}
+		}
+
+		var statusRecorder commondomain.StatusRecorder
+		if ingestStatusRegistry != nil {
+			statusRecorder = ingestStatusRegistry.Sink("indexer")
+		}
+
+		if ingestAdminServer != nil {
+			ingestAdminServer.Register(ingestadmin.TargetIndexer, "indexer", blockProcessStrategyManager)
+		}
+
+		// The published pool states are instrumented with their balances and TVL as for SQS.
+		var poolsTransformer domain.PoolsTransformer
+		if indexerConfig.IsPoolStatePublished() {
+			poolsTransformer = poolstransformer.NewPoolTransformer(poolKeepers, sqs.DefaultUSDCUOSMOPool, nil, nil)
+		}
+
+		eventFilter, err := indexerdomain.ParseEventFilter(indexerConfig.EventAllowlist)
+		if err != nil {
+			panic(fmt.Sprintf("failed to parse indexer event allowlist: %s", err))
+		}
+
+		indexerStreamingService := indexerservice.New(blockUpdatesProcessUtils, blockProcessStrategyManager, indexerPublisher, storeKeyMap, poolExtractor, poolTracker, poolsTransformer, keepers, app.GetTxConfig().TxDecoder(), eventFilter, nodeStatusChecker, checkpointer, statusRecorder, logger)
 
 		// Register the SQS streaming service with the app.
 		streamingServices = append(streamingServices, indexerStreamingService)
+		app.ingestServices = append(app.ingestServices, indexerStreamingService)
 	}
 
 	// Register the SQS streaming service with the app.
@@ -707,6 +858,18 @@ func NewOsmosisApp(
 func getSQSServiceWriteListeners(app *OsmosisApp, appCodec codec.Codec, blockPoolUpdateTracker domain.BlockPoolUpdateTracker, wasmkeeper *wasmkeeper.Keeper) (map[storetypes.StoreKey][]commondomain.WriteListener, map[string]storetypes.StoreKey) {
 	writeListeners, storeKeyMap := getPoolWriteListeners(app, appCodec, blockPoolUpdateTracker, wasmkeeper)
 
+	// Add write listeners for the taker fee overrides and the poolmanager params
+	// so that taker fee changes are pushed in the same block.
+	writeListeners[app.GetKey(poolmanagertypes.StoreKey)] = []commondomain.WriteListener{
+		writelistener.NewPoolManager(blockPoolUpdateTracker),
+	}
+	writeListeners[app.GetKey(paramstypes.StoreKey)] = []commondomain.WriteListener{
+		writelistener.NewPoolManagerParams(blockPoolUpdateTracker),
+	}
+
+	storeKeyMap[poolmanagertypes.StoreKey] = app.GetKey(poolmanagertypes.StoreKey)
+	storeKeyMap[paramstypes.StoreKey] = app.GetKey(paramstypes.StoreKey)
+
 	// Register all applicable keys as listeners
 	registerStoreKeys(app, storeKeyMap)
 
@@ -717,10 +880,9 @@ func getSQSServiceWriteListeners(app *OsmosisApp, appCodec codec.Codec, blockPoo
 func getIndexerServiceWriteListeners(ctx context.Context, app *OsmosisApp, appCodec codec.Codec, blockPoolUpdateTracker domain.BlockPoolUpdateTracker, wasmkeeper *wasmkeeper.Keeper, client indexerdomain.Publisher, blockProcessStrategyManager commondomain.BlockProcessStrategyManager) (map[storetypes.StoreKey][]commondomain.WriteListener, map[string]storetypes.StoreKey) {
 	writeListeners, storeKeyMap := getPoolWriteListeners(app, appCodec, blockPoolUpdateTracker, wasmkeeper)
 
-	// Add write listeners for the bank module.
-	writeListeners[app.GetKey(banktypes.ModuleName)] = []commondomain.WriteListener{
-		indexerwritelistener.NewBank(ctx, client, blockProcessStrategyManager),
-	}
+	// Add write listeners for the bank module, keeping the cosmwasm pool balance listener of the pools.
+	bankStoreKey := app.GetKey(banktypes.ModuleName)
+	writeListeners[bankStoreKey] = append(writeListeners[bankStoreKey], indexerwritelistener.NewBank(ctx, client, blockProcessStrategyManager))
 
 	storeKeyMap[banktypes.ModuleName] = app.GetKey(banktypes.ModuleName)
 
@@ -1080,6 +1242,63 @@ func (app *OsmosisApp) SetCheckTx(handler checktx.CheckTx) {
 	app.checkTxHandler = handler
 }
 
+// Close shuts the ingest services down, waiting for their blocks in flight and flushing their sinks
+// within the configured shutdown timeout, then closes the application.
+func (app *OsmosisApp) Close() error {
+	var errs []error
+
+	// isIngestShutDown is false if any ingest service failed to shut down, e.g. on timeout,
+	// in which case it may still be writing to the checkpoint store.
+	isIngestShutDown := true
+
+	if app.cancelIngest != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), app.ingestShutdownTimeout)
+
+		shutdownErrs := make([]error, len(app.ingestServices))
+		var wg sync.WaitGroup
+		for i, service := range app.ingestServices {
+			wg.Add(1)
+			go func(i int, service lifecycle.Shutdowner) {
+				defer wg.Done()
+				shutdownErrs[i] = service.Shutdown(ctx)
+			}(i, service)
+		}
+		wg.Wait()
+
+		cancel()
+		app.cancelIngest()
+
+		errs = append(errs, shutdownErrs...)
+		isIngestShutDown = errors.Join(shutdownErrs...) == nil
+	}
+
+	// The servers and the checkpoint store are only released once the ingest services are shut down,
+	// so that the final checkpoints are saved.
+	if app.ingestAdminServer != nil {
+		app.ingestAdminServer.Stop()
+	}
+
+	if app.ingestStatusServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), app.ingestShutdownTimeout)
+		errs = append(errs, app.ingestStatusServer.Shutdown(ctx))
+		cancel()
+	}
+
+	// The checkpoint store is left open if a service is possibly still running rather than
+	// closing it from under the service. The checkpoints are then loaded as saved so far on restart.
+	if app.ingestCheckpointStore != nil {
+		if isIngestShutDown {
+			errs = append(errs, app.ingestCheckpointStore.Close())
+		} else {
+			app.Logger().Error("skipped closing the ingest checkpoint store since the ingest services failed to shut down")
+		}
+	}
+
+	errs = append(errs, app.BaseApp.Close())
+
+	return errors.Join(errs...)
+}
+
 // MakeCodecs returns the application codec and a legacy Amino codec.
 func MakeCodecs() (codec.Codec, *codec.LegacyAmino) {
 	config := MakeEncodingConfig()
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/admin"
+	prototypes "github.com/osmosis-labs/osmosis/v30/ingest/types/proto/types"
+)
+
+const (
+	flagIngestAdminAddress = "admin-address"
+
+	ingestAdminTimeout = 10 * time.Second
+)
+
+// IngestCmd returns the commands that operate the ingest services of a node.
+func IngestCmd(appCreator servertypes.AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ingest",
+		Short: "Operate the SQS and indexer ingest services",
+	}
+
+	cmd.AddCommand(
+		ingestAdminCmd("resync", "Push all data at the next block",
+			func(c prototypes.IngestAdminClient) ingestAdminCall { return c.Resync }),
+		ingestAdminCmd("pause", "Stop pushing until resumed, e.g. during SQS maintenance",
+			func(c prototypes.IngestAdminClient) ingestAdminCall { return c.Pause }),
+		ingestAdminCmd("resume", "Resume pushing. All data is pushed at the next block",
+			func(c prototypes.IngestAdminClient) ingestAdminCall { return c.Resume }),
+		ingestDumpPoolsCmd(appCreator),
+		ingestIndexerSchemasCmd(),
+	)
+
+	return cmd
+}
+
+// ingestAdminCall is a call of the ingest admin service.
+type ingestAdminCall func(ctx context.Context, in *prototypes.IngestAdminRequest, opts ...grpc.CallOption) (*prototypes.IngestAdminReply, error)
+
+// ingestAdminCmd returns a command that sends a request to the ingest admin service of a running node.
+func ingestAdminCmd(use, short string, getCall func(prototypes.IngestAdminClient) ingestAdminCall) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use + " [sqs|indexer]",
+		Short: short,
+		Long: fmt.Sprintf(`%s.
+Applies to both the SQS sinks and the indexer unless the target is given.
+Requires admin-address to be set in the [osmosis-ingest] section of app.toml of the running node.
+
+Example:
+	osmosisd ingest %s sqs --admin-address %s
+`, short, use, admin.DefaultAddress),
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: []string{admin.TargetSQS, admin.TargetIndexer},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			adminAddress, err := cmd.Flags().GetString(flagIngestAdminAddress)
+			if err != nil {
+				return err
+			}
+
+			if err := admin.ValidateLoopbackAddress(adminAddress); err != nil {
+				return err
+			}
+
+			var target string
+			if len(args) > 0 {
+				target = args[0]
+			}
+
+			conn, err := grpc.NewClient(adminAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), ingestAdminTimeout)
+			defer cancel()
+
+			reply, err := getCall(prototypes.NewIngestAdminClient(conn))(ctx, &prototypes.IngestAdminRequest{Target: target})
+			if err != nil {
+				return fmt.Errorf("ingest %s: %w", use, err)
+			}
+
+			cmd.Printf("Applied %s to: %s\n", use, strings.Join(reply.Sinks, ", "))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagIngestAdminAddress, admin.DefaultAddress, "address of the ingest admin service of the running node")
+
+	return cmd
+}
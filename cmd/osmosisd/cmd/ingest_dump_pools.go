@@ -0,0 +1,169 @@
+package cmd
+
+// DONTCOVER
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmoutils"
+	osmosis "github.com/osmosis-labs/osmosis/v30/app"
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/poolextractor"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/pooltracker"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs"
+	poolsdump "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/dump"
+	poolstransformer "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/transformer"
+)
+
+const (
+	flagDumpPoolsPoolIDs = "pool-ids"
+	flagDumpPoolsOutput  = "output"
+	flagDumpPoolsDiff    = "diff"
+)
+
+// ingestDumpPoolsCmd returns a command that runs the SQS pool transformer against the local state at a height.
+func ingestDumpPoolsCmd(appCreator servertypes.AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump-pools [height]",
+		Short: "Dump the pools and taker fees that would be pushed to SQS at a given height",
+		Long: `Dump the pools and taker fees that would be pushed to SQS at a given height as JSON.
+Runs the SQS pool extractor and transformer against the application state at the height without starting the node,
+which is useful for debugging the data of a sidecar query server. The pool filters and APR data are not applied.
+If a previous dump is given, the added, removed and changed pools and taker fees are printed instead.
+The node must not be running.
+
+Example:
+	osmosisd ingest dump-pools 16841115 --pool-ids 1,1066 --output pools-16841115.json
+	osmosisd ingest dump-pools 16841120 --pool-ids 1,1066 --diff pools-16841115.json
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			poolIDsStr, err := cmd.Flags().GetString(flagDumpPoolsPoolIDs)
+			if err != nil {
+				return err
+			}
+
+			var poolIDs []uint64
+			if poolIDsStr != "" {
+				poolIDs, err = osmoutils.ParseUint64SliceFromString(poolIDsStr, ",")
+				if err != nil {
+					return err
+				}
+			}
+
+			outputPath, err := cmd.Flags().GetString(flagDumpPoolsOutput)
+			if err != nil {
+				return err
+			}
+
+			diffPath, err := cmd.Flags().GetString(flagDumpPoolsDiff)
+			if err != nil {
+				return err
+			}
+
+			// Read the previous dump first so that a bad path fails before loading the state.
+			var previousDump poolsdump.Dump
+			if diffPath != "" {
+				previousDump, err = poolsdump.ReadFile(diffPath)
+				if err != nil {
+					return err
+				}
+			}
+
+			dump, err := dumpPoolsAtHeight(server.GetServerContextFromCmd(cmd), appCreator, height, poolIDs)
+			if err != nil {
+				return err
+			}
+
+			var output any = dump
+			if diffPath != "" {
+				output, err = poolsdump.NewDiff(previousDump, dump)
+				if err != nil {
+					return err
+				}
+			}
+
+			bz, err := json.MarshalIndent(output, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			if outputPath == "" {
+				cmd.Println(string(bz))
+				return nil
+			}
+
+			return os.WriteFile(outputPath, bz, 0o644)
+		},
+	}
+
+	cmd.Flags().String(flagDumpPoolsPoolIDs, "", "comma-separated IDs of the dumped pools. All pools are dumped if empty")
+	cmd.Flags().String(flagDumpPoolsOutput, "", "file to write the output to. Printed to stdout if empty")
+	cmd.Flags().String(flagDumpPoolsDiff, "", "previous dump to diff the pools and taker fees against")
+
+	return cmd
+}
+
+// dumpPoolsAtHeight loads the application state at the given height and returns the output of the SQS pool transformer.
+// The state is read through a cache multistore so that nothing is written to the application DB.
+func dumpPoolsAtHeight(svrCtx *server.Context, appCreator servertypes.AppCreator, height int64, poolIDs []uint64) (poolsdump.Dump, error) {
+	db, err := openDB(svrCtx.Config.RootDir, server.GetAppDBBackend(svrCtx.Viper))
+	if err != nil {
+		return poolsdump.Dump{}, fmt.Errorf("error opening DB, make sure osmosisd is not running when calling this command: %w", err)
+	}
+	defer db.Close()
+
+	// Do not start the ingest services of the node configured in app.toml.
+	svrCtx.Viper.Set("osmosis-sqs.is-enabled", false)
+	svrCtx.Viper.Set("osmosis-indexer.is-enabled", false)
+	svrCtx.Viper.Set("osmosis-ingest.admin-address", "")
+
+	app, ok := appCreator(svrCtx.Logger, db, nil, svrCtx.Viper).(*osmosis.OsmosisApp)
+	if !ok {
+		return poolsdump.Dump{}, fmt.Errorf("expected *OsmosisApp")
+	}
+
+	cms, err := app.CommitMultiStore().CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return poolsdump.Dump{}, fmt.Errorf("failed to load state at height %d: %w", height, err)
+	}
+
+	ctx := sdk.NewContext(cms, cmtproto.Header{Height: height}, false, svrCtx.Logger)
+
+	keepers := commondomain.PoolExtractorKeepers{
+		GammKeeper:         app.GAMMKeeper,
+		CosmWasmPoolKeeper: app.CosmwasmPoolKeeper,
+		WasmKeeper:         app.WasmKeeper,
+		BankKeeper:         app.BankKeeper,
+		ProtorevKeeper:     app.ProtoRevKeeper,
+		PoolManagerKeeper:  app.PoolManagerKeeper,
+		ConcentratedKeeper: app.ConcentratedLiquidityKeeper,
+	}
+
+	blockPools, _, err := poolextractor.New(keepers, pooltracker.NewMemory()).ExtractAll(ctx)
+	if err != nil {
+		return poolsdump.Dump{}, fmt.Errorf("failed to extract pools: %w", err)
+	}
+
+	pools, _, takerFees, err := poolstransformer.NewPoolTransformer(keepers, sqs.DefaultUSDCUOSMOPool, nil, nil).Transform(ctx, blockPools)
+	if err != nil {
+		return poolsdump.Dump{}, fmt.Errorf("failed to transform pools: %w", err)
+	}
+
+	return poolsdump.New(app.AppCodec(), height, pools, takerFees, poolIDs)
+}
@@ -0,0 +1,259 @@
+package cmd
+
+// DONTCOVER
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"cosmossdk.io/log"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtcfg "github.com/cometbft/cometbft/config"
+	sm "github.com/cometbft/cometbft/state"
+	tmstore "github.com/cometbft/cometbft/store"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	osmosis "github.com/osmosis-labs/osmosis/v30/app"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/checkpoint"
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/poolextractor"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/pooltracker"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer"
+	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	indexerservice "github.com/osmosis-labs/osmosis/v30/ingest/indexer/service"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs"
+	sqsdomain "github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	poolstransformer "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/transformer"
+)
+
+const (
+	flagIndexerBackfillFrom = "from"
+	flagIndexerBackfillTo   = "to"
+)
+
+// IndexerCmd returns the commands that operate the indexer of a node.
+func IndexerCmd(appCreator servertypes.AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "indexer",
+		Short: "Operate the indexer ingest service",
+	}
+
+	cmd.AddCommand(
+		indexerBackfillCmd(appCreator),
+	)
+
+	return cmd
+}
+
+// indexerBackfillCmd returns a command that publishes a range of historical blocks with the indexer.
+func indexerBackfillCmd(appCreator servertypes.AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Publish a range of historical blocks with the indexer",
+		Long: `Publish a range of historical blocks with the indexer configured in the [osmosis-indexer] section of app.toml,
+e.g. for a range during which the indexer was disabled or misconfigured.
+The blocks are read from the blockstore and their results from the state DB, and the transactions, the pairs of the
+created pools and the state of the pools referenced by the events are published against the application state
+at every height, which requires an archive node. The token supplies are not published.
+The progress is checkpointed after every block, so running the same command again resumes an interrupted backfill.
+The node must not be running.
+
+Example:
+	osmosisd indexer backfill --from 16841115 --to 16842115
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := cmd.Flags().GetInt64(flagIndexerBackfillFrom)
+			if err != nil {
+				return err
+			}
+
+			to, err := cmd.Flags().GetInt64(flagIndexerBackfillTo)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			svrCtx := server.GetServerContextFromCmd(cmd)
+
+			backfiller, closeBackfiller, err := newIndexerBackfiller(svrCtx, appCreator)
+			if err != nil {
+				return err
+			}
+			defer closeBackfiller()
+
+			return backfiller.Run(ctx, from, to)
+		},
+	}
+
+	cmd.Flags().Int64(flagIndexerBackfillFrom, 0, "first height to publish")
+	cmd.Flags().Int64(flagIndexerBackfillTo, 0, "last height to publish")
+	_ = cmd.MarkFlagRequired(flagIndexerBackfillFrom)
+	_ = cmd.MarkFlagRequired(flagIndexerBackfillTo)
+
+	return cmd
+}
+
+// newIndexerBackfiller opens the databases of the node and returns the indexer backfiller
+// together with the function closing them.
+func newIndexerBackfiller(svrCtx *server.Context, appCreator servertypes.AppCreator) (*indexerservice.Backfiller, func(), error) {
+	indexerConfig := indexer.NewConfigFromOptions(svrCtx.Viper)
+	if !indexerConfig.IsEnabled {
+		return nil, nil, errors.New("the indexer must be enabled in the [osmosis-indexer] section of app.toml")
+	}
+
+	dataDir := filepath.Join(svrCtx.Config.RootDir, "data")
+	if indexerConfig.PubSubSpoolDir == "" {
+		indexerConfig.PubSubSpoolDir = filepath.Join(dataDir, "indexer-pubsub-spool")
+	}
+
+	var closers []io.Closer
+	closeAll := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			if err := closers[i].Close(); err != nil {
+				svrCtx.Logger.Error("Error closing indexer backfill resources", "err", err)
+			}
+		}
+	}
+
+	db, err := openDB(svrCtx.Config.RootDir, server.GetAppDBBackend(svrCtx.Viper))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening DB, make sure osmosisd is not running when calling this command: %w", err)
+	}
+	closers = append(closers, db)
+
+	blockStoreDB, err := cmtcfg.DefaultDBProvider(&cmtcfg.DBContext{ID: "blockstore", Config: svrCtx.Config})
+	if err != nil {
+		closeAll()
+		return nil, nil, err
+	}
+	blockStore := tmstore.NewBlockStore(blockStoreDB)
+	closers = append(closers, blockStore)
+
+	stateDB, err := cmtcfg.DefaultDBProvider(&cmtcfg.DBContext{ID: "state", Config: svrCtx.Config})
+	if err != nil {
+		closeAll()
+		return nil, nil, err
+	}
+	// The results are only read, so they are never discarded regardless of config.toml.
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{DiscardABCIResponses: false})
+	closers = append(closers, stateStore)
+
+	checkpointStore, err := checkpoint.OpenStore(dataDir)
+	if err != nil {
+		closeAll()
+		return nil, nil, err
+	}
+	closers = append(closers, checkpointStore)
+
+	// Do not start the ingest services of the node configured in app.toml.
+	svrCtx.Viper.Set("osmosis-sqs.is-enabled", false)
+	svrCtx.Viper.Set("osmosis-indexer.is-enabled", false)
+	svrCtx.Viper.Set("osmosis-ingest.admin-address", "")
+
+	app, ok := appCreator(svrCtx.Logger, db, nil, svrCtx.Viper).(*osmosis.OsmosisApp)
+	if !ok {
+		closeAll()
+		return nil, nil, fmt.Errorf("expected *OsmosisApp")
+	}
+
+	publisher, err := indexerConfig.Initialize()
+	if err != nil {
+		closeAll()
+		return nil, nil, fmt.Errorf("failed to initialize indexer publisher: %w", err)
+	}
+	if closer, ok := publisher.(io.Closer); ok {
+		closers = append(closers, closer)
+	}
+
+	eventFilter, err := indexerdomain.ParseEventFilter(indexerConfig.EventAllowlist)
+	if err != nil {
+		closeAll()
+		return nil, nil, fmt.Errorf("failed to parse indexer event allowlist: %w", err)
+	}
+
+	keepers := indexerdomain.Keepers{
+		BankKeeper:        app.BankKeeper,
+		PoolManagerKeeper: app.PoolManagerKeeper,
+	}
+
+	poolKeepers := commondomain.PoolExtractorKeepers{
+		GammKeeper:         app.GAMMKeeper,
+		CosmWasmPoolKeeper: app.CosmwasmPoolKeeper,
+		WasmKeeper:         app.WasmKeeper,
+		BankKeeper:         app.BankKeeper,
+		ProtorevKeeper:     app.ProtoRevKeeper,
+		PoolManagerKeeper:  app.PoolManagerKeeper,
+		ConcentratedKeeper: app.ConcentratedLiquidityKeeper,
+	}
+
+	var poolsTransformer sqsdomain.PoolsTransformer
+	if indexerConfig.IsPoolStatePublished() {
+		poolsTransformer = poolstransformer.NewPoolTransformer(poolKeepers, sqs.DefaultUSDCUOSMOPool, nil, nil)
+	}
+
+	poolTracker := pooltracker.NewMemory()
+
+	loader := &indexerBackfillBlockLoader{
+		app:        app,
+		blockStore: blockStore,
+		stateStore: stateStore,
+		logger:     svrCtx.Logger,
+	}
+
+	backfiller := indexerservice.NewBackfiller(publisher, poolextractor.New(poolKeepers, poolTracker), poolTracker, poolsTransformer, keepers, app.GetTxConfig().TxDecoder(), eventFilter, loader, checkpointStore, svrCtx.Logger)
+
+	return backfiller, closeAll, nil
+}
+
+// indexerBackfillBlockLoader loads the historical blocks from the blockstore and the state DB of the node
+// and the application state at their height.
+type indexerBackfillBlockLoader struct {
+	app        *osmosis.OsmosisApp
+	blockStore *tmstore.BlockStore
+	stateStore sm.Store
+	logger     log.Logger
+}
+
+var _ indexerservice.BackfillBlockLoader = &indexerBackfillBlockLoader{}
+
+// LoadBlock implements indexerservice.BackfillBlockLoader.
+// The state is read through a cache multistore so that nothing is written to the application DB.
+func (l *indexerBackfillBlockLoader) LoadBlock(height int64) (sdk.Context, abci.RequestFinalizeBlock, abci.ResponseFinalizeBlock, error) {
+	block := l.blockStore.LoadBlock(height)
+	if block == nil {
+		return sdk.Context{}, abci.RequestFinalizeBlock{}, abci.ResponseFinalizeBlock{}, fmt.Errorf("block not found in the blockstore with base %d and height %d", l.blockStore.Base(), l.blockStore.Height())
+	}
+
+	res, err := l.stateStore.LoadFinalizeBlockResponse(height)
+	if err != nil {
+		return sdk.Context{}, abci.RequestFinalizeBlock{}, abci.ResponseFinalizeBlock{}, fmt.Errorf("failed to load the block results, make sure they are not discarded or pruned: %w", err)
+	}
+
+	cms, err := l.app.CommitMultiStore().CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return sdk.Context{}, abci.RequestFinalizeBlock{}, abci.ResponseFinalizeBlock{}, fmt.Errorf("failed to load state, make sure it is not pruned: %w", err)
+	}
+
+	req := abci.RequestFinalizeBlock{
+		Txs:                block.Txs.ToSliceOfBytes(),
+		Hash:               block.Hash(),
+		Height:             block.Height,
+		Time:               block.Time,
+		NextValidatorsHash: block.NextValidatorsHash,
+		ProposerAddress:    block.ProposerAddress,
+	}
+
+	return sdk.NewContext(cms, *block.Header.ToProto(), false, l.logger), req, *res, nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+// DONTCOVER
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/encoding"
+)
+
+const (
+	flagIndexerSchemasOutputDir = "output-dir"
+)
+
+// ingestIndexerSchemasCmd returns a command that writes the schema files of an indexer encoding.
+func ingestIndexerSchemasCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "indexer-schemas [protobuf|avro]",
+		Short: "Write the schema files of the messages published by the indexer",
+		Long: `Write the schema files of the messages published by the indexer with the given encoding,
+so that they can be registered with a schema registry: indexer.proto for protobuf and an .avsc file per message for Avro.
+The version of the schemas is set in the schema_version field and attribute of every message.
+
+Example:
+	osmosisd ingest indexer-schemas avro --output-dir ./schemas
+`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{encoding.Protobuf, encoding.Avro},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputDir, err := cmd.Flags().GetString(flagIndexerSchemasOutputDir)
+			if err != nil {
+				return err
+			}
+
+			paths, err := encoding.WriteSchemas(args[0], outputDir)
+			if err != nil {
+				return err
+			}
+
+			for _, path := range paths {
+				cmd.Println(path)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagIndexerSchemasOutputDir, ".", "directory to write the schema files to")
+
+	return cmd
+}
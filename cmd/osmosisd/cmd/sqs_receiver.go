@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/receiver"
+	prototypes "github.com/osmosis-labs/osmosis/v30/ingest/types/proto/types"
+
+	// Register the compressors used by the ingest client.
+	_ "github.com/osmosis-labs/osmosis/v30/ingest/common/compression"
+)
+
+const (
+	flagSQSReceiverGRPCAddress = "grpc-address"
+	flagSQSReceiverHTTPAddress = "http-address"
+	flagSQSReceiverHistorySize = "history-size"
+)
+
+// SQSReceiverCmd starts a reference SQS ingest receiver for local development and testing.
+func SQSReceiverCmd(appCodec codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sqs-receiver",
+		Short: "Start a reference SQS ingest receiver",
+		Long: `Start a reference SQS ingest receiver that keeps the pushed pool state in memory and serves it over a JSON and gRPC read API.
+It is a local stand-in for the sidecar query server that is intended for integration testing and debugging.
+Point the node at it by setting grpc-ingest-address in the [osmosis-sqs] section of app.toml.
+
+JSON read API:
+	GET /pools[?height=]
+	GET /pools/{id}[?height=]
+	GET /taker-fee?denom0=&denom1=[&height=]
+
+gRPC read API, served on the ingest address:
+	osmosis.ingest.v1beta1.SQSReceiverQuery/Pools
+	osmosis.ingest.v1beta1.SQSReceiverQuery/Pool
+	osmosis.ingest.v1beta1.SQSReceiverQuery/TakerFee
+
+Example:
+	osmosisd sqs-receiver --grpc-address localhost:50051 --http-address localhost:9093
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			grpcAddress, err := cmd.Flags().GetString(flagSQSReceiverGRPCAddress)
+			if err != nil {
+				return err
+			}
+
+			httpAddress, err := cmd.Flags().GetString(flagSQSReceiverHTTPAddress)
+			if err != nil {
+				return err
+			}
+
+			historySize, err := cmd.Flags().GetInt(flagSQSReceiverHistorySize)
+			if err != nil {
+				return err
+			}
+
+			ingestReceiver := receiver.New(appCodec, historySize)
+
+			grpcListener, err := net.Listen("tcp", grpcAddress)
+			if err != nil {
+				return err
+			}
+
+			grpcServer := grpc.NewServer(grpc.MaxRecvMsgSize(sqs.DefaultConfig.GRPCIngestMaxCallSizeBytes))
+			prototypes.RegisterSQSIngesterServer(grpcServer, ingestReceiver)
+			prototypes.RegisterSQSReceiverQueryServer(grpcServer, ingestReceiver)
+
+			httpServer := &http.Server{
+				Addr:    httpAddress,
+				Handler: ingestReceiver.NewHTTPHandler(),
+			}
+
+			errCh := make(chan error, 2)
+			go func() {
+				errCh <- grpcServer.Serve(grpcListener)
+			}()
+			go func() {
+				if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+					errCh <- err
+				}
+			}()
+
+			cmd.Printf("SQS receiver listening for ingest on %s and serving the read API on %s\n", grpcAddress, httpAddress)
+
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+			select {
+			case err = <-errCh:
+			case <-sigCh:
+			}
+
+			grpcServer.GracefulStop()
+			if closeErr := httpServer.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+
+			if err != nil {
+				return fmt.Errorf("sqs receiver: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSQSReceiverGRPCAddress, "localhost:50051", "address to listen on for the ingest and gRPC read API calls")
+	cmd.Flags().String(flagSQSReceiverHTTPAddress, "localhost:9093", "address to serve the JSON read API on")
+	cmd.Flags().Int(flagSQSReceiverHistorySize, receiver.DefaultHistorySize, "number of heights for which the pool state is retained")
+
+	return cmd
+}
@@ -0,0 +1,169 @@
+package admin_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/admin"
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	prototypes "github.com/osmosis-labs/osmosis/v30/ingest/types/proto/types"
+)
+
+const (
+	sqsSink0    = "sqs/localhost:50051"
+	sqsSink1    = "sqs/localhost:50052"
+	indexerSink = "indexer"
+)
+
+// newIngestedManager returns a block process strategy manager that ingested the initial data.
+func newIngestedManager() commondomain.BlockProcessStrategyManager {
+	blockProcessStrategyManager := commondomain.NewBlockProcessStrategyManager()
+	blockProcessStrategyManager.MarkInitialDataIngested()
+	return blockProcessStrategyManager
+}
+
+// Validates that the requests are applied to the sinks of the target only.
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name string
+
+		target string
+
+		expectedSinks []string
+		expectedCode  codes.Code
+	}{
+		{
+			name:          "sqs",
+			target:        admin.TargetSQS,
+			expectedSinks: []string{sqsSink0, sqsSink1},
+		},
+		{
+			name:          "indexer",
+			target:        admin.TargetIndexer,
+			expectedSinks: []string{indexerSink},
+		},
+		{
+			name:          "all",
+			target:        "",
+			expectedSinks: []string{sqsSink0, sqsSink1, indexerSink},
+		},
+		{
+			name:         "unknown target",
+			target:       "other",
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managers := map[string]commondomain.BlockProcessStrategyManager{
+				sqsSink0:    newIngestedManager(),
+				sqsSink1:    newIngestedManager(),
+				indexerSink: newIngestedManager(),
+			}
+
+			server := admin.NewServer()
+			server.Register(admin.TargetSQS, sqsSink0, managers[sqsSink0])
+			server.Register(admin.TargetSQS, sqsSink1, managers[sqsSink1])
+			server.Register(admin.TargetIndexer, indexerSink, managers[indexerSink])
+
+			req := &prototypes.IngestAdminRequest{Target: tt.target}
+
+			// Pause
+			reply, err := server.Pause(context.Background(), req)
+			if tt.expectedCode != codes.OK {
+				require.Equal(t, tt.expectedCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedSinks, reply.Sinks)
+
+			for sink, manager := range managers {
+				require.Equal(t, contains(tt.expectedSinks, sink), manager.IsPaused(), sink)
+			}
+
+			// Resume
+			reply, err = server.Resume(context.Background(), req)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedSinks, reply.Sinks)
+
+			for sink, manager := range managers {
+				require.False(t, manager.IsPaused(), sink)
+				// All data is pushed after resuming.
+				require.Equal(t, contains(tt.expectedSinks, sink), manager.ShouldPushAllData(), sink)
+				manager.MarkInitialDataIngested()
+			}
+
+			// Resync
+			reply, err = server.Resync(context.Background(), req)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedSinks, reply.Sinks)
+
+			for sink, manager := range managers {
+				require.Equal(t, contains(tt.expectedSinks, sink), manager.ShouldPushAllData(), sink)
+			}
+		})
+	}
+}
+
+// Validates that the server responds with not found if no sink of the target is registered.
+func TestServer_NotFound(t *testing.T) {
+	server := admin.NewServer()
+	server.Register(admin.TargetSQS, sqsSink0, newIngestedManager())
+
+	_, err := server.Resync(context.Background(), &prototypes.IngestAdminRequest{Target: admin.TargetIndexer})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// Validates that the server is served over gRPC and refuses non-loopback addresses.
+func TestServer_Serve(t *testing.T) {
+	server := admin.NewServer()
+
+	_, err := server.Start("0.0.0.0:0", log.NewNopLogger())
+	require.Error(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	grpcServer := server.Serve(listener, log.NewNopLogger())
+	t.Cleanup(grpcServer.Stop)
+
+	manager := newIngestedManager()
+	server.Register(admin.TargetIndexer, indexerSink, manager)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	reply, err := prototypes.NewIngestAdminClient(conn).Pause(context.Background(), &prototypes.IngestAdminRequest{Target: admin.TargetIndexer})
+	require.NoError(t, err)
+	require.Equal(t, []string{indexerSink}, reply.Sinks)
+	require.True(t, manager.IsPaused())
+}
+
+func TestValidateLoopbackAddress(t *testing.T) {
+	for _, address := range []string{"localhost:9096", "127.0.0.1:9096", "[::1]:9096"} {
+		require.NoError(t, admin.ValidateLoopbackAddress(address), address)
+	}
+
+	for _, address := range []string{"0.0.0.0:9096", ":9096", "10.0.0.1:9096", "example.com:9096", "localhost"} {
+		require.Error(t, admin.ValidateLoopbackAddress(address), address)
+	}
+}
+
+func contains(sinks []string, sink string) bool {
+	for _, s := range sinks {
+		if s == sink {
+			return true
+		}
+	}
+	return false
+}
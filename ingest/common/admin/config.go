@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"fmt"
+	"net"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+
+	"github.com/osmosis-labs/osmosis/osmoutils"
+)
+
+// Config defines the config for the ingest admin server.
+type Config struct {
+	// AdminAddress defines the loopback address of the gRPC server that accepts the
+	// resync, pause and resume requests of the operator. If empty, the server is disabled.
+	AdminAddress string `mapstructure:"admin-address"`
+}
+
+// groupOptName is the name of the ingest options group.
+const groupOptName = "osmosis-ingest"
+
+// DefaultAddress is the address used by the admin commands by default.
+const DefaultAddress = "localhost:9096"
+
+// DefaultConfig defines the default config for the ingest admin server.
+var DefaultConfig = Config{
+	AdminAddress: "",
+}
+
+// NewConfigFromOptions returns a new ingest admin server config from the given options.
+// Panics if the address is not a loopback address.
+func NewConfigFromOptions(opts servertypes.AppOptions) Config {
+	config := Config{
+		AdminAddress: osmoutils.ParseString(opts, groupOptName, "admin-address"),
+	}
+
+	if config.AdminAddress != "" {
+		if err := ValidateLoopbackAddress(config.AdminAddress); err != nil {
+			panic(err)
+		}
+	}
+
+	return config
+}
+
+// ValidateLoopbackAddress returns error if the host of the given address is neither localhost
+// nor a loopback IP so that the admin service is not exposed to the network.
+func ValidateLoopbackAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid admin-address (%s): %w", address, err)
+	}
+
+	if host == "localhost" {
+		return nil
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+
+	return fmt.Errorf("admin-address (%s) must be a loopback address", address)
+}
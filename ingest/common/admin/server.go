@@ -0,0 +1,130 @@
+package admin
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"cosmossdk.io/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	prototypes "github.com/osmosis-labs/osmosis/v30/ingest/types/proto/types"
+)
+
+const (
+	// TargetSQS is the admin target of the SQS sinks.
+	TargetSQS = "sqs"
+	// TargetIndexer is the admin target of the indexer.
+	TargetIndexer = "indexer"
+)
+
+// sink is an ingest sink registered with the admin server.
+type sink struct {
+	target                      string
+	name                        string
+	blockProcessStrategyManager commondomain.BlockProcessStrategyManager
+}
+
+// Server is the ingest admin gRPC server.
+// It applies the operator requests to the block process strategy managers of the registered sinks.
+type Server struct {
+	prototypes.UnimplementedIngestAdminServer
+
+	mu    sync.Mutex
+	sinks []sink
+}
+
+var _ prototypes.IngestAdminServer = &Server{}
+
+// NewServer returns a new ingest admin server with no registered sinks.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Register registers the block process strategy manager of the sink with the given target and name.
+func (s *Server) Register(target, name string, blockProcessStrategyManager commondomain.BlockProcessStrategyManager) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sinks = append(s.sinks, sink{
+		target:                      target,
+		name:                        name,
+		blockProcessStrategyManager: blockProcessStrategyManager,
+	})
+}
+
+// Resync implements prototypes.IngestAdminServer.
+func (s *Server) Resync(ctx context.Context, req *prototypes.IngestAdminRequest) (*prototypes.IngestAdminReply, error) {
+	return s.apply(req.GetTarget(), commondomain.BlockProcessStrategyManager.RequestResync)
+}
+
+// Pause implements prototypes.IngestAdminServer.
+func (s *Server) Pause(ctx context.Context, req *prototypes.IngestAdminRequest) (*prototypes.IngestAdminReply, error) {
+	return s.apply(req.GetTarget(), commondomain.BlockProcessStrategyManager.Pause)
+}
+
+// Resume implements prototypes.IngestAdminServer.
+func (s *Server) Resume(ctx context.Context, req *prototypes.IngestAdminRequest) (*prototypes.IngestAdminReply, error) {
+	return s.apply(req.GetTarget(), commondomain.BlockProcessStrategyManager.Resume)
+}
+
+// Start serves the admin service on the given address in the background.
+// Returns error if the address is not a loopback address or if it fails to listen.
+func (s *Server) Start(address string, logger log.Logger) (*grpc.Server, error) {
+	if err := ValidateLoopbackAddress(address); err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Serve(listener, logger), nil
+}
+
+// Serve serves the admin service on the given listener in the background.
+func (s *Server) Serve(listener net.Listener, logger log.Logger) *grpc.Server {
+	grpcServer := grpc.NewServer()
+	prototypes.RegisterIngestAdminServer(grpcServer, s)
+
+	go func() {
+		logger.Info("Starting ingest admin server", "address", listener.Addr().String())
+		if err := grpcServer.Serve(listener); err != nil {
+			logger.Error("Ingest admin server failed", "err", err)
+		}
+	}()
+
+	return grpcServer
+}
+
+// apply applies the given operation to the sinks of the target.
+// If the target is empty, it is applied to all sinks.
+// Returns codes.InvalidArgument if the target is unknown and codes.NotFound if no sink of the target is registered.
+func (s *Server) apply(target string, operation func(commondomain.BlockProcessStrategyManager)) (*prototypes.IngestAdminReply, error) {
+	if target != "" && target != TargetSQS && target != TargetIndexer {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown target (%s), expected %s or %s", target, TargetSQS, TargetIndexer)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reply := &prototypes.IngestAdminReply{}
+	for _, sink := range s.sinks {
+		if target != "" && sink.target != target {
+			continue
+		}
+
+		operation(sink.blockProcessStrategyManager)
+		reply.Sinks = append(reply.Sinks, sink.name)
+	}
+
+	if len(reply.Sinks) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no enabled ingest service of target (%s)", target)
+	}
+
+	return reply, nil
+}
@@ -0,0 +1,236 @@
+package checkpoint_test
+
+import (
+	"testing"
+	"time"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/checkpoint"
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+)
+
+const (
+	defaultSink = "sqs/localhost:50051"
+	otherSink   = "sqs/localhost:5005"
+
+	defaultMaxAgeBlocks = uint64(100)
+)
+
+var (
+	hashA = []byte{0xa}
+	hashB = []byte{0xb}
+	hashC = []byte{0xc}
+)
+
+// Validates that the saved checkpoints are loaded per sink and that
+// full snapshots, nil hashes and removed pools delete the respective hashes.
+func TestStore_SaveLoad(t *testing.T) {
+	store := checkpoint.NewStore(dbm.NewMemDB())
+
+	_, ok, err := store.Load(defaultSink)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Full snapshot
+	require.NoError(t, store.Save(defaultSink, 10, map[uint64][]byte{1: hashA, 2: hashB, 3: hashC}, nil, true))
+	// Sink whose name is a prefix of the default sink.
+	require.NoError(t, store.Save(otherSink, 11, map[uint64][]byte{1: hashB}, nil, true))
+
+	cp, ok, err := store.Load(defaultSink)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, commondomain.Checkpoint{Height: 10, PoolHashes: map[uint64][]byte{1: hashA, 2: hashB, 3: hashC}}, cp)
+
+	// Update: pool 1 changed, pool 2 has no hash and pool 3 is removed.
+	require.NoError(t, store.Save(defaultSink, 11, map[uint64][]byte{1: hashC, 2: nil}, []uint64{3}, false))
+
+	cp, ok, err = store.Load(defaultSink)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, commondomain.Checkpoint{Height: 11, PoolHashes: map[uint64][]byte{1: hashC}}, cp)
+
+	// Full snapshot replaces the hashes.
+	require.NoError(t, store.Save(defaultSink, 12, map[uint64][]byte{4: hashA}, nil, true))
+
+	cp, ok, err = store.Load(defaultSink)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, commondomain.Checkpoint{Height: 12, PoolHashes: map[uint64][]byte{4: hashA}}, cp)
+
+	// Other sink is unaffected.
+	cp, ok, err = store.Load(otherSink)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, commondomain.Checkpoint{Height: 11, PoolHashes: map[uint64][]byte{1: hashB}}, cp)
+}
+
+func TestCheckpointer_WarmRestart(t *testing.T) {
+	const checkpointHeight = uint64(1_000)
+
+	tests := []struct {
+		name string
+
+		hasCheckpoint bool
+		height        uint64
+
+		expectedWarmRestart bool
+	}{
+		{
+			name:          "no checkpoint",
+			hasCheckpoint: false,
+			height:        checkpointHeight + 1,
+
+			expectedWarmRestart: false,
+		},
+		{
+			name:          "next height",
+			hasCheckpoint: true,
+			height:        checkpointHeight + 1,
+
+			expectedWarmRestart: true,
+		},
+		{
+			name:          "max age",
+			hasCheckpoint: true,
+			height:        checkpointHeight + defaultMaxAgeBlocks,
+
+			expectedWarmRestart: true,
+		},
+		{
+			name:          "too old",
+			hasCheckpoint: true,
+			height:        checkpointHeight + defaultMaxAgeBlocks + 1,
+
+			expectedWarmRestart: false,
+		},
+		{
+			name:          "checkpoint at height",
+			hasCheckpoint: true,
+			height:        checkpointHeight,
+
+			expectedWarmRestart: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := checkpoint.NewStore(dbm.NewMemDB())
+			if tt.hasCheckpoint {
+				require.NoError(t, store.Save(defaultSink, checkpointHeight, map[uint64][]byte{1: hashA}, nil, true))
+			}
+
+			checkpointer, err := checkpoint.NewCheckpointer(store, defaultSink, defaultMaxAgeBlocks)
+			require.NoError(t, err)
+
+			cp, ok := checkpointer.WarmRestart(tt.height)
+			require.Equal(t, tt.expectedWarmRestart, ok)
+			if tt.expectedWarmRestart {
+				require.Equal(t, commondomain.Checkpoint{Height: checkpointHeight, PoolHashes: map[uint64][]byte{1: hashA}}, cp)
+			}
+
+			// No warm restart after commit.
+			require.NoError(t, checkpointer.Commit(tt.height, map[uint64][]byte{1: hashB}, nil, false))
+
+			_, ok = checkpointer.WarmRestart(tt.height + 1)
+			require.False(t, ok)
+
+			// The commit is persisted.
+			cp, ok, err = store.Load(defaultSink)
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, tt.height, cp.Height)
+			require.Equal(t, hashB, cp.PoolHashes[1])
+		})
+	}
+}
+
+// Validates that the saved volume snapshots are loaded per sink in ascending order
+// of pool ID and time, and that deleted snapshots and removed pools are deleted.
+func TestStore_VolumeSnapshots(t *testing.T) {
+	store := checkpoint.NewStore(dbm.NewMemDB())
+
+	startTime := time.Unix(1_700_000_000, 0).UTC()
+	newSnapshot := func(poolID uint64, offset time.Duration, volume int64) commondomain.VolumeSnapshot {
+		return commondomain.VolumeSnapshot{PoolID: poolID, Time: startTime.Add(offset), Volume: osmomath.NewInt(volume)}
+	}
+
+	snapshots, err := store.LoadVolumeSnapshots(defaultSink)
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+
+	require.NoError(t, store.SaveVolumeSnapshots(defaultSink, []commondomain.VolumeSnapshot{
+		newSnapshot(2, 0, 5),
+		newSnapshot(1, time.Hour, 20),
+		newSnapshot(1, 0, 10),
+		newSnapshot(3, 0, 30),
+	}, nil, nil))
+	// Sink whose name is a prefix of the default sink.
+	require.NoError(t, store.SaveVolumeSnapshots(otherSink, []commondomain.VolumeSnapshot{newSnapshot(1, 0, 100)}, nil, nil))
+
+	snapshots, err = store.LoadVolumeSnapshots(defaultSink)
+	require.NoError(t, err)
+	requireVolumeSnapshots(t, []commondomain.VolumeSnapshot{
+		newSnapshot(1, 0, 10),
+		newSnapshot(1, time.Hour, 20),
+		newSnapshot(2, 0, 5),
+		newSnapshot(3, 0, 30),
+	}, snapshots)
+
+	// Pool 1 snapshot is pruned, pool 2 snapshot is replaced and pool 3 is removed.
+	require.NoError(t, store.SaveVolumeSnapshots(defaultSink,
+		[]commondomain.VolumeSnapshot{newSnapshot(2, 0, 15)},
+		[]commondomain.VolumeSnapshot{newSnapshot(1, 0, 0), newSnapshot(2, 0, 0)},
+		[]uint64{3}))
+
+	snapshots, err = store.LoadVolumeSnapshots(defaultSink)
+	require.NoError(t, err)
+	requireVolumeSnapshots(t, []commondomain.VolumeSnapshot{
+		newSnapshot(1, time.Hour, 20),
+		newSnapshot(2, 0, 15),
+	}, snapshots)
+
+	// Other sink is unaffected.
+	snapshots, err = store.LoadVolumeSnapshots(otherSink)
+	require.NoError(t, err)
+	requireVolumeSnapshots(t, []commondomain.VolumeSnapshot{newSnapshot(1, 0, 100)}, snapshots)
+
+	// Checkpoints do not touch the volume snapshots.
+	require.NoError(t, store.Save(defaultSink, 10, map[uint64][]byte{1: hashA}, nil, true))
+
+	snapshots, err = store.LoadVolumeSnapshots(defaultSink)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+}
+
+// Validates that the checkpoints of a closed store are loaded once reopened.
+func TestOpenStore_Close(t *testing.T) {
+	dataDir := t.TempDir()
+
+	store, err := checkpoint.OpenStore(dataDir)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(defaultSink, 10, map[uint64][]byte{1: hashA}, nil, true))
+	require.NoError(t, store.Close())
+
+	store, err = checkpoint.OpenStore(dataDir)
+	require.NoError(t, err)
+	defer store.Close()
+
+	cp, ok, err := store.Load(defaultSink)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, commondomain.Checkpoint{Height: 10, PoolHashes: map[uint64][]byte{1: hashA}}, cp)
+}
+
+func requireVolumeSnapshots(t *testing.T, expected, actual []commondomain.VolumeSnapshot) {
+	t.Helper()
+
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.Equal(t, expected[i].PoolID, actual[i].PoolID)
+		require.True(t, expected[i].Time.Equal(actual[i].Time))
+		require.Equal(t, expected[i].Volume.String(), actual[i].Volume.String())
+	}
+}
@@ -0,0 +1,73 @@
+package checkpoint
+
+import (
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+)
+
+// checkpointer is a commondomain.Checkpointer of a single sink.
+// The checkpoint is loaded once on creation and only retained until the first commit
+// since it is only needed for warm restart.
+type checkpointer struct {
+	store commondomain.CheckpointStore
+	sink  string
+
+	// maxAgeBlocks is the maximum number of blocks between the checkpoint
+	// and the warm restart height.
+	maxAgeBlocks uint64
+
+	checkpoint    commondomain.Checkpoint
+	hasCheckpoint bool
+
+	// isFailed is true if a commit failed. Until the next full snapshot, updates are not committed
+	// since the persisted checkpoint would silently miss the failed one. Instead, the persisted height
+	// falls behind the sink, and the sink requests a resync on warm restart.
+	isFailed bool
+}
+
+var _ commondomain.Checkpointer = &checkpointer{}
+
+// NewCheckpointer returns a new checkpointer of the given sink.
+// The sink can be warm restarted if its checkpoint is at most maxAgeBlocks old.
+// Returns error if the checkpoint fails to load.
+func NewCheckpointer(store commondomain.CheckpointStore, sink string, maxAgeBlocks uint64) (commondomain.Checkpointer, error) {
+	checkpoint, hasCheckpoint, err := store.Load(sink)
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkpointer{
+		store:         store,
+		sink:          sink,
+		maxAgeBlocks:  maxAgeBlocks,
+		checkpoint:    checkpoint,
+		hasCheckpoint: hasCheckpoint,
+	}, nil
+}
+
+// WarmRestart implements commondomain.Checkpointer.
+func (c *checkpointer) WarmRestart(height uint64) (commondomain.Checkpoint, bool) {
+	if !c.hasCheckpoint || c.checkpoint.Height >= height || height-c.checkpoint.Height > c.maxAgeBlocks {
+		return commondomain.Checkpoint{}, false
+	}
+
+	return c.checkpoint, true
+}
+
+// Commit implements commondomain.Checkpointer.
+func (c *checkpointer) Commit(height uint64, poolHashes map[uint64][]byte, removedPoolIDs []uint64, isFullSnapshot bool) error {
+	// The loaded checkpoint is stale once anything is committed.
+	c.checkpoint = commondomain.Checkpoint{}
+	c.hasCheckpoint = false
+
+	if c.isFailed && !isFullSnapshot {
+		return nil
+	}
+
+	if err := c.store.Save(c.sink, height, poolHashes, removedPoolIDs, isFullSnapshot); err != nil {
+		c.isFailed = true
+		return err
+	}
+
+	c.isFailed = false
+	return nil
+}
@@ -0,0 +1,266 @@
+package checkpoint
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	dbm "github.com/cosmos/cosmos-db"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+)
+
+const (
+	// dbName is the name of the checkpoint DB in the data directory of the node.
+	dbName = "ingest_checkpoint"
+
+	heightKeyPrefix         = "height/"
+	poolHashKeyPrefix       = "pool/"
+	volumeSnapshotKeyPrefix = "volume/"
+	// sinkSeparator separates the sink from the pool ID in the pool hash keys
+	// so that no sink key prefix is a prefix of another sink.
+	sinkSeparator = byte(0)
+)
+
+// store is a commondomain.CheckpointStore backed by a local DB.
+// Keys:
+// - height/{sink} -> big endian height
+// - pool/{sink}\x00{big endian pool ID} -> pool hash
+// - volume/{sink}\x00{big endian pool ID}{big endian unix nano time} -> cumulative volume
+type store struct {
+	db dbm.DB
+
+	mu sync.Mutex
+}
+
+var _ commondomain.CheckpointStore = &store{}
+
+// NewStore returns a new checkpoint store backed by the given DB.
+func NewStore(db dbm.DB) commondomain.CheckpointStore {
+	return &store{
+		db: db,
+	}
+}
+
+// OpenStore opens the checkpoint DB in the given data directory of the node
+// and returns a checkpoint store backed by it.
+func OpenStore(dataDir string) (commondomain.CheckpointStore, error) {
+	db, err := dbm.NewGoLevelDB(dbName, dataDir, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ingest checkpoint DB: %w", err)
+	}
+
+	return NewStore(db), nil
+}
+
+// Load implements commondomain.CheckpointStore.
+func (s *store) Load(sink string) (commondomain.Checkpoint, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	heightBz, err := s.db.Get(heightKey(sink))
+	if err != nil {
+		return commondomain.Checkpoint{}, false, err
+	}
+	if heightBz == nil {
+		return commondomain.Checkpoint{}, false, nil
+	}
+	if len(heightBz) != 8 {
+		return commondomain.Checkpoint{}, false, fmt.Errorf("invalid checkpoint height of sink (%s)", sink)
+	}
+
+	checkpoint := commondomain.Checkpoint{
+		Height:     binary.BigEndian.Uint64(heightBz),
+		PoolHashes: make(map[uint64][]byte),
+	}
+
+	prefix := poolHashPrefix(sink)
+	iterator, err := dbm.IteratePrefix(s.db, prefix)
+	if err != nil {
+		return commondomain.Checkpoint{}, false, err
+	}
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		poolIDBz := iterator.Key()[len(prefix):]
+		if len(poolIDBz) != 8 {
+			return commondomain.Checkpoint{}, false, fmt.Errorf("invalid checkpoint pool key of sink (%s)", sink)
+		}
+
+		checkpoint.PoolHashes[binary.BigEndian.Uint64(poolIDBz)] = iterator.Value()
+	}
+
+	if err := iterator.Error(); err != nil {
+		return commondomain.Checkpoint{}, false, err
+	}
+
+	return checkpoint, true, nil
+}
+
+// Save implements commondomain.CheckpointStore.
+func (s *store) Save(sink string, height uint64, poolHashes map[uint64][]byte, removedPoolIDs []uint64, isFullSnapshot bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	if isFullSnapshot {
+		if err := s.deletePoolHashes(batch, sink); err != nil {
+			return err
+		}
+	}
+
+	for poolID, hash := range poolHashes {
+		var err error
+		if hash == nil {
+			err = batch.Delete(poolHashKey(sink, poolID))
+		} else {
+			err = batch.Set(poolHashKey(sink, poolID), hash)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, poolID := range removedPoolIDs {
+		if err := batch.Delete(poolHashKey(sink, poolID)); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.Set(heightKey(sink), binary.BigEndian.AppendUint64(nil, height)); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
+// Close implements commondomain.CheckpointStore.
+func (s *store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.db.Close()
+}
+
+// LoadVolumeSnapshots implements commondomain.VolumeSnapshotStore.
+func (s *store) LoadVolumeSnapshots(sink string) ([]commondomain.VolumeSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefix := volumeSnapshotPrefix(sink)
+	iterator, err := dbm.IteratePrefix(s.db, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	snapshots := []commondomain.VolumeSnapshot{}
+	for ; iterator.Valid(); iterator.Next() {
+		suffix := iterator.Key()[len(prefix):]
+		if len(suffix) != 16 {
+			return nil, fmt.Errorf("invalid volume snapshot key of sink (%s)", sink)
+		}
+
+		var volume osmomath.Int
+		if err := volume.Unmarshal(iterator.Value()); err != nil {
+			return nil, fmt.Errorf("invalid volume snapshot of sink (%s): %w", sink, err)
+		}
+
+		snapshots = append(snapshots, commondomain.VolumeSnapshot{
+			PoolID: binary.BigEndian.Uint64(suffix[:8]),
+			Time:   time.Unix(0, int64(binary.BigEndian.Uint64(suffix[8:]))).UTC(),
+			Volume: volume,
+		})
+	}
+
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// SaveVolumeSnapshots implements commondomain.VolumeSnapshotStore.
+func (s *store) SaveVolumeSnapshots(sink string, added, deleted []commondomain.VolumeSnapshot, removedPoolIDs []uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	for _, poolID := range removedPoolIDs {
+		if err := s.deleteByPrefix(batch, volumeSnapshotPoolPrefix(sink, poolID)); err != nil {
+			return err
+		}
+	}
+
+	// Deleted before the added ones are set since a snapshot may be replaced at the same time.
+	for _, snapshot := range deleted {
+		if err := batch.Delete(volumeSnapshotKey(sink, snapshot.PoolID, snapshot.Time)); err != nil {
+			return err
+		}
+	}
+
+	for _, snapshot := range added {
+		volumeBz, err := snapshot.Volume.Marshal()
+		if err != nil {
+			return err
+		}
+
+		if err := batch.Set(volumeSnapshotKey(sink, snapshot.PoolID, snapshot.Time), volumeBz); err != nil {
+			return err
+		}
+	}
+
+	return batch.Write()
+}
+
+// deletePoolHashes deletes all pool hashes of the sink within the batch.
+func (s *store) deletePoolHashes(batch dbm.Batch, sink string) error {
+	return s.deleteByPrefix(batch, poolHashPrefix(sink))
+}
+
+// deleteByPrefix deletes all keys with the given prefix within the batch.
+func (s *store) deleteByPrefix(batch dbm.Batch, prefix []byte) error {
+	iterator, err := dbm.IteratePrefix(s.db, prefix)
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if err := batch.Delete(iterator.Key()); err != nil {
+			return err
+		}
+	}
+
+	return iterator.Error()
+}
+
+func heightKey(sink string) []byte {
+	return []byte(heightKeyPrefix + sink)
+}
+
+func poolHashPrefix(sink string) []byte {
+	return append([]byte(poolHashKeyPrefix+sink), sinkSeparator)
+}
+
+func poolHashKey(sink string, poolID uint64) []byte {
+	return binary.BigEndian.AppendUint64(poolHashPrefix(sink), poolID)
+}
+
+func volumeSnapshotPrefix(sink string) []byte {
+	return append([]byte(volumeSnapshotKeyPrefix+sink), sinkSeparator)
+}
+
+func volumeSnapshotPoolPrefix(sink string, poolID uint64) []byte {
+	return binary.BigEndian.AppendUint64(volumeSnapshotPrefix(sink), poolID)
+}
+
+func volumeSnapshotKey(sink string, poolID uint64, snapshotTime time.Time) []byte {
+	return binary.BigEndian.AppendUint64(volumeSnapshotPoolPrefix(sink, poolID), uint64(snapshotTime.UnixNano()))
+}
@@ -0,0 +1,30 @@
+// Package compression registers the gRPC compressors supported by the ingest transport.
+//
+// Importing this package registers the zstd and gzip compressors with gRPC
+// so that they can be negotiated by both the sender and the receiver.
+package compression
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+const (
+	// None disables compression.
+	None = "none"
+	// Gzip is the name of the gzip compressor.
+	Gzip = gzip.Name
+	// Zstd is the name of the zstd compressor.
+	Zstd = "zstd"
+)
+
+// Validate returns an error if the given compressor name is not supported.
+func Validate(name string) error {
+	switch name {
+	case None, Gzip, Zstd:
+		return nil
+	default:
+		return fmt.Errorf("unsupported compression (%s), expected one of (%s, %s, %s)", name, None, Gzip, Zstd)
+	}
+}
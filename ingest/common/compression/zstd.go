@@ -0,0 +1,93 @@
+package compression
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+// zstdCompressor implements encoding.Compressor.
+// Encoders and decoders are pooled since they are expensive to allocate.
+type zstdCompressor struct {
+	encoderPool sync.Pool
+	decoderPool sync.Pool
+}
+
+var _ encoding.Compressor = &zstdCompressor{}
+
+// Compress implements encoding.Compressor.
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	encoder, ok := c.encoderPool.Get().(*zstd.Encoder)
+	if !ok {
+		var err error
+		encoder, err = zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		encoder.Reset(w)
+	}
+
+	return &zstdWriter{Encoder: encoder, pool: &c.encoderPool}, nil
+}
+
+// Decompress implements encoding.Compressor.
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	decoder, ok := c.decoderPool.Get().(*zstd.Decoder)
+	if !ok {
+		var err error
+		decoder, err = zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+	} else if err := decoder.Reset(r); err != nil {
+		c.decoderPool.Put(decoder)
+		return nil, err
+	}
+
+	return &zstdReader{decoder: decoder, pool: &c.decoderPool}, nil
+}
+
+// Name implements encoding.Compressor.
+func (c *zstdCompressor) Name() string {
+	return Zstd
+}
+
+// zstdWriter returns the encoder to the pool on close.
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+// Close implements io.Closer.
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w.Encoder)
+	return err
+}
+
+// zstdReader returns the decoder to the pool once the stream is fully read.
+type zstdReader struct {
+	decoder *zstd.Decoder
+	pool    *sync.Pool
+}
+
+// Read implements io.Reader.
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.decoder == nil {
+		return 0, io.EOF
+	}
+
+	n, err := r.decoder.Read(p)
+	if err == io.EOF {
+		r.pool.Put(r.decoder)
+		r.decoder = nil
+	}
+	return n, err
+}
@@ -0,0 +1,53 @@
+package compression_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/compression"
+)
+
+// Validates that the registered compressors round-trip the data
+// and that pooled encoders and decoders are reused correctly.
+func TestCompressors_RoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("osmosis pool data "), 1024)
+
+	for _, name := range []string{compression.Zstd, compression.Gzip} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			compressor := encoding.GetCompressor(name)
+			require.NotNil(t, compressor)
+
+			// Run multiple times to exercise pooling.
+			for i := 0; i < 3; i++ {
+				var compressed bytes.Buffer
+				writer, err := compressor.Compress(&compressed)
+				require.NoError(t, err)
+
+				_, err = writer.Write(data)
+				require.NoError(t, err)
+				require.NoError(t, writer.Close())
+
+				require.Less(t, compressed.Len(), len(data))
+
+				reader, err := compressor.Decompress(&compressed)
+				require.NoError(t, err)
+
+				decompressed, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				require.Equal(t, data, decompressed)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, compression.Validate(compression.None))
+	require.NoError(t, compression.Validate(compression.Gzip))
+	require.NoError(t, compression.Validate(compression.Zstd))
+	require.Error(t, compression.Validate("lz4"))
+}
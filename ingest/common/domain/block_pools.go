@@ -3,6 +3,7 @@ package domain
 import (
 	"time"
 
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
 	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
 )
 
@@ -21,10 +22,23 @@ type BlockPools struct {
 	// ConcentratedPoolIDTickChange is the map of pool ID to tick change for concentrated pools.
 	// We use these pool IDs to append concentrated pools with all ticks at the end of the block.
 	ConcentratedPoolIDTickChange map[uint64]struct{}
+	// ConcentratedPoolIDChangedTicks is the map of concentrated pool ID to the tick indexes that were
+	// changed in the block. It is only populated when extracting the block updates.
+	// Pools present in this map have their ticks pushed as a delta. Pools absent from it
+	// have their full tick model pushed.
+	ConcentratedPoolIDChangedTicks map[uint64]map[int64]struct{}
 	// CosmWasmPools are the CosmWasm pools to be ingested.
 	CosmWasmPools []poolmanagertypes.PoolI
 	// CFMMPools are the CFMM pools to be ingested.
 	CFMMPools []poolmanagertypes.PoolI
+	// TakerFeeDenomPairs are the denom pairs whose taker fee override changed in the block.
+	// Their taker fees are ingested in addition to the ones of the pool denoms.
+	// It is only populated when extracting the block updates.
+	TakerFeeDenomPairs map[ingesttypes.DenomPair]struct{}
+	// IsTakerFeeParamsChanged signifies that the poolmanager params changed in the block.
+	// Since the default taker fee might have changed, the taker fees of all pools must be ingested.
+	// It is only populated when extracting the block updates.
+	IsTakerFeeParamsChanged bool
 }
 
 func (bp BlockPools) GetAll() []poolmanagertypes.PoolI {
@@ -1,10 +1,14 @@
 package domain
 
+import "sync/atomic"
+
 // BlockProcessStrategyManager is an interface for managing the strategy of pushing the blocks.
 // Either all block data or only the block update are the possible options
 // It is initialized with the strategy of pushing all data.
 // If it observes an error, it will switch to pushing all data.
 // If it ingested initial data and observed no error, it will switch to pushing only changed data.
+// Additionally, an operator may request pushing all data or pause pushing altogether.
+// The operator requests may be made concurrently with block processing.
 type BlockProcessStrategyManager interface {
 	// ShouldPushAllData returns true if all data should be pushed.
 	ShouldPushAllData() bool
@@ -15,10 +19,29 @@ type BlockProcessStrategyManager interface {
 
 	// MarkErrorObserved marks that an error has been observed.
 	MarkErrorObserved()
+
+	// RequestResync requests pushing all data.
+	// The request is applied on the next call to ShouldPushAllData so that it is not overridden
+	// by the block being processed marking its data as ingested.
+	RequestResync()
+
+	// Pause pauses pushing. While paused, blocks are skipped without pushing any data.
+	Pause()
+
+	// Resume resumes pushing. Since the data of the skipped blocks is missing,
+	// all data is pushed in the next block.
+	Resume()
+
+	// IsPaused returns true if pushing is paused.
+	IsPaused() bool
 }
 
 type blockProcessStrategyManager struct {
 	shouldPushAllData bool
+
+	// isResyncRequested and isPaused are set by the operator requests.
+	isResyncRequested atomic.Bool
+	isPaused          atomic.Bool
 }
 
 var _ BlockProcessStrategyManager = &blockProcessStrategyManager{}
@@ -32,6 +55,9 @@ func NewBlockProcessStrategyManager() BlockProcessStrategyManager {
 
 // ShouldPushAllData returns true if all data should be pushed.
 func (c *blockProcessStrategyManager) ShouldPushAllData() bool {
+	if c.isResyncRequested.Swap(false) {
+		c.shouldPushAllData = true
+	}
 	return c.shouldPushAllData
 }
 
@@ -44,3 +70,25 @@ func (c *blockProcessStrategyManager) MarkInitialDataIngested() {
 func (c *blockProcessStrategyManager) MarkErrorObserved() {
 	c.shouldPushAllData = true
 }
+
+// RequestResync implements BlockProcessStrategyManager.
+func (c *blockProcessStrategyManager) RequestResync() {
+	c.isResyncRequested.Store(true)
+}
+
+// Pause implements BlockProcessStrategyManager.
+func (c *blockProcessStrategyManager) Pause() {
+	c.isPaused.Store(true)
+}
+
+// Resume implements BlockProcessStrategyManager.
+func (c *blockProcessStrategyManager) Resume() {
+	if c.isPaused.Swap(false) {
+		c.RequestResync()
+	}
+}
+
+// IsPaused implements BlockProcessStrategyManager.
+func (c *blockProcessStrategyManager) IsPaused() bool {
+	return c.isPaused.Load()
+}
@@ -48,3 +48,50 @@ func (s *CommonDomainTestSuite) TestBlockProcessStrategyManager() {
 	// Unchanged after MarkInitialDataIngested twice
 	s.Require().False(blockStrategyManager.ShouldPushAllData())
 }
+
+// Validates that a requested resync switches to pushing all data on the next check,
+// even if the data of the block being processed is marked as ingested in the meantime.
+func (s *CommonDomainTestSuite) TestBlockProcessStrategyManager_RequestResync() {
+	blockStrategyManager := commondomain.NewBlockProcessStrategyManager()
+	blockStrategyManager.MarkInitialDataIngested()
+
+	// Requested while processing a block.
+	blockStrategyManager.RequestResync()
+	blockStrategyManager.MarkInitialDataIngested()
+
+	// ShouldPushAllData should return true after the resync request
+	s.Require().True(blockStrategyManager.ShouldPushAllData())
+	s.Require().True(blockStrategyManager.ShouldPushAllData())
+
+	blockStrategyManager.MarkInitialDataIngested()
+
+	// The request is applied once
+	s.Require().False(blockStrategyManager.ShouldPushAllData())
+}
+
+// Validates that resuming after a pause switches to pushing all data
+// and that resuming without a pause has no effect.
+func (s *CommonDomainTestSuite) TestBlockProcessStrategyManager_PauseResume() {
+	blockStrategyManager := commondomain.NewBlockProcessStrategyManager()
+	blockStrategyManager.MarkInitialDataIngested()
+
+	s.Require().False(blockStrategyManager.IsPaused())
+
+	blockStrategyManager.Pause()
+
+	// Pausing does not change the strategy
+	s.Require().True(blockStrategyManager.IsPaused())
+	s.Require().False(blockStrategyManager.ShouldPushAllData())
+
+	blockStrategyManager.Resume()
+
+	// ShouldPushAllData should return true after resuming
+	s.Require().False(blockStrategyManager.IsPaused())
+	s.Require().True(blockStrategyManager.ShouldPushAllData())
+
+	blockStrategyManager.MarkInitialDataIngested()
+	blockStrategyManager.Resume()
+
+	// Unchanged after resuming without a pause
+	s.Require().False(blockStrategyManager.ShouldPushAllData())
+}
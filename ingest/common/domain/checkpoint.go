@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+)
+
+// Checkpoint is the ingest progress of a sink persisted across node restarts.
+type Checkpoint struct {
+	// Height is the last height successfully pushed to the sink.
+	Height uint64
+	// PoolHashes are the hashes of the last pushed pool models by pool ID.
+	PoolHashes map[uint64][]byte
+}
+
+// CheckpointStore persists the checkpoints of the sinks.
+// Additionally, it persists the volume snapshots of the pool APR computers.
+type CheckpointStore interface {
+	VolumeSnapshotStore
+
+	// Load returns the checkpoint of the sink.
+	// Returns false if the sink has no checkpoint.
+	Load(sink string) (Checkpoint, bool, error)
+
+	// Save saves the pushed height and the hashes of the pushed pools of the sink.
+	// Pools with nil hash and removed pools are deleted from the checkpoint.
+	// If isFullSnapshot is true, the hashes of the pools that were not pushed are deleted.
+	Save(sink string, height uint64, poolHashes map[uint64][]byte, removedPoolIDs []uint64, isFullSnapshot bool) error
+
+	// Close closes the underlying DB.
+	Close() error
+}
+
+// Checkpointer tracks the ingest progress of a sink so that the sink can be warm restarted.
+// On warm restart, only the pools whose hash changed since the checkpoint are pushed
+// instead of all pools.
+type Checkpointer interface {
+	// WarmRestart returns the checkpoint if the sink can be warm restarted at the given height.
+	// That is the case if nothing was committed since the node started and the checkpoint
+	// exists and is not older than the configured maximum age.
+	WarmRestart(height uint64) (Checkpoint, bool)
+
+	// Commit commits the pushed height and the hashes of the pushed pools.
+	// Pools with nil hash and removed pools are forgotten so that they are always pushed on warm restart.
+	// If isFullSnapshot is true, all previously committed hashes are replaced.
+	Commit(height uint64, poolHashes map[uint64][]byte, removedPoolIDs []uint64, isFullSnapshot bool) error
+}
+
+// VolumeSnapshot is the cumulative OSMO volume of a pool at a block time.
+type VolumeSnapshot struct {
+	PoolID uint64
+	Time   time.Time
+	Volume osmomath.Int
+}
+
+// VolumeSnapshotStore persists the cumulative volume snapshots taken by a pool APR computer
+// so that the volume windows remain available across node restarts.
+type VolumeSnapshotStore interface {
+	// LoadVolumeSnapshots returns the volume snapshots of the sink in ascending order of pool ID and time.
+	LoadVolumeSnapshots(sink string) ([]VolumeSnapshot, error)
+
+	// SaveVolumeSnapshots saves the added snapshots of the sink and deletes the deleted snapshots
+	// as well as all snapshots of the removed pools. The volumes of the deleted snapshots are ignored.
+	SaveVolumeSnapshots(sink string, added, deleted []VolumeSnapshot, removedPoolIDs []uint64) error
+}
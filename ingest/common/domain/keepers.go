@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -10,8 +11,14 @@ import (
 	storetypes "cosmossdk.io/store/types"
 
 	"github.com/osmosis-labs/osmosis/v30/x/concentrated-liquidity/client/queryproto"
+	"github.com/osmosis-labs/osmosis/v30/x/concentrated-liquidity/model"
 	concentratedtypes "github.com/osmosis-labs/osmosis/v30/x/concentrated-liquidity/types"
+	incentivestypes "github.com/osmosis-labs/osmosis/v30/x/incentives/types"
+	minttypes "github.com/osmosis-labs/osmosis/v30/x/mint/types"
+	poolincentivestypes "github.com/osmosis-labs/osmosis/v30/x/pool-incentives/types"
 	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+	superfluidtypes "github.com/osmosis-labs/osmosis/v30/x/superfluid/types"
+	epochstypes "github.com/osmosis-labs/osmosis/x/epochs/types"
 )
 
 // Chain keepers required for extracting pool data.
@@ -25,6 +32,17 @@ type PoolExtractorKeepers struct {
 	ConcentratedKeeper ConcentratedKeeper
 }
 
+// Chain keepers required for computing the pool APR and fees data.
+type PoolAPRKeepers struct {
+	PoolManagerKeeper    PoolVolumeKeeper
+	IncentivesKeeper     IncentivesKeeper
+	PoolIncentivesKeeper PoolIncentivesKeeper
+	SuperfluidKeeper     SuperfluidKeeper
+	MintKeeper           MintKeeper
+	EpochsKeeper         EpochsKeeper
+	StakingKeeper        StakingKeeper
+}
+
 type WriteListener interface {
 	OnWrite(storeKey storetypes.StoreKey, key []byte, value []byte, delete bool) error
 }
@@ -80,6 +98,8 @@ type PoolManagerKeeper interface {
 
 	GetTradingPairTakerFee(ctx sdk.Context, denom0, denom1 string) (osmomath.Dec, error)
 
+	GetPool(ctx sdk.Context, poolId uint64) (poolmanagertypes.PoolI, error)
+
 	MultihopEstimateInGivenExactAmountOut(
 		ctx sdk.Context,
 		route []poolmanagertypes.SwapAmountOutRoute,
@@ -92,4 +112,48 @@ type ConcentratedKeeper interface {
 	PoolKeeper
 	GetTickLiquidityForFullRange(ctx sdk.Context, poolId uint64) ([]queryproto.LiquidityDepthWithRange, int64, error)
 	GetConcentratedPoolById(ctx sdk.Context, poolId uint64) (concentratedtypes.ConcentratedPoolExtension, error)
+	GetTickInfo(ctx sdk.Context, poolId uint64, tickIndex int64) (model.TickInfo, error)
+}
+
+// PoolVolumeKeeper is an interface for getting the cumulative pool volume.
+// The volume is denominated in OSMO using TWAP at the time of each swap.
+type PoolVolumeKeeper interface {
+	GetOsmoVolumeForPool(ctx sdk.Context, poolId uint64) osmomath.Int
+}
+
+// IncentivesKeeper is an interface for the incentives keeper.
+type IncentivesKeeper interface {
+	GetActiveGauges(ctx sdk.Context) []incentivestypes.Gauge
+	GetGaugeByID(ctx sdk.Context, gaugeID uint64) (*incentivestypes.Gauge, error)
+	GetGroupByGaugeID(ctx sdk.Context, gaugeID uint64) (incentivestypes.Group, error)
+	GetEpochInfo(ctx sdk.Context) epochstypes.EpochInfo
+}
+
+// PoolIncentivesKeeper is an interface for the pool incentives keeper.
+type PoolIncentivesKeeper interface {
+	GetDistrInfo(ctx sdk.Context) poolincentivestypes.DistrInfo
+	GetPoolIdFromGaugeId(ctx sdk.Context, gaugeId uint64, lockableDuration time.Duration) (uint64, error)
+	GetLongestLockableDuration(ctx sdk.Context) (time.Duration, error)
+}
+
+// SuperfluidKeeper is an interface for the superfluid keeper.
+type SuperfluidKeeper interface {
+	GetAllSuperfluidAssets(ctx sdk.Context) []superfluidtypes.SuperfluidAsset
+	GetRiskAdjustedOsmoValue(ctx sdk.Context, amount osmomath.Int) osmomath.Int
+}
+
+// MintKeeper is an interface for the mint keeper.
+type MintKeeper interface {
+	GetMinter(ctx sdk.Context) minttypes.Minter
+	GetParams(ctx sdk.Context) minttypes.Params
+}
+
+// EpochsKeeper is an interface for the epochs keeper.
+type EpochsKeeper interface {
+	GetEpochInfo(ctx sdk.Context, identifier string) epochstypes.EpochInfo
+}
+
+// StakingKeeper is an interface for getting the total bonded tokens.
+type StakingKeeper interface {
+	TotalBondedTokens(ctx context.Context) (osmomath.Int, error)
 }
@@ -0,0 +1,50 @@
+package domain
+
+// BlockStatus is the outcome of processing a block by a sink.
+type BlockStatus struct {
+	// Height is the height of the processed block.
+	Height uint64
+	// LastPushedHeight is the last height successfully pushed to the sink
+	// after processing the block.
+	LastPushedHeight uint64
+	// PayloadSizeBytes is the size of the data pushed in the block.
+	// Only recorded if the block was processed successfully.
+	PayloadSizeBytes uint64
+	// Err is the error that occurred while processing the block. Nil on success.
+	Err error
+	// IsNextBlockFullPush signifies that all data is to be pushed in the next block.
+	IsNextBlockFullPush bool
+	// IsPaused signifies that the block was skipped since pushing is paused by the operator.
+	IsPaused bool
+}
+
+// SinkStatus is the ingest status of a sink.
+type SinkStatus struct {
+	// Sink is the name of the sink.
+	Sink string `json:"sink"`
+	// Height is the height of the last processed block.
+	Height uint64 `json:"height"`
+	// LastPushedHeight is the last height successfully pushed to the sink.
+	LastPushedHeight uint64 `json:"last_pushed_height"`
+	// QueueLag is the number of processed blocks that were not pushed to the sink.
+	QueueLag uint64 `json:"queue_lag"`
+	// ConsecutiveFailures is the number of blocks that failed to be processed since the last success.
+	ConsecutiveFailures uint64 `json:"consecutive_failures"`
+	// LastError is the message of the last error. Empty if no error occurred.
+	LastError string `json:"last_error,omitempty"`
+	// IsNextBlockFullPush signifies that all data is to be pushed in the next block.
+	IsNextBlockFullPush bool `json:"is_next_block_full_push"`
+	// AvgPayloadSizeBytes is the average size of the data pushed in the recent blocks.
+	AvgPayloadSizeBytes uint64 `json:"avg_payload_size_bytes"`
+	// IsPaused signifies that pushing is paused by the operator.
+	IsPaused bool `json:"is_paused"`
+	// IsReady signifies that the sink is keeping up with the chain.
+	// A paused sink is not ready.
+	IsReady bool `json:"is_ready"`
+}
+
+// StatusRecorder records the ingest status of a sink.
+type StatusRecorder interface {
+	// RecordBlock records the outcome of processing a block.
+	RecordBlock(blockStatus BlockStatus)
+}
@@ -0,0 +1,115 @@
+package grpcsecurity_test
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/grpcsecurity"
+)
+
+func TestNewPerRPCCredentials(t *testing.T) {
+	// Disabled
+	perRPCCredentials, err := grpcsecurity.NewPerRPCCredentials(grpcsecurity.AuthConfig{})
+	require.NoError(t, err)
+	require.Nil(t, perRPCCredentials)
+
+	// Both set
+	_, err = grpcsecurity.NewPerRPCCredentials(grpcsecurity.AuthConfig{Token: "token", HMACSecret: "secret"})
+	require.Error(t, err)
+
+	// Bearer token
+	perRPCCredentials, err = grpcsecurity.NewPerRPCCredentials(grpcsecurity.AuthConfig{Token: "token"})
+	require.NoError(t, err)
+	require.True(t, perRPCCredentials.RequireTransportSecurity())
+
+	metadata, err := perRPCCredentials.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Bearer token", metadata[grpcsecurity.AuthorizationMetadataKey])
+}
+
+// This test validates that the HMAC metadata is signed over the method and timestamp,
+// that it is verified correctly by the server and that it is not sent over insecure connections.
+func TestNewPerRPCCredentials_HMAC(t *testing.T) {
+	const secret = "secret"
+
+	perRPCCredentials, err := grpcsecurity.NewPerRPCCredentials(grpcsecurity.AuthConfig{HMACSecret: secret})
+	require.NoError(t, err)
+	require.True(t, perRPCCredentials.RequireTransportSecurity())
+
+	// Verify the signature in a server interceptor.
+	var verifyErr error
+	interceptor := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		timestamp, err := strconv.ParseInt(md.Get(grpcsecurity.TimestampMetadataKey)[0], 10, 64)
+		require.NoError(t, err)
+		signature := md.Get(grpcsecurity.SignatureMetadataKey)[0]
+
+		verifyErr = grpcsecurity.VerifyHMAC([]byte(secret), info.FullMethod, timestamp, signature, time.Minute)
+
+		// Wrong secret
+		require.Error(t, grpcsecurity.VerifyHMAC([]byte("other"), info.FullMethod, timestamp, signature, time.Minute))
+
+		// Wrong method
+		require.Error(t, grpcsecurity.VerifyHMAC([]byte(secret), "/other", timestamp, signature, time.Minute))
+
+		return handler(ctx, req)
+	}
+
+	ca := newTestCA(t)
+	serverCertPEM, serverKeyPEM := ca.issue(t, 2, "sqs.internal")
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{serverCert}})), grpc.UnaryInterceptor(interceptor))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	transportCredentials, err := grpcsecurity.NewClientCredentials(grpcsecurity.TLSConfig{
+		Enabled:    true,
+		CAFile:     writeFile(t, t.TempDir(), "ca.pem", ca.pem),
+		ServerName: "sqs.internal",
+	})
+	require.NoError(t, err)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(transportCredentials), grpc.WithPerRPCCredentials(perRPCCredentials))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.NoError(t, verifyErr)
+
+	// The signature is not sent over an insecure connection.
+	_, err = grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithPerRPCCredentials(perRPCCredentials))
+	require.Error(t, err)
+}
+
+func TestVerifyHMAC_StaleTimestamp(t *testing.T) {
+	const method = "/osmosis.ingest.v1beta1.SQSIngester/ProcessBlock"
+	secret := []byte("secret")
+
+	timestamp := time.Now().Unix()
+	require.NoError(t, grpcsecurity.VerifyHMAC(secret, method, timestamp, grpcsecurity.SignHMAC(secret, method, timestamp), time.Minute))
+
+	staleTimestamp := time.Now().Add(-time.Hour).Unix()
+	require.Error(t, grpcsecurity.VerifyHMAC(secret, method, staleTimestamp, grpcsecurity.SignHMAC(secret, method, staleTimestamp), time.Minute))
+}
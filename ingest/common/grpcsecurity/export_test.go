@@ -0,0 +1,23 @@
+package grpcsecurity
+
+import "crypto/tls"
+
+// CertLoader exposes certLoader for testing.
+type CertLoader = certLoader
+
+func NewCertLoader(caFile, certFile, keyFile string) *CertLoader {
+	return &certLoader{
+		caFile:   caFile,
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+}
+
+// LoadCert returns the currently loaded certificate.
+func (l *certLoader) LoadCert() (*tls.Certificate, error) {
+	certs, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+	return certs.cert, nil
+}
@@ -0,0 +1,247 @@
+// Package grpcsecurity provides the transport credentials and the per-request
+// authentication used by the ingest gRPC transport.
+package grpcsecurity
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TLSConfig defines the TLS configuration of an ingest gRPC connection.
+type TLSConfig struct {
+	// Enabled defines if TLS is enabled. If false, the connection is insecure.
+	Enabled bool
+	// CAFile is the path to the PEM-encoded CA bundle used to verify the peer.
+	// If empty, the system roots are used.
+	CAFile string
+	// CertFile is the path to the PEM-encoded certificate presented to the peer.
+	// Setting it together with KeyFile enables mTLS on the client side.
+	CertFile string
+	// KeyFile is the path to the PEM-encoded private key of CertFile.
+	KeyFile string
+	// ServerName overrides the server name used to verify the server certificate.
+	// If empty, the host of the dialed address is used.
+	ServerName string
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c TLSConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("tls cert file and key file must be set together")
+	}
+
+	return nil
+}
+
+// NewClientCredentials returns the transport credentials for dialing with the given configuration.
+// If TLS is disabled, insecure credentials are returned.
+// The certificates are re-read from disk when they change so that rotated certificates are
+// picked up by new connections without a restart. Established connections are not affected.
+func NewClientCredentials(config TLSConfig) (credentials.TransportCredentials, error) {
+	if !config.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	loader := &certLoader{
+		caFile:   config.CAFile,
+		certFile: config.CertFile,
+		keyFile:  config.KeyFile,
+	}
+
+	// Load eagerly to surface misconfiguration at startup.
+	if _, err := loader.load(); err != nil {
+		return nil, err
+	}
+
+	return &reloadingCredentials{
+		loader:     loader,
+		serverName: config.ServerName,
+	}, nil
+}
+
+// NewClientTLSConfig returns the client TLS configuration for transports other than gRPC, e.g. the Kafka indexer driver.
+// Returns nil if TLS is disabled.
+// The client certificate is re-read from disk when it changes. The CA bundle is read once.
+func NewClientTLSConfig(config TLSConfig) (*tls.Config, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	loader := &certLoader{
+		caFile:   config.CAFile,
+		certFile: config.CertFile,
+		keyFile:  config.KeyFile,
+	}
+
+	certs, err := loader.load()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    certs.caPool,
+		ServerName: config.ServerName,
+	}
+
+	if config.CertFile != "" {
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			certs, err := loader.load()
+			if err != nil {
+				return nil, err
+			}
+			return certs.cert, nil
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// reloadingCredentials implements credentials.TransportCredentials.
+// It builds the TLS configuration from the latest certificates on every handshake.
+type reloadingCredentials struct {
+	loader     *certLoader
+	serverName string
+}
+
+var _ credentials.TransportCredentials = &reloadingCredentials{}
+
+// ClientHandshake implements credentials.TransportCredentials.
+func (c *reloadingCredentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	certs, err := c.loader.load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    certs.caPool,
+		ServerName: c.serverName,
+	}
+
+	if certs.cert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*certs.cert}
+	}
+
+	return credentials.NewTLS(tlsConfig).ClientHandshake(ctx, authority, rawConn)
+}
+
+// ServerHandshake implements credentials.TransportCredentials.
+func (c *reloadingCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("server handshake is not supported by client credentials")
+}
+
+// Info implements credentials.TransportCredentials.
+func (c *reloadingCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{
+		SecurityProtocol: "tls",
+		SecurityVersion:  "1.2",
+		ServerName:       c.serverName,
+	}
+}
+
+// Clone implements credentials.TransportCredentials.
+func (c *reloadingCredentials) Clone() credentials.TransportCredentials {
+	return &reloadingCredentials{
+		loader:     c.loader,
+		serverName: c.serverName,
+	}
+}
+
+// OverrideServerName implements credentials.TransportCredentials.
+func (c *reloadingCredentials) OverrideServerName(serverName string) error {
+	c.serverName = serverName
+	return nil
+}
+
+// loadedCerts are the certificates parsed from disk.
+type loadedCerts struct {
+	// caPool is nil if no CA file is configured, signifying the system roots.
+	caPool *x509.CertPool
+	// cert is nil if no certificate is configured.
+	cert *tls.Certificate
+}
+
+// certLoader loads the certificates from disk and caches them
+// until the modification time of any of the files changes.
+type certLoader struct {
+	caFile   string
+	certFile string
+	keyFile  string
+
+	mu       sync.Mutex
+	modTimes [3]time.Time
+	certs    *loadedCerts
+}
+
+// load returns the cached certificates, re-reading them from disk if any of the files changed.
+func (l *certLoader) load() (*loadedCerts, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var modTimes [3]time.Time
+	for i, path := range []string{l.caFile, l.certFile, l.keyFile} {
+		if path == "" {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[i] = info.ModTime()
+	}
+
+	if l.certs != nil && modTimes == l.modTimes {
+		return l.certs, nil
+	}
+
+	certs := &loadedCerts{}
+
+	if l.caFile != "" {
+		caBz, err := os.ReadFile(l.caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		certs.caPool = x509.NewCertPool()
+		if !certs.caPool.AppendCertsFromPEM(caBz) {
+			return nil, fmt.Errorf("no valid certificates found in tls ca file (%s)", l.caFile)
+		}
+	}
+
+	if l.certFile != "" {
+		cert, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+		if err != nil {
+			return nil, err
+		}
+		certs.cert = &cert
+	}
+
+	l.certs = certs
+	l.modTimes = modTimes
+
+	return certs, nil
+}
@@ -0,0 +1,282 @@
+package grpcsecurity_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/grpcsecurity"
+)
+
+// testCA is a self-signed certificate authority for testing.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	certBz, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certBz)
+	require.NoError(t, err)
+
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBz}),
+	}
+}
+
+// issue returns the PEM-encoded certificate and key signed by the CA.
+func (ca *testCA) issue(t *testing.T, serial int64, commonName string) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	certBz, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyBz, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBz}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBz})
+}
+
+func writeFile(t *testing.T, dir, name string, content []byte) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	return path
+}
+
+// This test validates that a client dials a server requiring mTLS
+// with a server name override.
+func TestNewClientCredentials_MTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+
+	serverCertPEM, serverKeyPEM := ca.issue(t, 2, "sqs.internal")
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	require.NoError(t, err)
+
+	clientCertPEM, clientKeyPEM := ca.issue(t, 3, "node")
+
+	caPool := x509.NewCertPool()
+	require.True(t, caPool.AppendCertsFromPEM(ca.pem))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	tlsConfig := grpcsecurity.TLSConfig{
+		Enabled:    true,
+		CAFile:     writeFile(t, dir, "ca.pem", ca.pem),
+		CertFile:   writeFile(t, dir, "client.pem", clientCertPEM),
+		KeyFile:    writeFile(t, dir, "client.key", clientKeyPEM),
+		ServerName: "sqs.internal",
+	}
+
+	check := func(config grpcsecurity.TLSConfig) error {
+		transportCredentials, err := grpcsecurity.NewClientCredentials(config)
+		require.NoError(t, err)
+
+		conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(transportCredentials))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		return err
+	}
+
+	// Happy path
+	require.NoError(t, check(tlsConfig))
+
+	// No client certificate
+	noClientCertConfig := tlsConfig
+	noClientCertConfig.CertFile = ""
+	noClientCertConfig.KeyFile = ""
+	require.Error(t, check(noClientCertConfig))
+
+	// Server name mismatch
+	wrongServerNameConfig := tlsConfig
+	wrongServerNameConfig.ServerName = "other.internal"
+	require.Error(t, check(wrongServerNameConfig))
+}
+
+// This test validates that the certificates are reloaded once they change on disk.
+func TestCertLoader_Reload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+
+	certPEM, keyPEM := ca.issue(t, 2, "node")
+	caFile := writeFile(t, dir, "ca.pem", ca.pem)
+	certFile := writeFile(t, dir, "client.pem", certPEM)
+	keyFile := writeFile(t, dir, "client.key", keyPEM)
+
+	loader := grpcsecurity.NewCertLoader(caFile, certFile, keyFile)
+
+	initialCert, err := loader.LoadCert()
+	require.NoError(t, err)
+
+	// Unchanged files return the cached certificate.
+	cachedCert, err := loader.LoadCert()
+	require.NoError(t, err)
+	require.Same(t, initialCert, cachedCert)
+
+	// Rotate the certificate.
+	rotatedCertPEM, rotatedKeyPEM := ca.issue(t, 3, "node")
+	writeFile(t, dir, "client.pem", rotatedCertPEM)
+	writeFile(t, dir, "client.key", rotatedKeyPEM)
+
+	// Ensure the modification time changes regardless of the file system resolution.
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+
+	rotatedCert, err := loader.LoadCert()
+	require.NoError(t, err)
+	require.NotEqual(t, initialCert.Certificate[0], rotatedCert.Certificate[0])
+}
+
+func TestTLSConfig_Validate(t *testing.T) {
+	require.NoError(t, grpcsecurity.TLSConfig{}.Validate())
+	require.NoError(t, grpcsecurity.TLSConfig{Enabled: true}.Validate())
+	require.NoError(t, grpcsecurity.TLSConfig{Enabled: true, CertFile: "cert", KeyFile: "key"}.Validate())
+	require.Error(t, grpcsecurity.TLSConfig{Enabled: true, CertFile: "cert"}.Validate())
+}
+
+// This test validates that the TLS config of non-gRPC transports connects to a server requiring mTLS.
+func TestNewClientTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+
+	serverCertPEM, serverKeyPEM := ca.issue(t, 2, "kafka.internal")
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	require.NoError(t, err)
+
+	clientCertPEM, clientKeyPEM := ca.issue(t, 3, "node")
+
+	caPool := x509.NewCertPool()
+	require.True(t, caPool.AppendCertsFromPEM(ca.pem))
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			// Complete the handshake so that the client observes its result.
+			_ = conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	tlsConfig := grpcsecurity.TLSConfig{
+		Enabled:    true,
+		CAFile:     writeFile(t, dir, "ca.pem", ca.pem),
+		CertFile:   writeFile(t, dir, "client.pem", clientCertPEM),
+		KeyFile:    writeFile(t, dir, "client.key", clientKeyPEM),
+		ServerName: "kafka.internal",
+	}
+
+	check := func(config grpcsecurity.TLSConfig) error {
+		clientTLSConfig, err := grpcsecurity.NewClientTLSConfig(config)
+		require.NoError(t, err)
+
+		conn, err := tls.Dial("tcp", listener.Addr().String(), clientTLSConfig)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		// With TLS 1.3 the client certificate is verified after the client handshake completes.
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, err = conn.Read(make([]byte, 1))
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	}
+
+	// Happy path
+	require.NoError(t, check(tlsConfig))
+
+	// No client certificate
+	noClientCertConfig := tlsConfig
+	noClientCertConfig.CertFile = ""
+	noClientCertConfig.KeyFile = ""
+	require.Error(t, check(noClientCertConfig))
+
+	// Server name mismatch
+	wrongServerNameConfig := tlsConfig
+	wrongServerNameConfig.ServerName = "other.internal"
+	require.Error(t, check(wrongServerNameConfig))
+
+	// Disabled
+	clientTLSConfig, err := grpcsecurity.NewClientTLSConfig(grpcsecurity.TLSConfig{})
+	require.NoError(t, err)
+	require.Nil(t, clientTLSConfig)
+}
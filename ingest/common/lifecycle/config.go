@@ -0,0 +1,45 @@
+package lifecycle
+
+import (
+	"fmt"
+	"time"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+
+	"github.com/osmosis-labs/osmosis/osmoutils"
+)
+
+// Config defines the config of the shutdown of the ingest services.
+type Config struct {
+	// ShutdownTimeoutSeconds defines the time the ingest services are given on shutdown
+	// to finish the block being processed and to flush their sinks.
+	ShutdownTimeoutSeconds uint64 `mapstructure:"shutdown-timeout-seconds"`
+}
+
+// groupOptName is the name of the ingest options group.
+const groupOptName = "osmosis-ingest"
+
+// DefaultConfig defines the default config of the shutdown of the ingest services.
+var DefaultConfig = Config{
+	ShutdownTimeoutSeconds: 30,
+}
+
+// NewConfigFromOptions returns a new ingest shutdown config from the given options.
+func NewConfigFromOptions(opts servertypes.AppOptions) Config {
+	shutdownTimeoutSeconds := osmoutils.ParseInt(opts, groupOptName, "shutdown-timeout-seconds")
+	if shutdownTimeoutSeconds < 0 {
+		panic(fmt.Sprintf("negative shutdown-timeout-seconds (%d)", shutdownTimeoutSeconds))
+	}
+	if shutdownTimeoutSeconds == 0 {
+		shutdownTimeoutSeconds = int(DefaultConfig.ShutdownTimeoutSeconds)
+	}
+
+	return Config{
+		ShutdownTimeoutSeconds: uint64(shutdownTimeoutSeconds),
+	}
+}
+
+// ShutdownTimeout returns the time the ingest services are given on shutdown.
+func (c Config) ShutdownTimeout() time.Duration {
+	return time.Duration(c.ShutdownTimeoutSeconds) * time.Second
+}
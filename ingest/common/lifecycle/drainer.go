@@ -0,0 +1,55 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Drainer tracks the calls in flight of a streaming service so that the service
+// is shut down only once they return. The zero value is ready to use.
+type Drainer struct {
+	mu       sync.Mutex
+	isClosed bool
+	inFlight sync.WaitGroup
+}
+
+// Begin marks the start of a call. Returns false if the drainer is closed,
+// in which case the call must return without processing. Otherwise, End must be called once the call returns.
+func (d *Drainer) Begin() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.isClosed {
+		return false
+	}
+
+	d.inFlight.Add(1)
+	return true
+}
+
+// End marks the end of a call started by Begin.
+func (d *Drainer) End() {
+	d.inFlight.Done()
+}
+
+// Drain closes the drainer so that no call starts anymore and waits for the calls in flight to return.
+// Returns error if ctx is done before they return.
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.mu.Lock()
+	d.isClosed = true
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("calls in flight did not return: %w", ctx.Err())
+	}
+}
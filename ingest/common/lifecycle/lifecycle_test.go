@@ -0,0 +1,81 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/lifecycle"
+)
+
+// closerFunc is an io.Closer calling the function.
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
+
+// Validates that the drainer waits for the calls in flight and rejects the calls once closed.
+func TestDrainer_Drain(t *testing.T) {
+	drainer := &lifecycle.Drainer{}
+
+	require.True(t, drainer.Begin())
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- drainer.Drain(context.Background())
+	}()
+
+	// The call in flight has not returned.
+	select {
+	case <-drained:
+		t.Fatal("drained with a call in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	drainer.End()
+	require.NoError(t, <-drained)
+
+	// No call starts once closed.
+	require.False(t, drainer.Begin())
+}
+
+// Validates that draining returns error if the call in flight does not return before the deadline.
+func TestDrainer_Drain_Timeout(t *testing.T) {
+	drainer := &lifecycle.Drainer{}
+
+	require.True(t, drainer.Begin())
+	defer drainer.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := drainer.Drain(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.False(t, drainer.Begin())
+}
+
+// Validates that the error of the closer is returned and that the deadline is enforced.
+func TestCloseWithin(t *testing.T) {
+	closeErr := errors.New("close failed")
+
+	err := lifecycle.CloseWithin(context.Background(), closerFunc(func() error {
+		return closeErr
+	}))
+	require.ErrorIs(t, err, closeErr)
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = lifecycle.CloseWithin(ctx, closerFunc(func() error {
+		<-unblock
+		return nil
+	}))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
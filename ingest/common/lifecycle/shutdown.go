@@ -0,0 +1,31 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Shutdowner is implemented by the ingest streaming services that drain their blocks in flight
+// and flush their sinks on shutdown.
+type Shutdowner interface {
+	// Shutdown stops processing new blocks, waits for the block in flight, if any,
+	// then flushes and closes the sink. Returns error if ctx is done before it completes.
+	Shutdown(ctx context.Context) error
+}
+
+// CloseWithin closes the closer, e.g. flushing the pending messages of a sink.
+// Returns error if ctx is done before the closer is closed, in which case it keeps closing in the background.
+func CloseWithin(ctx context.Context, closer io.Closer) error {
+	closeErr := make(chan error, 1)
+	go func() {
+		closeErr <- closer.Close()
+	}()
+
+	select {
+	case err := <-closeErr:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("sink not closed: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,17 @@
+package options
+
+import "strings"
+
+// ParseList parses a comma-separated list of an app option, ignoring surrounding whitespace and empty elements.
+// Returns nil if the list is empty.
+func ParseList(list string) []string {
+	var result []string
+	for _, element := range strings.Split(list, ",") {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			continue
+		}
+		result = append(result, element)
+	}
+	return result
+}
@@ -0,0 +1,28 @@
+package options_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/options"
+)
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     string
+		expected []string
+	}{
+		{name: "empty", list: "", expected: nil},
+		{name: "only separators", list: " , ,", expected: nil},
+		{name: "single element", list: "localhost:9092", expected: []string{"localhost:9092"}},
+		{name: "surrounding whitespace and empty elements", list: " uosmo, ,uatom ,", expected: []string{"uosmo", "uatom"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, options.ParseList(tt.list))
+		})
+	}
+}
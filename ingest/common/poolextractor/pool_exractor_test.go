@@ -62,6 +62,10 @@ func (s *PoolExtractorTestSuite) TestExtractor() {
 		TxnHash:     "txnhash",
 	})
 
+	// Track taker fee changes
+	poolTracker.TrackTakerFeeDenomPair(apptesting.ETH, apptesting.USDC)
+	poolTracker.TrackTakerFeeParamsChange()
+
 	// Initialize the extractor
 	extractor := poolextractor.New(keepers, poolTracker)
 
@@ -83,6 +87,10 @@ func (s *PoolExtractorTestSuite) TestExtractor() {
 	changedPools := blockPools.GetAll()
 	s.Require().Equal(2, len(changedPools))
 
+	// Validate that the taker fee changes are propagated
+	s.Require().Len(blockPools.TakerFeeDenomPairs, 1)
+	s.Require().True(blockPools.IsTakerFeeParamsChanged)
+
 	// Validate that the newly created pool is extracted
 	// Since only one newly created pool is injected during the test in the above code earlier,
 	// the length of the createdPoolIDs should be 1.
@@ -5,6 +5,7 @@ import (
 
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
 	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
 )
 
 // poolExtractor is an abstraction that extracts pools from the chain.
@@ -64,6 +65,12 @@ func (p *poolExtractor) ExtractAll(ctx sdk.Context) (commondomain.BlockPools, ma
 
 // ExtractChanged implements commondomain.PoolExtractor.
 func (p *poolExtractor) ExtractChanged(ctx sdk.Context) (commondomain.BlockPools, error) {
+	// Read the pools to replay from state so that they are processed
+	// together with the pools that were changed this block.
+	if err := p.trackReplayedPools(ctx); err != nil {
+		return commondomain.BlockPools{}, err
+	}
+
 	// If not cold start, we only process the pools that were changed this block.
 	concentratedPools := p.poolTracker.GetConcentratedPools()
 	concentratedPoolIDTickChange := p.poolTracker.GetConcentratedPoolIDTickChange()
@@ -75,6 +82,8 @@ func (p *poolExtractor) ExtractChanged(ctx sdk.Context) (commondomain.BlockPools
 		ConcentratedPoolIDTickChange: concentratedPoolIDTickChange,
 		CosmWasmPools:                cosmWasmPools,
 		CFMMPools:                    cfmmPools,
+		TakerFeeDenomPairs:           p.poolTracker.GetTakerFeeDenomPairs(),
+		IsTakerFeeParamsChanged:      p.poolTracker.IsTakerFeeParamsChanged(),
 	}
 
 	poolIDsTracked := make(map[uint64]struct{}, len(changedBlockPools.ConcentratedPools))
@@ -86,6 +95,10 @@ func (p *poolExtractor) ExtractChanged(ctx sdk.Context) (commondomain.BlockPools
 		poolIDsTracked[pool.GetId()] = struct{}{}
 	}
 
+	// Determine the concentrated pools whose ticks can be pushed as a delta.
+	// A pool for which a full tick snapshot is requested is omitted so that its full tick model is pushed.
+	changedBlockPools.ConcentratedPoolIDChangedTicks = getConcentratedPoolIDChangedTicks(concentratedPoolIDTickChange, p.poolTracker.GetConcentratedPoolIDChangedTicks(), p.poolTracker.GetConcentratedPoolIDTickSnapshot())
+
 	// Update concentrated pools
 	for poolID := range concentratedPoolIDTickChange {
 		// Skip if the pool if it is already tracked
@@ -149,3 +162,46 @@ func (p *poolExtractor) ExtractCreated(ctx sdk.Context) (commondomain.BlockPools
 
 	return result, createdPoolIDs, nil
 }
+
+// trackReplayedPools reads the pools to replay from state and tracks them as changed.
+// The replayed concentrated pools are pushed with the full tick model since
+// the tick deltas of the missed blocks are not retained.
+func (p *poolExtractor) trackReplayedPools(ctx sdk.Context) error {
+	for poolID := range p.poolTracker.GetReplayedPoolIDs() {
+		pool, err := p.keepers.PoolManagerKeeper.GetPool(ctx, poolID)
+		if err != nil {
+			return err
+		}
+
+		switch pool.GetType() {
+		case poolmanagertypes.Concentrated:
+			p.poolTracker.TrackConcentratedPoolIDTickSnapshot(poolID)
+		case poolmanagertypes.CosmWasm:
+			p.poolTracker.TrackCosmWasm(pool)
+		default:
+			p.poolTracker.TrackCFMM(pool)
+		}
+	}
+	return nil
+}
+
+// getConcentratedPoolIDChangedTicks returns the map of concentrated pool ID to the changed tick indexes
+// for every pool in poolIDTickChange that does not require a full tick snapshot.
+// Pools that changed without any tick writes (e.g. a swap within a single tick range) are
+// mapped to an empty set so that only their new current tick is pushed.
+func getConcentratedPoolIDChangedTicks(poolIDTickChange map[uint64]struct{}, changedTicks map[uint64]map[int64]struct{}, tickSnapshot map[uint64]struct{}) map[uint64]map[int64]struct{} {
+	result := make(map[uint64]map[int64]struct{}, len(poolIDTickChange))
+	for poolID := range poolIDTickChange {
+		if _, ok := tickSnapshot[poolID]; ok {
+			continue
+		}
+
+		poolChangedTicks, ok := changedTicks[poolID]
+		if !ok {
+			poolChangedTicks = map[int64]struct{}{}
+		}
+
+		result[poolID] = poolChangedTicks
+	}
+	return result
+}
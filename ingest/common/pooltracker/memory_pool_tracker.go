@@ -3,29 +3,41 @@ package pooltracker
 import (
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
 	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
 	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
 )
 
 // poolBlockUpdateTracker is a struct that tracks the pools that were updated in a block.
 type poolBlockUpdateTracker struct {
-	concentratedPools             map[uint64]poolmanagertypes.PoolI
-	concentratedPoolIDTickChange  map[uint64]struct{}
-	cfmmPools                     map[uint64]poolmanagertypes.PoolI
-	cosmwasmPools                 map[uint64]poolmanagertypes.PoolI
-	cosmwasmPoolsAddressToPoolMap map[string]poolmanagertypes.PoolI
+	concentratedPools              map[uint64]poolmanagertypes.PoolI
+	concentratedPoolIDTickChange   map[uint64]struct{}
+	concentratedPoolIDChangedTicks map[uint64]map[int64]struct{}
+	concentratedPoolIDTickSnapshot map[uint64]struct{}
+	replayedPoolIDs                map[uint64]struct{}
+	cfmmPools                      map[uint64]poolmanagertypes.PoolI
+	cosmwasmPools                  map[uint64]poolmanagertypes.PoolI
+	cosmwasmPoolsAddressToPoolMap  map[string]poolmanagertypes.PoolI
 	// Tracks the pool IDs that were created in the block.
 	createdPoolIDs map[uint64]commondomain.PoolCreation
+	// Tracks the denom pairs whose taker fee override was written in the block.
+	takerFeeDenomPairs map[ingesttypes.DenomPair]struct{}
+	// Tracks whether the poolmanager params were written in the block.
+	isTakerFeeParamsChanged bool
 }
 
 // NewMemory creates a new memory pool tracker.
 func NewMemory() domain.BlockPoolUpdateTracker {
 	return &poolBlockUpdateTracker{
-		concentratedPools:             map[uint64]poolmanagertypes.PoolI{},
-		concentratedPoolIDTickChange:  map[uint64]struct{}{},
-		cfmmPools:                     map[uint64]poolmanagertypes.PoolI{},
-		cosmwasmPools:                 map[uint64]poolmanagertypes.PoolI{},
-		cosmwasmPoolsAddressToPoolMap: map[string]poolmanagertypes.PoolI{},
-		createdPoolIDs:                map[uint64]commondomain.PoolCreation{},
+		concentratedPools:              map[uint64]poolmanagertypes.PoolI{},
+		concentratedPoolIDTickChange:   map[uint64]struct{}{},
+		concentratedPoolIDChangedTicks: map[uint64]map[int64]struct{}{},
+		concentratedPoolIDTickSnapshot: map[uint64]struct{}{},
+		replayedPoolIDs:                map[uint64]struct{}{},
+		cfmmPools:                      map[uint64]poolmanagertypes.PoolI{},
+		cosmwasmPools:                  map[uint64]poolmanagertypes.PoolI{},
+		cosmwasmPoolsAddressToPoolMap:  map[string]poolmanagertypes.PoolI{},
+		createdPoolIDs:                 map[uint64]commondomain.PoolCreation{},
+		takerFeeDenomPairs:             map[ingesttypes.DenomPair]struct{}{},
 	}
 }
 
@@ -54,11 +66,44 @@ func (pt *poolBlockUpdateTracker) TrackConcentratedPoolIDTickChange(poolID uint6
 	pt.concentratedPoolIDTickChange[poolID] = struct{}{}
 }
 
+// TrackConcentratedTickChange implements PoolTracker.
+func (pt *poolBlockUpdateTracker) TrackConcentratedTickChange(poolID uint64, tickIndex int64) {
+	pt.concentratedPoolIDTickChange[poolID] = struct{}{}
+
+	changedTicks, ok := pt.concentratedPoolIDChangedTicks[poolID]
+	if !ok {
+		changedTicks = map[int64]struct{}{}
+		pt.concentratedPoolIDChangedTicks[poolID] = changedTicks
+	}
+	changedTicks[tickIndex] = struct{}{}
+}
+
+// TrackConcentratedPoolIDTickSnapshot implements PoolTracker.
+func (pt *poolBlockUpdateTracker) TrackConcentratedPoolIDTickSnapshot(poolID uint64) {
+	pt.concentratedPoolIDTickChange[poolID] = struct{}{}
+	pt.concentratedPoolIDTickSnapshot[poolID] = struct{}{}
+}
+
+// TrackReplayedPoolID implements PoolTracker.
+func (pt *poolBlockUpdateTracker) TrackReplayedPoolID(poolID uint64) {
+	pt.replayedPoolIDs[poolID] = struct{}{}
+}
+
 // TrackCreatedPoolID implements domain.BlockPoolUpdateTracker.
 func (pt *poolBlockUpdateTracker) TrackCreatedPoolID(poolCreation commondomain.PoolCreation) {
 	pt.createdPoolIDs[poolCreation.PoolId] = poolCreation
 }
 
+// TrackTakerFeeDenomPair implements domain.BlockPoolUpdateTracker.
+func (pt *poolBlockUpdateTracker) TrackTakerFeeDenomPair(denom0, denom1 string) {
+	pt.takerFeeDenomPairs[ingesttypes.DenomPair{Denom0: denom0, Denom1: denom1}] = struct{}{}
+}
+
+// TrackTakerFeeParamsChange implements domain.BlockPoolUpdateTracker.
+func (pt *poolBlockUpdateTracker) TrackTakerFeeParamsChange() {
+	pt.isTakerFeeParamsChanged = true
+}
+
 // GetConcentratedPools implements PoolTracker.
 func (pt *poolBlockUpdateTracker) GetConcentratedPools() []poolmanagertypes.PoolI {
 	return poolMapToSlice(pt.concentratedPools)
@@ -69,6 +114,21 @@ func (pt *poolBlockUpdateTracker) GetConcentratedPoolIDTickChange() map[uint64]s
 	return pt.concentratedPoolIDTickChange
 }
 
+// GetConcentratedPoolIDChangedTicks implements PoolTracker.
+func (pt *poolBlockUpdateTracker) GetConcentratedPoolIDChangedTicks() map[uint64]map[int64]struct{} {
+	return pt.concentratedPoolIDChangedTicks
+}
+
+// GetConcentratedPoolIDTickSnapshot implements PoolTracker.
+func (pt *poolBlockUpdateTracker) GetConcentratedPoolIDTickSnapshot() map[uint64]struct{} {
+	return pt.concentratedPoolIDTickSnapshot
+}
+
+// GetReplayedPoolIDs implements PoolTracker.
+func (pt *poolBlockUpdateTracker) GetReplayedPoolIDs() map[uint64]struct{} {
+	return pt.replayedPoolIDs
+}
+
 // GetCFMMPools implements PoolTracker.
 func (pt *poolBlockUpdateTracker) GetCFMMPools() []poolmanagertypes.PoolI {
 	return poolMapToSlice(pt.cfmmPools)
@@ -89,13 +149,28 @@ func (pt *poolBlockUpdateTracker) GetCreatedPoolIDs() map[uint64]commondomain.Po
 	return pt.createdPoolIDs
 }
 
+// GetTakerFeeDenomPairs implements domain.BlockPoolUpdateTracker.
+func (pt *poolBlockUpdateTracker) GetTakerFeeDenomPairs() map[ingesttypes.DenomPair]struct{} {
+	return pt.takerFeeDenomPairs
+}
+
+// IsTakerFeeParamsChanged implements domain.BlockPoolUpdateTracker.
+func (pt *poolBlockUpdateTracker) IsTakerFeeParamsChanged() bool {
+	return pt.isTakerFeeParamsChanged
+}
+
 // Reset implements PoolTracker.
 func (pt *poolBlockUpdateTracker) Reset() {
 	pt.concentratedPools = map[uint64]poolmanagertypes.PoolI{}
 	pt.cfmmPools = map[uint64]poolmanagertypes.PoolI{}
 	pt.cosmwasmPools = map[uint64]poolmanagertypes.PoolI{}
 	pt.concentratedPoolIDTickChange = map[uint64]struct{}{}
+	pt.concentratedPoolIDChangedTicks = map[uint64]map[int64]struct{}{}
+	pt.concentratedPoolIDTickSnapshot = map[uint64]struct{}{}
+	pt.replayedPoolIDs = map[uint64]struct{}{}
 	pt.createdPoolIDs = map[uint64]commondomain.PoolCreation{}
+	pt.takerFeeDenomPairs = map[ingesttypes.DenomPair]struct{}{}
+	pt.isTakerFeeParamsChanged = false
 }
 
 // poolMapToSlice converts a map of pools to a slice of pools.
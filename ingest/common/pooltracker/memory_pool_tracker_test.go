@@ -94,6 +94,45 @@ func (s *PoolTrackerTestSuite) TestPoolTracker_Track() {
 	concentratedPoolIDTickChange := poolTracker.GetConcentratedPoolIDTickChange()
 	s.Require().Len(concentratedPoolIDTickChange, 1)
 
+	// Track changed ticks
+	poolTracker.TrackConcentratedTickChange(allPools.ConcentratedPoolID, 1)
+	poolTracker.TrackConcentratedTickChange(allPools.ConcentratedPoolID, 1)
+	poolTracker.TrackConcentratedTickChange(allPools.ConcentratedPoolID, -1)
+
+	// Get changed ticks
+	concentratedPoolIDChangedTicks := poolTracker.GetConcentratedPoolIDChangedTicks()
+	s.Require().Len(concentratedPoolIDChangedTicks, 1)
+	s.Require().Len(concentratedPoolIDChangedTicks[allPools.ConcentratedPoolID], 2)
+
+	// Track tick snapshot request
+	poolTracker.TrackConcentratedPoolIDTickSnapshot(allPools.ConcentratedPoolID)
+
+	// Get tick snapshot requests
+	concentratedPoolIDTickSnapshot := poolTracker.GetConcentratedPoolIDTickSnapshot()
+	s.Require().Len(concentratedPoolIDTickSnapshot, 1)
+
+	// Track replayed pool IDs
+	poolTracker.TrackReplayedPoolID(allPools.BalancerPoolID)
+	poolTracker.TrackReplayedPoolID(allPools.BalancerPoolID)
+
+	// Get replayed pool IDs
+	replayedPoolIDs := poolTracker.GetReplayedPoolIDs()
+	s.Require().Len(replayedPoolIDs, 1)
+
+	// Track taker fee denom pairs, in both directions
+	poolTracker.TrackTakerFeeDenomPair("uosmo", "uatom")
+	poolTracker.TrackTakerFeeDenomPair("uosmo", "uatom")
+	poolTracker.TrackTakerFeeDenomPair("uatom", "uosmo")
+
+	// Get taker fee denom pairs
+	takerFeeDenomPairs := poolTracker.GetTakerFeeDenomPairs()
+	s.Require().Len(takerFeeDenomPairs, 2)
+
+	// Track taker fee params change
+	s.Require().False(poolTracker.IsTakerFeeParamsChanged())
+	poolTracker.TrackTakerFeeParamsChange()
+	s.Require().True(poolTracker.IsTakerFeeParamsChanged())
+
 	// Reset the pool tracker
 	poolTracker.Reset()
 
@@ -109,4 +148,18 @@ func (s *PoolTrackerTestSuite) TestPoolTracker_Track() {
 
 	concentratedPoolIDTickChange = poolTracker.GetConcentratedPoolIDTickChange()
 	s.Require().Len(concentratedPoolIDTickChange, 0)
+
+	concentratedPoolIDChangedTicks = poolTracker.GetConcentratedPoolIDChangedTicks()
+	s.Require().Len(concentratedPoolIDChangedTicks, 0)
+
+	concentratedPoolIDTickSnapshot = poolTracker.GetConcentratedPoolIDTickSnapshot()
+	s.Require().Len(concentratedPoolIDTickSnapshot, 0)
+
+	replayedPoolIDs = poolTracker.GetReplayedPoolIDs()
+	s.Require().Len(replayedPoolIDs, 0)
+
+	takerFeeDenomPairs = poolTracker.GetTakerFeeDenomPairs()
+	s.Require().Len(takerFeeDenomPairs, 0)
+
+	s.Require().False(poolTracker.IsTakerFeeParamsChanged())
 }
@@ -0,0 +1,55 @@
+package status
+
+import (
+	"fmt"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+
+	"github.com/osmosis-labs/osmosis/osmoutils"
+)
+
+// Config defines the config for the ingest status server.
+type Config struct {
+	// StatusAddress defines the address of the HTTP server that reports the status and the readiness
+	// of the ingest sinks. If empty, the server is disabled.
+	StatusAddress string `mapstructure:"status-address"`
+	// StatusMaxLagBlocks defines the maximum number of processed blocks not pushed to a ready sink.
+	StatusMaxLagBlocks uint64 `mapstructure:"status-max-lag-blocks"`
+	// StatusMaxConsecutiveFailures defines the number of consecutive failures after which a sink is not ready.
+	StatusMaxConsecutiveFailures uint64 `mapstructure:"status-max-consecutive-failures"`
+}
+
+// groupOptName is the name of the ingest options group.
+const groupOptName = "osmosis-ingest"
+
+// DefaultConfig defines the default config for the ingest status server.
+var DefaultConfig = Config{
+	StatusAddress:                "",
+	StatusMaxLagBlocks:           10,
+	StatusMaxConsecutiveFailures: 5,
+}
+
+// NewConfigFromOptions returns a new ingest status server config from the given options.
+func NewConfigFromOptions(opts servertypes.AppOptions) Config {
+	statusMaxLagBlocks := osmoutils.ParseInt(opts, groupOptName, "status-max-lag-blocks")
+	if statusMaxLagBlocks < 0 {
+		panic(fmt.Sprintf("negative status-max-lag-blocks (%d)", statusMaxLagBlocks))
+	}
+	if statusMaxLagBlocks == 0 {
+		statusMaxLagBlocks = int(DefaultConfig.StatusMaxLagBlocks)
+	}
+
+	statusMaxConsecutiveFailures := osmoutils.ParseInt(opts, groupOptName, "status-max-consecutive-failures")
+	if statusMaxConsecutiveFailures < 0 {
+		panic(fmt.Sprintf("negative status-max-consecutive-failures (%d)", statusMaxConsecutiveFailures))
+	}
+	if statusMaxConsecutiveFailures == 0 {
+		statusMaxConsecutiveFailures = int(DefaultConfig.StatusMaxConsecutiveFailures)
+	}
+
+	return Config{
+		StatusAddress:                osmoutils.ParseString(opts, groupOptName, "status-address"),
+		StatusMaxLagBlocks:           uint64(statusMaxLagBlocks),
+		StatusMaxConsecutiveFailures: uint64(statusMaxConsecutiveFailures),
+	}
+}
@@ -0,0 +1,149 @@
+package status
+
+import (
+	"sync"
+
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+)
+
+// payloadWindowSize is the number of recent pushes over which the payload size is averaged.
+const payloadWindowSize = 100
+
+// Registry tracks the ingest status of the sinks of the node.
+// The statuses are recorded by the streaming services and read by the status server.
+type Registry struct {
+	// maxLagBlocks is the maximum queue lag of a ready sink.
+	maxLagBlocks uint64
+	// maxConsecutiveFailures is the number of consecutive failures
+	// after which a sink is not ready.
+	maxConsecutiveFailures uint64
+
+	mu    sync.Mutex
+	sinks []*sinkTracker
+}
+
+// sinkTracker is a commondomain.StatusRecorder of a single sink.
+type sinkTracker struct {
+	registry *Registry
+
+	status commondomain.SinkStatus
+
+	// payloadSizes is a ring buffer of the payload sizes of the recent pushes.
+	payloadSizes    []uint64
+	nextPayloadSize int
+	payloadSizesSum uint64
+}
+
+var _ commondomain.StatusRecorder = &sinkTracker{}
+
+// NewRegistry returns a new status registry.
+// A sink is ready once it pushed a block if its queue lag is at most maxLagBlocks
+// and if fewer than maxConsecutiveFailures blocks failed since the last success.
+func NewRegistry(maxLagBlocks, maxConsecutiveFailures uint64) *Registry {
+	return &Registry{
+		maxLagBlocks:           maxLagBlocks,
+		maxConsecutiveFailures: maxConsecutiveFailures,
+	}
+}
+
+// Sink registers a sink with the given name and returns its status recorder.
+func (r *Registry) Sink(name string) commondomain.StatusRecorder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tracker := &sinkTracker{
+		registry: r,
+		status: commondomain.SinkStatus{
+			Sink: name,
+		},
+		payloadSizes: make([]uint64, 0, payloadWindowSize),
+	}
+	r.sinks = append(r.sinks, tracker)
+
+	return tracker
+}
+
+// Statuses returns the statuses of the sinks in the order of registration.
+func (r *Registry) Statuses() []commondomain.SinkStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := make([]commondomain.SinkStatus, 0, len(r.sinks))
+	for _, tracker := range r.sinks {
+		statuses = append(statuses, tracker.status)
+	}
+
+	return statuses
+}
+
+// IsReady returns true if all sinks are ready.
+// Returns false if no sink is registered.
+func (r *Registry) IsReady() bool {
+	return isReady(r.Statuses())
+}
+
+// isReady returns true if all of the given statuses are ready.
+// Returns false if there is no status.
+func isReady(statuses []commondomain.SinkStatus) bool {
+	if len(statuses) == 0 {
+		return false
+	}
+
+	for _, status := range statuses {
+		if !status.IsReady {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RecordBlock implements commondomain.StatusRecorder.
+func (t *sinkTracker) RecordBlock(blockStatus commondomain.BlockStatus) {
+	r := t.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t.status.Height = blockStatus.Height
+	t.status.LastPushedHeight = blockStatus.LastPushedHeight
+	t.status.IsNextBlockFullPush = blockStatus.IsNextBlockFullPush
+
+	t.status.IsPaused = blockStatus.IsPaused
+
+	t.status.QueueLag = 0
+	if blockStatus.Height > blockStatus.LastPushedHeight {
+		t.status.QueueLag = blockStatus.Height - blockStatus.LastPushedHeight
+	}
+
+	// A skipped block neither fails nor succeeds.
+	if blockStatus.IsPaused {
+		t.status.IsReady = false
+		return
+	}
+
+	if blockStatus.Err != nil {
+		t.status.ConsecutiveFailures++
+		t.status.LastError = blockStatus.Err.Error()
+	} else {
+		t.status.ConsecutiveFailures = 0
+		t.recordPayloadSize(blockStatus.PayloadSizeBytes)
+	}
+
+	t.status.IsReady = t.status.LastPushedHeight > 0 &&
+		t.status.QueueLag <= r.maxLagBlocks &&
+		t.status.ConsecutiveFailures < r.maxConsecutiveFailures
+}
+
+// recordPayloadSize records the payload size of a push and updates the average.
+func (t *sinkTracker) recordPayloadSize(payloadSizeBytes uint64) {
+	if len(t.payloadSizes) < payloadWindowSize {
+		t.payloadSizes = append(t.payloadSizes, payloadSizeBytes)
+	} else {
+		t.payloadSizesSum -= t.payloadSizes[t.nextPayloadSize]
+		t.payloadSizes[t.nextPayloadSize] = payloadSizeBytes
+		t.nextPayloadSize = (t.nextPayloadSize + 1) % payloadWindowSize
+	}
+	t.payloadSizesSum += payloadSizeBytes
+
+	t.status.AvgPayloadSizeBytes = t.payloadSizesSum / uint64(len(t.payloadSizes))
+}
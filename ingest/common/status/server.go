@@ -0,0 +1,89 @@
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"cosmossdk.io/log"
+
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+)
+
+const (
+	// StatusPath is the path of the endpoint that reports the statuses of the sinks.
+	// It always responds with http.StatusOK.
+	StatusPath = "/status"
+	// ReadyPath is the path of the endpoint that reports the readiness of the sinks.
+	// It responds with http.StatusServiceUnavailable if any sink is not ready.
+	ReadyPath = "/ready"
+
+	readHeaderTimeout = 5 * time.Second
+)
+
+// statusResponse is the response of the status and the readiness endpoints.
+type statusResponse struct {
+	IsReady bool                      `json:"is_ready"`
+	Sinks   []commondomain.SinkStatus `json:"sinks"`
+}
+
+// NewHandler returns the HTTP handler of the status and the readiness endpoints.
+func NewHandler(registry *Registry) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(StatusPath, func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, registry, false)
+	})
+
+	mux.HandleFunc(ReadyPath, func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, registry, true)
+	})
+
+	return mux
+}
+
+// StartServer starts serving the status and the readiness endpoints on the given address
+// in the background. Errors other than the server being closed are logged.
+// Returns error if the address cannot be bound.
+func StartServer(address string, registry *Registry, logger log.Logger) (*http.Server, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &http.Server{
+		Addr:              address,
+		Handler:           NewHandler(registry),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go func() {
+		logger.Info("Starting ingest status server", "address", listener.Addr().String())
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Ingest status server failed", "err", err)
+		}
+	}()
+
+	return server, nil
+}
+
+// writeStatus writes the statuses of the sinks as JSON.
+// If isReadiness is true and any sink is not ready, it responds with http.StatusServiceUnavailable.
+func writeStatus(w http.ResponseWriter, registry *Registry, isReadiness bool) {
+	statuses := registry.Statuses()
+	response := statusResponse{
+		IsReady: isReady(statuses),
+		Sinks:   statuses,
+	}
+
+	statusCode := http.StatusOK
+	if isReadiness && !response.IsReady {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,276 @@
+package status_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/require"
+
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/status"
+)
+
+const (
+	defaultMaxLagBlocks           = uint64(2)
+	defaultMaxConsecutiveFailures = uint64(2)
+
+	sqsSink     = "sqs/localhost:50051"
+	indexerSink = "indexer"
+)
+
+var errPush = errors.New("push failed")
+
+// Validates the recorded statuses, including the consecutive failures, the queue lag,
+// the average payload size and the readiness.
+func TestRegistry_RecordBlock(t *testing.T) {
+	tests := []struct {
+		name string
+
+		blockStatuses []commondomain.BlockStatus
+
+		expectedStatus commondomain.SinkStatus
+	}{
+		{
+			name: "no block, not ready",
+
+			expectedStatus: commondomain.SinkStatus{
+				Sink: sqsSink,
+			},
+		},
+		{
+			name: "pushed, ready",
+
+			blockStatuses: []commondomain.BlockStatus{
+				{Height: 10, LastPushedHeight: 10, PayloadSizeBytes: 100, IsNextBlockFullPush: true},
+				{Height: 11, LastPushedHeight: 11, PayloadSizeBytes: 300},
+			},
+
+			expectedStatus: commondomain.SinkStatus{
+				Sink:                sqsSink,
+				Height:              11,
+				LastPushedHeight:    11,
+				AvgPayloadSizeBytes: 200,
+				IsReady:             true,
+			},
+		},
+		{
+			name: "failures below the maximum, ready",
+
+			blockStatuses: []commondomain.BlockStatus{
+				{Height: 10, LastPushedHeight: 10, PayloadSizeBytes: 100},
+				{Height: 11, LastPushedHeight: 10, Err: errPush, IsNextBlockFullPush: true},
+			},
+
+			expectedStatus: commondomain.SinkStatus{
+				Sink:                sqsSink,
+				Height:              11,
+				LastPushedHeight:    10,
+				QueueLag:            1,
+				ConsecutiveFailures: 1,
+				LastError:           errPush.Error(),
+				IsNextBlockFullPush: true,
+				AvgPayloadSizeBytes: 100,
+				IsReady:             true,
+			},
+		},
+		{
+			name: "consecutive failures, not ready",
+
+			blockStatuses: []commondomain.BlockStatus{
+				{Height: 10, LastPushedHeight: 10, PayloadSizeBytes: 100},
+				{Height: 11, LastPushedHeight: 10, Err: errPush},
+				{Height: 12, LastPushedHeight: 10, Err: errPush},
+			},
+
+			expectedStatus: commondomain.SinkStatus{
+				Sink:                sqsSink,
+				Height:              12,
+				LastPushedHeight:    10,
+				QueueLag:            2,
+				ConsecutiveFailures: 2,
+				LastError:           errPush.Error(),
+				AvgPayloadSizeBytes: 100,
+				IsReady:             false,
+			},
+		},
+		{
+			name: "success resets the consecutive failures but retains the last error",
+
+			blockStatuses: []commondomain.BlockStatus{
+				{Height: 10, LastPushedHeight: 10, PayloadSizeBytes: 100},
+				{Height: 11, LastPushedHeight: 10, Err: errPush},
+				{Height: 12, LastPushedHeight: 12, PayloadSizeBytes: 200},
+			},
+
+			expectedStatus: commondomain.SinkStatus{
+				Sink:                sqsSink,
+				Height:              12,
+				LastPushedHeight:    12,
+				LastError:           errPush.Error(),
+				AvgPayloadSizeBytes: 150,
+				IsReady:             true,
+			},
+		},
+		{
+			name: "paused, not ready and failures retained",
+
+			blockStatuses: []commondomain.BlockStatus{
+				{Height: 10, LastPushedHeight: 10, PayloadSizeBytes: 100},
+				{Height: 11, LastPushedHeight: 10, Err: errPush},
+				{Height: 12, LastPushedHeight: 10, IsPaused: true, IsNextBlockFullPush: true},
+			},
+
+			expectedStatus: commondomain.SinkStatus{
+				Sink:                sqsSink,
+				Height:              12,
+				LastPushedHeight:    10,
+				QueueLag:            2,
+				ConsecutiveFailures: 1,
+				LastError:           errPush.Error(),
+				IsNextBlockFullPush: true,
+				AvgPayloadSizeBytes: 100,
+				IsPaused:            true,
+				IsReady:             false,
+			},
+		},
+		{
+			name: "resumed, ready",
+
+			blockStatuses: []commondomain.BlockStatus{
+				{Height: 10, LastPushedHeight: 10, PayloadSizeBytes: 100},
+				{Height: 11, LastPushedHeight: 10, IsPaused: true},
+				{Height: 12, LastPushedHeight: 12, PayloadSizeBytes: 300},
+			},
+
+			expectedStatus: commondomain.SinkStatus{
+				Sink:                sqsSink,
+				Height:              12,
+				LastPushedHeight:    12,
+				AvgPayloadSizeBytes: 200,
+				IsReady:             true,
+			},
+		},
+		{
+			name: "sink lags behind, not ready",
+
+			blockStatuses: []commondomain.BlockStatus{
+				{Height: 13, LastPushedHeight: 10, PayloadSizeBytes: 100},
+			},
+
+			expectedStatus: commondomain.SinkStatus{
+				Sink:                sqsSink,
+				Height:              13,
+				LastPushedHeight:    10,
+				QueueLag:            3,
+				AvgPayloadSizeBytes: 100,
+				IsReady:             false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := status.NewRegistry(defaultMaxLagBlocks, defaultMaxConsecutiveFailures)
+
+			recorder := registry.Sink(sqsSink)
+			for _, blockStatus := range tt.blockStatuses {
+				recorder.RecordBlock(blockStatus)
+			}
+
+			require.Equal(t, []commondomain.SinkStatus{tt.expectedStatus}, registry.Statuses())
+			require.Equal(t, tt.expectedStatus.IsReady, registry.IsReady())
+		})
+	}
+}
+
+// Validates that the payload size is averaged over the recent pushes only.
+func TestRegistry_AvgPayloadSizeWindow(t *testing.T) {
+	registry := status.NewRegistry(defaultMaxLagBlocks, defaultMaxConsecutiveFailures)
+	recorder := registry.Sink(sqsSink)
+
+	// Large payload of the initial full push.
+	recorder.RecordBlock(commondomain.BlockStatus{Height: 1, LastPushedHeight: 1, PayloadSizeBytes: 1_000_000})
+
+	for height := uint64(2); height <= 100; height++ {
+		recorder.RecordBlock(commondomain.BlockStatus{Height: height, LastPushedHeight: height, PayloadSizeBytes: 100})
+	}
+	require.Equal(t, uint64((1_000_000+99*100)/100), registry.Statuses()[0].AvgPayloadSizeBytes)
+
+	// The initial full push is evicted from the window.
+	recorder.RecordBlock(commondomain.BlockStatus{Height: 101, LastPushedHeight: 101, PayloadSizeBytes: 100})
+	require.Equal(t, uint64(100), registry.Statuses()[0].AvgPayloadSizeBytes)
+}
+
+// Validates that the status endpoint always responds with OK
+// and that the readiness endpoint responds with service unavailable unless all sinks are ready.
+func TestHandler(t *testing.T) {
+	registry := status.NewRegistry(defaultMaxLagBlocks, defaultMaxConsecutiveFailures)
+	handler := status.NewHandler(registry)
+
+	sqsRecorder := registry.Sink(sqsSink)
+	indexerRecorder := registry.Sink(indexerSink)
+
+	sqsRecorder.RecordBlock(commondomain.BlockStatus{Height: 10, LastPushedHeight: 10, PayloadSizeBytes: 100})
+
+	type response struct {
+		IsReady bool                      `json:"is_ready"`
+		Sinks   []commondomain.SinkStatus `json:"sinks"`
+	}
+
+	get := func(path string) (int, response) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+		var resp response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+		return recorder.Code, resp
+	}
+
+	// The indexer did not push yet.
+	code, resp := get(status.StatusPath)
+	require.Equal(t, http.StatusOK, code)
+	require.False(t, resp.IsReady)
+	require.Equal(t, registry.Statuses(), resp.Sinks)
+
+	code, resp = get(status.ReadyPath)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.False(t, resp.IsReady)
+
+	indexerRecorder.RecordBlock(commondomain.BlockStatus{Height: 10, LastPushedHeight: 10})
+
+	code, resp = get(status.ReadyPath)
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, resp.IsReady)
+	require.Equal(t, []string{sqsSink, indexerSink}, []string{resp.Sinks[0].Sink, resp.Sinks[1].Sink})
+}
+
+// Validates that the server is served on the bound address
+// and that an error is returned if the address is already in use.
+func TestStartServer(t *testing.T) {
+	registry := status.NewRegistry(defaultMaxLagBlocks, defaultMaxConsecutiveFailures)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// The address is in use.
+	_, err = status.StartServer(listener.Addr().String(), registry, log.NewNopLogger())
+	require.Error(t, err)
+
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	server, err := status.StartServer(address, registry, log.NewNopLogger())
+	require.NoError(t, err)
+	defer server.Close()
+
+	resp, err := http.Get("http://" + address + status.StatusPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
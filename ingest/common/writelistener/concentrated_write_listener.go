@@ -43,13 +43,23 @@ func (s *concentratedPoolWriteListener) OnWrite(storeKey storetypes.StoreKey, ke
 
 	// Process pool tick write
 	if bytes.Equal(concentratedtypes.TickPrefix, key[:1]) {
-		poolIDPrefixBz := key[len(concentratedtypes.TickPrefix) : concentratedtypes.KeyTickPrefixByPoolIdLengthBytes+1]
+		// The key is the tick prefix, followed by the pool ID and the tick index.
+		// KeyTickPrefixByPoolIdLengthBytes is the length of the prefix together with the pool ID.
+		poolIDPrefixBz := key[len(concentratedtypes.TickPrefix):concentratedtypes.KeyTickPrefixByPoolIdLengthBytes]
 
 		poolID := sdk.BigEndianToUint64(poolIDPrefixBz)
 
-		// We simply track the pool ID so that we can read the pool and all its ticks
-		// from the store at the end of the block.
-		s.poolTracker.TrackConcentratedPoolIDTickChange(poolID)
+		tickIndex, err := concentratedtypes.TickIndexFromBytes(key[concentratedtypes.KeyTickPrefixByPoolIdLengthBytes:])
+		if err != nil {
+			// If the tick index cannot be parsed, fall back to tracking the pool ID only.
+			// The pool is then read with all of its ticks at the end of the block.
+			s.poolTracker.TrackConcentratedPoolIDTickSnapshot(poolID)
+			return nil
+		}
+
+		// We track the pool ID together with the changed tick index so that
+		// only the changed ticks are read from the store at the end of the block.
+		s.poolTracker.TrackConcentratedTickChange(poolID, tickIndex)
 	}
 
 	return nil
@@ -47,6 +47,7 @@ func (s *WriteListenerTestSuite) TestWriteListener_Concentrated() {
 
 		expectedPoolUpdate     bool
 		expectedPoolTickUpdate bool
+		expectedTickIndex      int64
 	}{
 		{
 			name: "concentrated write unrelated to pool state, no-op",
@@ -77,6 +78,16 @@ func (s *WriteListenerTestSuite) TestWriteListener_Concentrated() {
 			value: concentratedPoolModelBz,
 
 			expectedPoolTickUpdate: true,
+			expectedTickIndex:      1,
+		},
+		{
+			name: "write concentrated negative tick index",
+
+			key:   concentratedtypes.KeyTick(concentratedPoolModel.Id, -100),
+			value: concentratedPoolModelBz,
+
+			expectedPoolTickUpdate: true,
+			expectedTickIndex:      -100,
 		},
 	}
 
@@ -114,6 +125,13 @@ func (s *WriteListenerTestSuite) TestWriteListener_Concentrated() {
 				// Check that the pool ID is the one we expect
 				_, ok := concentratedPoolIDTickChange[concentratedPoolModel.Id]
 				s.Require().True(ok)
+
+				// Check that the changed tick index is tracked
+				concentratedPoolIDChangedTicks := poolTracker.GetConcentratedPoolIDChangedTicks()
+				s.Require().Len(concentratedPoolIDChangedTicks[concentratedPoolModel.Id], 1)
+				_, ok = concentratedPoolIDChangedTicks[concentratedPoolModel.Id][tc.expectedTickIndex]
+				s.Require().True(ok)
+				s.Require().Empty(poolTracker.GetConcentratedPoolIDTickSnapshot())
 			} else {
 				concentratedPoolIDTickChange := poolTracker.GetConcentratedPoolIDTickChange()
 				s.Require().Len(concentratedPoolIDTickChange, 0)
@@ -0,0 +1,64 @@
+package writelistener
+
+import (
+	"bytes"
+
+	storetypes "cosmossdk.io/store/types"
+
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+var (
+	_ commondomain.WriteListener = (*poolManagerWriteListener)(nil)
+	_ commondomain.WriteListener = (*poolManagerParamsWriteListener)(nil)
+)
+
+// poolManagerParamsKeyPrefix is the prefix of the poolmanager params in the params store.
+var poolManagerParamsKeyPrefix = []byte(poolmanagertypes.ModuleName + "/")
+
+type poolManagerWriteListener struct {
+	poolTracker domain.BlockPoolUpdateTracker
+}
+
+func NewPoolManager(poolTracker domain.BlockPoolUpdateTracker) *poolManagerWriteListener {
+	return &poolManagerWriteListener{
+		poolTracker: poolTracker,
+	}
+}
+
+// OnWrite implements types.WriteListener
+// Tracks the denom pairs whose taker fee override was set or removed.
+func (s *poolManagerWriteListener) OnWrite(storeKey storetypes.StoreKey, key []byte, value []byte, delete bool) error {
+	if len(key) > 0 && bytes.Equal(poolmanagertypes.DenomTradePairPrefix, key[:1]) {
+		tokenInDenom, tokenOutDenom, err := poolmanagertypes.ParseDenomTradePairKey(key)
+		if err != nil {
+			return err
+		}
+
+		s.poolTracker.TrackTakerFeeDenomPair(tokenInDenom, tokenOutDenom)
+	}
+
+	return nil
+}
+
+type poolManagerParamsWriteListener struct {
+	poolTracker domain.BlockPoolUpdateTracker
+}
+
+func NewPoolManagerParams(poolTracker domain.BlockPoolUpdateTracker) *poolManagerParamsWriteListener {
+	return &poolManagerParamsWriteListener{
+		poolTracker: poolTracker,
+	}
+}
+
+// OnWrite implements types.WriteListener
+// Tracks writes to the poolmanager subspace of the params store since they might change the default taker fee.
+func (s *poolManagerParamsWriteListener) OnWrite(storeKey storetypes.StoreKey, key []byte, value []byte, delete bool) error {
+	if bytes.HasPrefix(key, poolManagerParamsKeyPrefix) {
+		s.poolTracker.TrackTakerFeeParamsChange()
+	}
+
+	return nil
+}
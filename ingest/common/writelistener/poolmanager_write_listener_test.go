@@ -0,0 +1,127 @@
+package writelistener_test
+
+import (
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/pooltracker"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/writelistener"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+const (
+	denomA = "denomA"
+	denomB = "denomB"
+)
+
+// Tests that the poolmanager write listener correctly tracks the denom pairs
+// whose taker fee override was written.
+func (s *WriteListenerTestSuite) TestWriteListener_PoolManager() {
+	s.Setup()
+
+	testCases := []struct {
+		name string
+
+		key      []byte
+		value    []byte
+		isDelete bool
+
+		expectedDenomPairs map[ingesttypes.DenomPair]struct{}
+	}{
+		{
+			name: "poolmanager write unrelated to taker fee, no-op",
+
+			key:   poolmanagertypes.KeyNextGlobalPoolId,
+			value: someValue,
+
+			expectedDenomPairs: map[ingesttypes.DenomPair]struct{}{},
+		},
+		{
+			name: "write taker fee override",
+
+			key:   poolmanagertypes.FormatDenomTradePairKey(denomA, denomB),
+			value: someValue,
+
+			expectedDenomPairs: map[ingesttypes.DenomPair]struct{}{
+				{Denom0: denomA, Denom1: denomB}: {},
+			},
+		},
+		{
+			name: "delete taker fee override in the opposite direction",
+
+			key:      poolmanagertypes.FormatDenomTradePairKey(denomB, denomA),
+			isDelete: true,
+
+			expectedDenomPairs: map[ingesttypes.DenomPair]struct{}{
+				{Denom0: denomB, Denom1: denomA}: {},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		s.Run(tc.name, func() {
+			poolTracker := pooltracker.NewMemory()
+
+			poolManagerWriteListener := writelistener.NewPoolManager(poolTracker)
+
+			poolManagerKVStore := s.App.GetKey(poolmanagertypes.StoreKey)
+
+			err := poolManagerWriteListener.OnWrite(poolManagerKVStore, tc.key, tc.value, tc.isDelete)
+			s.Require().NoError(err)
+
+			s.Require().Equal(tc.expectedDenomPairs, poolTracker.GetTakerFeeDenomPairs())
+
+			// Params are not tracked by this listener.
+			s.Require().False(poolTracker.IsTakerFeeParamsChanged())
+		})
+	}
+}
+
+// Tests that the poolmanager params write listener only tracks writes to the poolmanager params.
+func (s *WriteListenerTestSuite) TestWriteListener_PoolManagerParams() {
+	s.Setup()
+
+	testCases := []struct {
+		name string
+
+		key []byte
+
+		expectedIsTakerFeeParamsChanged bool
+	}{
+		{
+			name: "write to other module params, no-op",
+
+			key: []byte("gamm/PoolCreationFee"),
+		},
+		{
+			name: "write to module with common name prefix, no-op",
+
+			key: []byte(poolmanagertypes.ModuleName + "x/DefaultTakerFee"),
+		},
+		{
+			name: "write to poolmanager params",
+
+			key: []byte(poolmanagertypes.ModuleName + "/DefaultTakerFee"),
+
+			expectedIsTakerFeeParamsChanged: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		s.Run(tc.name, func() {
+			poolTracker := pooltracker.NewMemory()
+
+			paramsWriteListener := writelistener.NewPoolManagerParams(poolTracker)
+
+			paramsKVStore := s.App.GetKey(paramstypes.StoreKey)
+
+			err := paramsWriteListener.OnWrite(paramsKVStore, tc.key, someValue, false)
+			s.Require().NoError(err)
+
+			s.Require().Equal(tc.expectedIsTakerFeeParamsChanged, poolTracker.IsTakerFeeParamsChanged())
+			s.Require().Empty(poolTracker.GetTakerFeeDenomPairs())
+		})
+	}
+}
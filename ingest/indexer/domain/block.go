@@ -8,4 +8,7 @@ type Block struct {
 	BlockTime   time.Time `json:"timestamp"`
 	GasConsumed uint64    `json:"gas_consumed"`
 	IngestedAt  time.Time `json:"ingested_at"`
+	// MessageID is the deterministic ID of the message, see BlockMessageID.
+	MessageID     string `json:"message_id"`
+	SchemaVersion int    `json:"schema_version"`
 }
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+// DefaultEventAllowlist is the allowlist of the transaction events published by default:
+// the swaps, the joins and exits of the pools and the creations and withdrawals of the CL positions.
+const DefaultEventAllowlist = "token_swapped,pool_joined,pool_exited,create_position,withdraw_position"
+
+// EventFilter selects the transaction events published by the indexer.
+type EventFilter struct {
+	rules []eventRule
+}
+
+// eventRule matches the events whose type matches the type pattern and that have
+// a matching attribute for every attribute pattern.
+type eventRule struct {
+	typePattern       string
+	attributePatterns []attributePattern
+}
+
+// attributePattern matches the attributes whose key and value match the patterns.
+type attributePattern struct {
+	keyPattern   string
+	valuePattern string
+}
+
+// ParseEventFilter parses a comma-separated allowlist of event rules.
+// A rule is a glob pattern of the event type, e.g. "token_swapped" or "ibc_*", optionally followed by
+// attribute filters in brackets that must all match, e.g. "wasm[_contract_address=osmo1abc*,action=swap]".
+// In the patterns, "*" matches any sequence of characters and "?" any single character.
+// Returns error if a rule is malformed.
+func ParseEventFilter(allowlist string) (EventFilter, error) {
+	var filter EventFilter
+	for _, rule := range splitOutsideBrackets(allowlist) {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		typePattern, attributes, hasAttributes := strings.Cut(rule, "[")
+		parsedRule := eventRule{typePattern: strings.TrimSpace(typePattern)}
+		if parsedRule.typePattern == "" {
+			return EventFilter{}, fmt.Errorf("event rule (%s) has no event type", rule)
+		}
+
+		if hasAttributes {
+			if !strings.HasSuffix(attributes, "]") {
+				return EventFilter{}, fmt.Errorf("event rule (%s) has unterminated attribute filters", rule)
+			}
+
+			for _, attribute := range strings.Split(strings.TrimSuffix(attributes, "]"), ",") {
+				key, value, ok := strings.Cut(attribute, "=")
+				key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+				if !ok || key == "" {
+					return EventFilter{}, fmt.Errorf("event rule (%s) has malformed attribute filter (%s), expected key=value", rule, attribute)
+				}
+				parsedRule.attributePatterns = append(parsedRule.attributePatterns, attributePattern{keyPattern: key, valuePattern: value})
+			}
+		}
+
+		filter.rules = append(filter.rules, parsedRule)
+	}
+
+	return filter, nil
+}
+
+// Match returns true if any rule of the filter matches the event.
+func (f EventFilter) Match(event abci.Event) bool {
+	for _, rule := range f.rules {
+		if rule.match(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// match returns true if the event type and all the attribute patterns of the rule match the event.
+func (r eventRule) match(event abci.Event) bool {
+	if !matchGlob(r.typePattern, event.Type) {
+		return false
+	}
+
+	for _, pattern := range r.attributePatterns {
+		matched := false
+		for _, attribute := range event.Attributes {
+			if matchGlob(pattern.keyPattern, attribute.Key) && matchGlob(pattern.valuePattern, attribute.Value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// splitOutsideBrackets splits the list on the commas that are not inside brackets.
+func splitOutsideBrackets(list string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i, c := range list {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, list[start:])
+}
+
+// matchGlob returns true if the glob pattern matches the whole string.
+// Unlike path.Match, "*" also matches "/" so that patterns match denoms such as "ibc/...".
+func matchGlob(pattern, s string) bool {
+	// The position of the last "*" and the position in s it currently matches up to, for backtracking.
+	starIdx, matchIdx := -1, 0
+	p, i := 0, 0
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == s[i]):
+			p++
+			i++
+		case p < len(pattern) && pattern[p] == '*':
+			starIdx, matchIdx = p, i
+			p++
+		case starIdx != -1:
+			p = starIdx + 1
+			matchIdx++
+			i = matchIdx
+		default:
+			return false
+		}
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
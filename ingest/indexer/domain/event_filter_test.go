@@ -0,0 +1,64 @@
+package domain_test
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+)
+
+// newEvent returns an event of the given type with the given key and value pairs as attributes.
+func newEvent(eventType string, keyValues ...string) abci.Event {
+	event := abci.Event{Type: eventType}
+	for i := 0; i < len(keyValues); i += 2 {
+		event.Attributes = append(event.Attributes, abci.EventAttribute{Key: keyValues[i], Value: keyValues[i+1]})
+	}
+	return event
+}
+
+func TestEventFilter_Match(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist string
+		event     abci.Event
+		expected  bool
+	}{
+		{"default swap", domain.DefaultEventAllowlist, newEvent("token_swapped"), true},
+		{"default withdraw position", domain.DefaultEventAllowlist, newEvent("withdraw_position"), true},
+		{"default transfer", domain.DefaultEventAllowlist, newEvent("transfer"), false},
+		{"empty", "", newEvent("token_swapped"), false},
+		{"prefix glob", "ibc_*", newEvent("ibc_transfer"), true},
+		{"prefix glob mismatch", "ibc_*", newEvent("fungible_token_packet"), false},
+		{"single character glob", "pool_?oined", newEvent("pool_joined"), true},
+		{"all", "*", newEvent("message"), true},
+		{"attribute", "wasm[_contract_address=osmo1abc*]", newEvent("wasm", "_contract_address", "osmo1abcdef"), true},
+		{"attribute mismatch", "wasm[_contract_address=osmo1abc*]", newEvent("wasm", "_contract_address", "osmo1xyz"), false},
+		{"attribute missing", "wasm[_contract_address=osmo1abc*]", newEvent("wasm"), false},
+		{"all attributes", "wasm[_contract_address=osmo1abc*,action=swap]", newEvent("wasm", "_contract_address", "osmo1abc", "action", "swap"), true},
+		{"one of the attributes", "wasm[_contract_address=osmo1abc*,action=swap]", newEvent("wasm", "_contract_address", "osmo1abc", "action", "claim"), false},
+		{"attribute glob matching slash", "tf_mint[denom=factory/*]", newEvent("tf_mint", "denom", "factory/osmo1abc/token"), true},
+		{"several rules", " token_swapped , wasm[action=swap] ", newEvent("wasm", "action", "swap"), true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := domain.ParseEventFilter(tc.allowlist)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, filter.Match(tc.event))
+		})
+	}
+}
+
+func TestParseEventFilter_Invalid(t *testing.T) {
+	for _, allowlist := range []string{
+		"[action=swap]",
+		"wasm[action=swap",
+		"wasm[action]",
+		"wasm[=swap]",
+	} {
+		_, err := domain.ParseEventFilter(allowlist)
+		require.Error(t, err, allowlist)
+	}
+}
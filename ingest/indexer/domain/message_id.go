@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SchemaVersion is the version of the schema of the published messages.
+// It is incremented whenever a message field is changed or removed.
+// Version 2 added the messages, signers and result of the transactions and the pool messages.
+const SchemaVersion = 2
+
+// The transport attributes of the published messages, e.g. the Pub/Sub attributes or the Kafka headers.
+const (
+	MessageIDAttribute     = "message_id"
+	SchemaVersionAttribute = "schema_version"
+	// EncodingAttribute is the encoding of the message data, e.g. "json", "protobuf" or "avro".
+	EncodingAttribute = "encoding"
+)
+
+// MessageAttributes returns the transport attributes of the message with the given ID and encoding.
+func MessageAttributes(messageID string, encoding string) map[string]string {
+	return map[string]string{
+		MessageIDAttribute:     messageID,
+		SchemaVersionAttribute: strconv.Itoa(SchemaVersion),
+		EncodingAttribute:      encoding,
+	}
+}
+
+// The message IDs are deterministic so that consumers can deduplicate redeliveries and replays.
+// A message published again for the same block has the same ID.
+
+// BlockMessageID returns the message ID of the block at the given height.
+func BlockMessageID(chainID string, height uint64) string {
+	return fmt.Sprintf("%s/%d", chainID, height)
+}
+
+// TransactionMessageID returns the message ID of the transaction with the given index in the block.
+func TransactionMessageID(chainID string, height uint64, txIndex int) string {
+	return fmt.Sprintf("%s/%d/tx/%d", chainID, height, txIndex)
+}
+
+// EventID returns the ID of the event with the given index in the transaction.
+func EventID(chainID string, height uint64, txIndex int, eventIndex int) string {
+	return fmt.Sprintf("%s/%d/tx/%d/event/%d", chainID, height, txIndex, eventIndex)
+}
+
+// PairMessageID returns the message ID of the pair of the pool at the given height.
+func PairMessageID(chainID string, height uint64, poolID uint64, idxDenom0, idxDenom1 uint8) string {
+	return fmt.Sprintf("%s/%d/pair/%d/%d/%d", chainID, height, poolID, idxDenom0, idxDenom1)
+}
+
+// PoolMessageID returns the message ID of the state of the pool at the given height.
+func PoolMessageID(chainID string, height uint64, poolID uint64) string {
+	return fmt.Sprintf("%s/%d/pool/%d", chainID, height, poolID)
+}
+
+// TokenSupplyMessageID returns the message ID of the token supply of the denom at the given height.
+// The supply of a denom may be published several times per block, so the occurrence of the message
+// in the block is appended if it is not the first.
+func TokenSupplyMessageID(chainID string, height uint64, denom string, occurrence int) string {
+	return withOccurrence(fmt.Sprintf("%s/%d/token_supply/%s", chainID, height, denom), occurrence)
+}
+
+// TokenSupplyOffsetMessageID returns the message ID of the token supply offset of the denom at the given height.
+// The occurrence is appended as for TokenSupplyMessageID.
+func TokenSupplyOffsetMessageID(chainID string, height uint64, denom string, occurrence int) string {
+	return withOccurrence(fmt.Sprintf("%s/%d/token_supply_offset/%s", chainID, height, denom), occurrence)
+}
+
+// withOccurrence appends the occurrence to the message ID if it is not the first.
+func withOccurrence(messageID string, occurrence int) string {
+	if occurrence == 0 {
+		return messageID
+	}
+	return fmt.Sprintf("%s#%d", messageID, occurrence)
+}
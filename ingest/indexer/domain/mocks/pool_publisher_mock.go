@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+)
+
+var _ indexerdomain.PoolPublisher = &MockPoolPublisher{}
+
+// MockPoolPublisher is a mock implementation of the PoolPublisher interface.
+type MockPoolPublisher struct {
+	PublishPoolsError    error
+	PublishPoolsCalled   bool
+	CalledWithBlockPools commondomain.BlockPools
+	NumPoolsPublished    int
+}
+
+func (m *MockPoolPublisher) PublishPools(ctx sdk.Context, blockPools commondomain.BlockPools) error {
+	m.PublishPoolsCalled = true
+	m.CalledWithBlockPools = blockPools
+	m.NumPoolsPublished += len(blockPools.GetAll())
+	return m.PublishPoolsError
+}
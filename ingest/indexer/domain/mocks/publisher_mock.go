@@ -14,6 +14,7 @@ type PublisherMock struct {
 	CalledWithTokenSupply            indexerdomain.TokenSupply
 	CalledWithTokenSupplyOffset      indexerdomain.TokenSupplyOffset
 	CalledWithTransaction            indexerdomain.Transaction
+	CalledWithPool                   indexerdomain.Pool
 	NumPublishPairCalls              int
 	NumPublishPairCallsWithCreation  int
 	PublishPairCallMutex             sync.Mutex
@@ -21,11 +22,16 @@ type PublisherMock struct {
 	NumPublishTokenSupplyCalls       int
 	NumPublishTokenSupplyOffsetCalls int
 	NumPublishTransactionCalls       int
+	NumPublishPoolCalls              int
 	ForcePairError                   error
 	ForceBlockError                  error
 	ForceTokenSupplyError            error
 	ForceTokenSupplyOffsetError      error
 	ForceTransactionError            error
+	ForcePoolError                   error
+	// PublishedBytes is the count to return when PopPublishedBytes is called.
+	PublishedBytes uint64
+	NumCloseCalls  int
 }
 
 // PublishPair implements domain.Publisher.
@@ -71,4 +77,49 @@ func (p *PublisherMock) PublishTransaction(ctx context.Context, txn indexerdomai
 	return p.ForceTransactionError
 }
 
-var _ indexerdomain.Publisher = &PublisherMock{}
+// PublishPool implements domain.Publisher.
+func (p *PublisherMock) PublishPool(ctx context.Context, pool indexerdomain.Pool) error {
+	p.CalledWithPool = pool
+	p.NumPublishPoolCalls++
+	return p.ForcePoolError
+}
+
+// PopPublishedBytes implements domain.PublishedBytesCounter.
+func (p *PublisherMock) PopPublishedBytes() uint64 {
+	publishedBytes := p.PublishedBytes
+	p.PublishedBytes = 0
+	return publishedBytes
+}
+
+// Close implements io.Closer.
+func (p *PublisherMock) Close() error {
+	p.NumCloseCalls++
+	return nil
+}
+
+var (
+	_ indexerdomain.Publisher             = &PublisherMock{}
+	_ indexerdomain.PublishedBytesCounter = &PublisherMock{}
+)
+
+// BlockCommitterPublisherMock is a mock for a Publisher that writes blocks atomically.
+type BlockCommitterPublisherMock struct {
+	PublisherMock
+	NumCommitBlockCalls   int
+	NumRollbackBlockCalls int
+	ForceCommitBlockError error
+}
+
+// CommitBlock implements domain.BlockCommitter.
+func (p *BlockCommitterPublisherMock) CommitBlock(ctx context.Context) error {
+	p.NumCommitBlockCalls++
+	return p.ForceCommitBlockError
+}
+
+// RollbackBlock implements domain.BlockCommitter.
+func (p *BlockCommitterPublisherMock) RollbackBlock() error {
+	p.NumRollbackBlockCalls++
+	return nil
+}
+
+var _ indexerdomain.BlockCommitter = &BlockCommitterPublisherMock{}
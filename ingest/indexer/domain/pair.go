@@ -18,6 +18,9 @@ type Pair struct {
 	PairCreatedAt        time.Time `json:"pair_created_at"`
 	PairCreatedAtHeight  uint64    `json:"pair_created_at_height"`
 	PairCreatedAtTxnHash string    `json:"pair_created_at_txn_hash"`
+	// MessageID is the deterministic ID of the message, see PairMessageID.
+	MessageID     string `json:"message_id"`
+	SchemaVersion int    `json:"schema_version"`
 }
 
 // ShouldFilterDenom returns true if the given denom should be filtered out.
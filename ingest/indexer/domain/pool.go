@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+)
+
+// Pool represents the state of a pool at the end of a block and message to be published to the pool topic.
+type Pool struct {
+	PoolID uint64 `json:"pool_id"`
+	// Type is the pool type, e.g. "Balancer", "Stableswap", "Concentrated" or "CosmWasm".
+	Type         string       `json:"type"`
+	Denoms       []string     `json:"denoms"`
+	Balances     sdk.Coins    `json:"balances"`
+	SpreadFactor osmomath.Dec `json:"spread_factor"`
+	// CurrentTick and CurrentSqrtPrice are only set for the concentrated liquidity pools.
+	CurrentTick      int64  `json:"current_tick"`
+	CurrentSqrtPrice string `json:"current_sqrt_price"`
+	// LiquidityCap is the TVL of the pool in USDC, computed from the spot prices as for SQS.
+	// LiquidityCapError is not empty if the price of some of the balances could not be computed.
+	LiquidityCap      osmomath.Int `json:"liquidity_cap"`
+	LiquidityCapError string       `json:"liquidity_cap_error"`
+	Height            uint64       `json:"height"`
+	BlockTime         time.Time    `json:"timestamp"`
+	IngestedAt        time.Time    `json:"ingested_at"`
+	// MessageID is the deterministic ID of the message, see PoolMessageID.
+	MessageID     string `json:"message_id"`
+	SchemaVersion int    `json:"schema_version"`
+}
@@ -22,6 +22,22 @@ type Publisher interface {
 	PublishBlock(ctx context.Context, block Block) error
 	PublishTransaction(ctx context.Context, txn Transaction) error
 	PublishPair(ctx context.Context, pair Pair) error
+	PublishPool(ctx context.Context, pool Pool) error
+}
+
+// PublishedBytesCounter is implemented by the publishers that count the bytes of the published messages.
+type PublishedBytesCounter interface {
+	// PopPublishedBytes returns the number of bytes published since the last call and resets the count.
+	PopPublishedBytes() uint64
+}
+
+// BlockCommitter is implemented by the publishers that write the data of a block atomically.
+// The data published for a block is visible only once the block is committed.
+type BlockCommitter interface {
+	// CommitBlock commits the data published since the last commit or rollback.
+	CommitBlock(ctx context.Context) error
+	// RollbackBlock discards the data published since the last commit or rollback.
+	RollbackBlock() error
 }
 
 // PairPublisher is an interface for publishing pair data.
@@ -33,3 +49,10 @@ type PairPublisher interface {
 	//   with the taker fee and spread factor, as well as the newly created pool metadata, if any.
 	PublishPoolPairs(ctx sdk.Context, pools []poolmanagertypes.PoolI, createdPoolIDs map[uint64]commondomain.PoolCreation) error
 }
+
+// PoolPublisher is an interface for publishing pool state data.
+type PoolPublisher interface {
+	// PublishPools publishes the state of the given pools: their balances, spread factor,
+	// current tick and sqrt price for the concentrated pools and their liquidity capitalization.
+	PublishPools(ctx sdk.Context, blockPools commondomain.BlockPools) error
+}
@@ -10,10 +10,16 @@ type TokenSupply struct {
 	Denom      string       `json:"denom"`
 	Supply     osmomath.Int `json:"supply"`
 	IngestedAt time.Time    `json:"ingested_at"`
+	// MessageID is the deterministic ID of the message, see TokenSupplyMessageID.
+	MessageID     string `json:"message_id"`
+	SchemaVersion int    `json:"schema_version"`
 }
 
 type TokenSupplyOffset struct {
 	Denom        string       `json:"denom"`
 	SupplyOffset osmomath.Int `json:"supply_offset"`
 	IngestedAt   time.Time    `json:"ingested_at"`
+	// MessageID is the deterministic ID of the message, see TokenSupplyOffsetMessageID.
+	MessageID     string `json:"message_id"`
+	SchemaVersion int    `json:"schema_version"`
 }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/cometbft/cometbft/abci/types"
@@ -12,6 +13,21 @@ import (
 type EventWrapper struct {
 	Index int         `json:"event_index"`
 	Event types.Event `json:"event"`
+	// EventID is the deterministic ID of the event, see EventID.
+	EventID string `json:"event_id"`
+}
+
+// The statuses of a transaction.
+const (
+	TransactionStatusSuccess = "success"
+	TransactionStatusFailed  = "failed"
+)
+
+// TransactionMessage is a message of a transaction.
+type TransactionMessage struct {
+	TypeURL string `json:"type_url"`
+	// Body is the message encoded as proto3 JSON.
+	Body json.RawMessage `json:"body"`
 }
 
 type Transaction struct {
@@ -25,4 +41,23 @@ type Transaction struct {
 	TransactionIndexId int            `json:"tx_index_id"`
 	Events             []EventWrapper `json:"events"`
 	IngestedAt         time.Time      `json:"ingested_at"`
+	// Messages are all the messages of the transaction. MessageType is the type of the first one.
+	Messages []TransactionMessage `json:"messages"`
+	// Signers are the bech32 addresses of the signers.
+	Signers       []string `json:"signers"`
+	Memo          string   `json:"memo"`
+	TimeoutHeight uint64   `json:"timeout_height"`
+	FeePayer      string   `json:"fee_payer"`
+	// FeeGranter is empty if the fee is not granted.
+	FeeGranter string `json:"fee_granter"`
+	// Status is TransactionStatusSuccess or TransactionStatusFailed.
+	// The events of failed transactions are not published.
+	Status string `json:"status"`
+	// Code, Codespace and Log are the result of the transaction, explaining why it failed.
+	Code      uint32 `json:"code"`
+	Codespace string `json:"codespace"`
+	Log       string `json:"log"`
+	// MessageID is the deterministic ID of the message, see TransactionMessageID.
+	MessageID     string `json:"message_id"`
+	SchemaVersion int    `json:"schema_version"`
 }
@@ -0,0 +1,313 @@
+package encoding
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hamba/avro/v2"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+)
+
+// The Avro schema files of the messages.
+const (
+	blockAvroSchema             = "block.avsc"
+	transactionAvroSchema       = "transaction.avsc"
+	pairAvroSchema              = "pair.avsc"
+	poolAvroSchema              = "pool.avsc"
+	tokenSupplyAvroSchema       = "token_supply.avsc"
+	tokenSupplyOffsetAvroSchema = "token_supply_offset.avsc"
+)
+
+// avroEncoder encodes the messages with the Avro schemas.
+type avroEncoder struct {
+	schemas map[string]avro.Schema
+}
+
+// newAvroEncoder returns the Avro encoder with the embedded schemas parsed.
+// Returns error if a schema fails to parse.
+func newAvroEncoder() (*avroEncoder, error) {
+	encoder := &avroEncoder{
+		schemas: make(map[string]avro.Schema),
+	}
+
+	for _, name := range []string{blockAvroSchema, transactionAvroSchema, pairAvroSchema, poolAvroSchema, tokenSupplyAvroSchema, tokenSupplyOffsetAvroSchema} {
+		bz, err := schemas.ReadFile("schemas/" + name)
+		if err != nil {
+			return nil, err
+		}
+
+		schema, err := avro.Parse(string(bz))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse avro schema %s: %w", name, err)
+		}
+
+		encoder.schemas[name] = schema
+	}
+
+	return encoder, nil
+}
+
+// Encoding implements Encoder.
+func (e *avroEncoder) Encoding() string {
+	return Avro
+}
+
+// Encode implements Encoder.
+func (e *avroEncoder) Encode(message any) ([]byte, error) {
+	schemaName, record, err := toAvro(message)
+	if err != nil {
+		return nil, err
+	}
+	return avro.Marshal(e.schemas[schemaName], record)
+}
+
+// avroBlock is the Avro record of a Block.
+type avroBlock struct {
+	ChainID       string    `avro:"chain_id"`
+	Height        int64     `avro:"height"`
+	Timestamp     time.Time `avro:"timestamp"`
+	GasConsumed   int64     `avro:"gas_consumed"`
+	IngestedAt    time.Time `avro:"ingested_at"`
+	MessageID     string    `avro:"message_id"`
+	SchemaVersion int32     `avro:"schema_version"`
+}
+
+// avroCoin is the Avro record of a fee of a Transaction.
+type avroCoin struct {
+	Denom  string `avro:"denom"`
+	Amount string `avro:"amount"`
+}
+
+// avroEventAttribute is the Avro record of an attribute of an Event.
+type avroEventAttribute struct {
+	Key   string `avro:"key"`
+	Value string `avro:"value"`
+}
+
+// avroEvent is the Avro record of an event of a Transaction.
+type avroEvent struct {
+	EventID    string               `avro:"event_id"`
+	EventIndex int32                `avro:"event_index"`
+	Type       string               `avro:"type"`
+	Attributes []avroEventAttribute `avro:"attributes"`
+}
+
+// avroTransactionMessage is the Avro record of a message of a Transaction.
+type avroTransactionMessage struct {
+	TypeURL string `avro:"type_url"`
+	Body    string `avro:"body"`
+}
+
+// avroTransaction is the Avro record of a Transaction.
+type avroTransaction struct {
+	Height        int64       `avro:"height"`
+	Timestamp     time.Time   `avro:"timestamp"`
+	GasWanted     int64       `avro:"gas_wanted"`
+	GasUsed       int64       `avro:"gas_used"`
+	Fees          []avroCoin  `avro:"fees"`
+	MsgType       string      `avro:"msg_type"`
+	TxHash        string      `avro:"tx_hash"`
+	TxIndexID     int64       `avro:"tx_index_id"`
+	Events        []avroEvent `avro:"events"`
+	IngestedAt    time.Time   `avro:"ingested_at"`
+	MessageID     string      `avro:"message_id"`
+	SchemaVersion int32       `avro:"schema_version"`
+
+	Messages      []avroTransactionMessage `avro:"messages"`
+	Signers       []string                 `avro:"signers"`
+	Memo          string                   `avro:"memo"`
+	TimeoutHeight int64                    `avro:"timeout_height"`
+	FeePayer      string                   `avro:"fee_payer"`
+	FeeGranter    string                   `avro:"fee_granter"`
+	Status        string                   `avro:"status"`
+	Code          int64                    `avro:"code"`
+	Codespace     string                   `avro:"codespace"`
+	Log           string                   `avro:"log"`
+}
+
+// avroPair is the Avro record of a Pair.
+type avroPair struct {
+	PoolID               int64      `avro:"pool_id"`
+	MultiAsset           bool       `avro:"multi_asset"`
+	Denom0               string     `avro:"denom_0"`
+	IdxDenom0            int32      `avro:"idx_denom_0"`
+	Denom1               string     `avro:"denom_1"`
+	IdxDenom1            int32      `avro:"idx_denom_1"`
+	FeeBps               int64      `avro:"fee_bps"`
+	IngestedAt           time.Time  `avro:"ingested_at"`
+	PairCreatedAt        *time.Time `avro:"pair_created_at"`
+	PairCreatedAtHeight  int64      `avro:"pair_created_at_height"`
+	PairCreatedAtTxnHash string     `avro:"pair_created_at_txn_hash"`
+	MessageID            string     `avro:"message_id"`
+	SchemaVersion        int32      `avro:"schema_version"`
+}
+
+// avroPool is the Avro record of a Pool.
+type avroPool struct {
+	PoolID            int64      `avro:"pool_id"`
+	Type              string     `avro:"type"`
+	Denoms            []string   `avro:"denoms"`
+	Balances          []avroCoin `avro:"balances"`
+	SpreadFactor      string     `avro:"spread_factor"`
+	CurrentTick       int64      `avro:"current_tick"`
+	CurrentSqrtPrice  string     `avro:"current_sqrt_price"`
+	LiquidityCap      string     `avro:"liquidity_cap"`
+	LiquidityCapError string     `avro:"liquidity_cap_error"`
+	Height            int64      `avro:"height"`
+	Timestamp         time.Time  `avro:"timestamp"`
+	IngestedAt        time.Time  `avro:"ingested_at"`
+	MessageID         string     `avro:"message_id"`
+	SchemaVersion     int32      `avro:"schema_version"`
+}
+
+// avroTokenSupply is the Avro record of a TokenSupply.
+type avroTokenSupply struct {
+	Denom         string    `avro:"denom"`
+	Supply        string    `avro:"supply"`
+	IngestedAt    time.Time `avro:"ingested_at"`
+	MessageID     string    `avro:"message_id"`
+	SchemaVersion int32     `avro:"schema_version"`
+}
+
+// avroTokenSupplyOffset is the Avro record of a TokenSupplyOffset.
+type avroTokenSupplyOffset struct {
+	Denom         string    `avro:"denom"`
+	SupplyOffset  string    `avro:"supply_offset"`
+	IngestedAt    time.Time `avro:"ingested_at"`
+	MessageID     string    `avro:"message_id"`
+	SchemaVersion int32     `avro:"schema_version"`
+}
+
+// toAvro converts the given Block, Transaction, Pair, Pool, TokenSupply or TokenSupplyOffset to its Avro record.
+// Returns the name of the schema file of the record. Returns error if the message is of another type.
+func toAvro(message any) (string, any, error) {
+	switch m := message.(type) {
+	case domain.Block:
+		return blockAvroSchema, avroBlock{
+			ChainID:       m.ChainId,
+			Height:        int64(m.Height),
+			Timestamp:     m.BlockTime,
+			GasConsumed:   int64(m.GasConsumed),
+			IngestedAt:    m.IngestedAt,
+			MessageID:     m.MessageID,
+			SchemaVersion: int32(m.SchemaVersion),
+		}, nil
+	case domain.Transaction:
+		fees := make([]avroCoin, 0, len(m.Fees))
+		for _, fee := range m.Fees {
+			fees = append(fees, avroCoin{Denom: fee.Denom, Amount: intString(fee.Amount)})
+		}
+
+		events := make([]avroEvent, 0, len(m.Events))
+		for _, event := range m.Events {
+			attributes := make([]avroEventAttribute, 0, len(event.Event.Attributes))
+			for _, attribute := range event.Event.Attributes {
+				attributes = append(attributes, avroEventAttribute{Key: attribute.Key, Value: attribute.Value})
+			}
+
+			events = append(events, avroEvent{
+				EventID:    event.EventID,
+				EventIndex: int32(event.Index),
+				Type:       event.Event.Type,
+				Attributes: attributes,
+			})
+		}
+
+		messages := make([]avroTransactionMessage, 0, len(m.Messages))
+		for _, message := range m.Messages {
+			messages = append(messages, avroTransactionMessage{TypeURL: message.TypeURL, Body: string(message.Body)})
+		}
+
+		return transactionAvroSchema, avroTransaction{
+			Height:        int64(m.Height),
+			Timestamp:     m.BlockTime,
+			GasWanted:     int64(m.GasWanted),
+			GasUsed:       int64(m.GasUsed),
+			Fees:          fees,
+			MsgType:       m.MessageType,
+			TxHash:        m.TransactionHash,
+			TxIndexID:     int64(m.TransactionIndexId),
+			Events:        events,
+			IngestedAt:    m.IngestedAt,
+			MessageID:     m.MessageID,
+			SchemaVersion: int32(m.SchemaVersion),
+			Messages:      messages,
+			Signers:       m.Signers,
+			Memo:          m.Memo,
+			TimeoutHeight: int64(m.TimeoutHeight),
+			FeePayer:      m.FeePayer,
+			FeeGranter:    m.FeeGranter,
+			Status:        m.Status,
+			Code:          int64(m.Code),
+			Codespace:     m.Codespace,
+			Log:           m.Log,
+		}, nil
+	case domain.Pair:
+		var pairCreatedAt *time.Time
+		if !m.PairCreatedAt.IsZero() {
+			pairCreatedAt = &m.PairCreatedAt
+		}
+
+		return pairAvroSchema, avroPair{
+			PoolID:               int64(m.PoolID),
+			MultiAsset:           m.MultiAsset,
+			Denom0:               m.Denom0,
+			IdxDenom0:            int32(m.IdxDenom0),
+			Denom1:               m.Denom1,
+			IdxDenom1:            int32(m.IdxDenom1),
+			FeeBps:               int64(m.FeeBps),
+			IngestedAt:           m.IngestedAt,
+			PairCreatedAt:        pairCreatedAt,
+			PairCreatedAtHeight:  int64(m.PairCreatedAtHeight),
+			PairCreatedAtTxnHash: m.PairCreatedAtTxnHash,
+			MessageID:            m.MessageID,
+			SchemaVersion:        int32(m.SchemaVersion),
+		}, nil
+	case domain.Pool:
+		denoms := m.Denoms
+		if denoms == nil {
+			denoms = []string{}
+		}
+
+		balances := make([]avroCoin, 0, len(m.Balances))
+		for _, balance := range m.Balances {
+			balances = append(balances, avroCoin{Denom: balance.Denom, Amount: intString(balance.Amount)})
+		}
+
+		return poolAvroSchema, avroPool{
+			PoolID:            int64(m.PoolID),
+			Type:              m.Type,
+			Denoms:            denoms,
+			Balances:          balances,
+			SpreadFactor:      decString(m.SpreadFactor),
+			CurrentTick:       m.CurrentTick,
+			CurrentSqrtPrice:  m.CurrentSqrtPrice,
+			LiquidityCap:      intString(m.LiquidityCap),
+			LiquidityCapError: m.LiquidityCapError,
+			Height:            int64(m.Height),
+			Timestamp:         m.BlockTime,
+			IngestedAt:        m.IngestedAt,
+			MessageID:         m.MessageID,
+			SchemaVersion:     int32(m.SchemaVersion),
+		}, nil
+	case domain.TokenSupply:
+		return tokenSupplyAvroSchema, avroTokenSupply{
+			Denom:         m.Denom,
+			Supply:        intString(m.Supply),
+			IngestedAt:    m.IngestedAt,
+			MessageID:     m.MessageID,
+			SchemaVersion: int32(m.SchemaVersion),
+		}, nil
+	case domain.TokenSupplyOffset:
+		return tokenSupplyOffsetAvroSchema, avroTokenSupplyOffset{
+			Denom:         m.Denom,
+			SupplyOffset:  intString(m.SupplyOffset),
+			IngestedAt:    m.IngestedAt,
+			MessageID:     m.MessageID,
+			SchemaVersion: int32(m.SchemaVersion),
+		}, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported indexer message type %T", message)
+	}
+}
@@ -0,0 +1,126 @@
+package encoding
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+)
+
+// The encodings of the published messages.
+const (
+	// JSON encodes the messages as JSON. It is the default.
+	JSON = "json"
+	// Protobuf encodes the messages with the protobuf definitions in schemas/osmosis/indexer/v1beta1/indexer.proto.
+	Protobuf = "protobuf"
+	// Avro encodes the messages with the Avro schemas in schemas/*.avsc without the container header.
+	Avro = "avro"
+)
+
+// schemas are the schema files of the encodings. The protobuf definitions are laid out
+// by package so that they can be generated with protoc.
+//
+//go:embed schemas
+var schemas embed.FS
+
+// Encoder encodes the messages published by the indexer.
+type Encoder interface {
+	// Encoding returns the name of the encoding.
+	Encoding() string
+	// Encode encodes the given Block, Transaction, Pair, Pool, TokenSupply or TokenSupplyOffset.
+	// Returns error if the message is of another type.
+	Encode(message any) ([]byte, error)
+}
+
+// New returns the encoder of the given encoding. The JSON encoder is returned if the encoding is empty.
+// Returns error if the encoding is unknown.
+func New(encoding string) (Encoder, error) {
+	switch encoding {
+	case "", JSON:
+		return jsonEncoder{}, nil
+	case Protobuf:
+		return protobufEncoder{}, nil
+	case Avro:
+		return newAvroEncoder()
+	default:
+		return nil, fmt.Errorf("unsupported indexer encoding (%s), expected one of %s, %s, %s", encoding, JSON, Protobuf, Avro)
+	}
+}
+
+// WriteSchemas writes the schema files of the encoding to the directory so that they can be
+// registered with a schema registry: indexer.proto for protobuf and an .avsc file per message for Avro.
+// Returns error if the encoding has no schema files.
+func WriteSchemas(encoding string, dir string) ([]string, error) {
+	var extension string
+	switch encoding {
+	case Protobuf:
+		extension = ".proto"
+	case Avro:
+		extension = ".avsc"
+	default:
+		return nil, fmt.Errorf("indexer encoding (%s) has no schema files, expected %s or %s", encoding, Protobuf, Avro)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	err := fs.WalkDir(schemas, "schemas", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.HasSuffix(name, extension) {
+			return err
+		}
+
+		bz, err := schemas.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		if err := os.WriteFile(path, bz, 0o644); err != nil {
+			return err
+		}
+		paths = append(paths, path)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// jsonEncoder encodes the messages as JSON.
+type jsonEncoder struct{}
+
+// Encoding implements Encoder.
+func (jsonEncoder) Encoding() string {
+	return JSON
+}
+
+// Encode implements Encoder.
+func (jsonEncoder) Encode(message any) ([]byte, error) {
+	return json.Marshal(message)
+}
+
+// intString returns the integer as a string, zero if nil.
+func intString(i osmomath.Int) string {
+	if i.IsNil() {
+		return "0"
+	}
+	return i.String()
+}
+
+// decString returns the decimal as a string, zero if nil.
+func decString(d osmomath.Dec) string {
+	if d.IsNil() {
+		return "0"
+	}
+	return d.String()
+}
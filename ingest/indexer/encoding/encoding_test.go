@@ -0,0 +1,228 @@
+package encoding_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/hamba/avro/v2"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/encoding"
+	indexertypes "github.com/osmosis-labs/osmosis/v30/ingest/indexer/types/proto/types"
+)
+
+var (
+	blockTime = time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+
+	transaction = domain.Transaction{
+		Height:             10,
+		BlockTime:          blockTime,
+		GasWanted:          200,
+		GasUsed:            100,
+		Fees:               sdk.NewCoins(sdk.NewCoin("uosmo", osmomath.NewInt(1000))),
+		MessageType:        "/osmosis.poolmanager.v1beta1.MsgSwapExactAmountIn",
+		TransactionHash:    "ABC",
+		TransactionIndexId: 2,
+		Events: []domain.EventWrapper{{
+			Index:   3,
+			Event:   abcitypes.Event{Type: "token_swapped", Attributes: []abcitypes.EventAttribute{{Key: "pool_id", Value: "1"}}},
+			EventID: "osmosis-1/10/tx/2/event/3",
+		}},
+		IngestedAt:    blockTime,
+		Messages:      []domain.TransactionMessage{{TypeURL: "/osmosis.poolmanager.v1beta1.MsgSwapExactAmountIn", Body: []byte(`{"sender":"osmo1"}`)}},
+		Signers:       []string{"osmo1"},
+		FeePayer:      "osmo1",
+		Status:        domain.TransactionStatusFailed,
+		Code:          6,
+		Codespace:     "poolmanager",
+		Log:           "token is lesser than min amount",
+		MessageID:     "osmosis-1/10/tx/2",
+		SchemaVersion: domain.SchemaVersion,
+	}
+
+	pair = domain.Pair{
+		PoolID:        1,
+		Denom0:        "uosmo",
+		IdxDenom0:     0,
+		Denom1:        "uion",
+		IdxDenom1:     1,
+		FeeBps:        20,
+		IngestedAt:    blockTime,
+		MessageID:     "osmosis-1/10/pair/1/0/1",
+		SchemaVersion: domain.SchemaVersion,
+	}
+
+	pool = domain.Pool{
+		PoolID:        1,
+		Type:          "Concentrated",
+		Denoms:        []string{"uatom", "uosmo"},
+		Balances:      sdk.NewCoins(sdk.NewCoin("uosmo", osmomath.NewInt(1000))),
+		SpreadFactor:  osmomath.NewDecWithPrec(2, 3),
+		CurrentTick:   -10,
+		Height:        10,
+		BlockTime:     blockTime,
+		IngestedAt:    blockTime,
+		MessageID:     "osmosis-1/10/pool/1",
+		SchemaVersion: domain.SchemaVersion,
+	}
+)
+
+func TestNew(t *testing.T) {
+	for _, name := range []string{"", encoding.JSON, encoding.Protobuf, encoding.Avro} {
+		encoder, err := encoding.New(name)
+		require.NoError(t, err, name)
+		if name != "" {
+			require.Equal(t, name, encoder.Encoding())
+		}
+	}
+
+	_, err := encoding.New("xml")
+	require.Error(t, err)
+}
+
+func TestEncode_JSON(t *testing.T) {
+	encoder, err := encoding.New(encoding.JSON)
+	require.NoError(t, err)
+
+	bz, err := encoder.Encode(pair)
+	require.NoError(t, err)
+
+	var decoded domain.Pair
+	require.NoError(t, json.Unmarshal(bz, &decoded))
+	require.Equal(t, pair.MessageID, decoded.MessageID)
+}
+
+func TestEncode_Protobuf(t *testing.T) {
+	encoder, err := encoding.New(encoding.Protobuf)
+	require.NoError(t, err)
+
+	bz, err := encoder.Encode(transaction)
+	require.NoError(t, err)
+
+	var decoded indexertypes.Transaction
+	require.NoError(t, proto.Unmarshal(bz, &decoded))
+	require.Equal(t, uint64(10), decoded.Height)
+	require.Equal(t, blockTime.Unix(), decoded.Timestamp.Seconds)
+	require.Equal(t, []*indexertypes.Coin{{Denom: "uosmo", Amount: "1000"}}, decoded.Fees)
+	require.Equal(t, "osmosis-1/10/tx/2/event/3", decoded.Events[0].EventId)
+	require.Equal(t, int32(3), decoded.Events[0].EventIndex)
+	require.Equal(t, []*indexertypes.EventAttribute{{Key: "pool_id", Value: "1"}}, decoded.Events[0].Attributes)
+	require.Equal(t, "osmosis-1/10/tx/2", decoded.MessageId)
+	require.Equal(t, uint32(domain.SchemaVersion), decoded.SchemaVersion)
+	require.Equal(t, []*indexertypes.TransactionMessage{{TypeUrl: "/osmosis.poolmanager.v1beta1.MsgSwapExactAmountIn", Body: `{"sender":"osmo1"}`}}, decoded.Messages)
+	require.Equal(t, []string{"osmo1"}, decoded.Signers)
+	require.Equal(t, domain.TransactionStatusFailed, decoded.Status)
+	require.Equal(t, uint32(6), decoded.Code)
+	require.Equal(t, "poolmanager", decoded.Codespace)
+
+	// The nil supply is encoded as zero.
+	bz, err = encoder.Encode(domain.TokenSupply{Denom: "uosmo"})
+	require.NoError(t, err)
+
+	var supply indexertypes.TokenSupply
+	require.NoError(t, proto.Unmarshal(bz, &supply))
+	require.Equal(t, "0", supply.Supply)
+
+	// The pair created at is only set if not zero.
+	bz, err = encoder.Encode(pair)
+	require.NoError(t, err)
+
+	var decodedPair indexertypes.Pair
+	require.NoError(t, proto.Unmarshal(bz, &decodedPair))
+	require.Equal(t, "uion", decodedPair.Denom_1)
+	require.Nil(t, decodedPair.PairCreatedAt)
+
+	// The nil liquidity cap is encoded as zero.
+	bz, err = encoder.Encode(pool)
+	require.NoError(t, err)
+
+	var decodedPool indexertypes.Pool
+	require.NoError(t, proto.Unmarshal(bz, &decodedPool))
+	require.Equal(t, []*indexertypes.Coin{{Denom: "uosmo", Amount: "1000"}}, decodedPool.Balances)
+	require.Equal(t, "0.002000000000000000", decodedPool.SpreadFactor)
+	require.Equal(t, "0", decodedPool.LiquidityCap)
+	require.Equal(t, int64(-10), decodedPool.CurrentTick)
+
+	_, err = encoder.Encode("block")
+	require.Error(t, err)
+}
+
+func TestEncode_Avro(t *testing.T) {
+	encoder, err := encoding.New(encoding.Avro)
+	require.NoError(t, err)
+
+	schema, err := avro.ParseFiles(filepath.Join("schemas", "transaction.avsc"))
+	require.NoError(t, err)
+
+	bz, err := encoder.Encode(transaction)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, avro.Unmarshal(schema, bz, &decoded))
+	require.Equal(t, int64(10), decoded["height"])
+	require.Equal(t, blockTime, decoded["timestamp"])
+	require.Equal(t, "osmosis-1/10/tx/2", decoded["message_id"])
+	require.Equal(t, int(domain.SchemaVersion), decoded["schema_version"])
+	require.Equal(t, domain.TransactionStatusFailed, decoded["status"])
+	require.Equal(t, int64(6), decoded["code"])
+
+	// The pair created at is null if zero.
+	schema, err = avro.ParseFiles(filepath.Join("schemas", "pair.avsc"))
+	require.NoError(t, err)
+
+	bz, err = encoder.Encode(pair)
+	require.NoError(t, err)
+
+	decoded = nil
+	require.NoError(t, avro.Unmarshal(schema, bz, &decoded))
+	require.Equal(t, "uion", decoded["denom_1"])
+	require.Nil(t, decoded["pair_created_at"])
+
+	schema, err = avro.ParseFiles(filepath.Join("schemas", "pool.avsc"))
+	require.NoError(t, err)
+
+	bz, err = encoder.Encode(pool)
+	require.NoError(t, err)
+
+	decoded = nil
+	require.NoError(t, avro.Unmarshal(schema, bz, &decoded))
+	require.Equal(t, int64(1), decoded["pool_id"])
+	require.Equal(t, []any{"uatom", "uosmo"}, decoded["denoms"])
+	require.Equal(t, "0", decoded["liquidity_cap"])
+
+	_, err = encoder.Encode("block")
+	require.Error(t, err)
+}
+
+func TestWriteSchemas(t *testing.T) {
+	dir := t.TempDir()
+
+	paths, err := encoding.WriteSchemas(encoding.Avro, dir)
+	require.NoError(t, err)
+	require.Len(t, paths, 6)
+
+	for _, path := range paths {
+		_, err := avro.ParseFiles(path)
+		require.NoError(t, err, path)
+	}
+
+	paths, err = encoding.WriteSchemas(encoding.Protobuf, dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "indexer.proto")}, paths)
+
+	bz, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	require.Contains(t, string(bz), "package osmosis.indexer.v1beta1;")
+
+	_, err = encoding.WriteSchemas(encoding.JSON, dir)
+	require.Error(t, err)
+}
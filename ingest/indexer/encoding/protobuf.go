@@ -0,0 +1,165 @@
+package encoding
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+	gogotypes "github.com/cosmos/gogoproto/types"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	indexertypes "github.com/osmosis-labs/osmosis/v30/ingest/indexer/types/proto/types"
+)
+
+// protobufEncoder encodes the messages with the protobuf definitions.
+type protobufEncoder struct{}
+
+// Encoding implements Encoder.
+func (protobufEncoder) Encoding() string {
+	return Protobuf
+}
+
+// Encode implements Encoder.
+func (protobufEncoder) Encode(message any) ([]byte, error) {
+	protoMessage, err := toProto(message)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(protoMessage)
+}
+
+// toProto converts the given Block, Transaction, Pair, Pool, TokenSupply or TokenSupplyOffset to its protobuf message.
+// Returns error if the message is of another type.
+func toProto(message any) (proto.Message, error) {
+	switch m := message.(type) {
+	case domain.Block:
+		return &indexertypes.Block{
+			ChainId:       m.ChainId,
+			Height:        m.Height,
+			Timestamp:     timestampProto(m.BlockTime),
+			GasConsumed:   m.GasConsumed,
+			IngestedAt:    timestampProto(m.IngestedAt),
+			MessageId:     m.MessageID,
+			SchemaVersion: uint32(m.SchemaVersion),
+		}, nil
+	case domain.Transaction:
+		fees := make([]*indexertypes.Coin, 0, len(m.Fees))
+		for _, fee := range m.Fees {
+			fees = append(fees, &indexertypes.Coin{Denom: fee.Denom, Amount: intString(fee.Amount)})
+		}
+
+		events := make([]*indexertypes.Event, 0, len(m.Events))
+		for _, event := range m.Events {
+			attributes := make([]*indexertypes.EventAttribute, 0, len(event.Event.Attributes))
+			for _, attribute := range event.Event.Attributes {
+				attributes = append(attributes, &indexertypes.EventAttribute{Key: attribute.Key, Value: attribute.Value})
+			}
+
+			events = append(events, &indexertypes.Event{
+				EventId:    event.EventID,
+				EventIndex: int32(event.Index),
+				Type:       event.Event.Type,
+				Attributes: attributes,
+			})
+		}
+
+		messages := make([]*indexertypes.TransactionMessage, 0, len(m.Messages))
+		for _, message := range m.Messages {
+			messages = append(messages, &indexertypes.TransactionMessage{TypeUrl: message.TypeURL, Body: string(message.Body)})
+		}
+
+		return &indexertypes.Transaction{
+			Height:        m.Height,
+			Timestamp:     timestampProto(m.BlockTime),
+			GasWanted:     m.GasWanted,
+			GasUsed:       m.GasUsed,
+			Fees:          fees,
+			MsgType:       m.MessageType,
+			TxHash:        m.TransactionHash,
+			TxIndexId:     int64(m.TransactionIndexId),
+			Events:        events,
+			IngestedAt:    timestampProto(m.IngestedAt),
+			MessageId:     m.MessageID,
+			SchemaVersion: uint32(m.SchemaVersion),
+			Messages:      messages,
+			Signers:       m.Signers,
+			Memo:          m.Memo,
+			TimeoutHeight: m.TimeoutHeight,
+			FeePayer:      m.FeePayer,
+			FeeGranter:    m.FeeGranter,
+			Status:        m.Status,
+			Code:          m.Code,
+			Codespace:     m.Codespace,
+			Log:           m.Log,
+		}, nil
+	case domain.Pair:
+		var pairCreatedAt *gogotypes.Timestamp
+		if !m.PairCreatedAt.IsZero() {
+			pairCreatedAt = timestampProto(m.PairCreatedAt)
+		}
+
+		return &indexertypes.Pair{
+			PoolId:               m.PoolID,
+			MultiAsset:           m.MultiAsset,
+			Denom_0:              m.Denom0,
+			IdxDenom_0:           uint32(m.IdxDenom0),
+			Denom_1:              m.Denom1,
+			IdxDenom_1:           uint32(m.IdxDenom1),
+			FeeBps:               m.FeeBps,
+			IngestedAt:           timestampProto(m.IngestedAt),
+			PairCreatedAt:        pairCreatedAt,
+			PairCreatedAtHeight:  m.PairCreatedAtHeight,
+			PairCreatedAtTxnHash: m.PairCreatedAtTxnHash,
+			MessageId:            m.MessageID,
+			SchemaVersion:        uint32(m.SchemaVersion),
+		}, nil
+	case domain.Pool:
+		balances := make([]*indexertypes.Coin, 0, len(m.Balances))
+		for _, balance := range m.Balances {
+			balances = append(balances, &indexertypes.Coin{Denom: balance.Denom, Amount: intString(balance.Amount)})
+		}
+
+		return &indexertypes.Pool{
+			PoolId:            m.PoolID,
+			Type:              m.Type,
+			Denoms:            m.Denoms,
+			Balances:          balances,
+			SpreadFactor:      decString(m.SpreadFactor),
+			CurrentTick:       m.CurrentTick,
+			CurrentSqrtPrice:  m.CurrentSqrtPrice,
+			LiquidityCap:      intString(m.LiquidityCap),
+			LiquidityCapError: m.LiquidityCapError,
+			Height:            m.Height,
+			Timestamp:         timestampProto(m.BlockTime),
+			IngestedAt:        timestampProto(m.IngestedAt),
+			MessageId:         m.MessageID,
+			SchemaVersion:     uint32(m.SchemaVersion),
+		}, nil
+	case domain.TokenSupply:
+		return &indexertypes.TokenSupply{
+			Denom:         m.Denom,
+			Supply:        intString(m.Supply),
+			IngestedAt:    timestampProto(m.IngestedAt),
+			MessageId:     m.MessageID,
+			SchemaVersion: uint32(m.SchemaVersion),
+		}, nil
+	case domain.TokenSupplyOffset:
+		return &indexertypes.TokenSupplyOffset{
+			Denom:         m.Denom,
+			SupplyOffset:  intString(m.SupplyOffset),
+			IngestedAt:    timestampProto(m.IngestedAt),
+			MessageId:     m.MessageID,
+			SchemaVersion: uint32(m.SchemaVersion),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported indexer message type %T", message)
+	}
+}
+
+// timestampProto converts the time to a protobuf timestamp.
+func timestampProto(t time.Time) *gogotypes.Timestamp {
+	return &gogotypes.Timestamp{
+		Seconds: t.Unix(),
+		Nanos:   int32(t.Nanosecond()),
+	}
+}
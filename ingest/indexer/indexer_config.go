@@ -1,16 +1,23 @@
 package indexer
 
 import (
+	"strings"
+
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 
 	"github.com/osmosis-labs/osmosis/osmoutils"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/grpcsecurity"
+	ingestoptions "github.com/osmosis-labs/osmosis/v30/ingest/common/options"
 	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/encoding"
 	service "github.com/osmosis-labs/osmosis/v30/ingest/indexer/service/client"
 )
 
 // Config defines the config for the indexer.
 type Config struct {
-	IsEnabled                bool   `mapstructure:"enabled"`
+	IsEnabled bool `mapstructure:"enabled"`
+	// Driver defines the publisher driver, e.g. "pubsub" or "kafka".
+	Driver                   string `mapstructure:"driver"`
 	MaxPublishDelay          int    `mapstructure:"max-publish-delay"`
 	GCPProjectId             string `mapstructure:"gcp-project-id"`
 	BlockTopicId             string `mapstructure:"block-topic-id"`
@@ -19,6 +26,69 @@ type Config struct {
 	TokenSupplyTopicId       string `mapstructure:"token-supply-topic-id"`
 	TokenSupplyOffsetTopicId string `mapstructure:"token-supply-offset-topic-id"`
 	PairTopicId              string `mapstructure:"pair-offset-topic-id"`
+	// CheckpointEnabled defines if the published pools are checkpointed so that only the pools
+	// whose pair data changed are published after a restart.
+	CheckpointEnabled bool `mapstructure:"checkpoint-enabled"`
+	// CheckpointMaxAgeBlocks defines the maximum number of blocks between the checkpoint and the restart height.
+	// All pools are published if the checkpoint is older.
+	CheckpointMaxAgeBlocks uint64 `mapstructure:"checkpoint-max-age-blocks"`
+	// Encoding defines the encoding of the messages of the pubsub and kafka drivers, "json", "protobuf" or "avro".
+	Encoding string `mapstructure:"encoding"`
+	// EventAllowlist defines the comma-separated rules of the published transaction events, e.g.
+	// "token_swapped,ibc_*,wasm[_contract_address=osmo1abc*]". See domain.ParseEventFilter for the syntax.
+	EventAllowlist string `mapstructure:"event-allowlist"`
+
+	// PubSubOrderingEnabled defines if the pubsub driver publishes with ordering keys:
+	// blocks and transactions by height, pairs and pools by pool ID and token supplies by denom.
+	PubSubOrderingEnabled bool `mapstructure:"pubsub-ordering-enabled"`
+	// PubSubPublishTimeoutMs defines the maximum time in milliseconds the results of the messages of a block are awaited.
+	// The messages whose result is not ready in time are spooled.
+	PubSubPublishTimeoutMs int `mapstructure:"pubsub-publish-timeout-ms"`
+	// PubSubMaxRetries defines the number of times a message that failed to publish is republished before it is spooled.
+	PubSubMaxRetries int `mapstructure:"pubsub-max-retries"`
+	// PubSubRetryBackoffMs defines the delay before the first retry in milliseconds. It doubles with every retry.
+	PubSubRetryBackoffMs int `mapstructure:"pubsub-retry-backoff-ms"`
+	// PubSubSpoolDir defines the directory of the dead-letter spool of the messages that time out or still fail after the retries.
+	// Defaults to data/indexer-pubsub-spool in the node home.
+	PubSubSpoolDir string `mapstructure:"pubsub-spool-dir"`
+	// PubSubSpoolReplayIntervalSeconds defines the minimum interval between the replays of the spooled messages.
+	PubSubSpoolReplayIntervalSeconds int `mapstructure:"pubsub-spool-replay-interval-seconds"`
+
+	// KafkaBrokers defines the seed brokers of the kafka driver.
+	KafkaBrokers []string `mapstructure:"kafka-brokers"`
+	// KafkaClientID defines the client ID reported to the brokers.
+	KafkaClientID string `mapstructure:"kafka-client-id"`
+	// KafkaTLSEnabled defines if the broker connections use TLS.
+	KafkaTLSEnabled bool `mapstructure:"kafka-tls-enabled"`
+	// KafkaTLSCAFile defines the path to the CA bundle used to verify the brokers.
+	// If empty, the system roots are used.
+	KafkaTLSCAFile string `mapstructure:"kafka-tls-ca-file"`
+	// KafkaTLSCertFile defines the path to the client certificate for mTLS.
+	KafkaTLSCertFile string `mapstructure:"kafka-tls-cert-file"`
+	// KafkaTLSKeyFile defines the path to the client key for mTLS.
+	KafkaTLSKeyFile string `mapstructure:"kafka-tls-key-file"`
+	// KafkaTLSServerName overrides the server name used to verify the broker certificates.
+	KafkaTLSServerName string `mapstructure:"kafka-tls-server-name"`
+	// KafkaSASLMechanism defines the SASL mechanism, one of "PLAIN", "SCRAM-SHA-256" or "SCRAM-SHA-512".
+	// SASL is disabled if empty.
+	KafkaSASLMechanism string `mapstructure:"kafka-sasl-mechanism"`
+	KafkaSASLUsername  string `mapstructure:"kafka-sasl-username"`
+	KafkaSASLPassword  string `mapstructure:"kafka-sasl-password"`
+
+	// SQLDSN defines the database of the sqlite and postgres drivers:
+	// the path of the database file for sqlite and the connection string for postgres.
+	SQLDSN string `mapstructure:"sql-dsn"`
+
+	// FileDir defines the directory the file driver writes the topic directories to.
+	FileDir string `mapstructure:"file-dir"`
+	// FileFormat defines the format of the file driver, "ndjson" or "parquet".
+	FileFormat string `mapstructure:"file-format"`
+	// FileRotationIntervalSeconds defines the time buckets of the files of the file driver.
+	// Time-based rotation is disabled if zero.
+	FileRotationIntervalSeconds int `mapstructure:"file-rotation-interval-seconds"`
+	// FileRotationMaxBytes defines the size of the messages after which a file of the file driver is rotated.
+	// Size-based rotation is disabled if zero.
+	FileRotationMaxBytes int `mapstructure:"file-rotation-max-bytes"`
 }
 
 // groupOptName is the name of the indexer options group.
@@ -29,6 +99,7 @@ const (
 // DefaultConfig defines the default config for the indexer client.
 var DefaultConfig = Config{
 	IsEnabled:                false,
+	Driver:                   PubSubDriver,
 	MaxPublishDelay:          4,
 	GCPProjectId:             "",
 	BlockTopicId:             "",
@@ -36,6 +107,19 @@ var DefaultConfig = Config{
 	PoolTopicId:              "",
 	TokenSupplyTopicId:       "",
 	TokenSupplyOffsetTopicId: "",
+	CheckpointEnabled:        false,
+	CheckpointMaxAgeBlocks:   6000,
+	Encoding:                 encoding.JSON,
+	EventAllowlist:           domain.DefaultEventAllowlist,
+
+	PubSubPublishTimeoutMs:           10000,
+	PubSubMaxRetries:                 3,
+	PubSubRetryBackoffMs:             500,
+	PubSubSpoolReplayIntervalSeconds: 30,
+
+	FileFormat:                  service.FileFormatNDJSON,
+	FileRotationIntervalSeconds: 3600,
+	FileRotationMaxBytes:        256 << 20,
 }
 
 // NewConfigFromOptions returns a new indexer config from the given options.
@@ -48,6 +132,14 @@ func NewConfigFromOptions(opts servertypes.AppOptions) Config {
 		}
 	}
 
+	driver := osmoutils.ParseString(opts, groupOptName, "driver")
+	if driver == "" {
+		driver = DefaultConfig.Driver
+	}
+	if _, err := getPublisherDriver(driver); err != nil {
+		panic(err)
+	}
+
 	maxPublishDelay := osmoutils.ParseInt(opts, groupOptName, "max-publish-delay")
 	gcpProjectId := osmoutils.ParseString(opts, groupOptName, "gcp-project-id")
 	blockTopicId := osmoutils.ParseString(opts, groupOptName, "block-topic-id")
@@ -56,9 +148,36 @@ func NewConfigFromOptions(opts servertypes.AppOptions) Config {
 	tokenSupplyTopicId := osmoutils.ParseString(opts, groupOptName, "token-supply-topic-id")
 	tokenSupplyOffsetTopicId := osmoutils.ParseString(opts, groupOptName, "token-supply-offset-topic-id")
 	pairTopicID := osmoutils.ParseString(opts, groupOptName, "pair-topic-id")
+	checkpointEnabled := osmoutils.ParseBool(opts, groupOptName, "checkpoint-enabled", DefaultConfig.CheckpointEnabled)
+	checkpointMaxAgeBlocks := osmoutils.ParseInt(opts, groupOptName, "checkpoint-max-age-blocks")
+	if checkpointMaxAgeBlocks <= 0 {
+		checkpointMaxAgeBlocks = int(DefaultConfig.CheckpointMaxAgeBlocks)
+	}
 
-	return Config{
+	messageEncoding := osmoutils.ParseString(opts, groupOptName, "encoding")
+	if messageEncoding == "" {
+		messageEncoding = DefaultConfig.Encoding
+	}
+	if _, err := encoding.New(messageEncoding); err != nil {
+		panic(err)
+	}
+
+	eventAllowlist := osmoutils.ParseString(opts, groupOptName, "event-allowlist")
+	if strings.TrimSpace(eventAllowlist) == "" {
+		eventAllowlist = DefaultConfig.EventAllowlist
+	}
+	if _, err := domain.ParseEventFilter(eventAllowlist); err != nil {
+		panic(err)
+	}
+
+	fileFormat := osmoutils.ParseString(opts, groupOptName, "file-format")
+	if fileFormat == "" {
+		fileFormat = DefaultConfig.FileFormat
+	}
+
+	config := Config{
 		IsEnabled:                isEnabled,
+		Driver:                   driver,
 		MaxPublishDelay:          maxPublishDelay,
 		GCPProjectId:             gcpProjectId,
 		BlockTopicId:             blockTopicId,
@@ -67,11 +186,92 @@ func NewConfigFromOptions(opts servertypes.AppOptions) Config {
 		TokenSupplyTopicId:       tokenSupplyTopicId,
 		TokenSupplyOffsetTopicId: tokenSupplyOffsetTopicId,
 		PairTopicId:              pairTopicID,
+		CheckpointEnabled:        checkpointEnabled,
+		CheckpointMaxAgeBlocks:   uint64(checkpointMaxAgeBlocks),
+		Encoding:                 messageEncoding,
+		EventAllowlist:           eventAllowlist,
+
+		PubSubOrderingEnabled:            osmoutils.ParseBool(opts, groupOptName, "pubsub-ordering-enabled", false),
+		PubSubPublishTimeoutMs:           parseIntOrDefault(opts, "pubsub-publish-timeout-ms", DefaultConfig.PubSubPublishTimeoutMs),
+		PubSubMaxRetries:                 parseIntOrDefault(opts, "pubsub-max-retries", DefaultConfig.PubSubMaxRetries),
+		PubSubRetryBackoffMs:             parseIntOrDefault(opts, "pubsub-retry-backoff-ms", DefaultConfig.PubSubRetryBackoffMs),
+		PubSubSpoolDir:                   osmoutils.ParseString(opts, groupOptName, "pubsub-spool-dir"),
+		PubSubSpoolReplayIntervalSeconds: parseIntOrDefault(opts, "pubsub-spool-replay-interval-seconds", DefaultConfig.PubSubSpoolReplayIntervalSeconds),
+
+		KafkaBrokers:       ingestoptions.ParseList(osmoutils.ParseString(opts, groupOptName, "kafka-brokers")),
+		KafkaClientID:      osmoutils.ParseString(opts, groupOptName, "kafka-client-id"),
+		KafkaTLSEnabled:    osmoutils.ParseBool(opts, groupOptName, "kafka-tls-enabled", false),
+		KafkaTLSCAFile:     osmoutils.ParseString(opts, groupOptName, "kafka-tls-ca-file"),
+		KafkaTLSCertFile:   osmoutils.ParseString(opts, groupOptName, "kafka-tls-cert-file"),
+		KafkaTLSKeyFile:    osmoutils.ParseString(opts, groupOptName, "kafka-tls-key-file"),
+		KafkaTLSServerName: osmoutils.ParseString(opts, groupOptName, "kafka-tls-server-name"),
+		KafkaSASLMechanism: osmoutils.ParseString(opts, groupOptName, "kafka-sasl-mechanism"),
+		KafkaSASLUsername:  osmoutils.ParseString(opts, groupOptName, "kafka-sasl-username"),
+		KafkaSASLPassword:  osmoutils.ParseString(opts, groupOptName, "kafka-sasl-password"),
+
+		SQLDSN: osmoutils.ParseString(opts, groupOptName, "sql-dsn"),
+
+		FileDir:                     osmoutils.ParseString(opts, groupOptName, "file-dir"),
+		FileFormat:                  fileFormat,
+		FileRotationIntervalSeconds: parseIntOrDefault(opts, "file-rotation-interval-seconds", DefaultConfig.FileRotationIntervalSeconds),
+		FileRotationMaxBytes:        parseIntOrDefault(opts, "file-rotation-max-bytes", DefaultConfig.FileRotationMaxBytes),
+	}
+
+	if err := config.KafkaTLSConfig().Validate(); err != nil {
+		panic(err)
+	}
+
+	return config
+}
+
+// KafkaTLSConfig returns the TLS configuration of the broker connections of the kafka driver.
+func (c Config) KafkaTLSConfig() grpcsecurity.TLSConfig {
+	return grpcsecurity.TLSConfig{
+		Enabled:    c.KafkaTLSEnabled,
+		CAFile:     c.KafkaTLSCAFile,
+		CertFile:   c.KafkaTLSCertFile,
+		KeyFile:    c.KafkaTLSKeyFile,
+		ServerName: c.KafkaTLSServerName,
+	}
+}
+
+// IsPoolStatePublished returns true if the state of the changed pools is published every block.
+// The sqlite, postgres and file drivers always publish it. The other drivers publish it only if the pool topic is set.
+func (c Config) IsPoolStatePublished() bool {
+	switch c.Driver {
+	case SQLiteDriver, PostgresDriver, FileDriver:
+		return true
+	default:
+		return c.PoolTopicId != ""
+	}
+}
+
+// Initialize initializes the indexer by creating the client of the configured publisher driver
+// and returning a new IndexerPublisher.
+// Returns error if the driver is unknown or fails to create the client.
+func (c Config) Initialize() (domain.Publisher, error) {
+	driverName := c.Driver
+	if driverName == "" {
+		driverName = DefaultConfig.Driver
+	}
+
+	driver, err := getPublisherDriver(driverName)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := driver(c)
+	if err != nil {
+		return nil, err
 	}
+
+	return NewIndexerPublisher(client), nil
 }
 
-// Initialize initializes the indexer by creating a new PubSubClient and returning a new IndexerIngester.
-func (c Config) Initialize() domain.Publisher {
-	pubSubClient := service.NewPubSubCLient(c.MaxPublishDelay, c.GCPProjectId, c.BlockTopicId, c.TransactionTopicId, c.PoolTopicId, c.TokenSupplyTopicId, c.TokenSupplyOffsetTopicId, c.PairTopicId)
-	return NewIndexerPublisher(*pubSubClient)
+// parseIntOrDefault parses the int option of the indexer group, returning the default if the option is not set.
+func parseIntOrDefault(opts servertypes.AppOptions, key string, defaultValue int) int {
+	if opts.Get(groupOptName+"."+key) == nil {
+		return defaultValue
+	}
+	return osmoutils.ParseInt(opts, groupOptName, key)
 }
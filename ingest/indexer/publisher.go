@@ -2,26 +2,106 @@ package indexer
 
 import (
 	"context"
+	"io"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
-	service "github.com/osmosis-labs/osmosis/v30/ingest/indexer/service/client"
 )
 
+// PublisherClient is the client created by a publisher driver.
+type PublisherClient interface {
+	domain.Publisher
+	domain.PublishedBytesCounter
+}
+
 // indexerIngester is an implementation of domain.Publisher.
+// It sets the deterministic message ID and the schema version of every published message.
 type indexerPublisher struct {
-	pubsubClient service.PubSubClient
+	client PublisherClient
+
+	mu sync.Mutex
+	// chainID and height identify the last published block. They are used for the message IDs
+	// when the context is not an SDK context, e.g. for the token supplies of the bank write listener.
+	chainID string
+	height  uint64
+	// supplyOccurrences counts the token supply and offset messages by denom published at the height.
+	supplyOccurrences map[string]int
+}
+
+var (
+	_ domain.PublishedBytesCounter = &indexerPublisher{}
+	_ io.Closer                    = &indexerPublisher{}
+)
+
+// blockCommitterIndexerPublisher is an indexerPublisher whose client writes blocks atomically.
+type blockCommitterIndexerPublisher struct {
+	*indexerPublisher
+	domain.BlockCommitter
 }
 
-// NewIndexerPublisher creates a new IndexerPublisher with the given PubSubClient
-func NewIndexerPublisher(pubsubClient service.PubSubClient) domain.Publisher {
-	return &indexerPublisher{
-		pubsubClient: pubsubClient,
+// NewIndexerPublisher creates a new IndexerPublisher with the given publisher driver client.
+// The publisher implements domain.BlockCommitter if the client does.
+func NewIndexerPublisher(client PublisherClient) domain.Publisher {
+	publisher := &indexerPublisher{
+		client:            client,
+		supplyOccurrences: make(map[string]int),
+	}
+
+	if committer, ok := client.(domain.BlockCommitter); ok {
+		return &blockCommitterIndexerPublisher{
+			indexerPublisher: publisher,
+			BlockCommitter:   committer,
+		}
 	}
+
+	return publisher
+}
+
+// blockContext returns the chain ID and height of the block of the message, preferring the SDK context.
+// Must be called with the mutex held.
+func (i *indexerPublisher) blockContext(ctx context.Context) (string, uint64) {
+	chainID, height := i.chainID, i.height
+	if sdkCtx, ok := ctx.(sdk.Context); ok {
+		if sdkCtx.ChainID() != "" {
+			chainID = sdkCtx.ChainID()
+		}
+		if sdkCtx.BlockHeight() > 0 {
+			height = uint64(sdkCtx.BlockHeight())
+		}
+	}
+	return chainID, height
+}
+
+// setBlock sets the last published block, resetting the supply occurrences if the height changed.
+// Must be called with the mutex held.
+func (i *indexerPublisher) setBlock(chainID string, height uint64) {
+	if height != i.height {
+		i.supplyOccurrences = make(map[string]int)
+	}
+	i.chainID, i.height = chainID, height
+}
+
+// nextSupplyOccurrence returns the occurrence of the token supply or offset message with the given key at the height.
+// Must be called with the mutex held.
+func (i *indexerPublisher) nextSupplyOccurrence(chainID string, height uint64, key string) int {
+	i.setBlock(chainID, height)
+	occurrence := i.supplyOccurrences[key]
+	i.supplyOccurrences[key]++
+	return occurrence
 }
 
 // PublishBlock implements domain.Publisher.
 func (i *indexerPublisher) PublishBlock(ctx context.Context, block domain.Block) error {
-	err := i.pubsubClient.PublishBlock(ctx, block)
+	i.mu.Lock()
+	i.setBlock(block.ChainId, block.Height)
+	i.mu.Unlock()
+
+	block.MessageID = domain.BlockMessageID(block.ChainId, block.Height)
+	block.SchemaVersion = domain.SchemaVersion
+
+	err := i.client.PublishBlock(ctx, block)
 	if err != nil {
 		return err
 	}
@@ -30,7 +110,22 @@ func (i *indexerPublisher) PublishBlock(ctx context.Context, block domain.Block)
 
 // PublishTransaction implements domain.Publisher.
 func (i *indexerPublisher) PublishTransaction(ctx context.Context, txn domain.Transaction) error {
-	err := i.pubsubClient.PublishTransaction(ctx, txn)
+	i.mu.Lock()
+	chainID, _ := i.blockContext(ctx)
+	i.mu.Unlock()
+
+	txn.MessageID = domain.TransactionMessageID(chainID, txn.Height, txn.TransactionIndexId)
+	txn.SchemaVersion = domain.SchemaVersion
+
+	// Copy the events so that the events of the caller are not modified.
+	events := make([]domain.EventWrapper, len(txn.Events))
+	for j, event := range txn.Events {
+		event.EventID = domain.EventID(chainID, txn.Height, txn.TransactionIndexId, event.Index)
+		events[j] = event
+	}
+	txn.Events = events
+
+	err := i.client.PublishTransaction(ctx, txn)
 	if err != nil {
 		return err
 	}
@@ -39,7 +134,15 @@ func (i *indexerPublisher) PublishTransaction(ctx context.Context, txn domain.Tr
 
 // PublishTokenSupply implements domain.Publisher.
 func (i *indexerPublisher) PublishTokenSupply(ctx context.Context, tokenSupply domain.TokenSupply) error {
-	err := i.pubsubClient.PublishTokenSupply(ctx, tokenSupply)
+	i.mu.Lock()
+	chainID, height := i.blockContext(ctx)
+	occurrence := i.nextSupplyOccurrence(chainID, height, "supply/"+tokenSupply.Denom)
+	i.mu.Unlock()
+
+	tokenSupply.MessageID = domain.TokenSupplyMessageID(chainID, height, tokenSupply.Denom, occurrence)
+	tokenSupply.SchemaVersion = domain.SchemaVersion
+
+	err := i.client.PublishTokenSupply(ctx, tokenSupply)
 	if err != nil {
 		return err
 	}
@@ -48,7 +151,15 @@ func (i *indexerPublisher) PublishTokenSupply(ctx context.Context, tokenSupply d
 
 // PublishTokenSupplyOffset implements domain.Publisher.
 func (i *indexerPublisher) PublishTokenSupplyOffset(ctx context.Context, tokenSupplyOffset domain.TokenSupplyOffset) error {
-	err := i.pubsubClient.PublishTokenSupplyOffset(ctx, tokenSupplyOffset)
+	i.mu.Lock()
+	chainID, height := i.blockContext(ctx)
+	occurrence := i.nextSupplyOccurrence(chainID, height, "offset/"+tokenSupplyOffset.Denom)
+	i.mu.Unlock()
+
+	tokenSupplyOffset.MessageID = domain.TokenSupplyOffsetMessageID(chainID, height, tokenSupplyOffset.Denom, occurrence)
+	tokenSupplyOffset.SchemaVersion = domain.SchemaVersion
+
+	err := i.client.PublishTokenSupplyOffset(ctx, tokenSupplyOffset)
 	if err != nil {
 		return err
 	}
@@ -57,9 +168,45 @@ func (i *indexerPublisher) PublishTokenSupplyOffset(ctx context.Context, tokenSu
 
 // PublishPair implements domain.Publisher.
 func (i *indexerPublisher) PublishPair(ctx context.Context, pair domain.Pair) error {
-	err := i.pubsubClient.PublishPair(ctx, pair)
+	i.mu.Lock()
+	chainID, height := i.blockContext(ctx)
+	i.mu.Unlock()
+
+	pair.MessageID = domain.PairMessageID(chainID, height, pair.PoolID, pair.IdxDenom0, pair.IdxDenom1)
+	pair.SchemaVersion = domain.SchemaVersion
+
+	err := i.client.PublishPair(ctx, pair)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// PublishPool implements domain.Publisher.
+func (i *indexerPublisher) PublishPool(ctx context.Context, pool domain.Pool) error {
+	i.mu.Lock()
+	chainID, _ := i.blockContext(ctx)
+	i.mu.Unlock()
+
+	pool.MessageID = domain.PoolMessageID(chainID, pool.Height, pool.PoolID)
+	pool.SchemaVersion = domain.SchemaVersion
+
+	err := i.client.PublishPool(ctx, pool)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// PopPublishedBytes implements domain.PublishedBytesCounter.
+func (i *indexerPublisher) PopPublishedBytes() uint64 {
+	return i.client.PopPublishedBytes()
+}
+
+// Close closes the client if it holds resources, e.g. open files or connections.
+func (i *indexerPublisher) Close() error {
+	if closer, ok := i.client.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
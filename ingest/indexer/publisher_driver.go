@@ -0,0 +1,127 @@
+package indexer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/encoding"
+	service "github.com/osmosis-labs/osmosis/v30/ingest/indexer/service/client"
+)
+
+const (
+	// PubSubDriver publishes to Google Cloud Pub/Sub.
+	PubSubDriver = "pubsub"
+	// KafkaDriver publishes to Kafka-protocol brokers such as Kafka or Redpanda.
+	KafkaDriver = "kafka"
+	// SQLiteDriver writes to an SQLite database file.
+	SQLiteDriver = "sqlite"
+	// PostgresDriver writes to a Postgres database.
+	PostgresDriver = "postgres"
+	// FileDriver writes to rolling NDJSON or Parquet files.
+	FileDriver = "file"
+)
+
+// PublisherDriver creates the client that publishes the indexed data with the given config.
+type PublisherDriver func(config Config) (PublisherClient, error)
+
+var (
+	publisherDriversMu sync.RWMutex
+	publisherDrivers   = map[string]PublisherDriver{
+		PubSubDriver: newPubSubPublisherClient,
+		KafkaDriver:  newKafkaPublisherClient,
+		SQLiteDriver: func(c Config) (PublisherClient, error) {
+			return service.NewSQLClient(service.SQLDialectSQLite, c.SQLDSN)
+		},
+		PostgresDriver: func(c Config) (PublisherClient, error) {
+			return service.NewSQLClient(service.SQLDialectPostgres, c.SQLDSN)
+		},
+		FileDriver: newFilePublisherClient,
+	}
+)
+
+// RegisterPublisherDriver registers a publisher driver selectable with the driver option.
+// Panics if a driver with the same name is already registered.
+func RegisterPublisherDriver(name string, driver PublisherDriver) {
+	publisherDriversMu.Lock()
+	defer publisherDriversMu.Unlock()
+
+	if _, ok := publisherDrivers[name]; ok {
+		panic(fmt.Sprintf("indexer publisher driver (%s) is already registered", name))
+	}
+
+	publisherDrivers[name] = driver
+}
+
+// getPublisherDriver returns the publisher driver with the given name.
+// Returns error if no such driver is registered.
+func getPublisherDriver(name string) (PublisherDriver, error) {
+	publisherDriversMu.RLock()
+	defer publisherDriversMu.RUnlock()
+
+	driver, ok := publisherDrivers[name]
+	if !ok {
+		names := make([]string, 0, len(publisherDrivers))
+		for name := range publisherDrivers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("unknown indexer publisher driver (%s), expected one of %s", name, strings.Join(names, ", "))
+	}
+
+	return driver, nil
+}
+
+// newPubSubPublisherClient is the PublisherDriver of PubSubDriver.
+func newPubSubPublisherClient(c Config) (PublisherClient, error) {
+	encoder, err := encoding.New(c.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.NewPubSubCLient(c.MaxPublishDelay, c.GCPProjectId, c.BlockTopicId, c.TransactionTopicId, c.PoolTopicId, c.TokenSupplyTopicId, c.TokenSupplyOffsetTopicId, c.PairTopicId, service.PubSubReliabilityConfig{
+		OrderingEnabled:     c.PubSubOrderingEnabled,
+		PublishTimeout:      time.Duration(c.PubSubPublishTimeoutMs) * time.Millisecond,
+		MaxRetries:          c.PubSubMaxRetries,
+		RetryBackoff:        time.Duration(c.PubSubRetryBackoffMs) * time.Millisecond,
+		SpoolDir:            c.PubSubSpoolDir,
+		SpoolReplayInterval: time.Duration(c.PubSubSpoolReplayIntervalSeconds) * time.Second,
+	}, encoder), nil
+}
+
+// newKafkaPublisherClient is the PublisherDriver of KafkaDriver.
+// The topic ids are used as the Kafka topics.
+func newKafkaPublisherClient(c Config) (PublisherClient, error) {
+	return service.NewKafkaClient(service.KafkaConfig{
+		Brokers:  c.KafkaBrokers,
+		ClientID: c.KafkaClientID,
+
+		TLS: c.KafkaTLSConfig(),
+
+		SASLMechanism: c.KafkaSASLMechanism,
+		SASLUsername:  c.KafkaSASLUsername,
+		SASLPassword:  c.KafkaSASLPassword,
+
+		Encoding: c.Encoding,
+
+		BlockTopic:             c.BlockTopicId,
+		TransactionTopic:       c.TransactionTopicId,
+		TokenSupplyTopic:       c.TokenSupplyTopicId,
+		TokenSupplyOffsetTopic: c.TokenSupplyOffsetTopicId,
+		PairTopic:              c.PairTopicId,
+		PoolTopic:              c.PoolTopicId,
+	})
+}
+
+// newFilePublisherClient is the PublisherDriver of FileDriver.
+func newFilePublisherClient(c Config) (PublisherClient, error) {
+	return service.NewFileClient(service.FileConfig{
+		Dir:              c.FileDir,
+		Format:           c.FileFormat,
+		RotationInterval: time.Duration(c.FileRotationIntervalSeconds) * time.Second,
+		RotationMaxBytes: int64(c.FileRotationMaxBytes),
+	})
+}
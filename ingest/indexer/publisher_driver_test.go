@@ -0,0 +1,58 @@
+package indexer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain/mocks"
+)
+
+// Validates that the publisher is created by the configured driver.
+func TestConfig_Initialize(t *testing.T) {
+	// Default
+	_, err := indexer.Config{}.Initialize()
+	require.NoError(t, err)
+
+	_, err = indexer.Config{Driver: indexer.PubSubDriver}.Initialize()
+	require.NoError(t, err)
+
+	_, err = indexer.Config{Driver: indexer.KafkaDriver, KafkaBrokers: []string{"localhost:9092"}}.Initialize()
+	require.NoError(t, err)
+
+	// No brokers
+	_, err = indexer.Config{Driver: indexer.KafkaDriver}.Initialize()
+	require.Error(t, err)
+
+	_, err = indexer.Config{Driver: "unknown"}.Initialize()
+	require.Error(t, err)
+
+	// Unknown encoding
+	_, err = indexer.Config{Driver: indexer.PubSubDriver, Encoding: "xml"}.Initialize()
+	require.Error(t, err)
+
+	// Custom driver
+	var initializedConfig indexer.Config
+	indexer.RegisterPublisherDriver("custom", func(config indexer.Config) (indexer.PublisherClient, error) {
+		initializedConfig = config
+		return &mocks.PublisherMock{}, nil
+	})
+
+	_, err = indexer.Config{Driver: "custom", BlockTopicId: "blocks"}.Initialize()
+	require.NoError(t, err)
+	require.Equal(t, "blocks", initializedConfig.BlockTopicId)
+
+	require.Panics(t, func() {
+		indexer.RegisterPublisherDriver(indexer.KafkaDriver, nil)
+	})
+}
+
+// Validates that the pool state is published by the drivers without topics and by the others if the pool topic is set.
+func TestConfig_IsPoolStatePublished(t *testing.T) {
+	require.False(t, indexer.Config{}.IsPoolStatePublished())
+	require.False(t, indexer.Config{Driver: indexer.KafkaDriver}.IsPoolStatePublished())
+	require.True(t, indexer.Config{Driver: indexer.PubSubDriver, PoolTopicId: "pools"}.IsPoolStatePublished())
+	require.True(t, indexer.Config{Driver: indexer.SQLiteDriver}.IsPoolStatePublished())
+	require.True(t, indexer.Config{Driver: indexer.FileDriver}.IsPoolStatePublished())
+}
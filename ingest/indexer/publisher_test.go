@@ -0,0 +1,55 @@
+package indexer_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain/mocks"
+)
+
+// Validates that the publisher sets deterministic message IDs and the schema version on every message.
+func TestIndexerPublisher_MessageIDs(t *testing.T) {
+	client := &mocks.PublisherMock{}
+	publisher := indexer.NewIndexerPublisher(client)
+
+	ctx := context.Background()
+
+	require.NoError(t, publisher.PublishBlock(ctx, domain.Block{ChainId: "osmosis-1", Height: 10}))
+	require.Equal(t, "osmosis-1/10", client.CalledWithBlock.MessageID)
+	require.Equal(t, domain.SchemaVersion, client.CalledWithBlock.SchemaVersion)
+
+	events := []domain.EventWrapper{{Index: 3}}
+	require.NoError(t, publisher.PublishTransaction(ctx, domain.Transaction{Height: 10, TransactionIndexId: 2, Events: events}))
+	require.Equal(t, "osmosis-1/10/tx/2", client.CalledWithTransaction.MessageID)
+	require.Equal(t, "osmosis-1/10/tx/2/event/3", client.CalledWithTransaction.Events[0].EventID)
+	require.Empty(t, events[0].EventID)
+
+	// The supplies of the bank write listener have no SDK context and use the last published block.
+	// The occurrence is appended to the supplies published again for the denom in the same block.
+	require.NoError(t, publisher.PublishTokenSupply(ctx, domain.TokenSupply{Denom: "ibc/ABC"}))
+	require.Equal(t, "osmosis-1/10/token_supply/ibc/ABC", client.CalledWithTokenSupply.MessageID)
+	require.NoError(t, publisher.PublishTokenSupply(ctx, domain.TokenSupply{Denom: "ibc/ABC"}))
+	require.Equal(t, "osmosis-1/10/token_supply/ibc/ABC#1", client.CalledWithTokenSupply.MessageID)
+	require.NoError(t, publisher.PublishTokenSupplyOffset(ctx, domain.TokenSupplyOffset{Denom: "ibc/ABC"}))
+	require.Equal(t, "osmosis-1/10/token_supply_offset/ibc/ABC", client.CalledWithTokenSupplyOffset.MessageID)
+
+	// The occurrences are reset at the next block.
+	require.NoError(t, publisher.PublishBlock(ctx, domain.Block{ChainId: "osmosis-1", Height: 11}))
+	require.NoError(t, publisher.PublishTokenSupply(ctx, domain.TokenSupply{Denom: "ibc/ABC"}))
+	require.Equal(t, "osmosis-1/11/token_supply/ibc/ABC", client.CalledWithTokenSupply.MessageID)
+
+	// The SDK context takes precedence.
+	sdkCtx := sdk.Context{}.WithChainID("osmosis-1").WithBlockHeight(12)
+	require.NoError(t, publisher.PublishPair(sdkCtx, domain.Pair{PoolID: 1, IdxDenom0: 0, IdxDenom1: 1}))
+	require.Equal(t, "osmosis-1/12/pair/1/0/1", client.CalledWithPair.MessageID)
+	require.Equal(t, domain.SchemaVersion, client.CalledWithPair.SchemaVersion)
+
+	require.NoError(t, publisher.PublishPool(sdkCtx, domain.Pool{PoolID: 1, Height: 12}))
+	require.Equal(t, "osmosis-1/12/pool/1", client.CalledWithPool.MessageID)
+	require.Equal(t, domain.SchemaVersion, client.CalledWithPool.SchemaVersion)
+}
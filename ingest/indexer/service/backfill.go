@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"cosmossdk.io/log"
+	storetypes "cosmossdk.io/store/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/service/blockprocessor"
+	sqsdomain "github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+// backfillLogInterval is the number of backfilled blocks between progress logs.
+const backfillLogInterval = 1000
+
+// BackfillBlockLoader loads the historical blocks to backfill.
+type BackfillBlockLoader interface {
+	// LoadBlock returns the context of the application state at the given height
+	// together with the FinalizeBlock request and response of the block.
+	LoadBlock(height int64) (sdk.Context, abci.RequestFinalizeBlock, abci.ResponseFinalizeBlock, error)
+}
+
+// Backfiller publishes historical blocks with the same logic as the indexer streaming service,
+// e.g. for a range of blocks during which the indexer was disabled or misconfigured.
+// The progress is checkpointed after every block so that an interrupted backfill resumes where it stopped.
+type Backfiller struct {
+	service *indexerStreamingService
+
+	loader BackfillBlockLoader
+
+	checkpointStore commondomain.CheckpointStore
+}
+
+// NewBackfiller creates a new backfiller publishing the blocks loaded by loader to the client.
+// poolsTransformer instruments the published pool states. The pool states are not published if nil.
+// eventFilter selects the published events of the transactions, as for the indexer streaming service.
+// checkpointStore persists the progress of the backfills.
+func NewBackfiller(client domain.Publisher, poolExtractor commondomain.PoolExtractor, poolTracker sqsdomain.BlockPoolUpdateTracker, poolsTransformer sqsdomain.PoolsTransformer, keepers domain.Keepers, txDecoder sdk.TxDecoder, eventFilter domain.EventFilter, loader BackfillBlockLoader, checkpointStore commondomain.CheckpointStore, logger log.Logger) *Backfiller {
+	// Historical blocks are always processed incrementally: the pairs of the created pools
+	// and the state of the changed pools are published, never all pools.
+	blockProcessStrategyManager := commondomain.NewBlockProcessStrategyManager()
+	blockProcessStrategyManager.MarkInitialDataIngested()
+
+	return &Backfiller{
+		service: New(&commondomain.BlockUpdateProcessUtils{}, blockProcessStrategyManager, client, nil, poolExtractor, poolTracker, poolsTransformer, keepers, txDecoder, eventFilter, nil, nil, nil, logger),
+
+		loader: loader,
+
+		checkpointStore: checkpointStore,
+	}
+}
+
+// BackfillCheckpointSink returns the checkpoint sink of the backfill of the given range of heights.
+func BackfillCheckpointSink(from, to int64) string {
+	return fmt.Sprintf("indexer-backfill/%d-%d", from, to)
+}
+
+// Run publishes the blocks from the from height to the to height, both inclusive.
+// If a backfill of the same range was interrupted, it resumes after the last published height.
+// Stops between blocks once ctx is done.
+// Returns error if a block fails to be loaded or published, in which case the backfill can be resumed.
+func (b *Backfiller) Run(ctx context.Context, from, to int64) error {
+	if from <= 0 || to < from {
+		return fmt.Errorf("invalid backfill range from %d to %d", from, to)
+	}
+
+	sink := BackfillCheckpointSink(from, to)
+
+	startHeight := from
+	checkpoint, ok, err := b.checkpointStore.Load(sink)
+	if err != nil {
+		return fmt.Errorf("failed to load backfill checkpoint: %w", err)
+	}
+	if ok {
+		startHeight = int64(checkpoint.Height) + 1
+		b.service.logger.Info("Resuming indexer backfill", "from", from, "to", to, "height", startHeight)
+	}
+
+	for height := startHeight; height <= to; height++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		sdkCtx, req, res, err := b.loader.LoadBlock(height)
+		if err != nil {
+			return fmt.Errorf("failed to load block %d: %w", height, err)
+		}
+
+		if err := b.publishBlock(sdkCtx, req, res); err != nil {
+			return fmt.Errorf("failed to publish block %d: %w", height, err)
+		}
+
+		if err := b.checkpointStore.Save(sink, uint64(height), nil, nil, false); err != nil {
+			return fmt.Errorf("failed to checkpoint backfill at height %d: %w", height, err)
+		}
+
+		if (height-from+1)%backfillLogInterval == 0 || height == to {
+			b.service.logger.Info("Indexer backfill progress", "from", from, "to", to, "height", height)
+		}
+	}
+
+	return nil
+}
+
+// publishBlock publishes the block, its transactions, the pairs of the created pools and the state of the changed pools
+// like ListenFinalizeBlock and ListenCommit do for a live block.
+// Since the change set of the block is not available, the changed pools are the pools referenced by its events.
+// The gas consumed by the block is the gas used by its transactions.
+func (b *Backfiller) publishBlock(ctx sdk.Context, req abci.RequestFinalizeBlock, res abci.ResponseFinalizeBlock) error {
+	s := b.service
+
+	defer s.poolTracker.Reset()
+
+	var gasUsed uint64
+	for _, txResult := range res.TxResults {
+		gasUsed += uint64(txResult.GasUsed)
+	}
+	ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	ctx.GasMeter().ConsumeGas(gasUsed, "indexer backfill")
+
+	if err := s.publishBlock(ctx, req); err != nil {
+		return b.rollbackBlock(err)
+	}
+
+	if err := s.publishTxn(ctx, req, res); err != nil {
+		return b.rollbackBlock(err)
+	}
+
+	// The pools are read from the state at the height.
+	for poolID := range eventPoolIDs(res) {
+		s.poolTracker.TrackReplayedPoolID(poolID)
+	}
+
+	blockProcessor := blockprocessor.NewBlockProcessor(s.blockProcessStrategyManager, s.client, s.poolExtractor, s.keepers, s.nodeStatusChecker, s.blockUpdatesProcessUtils, nil, s.poolsTransformer)
+	if err := blockProcessor.ProcessBlock(ctx); err != nil {
+		return b.rollbackBlock(err)
+	}
+
+	if committer, ok := s.client.(domain.BlockCommitter); ok {
+		if err := committer.CommitBlock(ctx); err != nil {
+			return b.rollbackBlock(err)
+		}
+	}
+
+	return nil
+}
+
+// rollbackBlock discards the data published for the block if the publisher writes blocks atomically.
+// Returns the given error of the block.
+func (b *Backfiller) rollbackBlock(err error) error {
+	if committer, ok := b.service.client.(domain.BlockCommitter); ok {
+		if rollbackErr := committer.RollbackBlock(); rollbackErr != nil {
+			err = errors.Join(err, rollbackErr)
+		}
+	}
+	return err
+}
+
+// eventPoolIDs returns the IDs of the pools referenced by the events of the block and of its successful transactions.
+func eventPoolIDs(res abci.ResponseFinalizeBlock) map[uint64]struct{} {
+	poolIDs := make(map[uint64]struct{})
+
+	addPoolIDs := func(events []abci.Event) {
+		for _, event := range events {
+			for _, attribute := range event.Attributes {
+				if attribute.Key != poolmanagertypes.AttributeKeyPoolId {
+					continue
+				}
+
+				poolID, err := strconv.ParseUint(attribute.Value, 10, 64)
+				if err != nil {
+					continue
+				}
+				poolIDs[poolID] = struct{}{}
+			}
+		}
+	}
+
+	addPoolIDs(res.Events)
+	for _, txResult := range res.TxResults {
+		if txResult.IsErr() {
+			continue
+		}
+		addPoolIDs(txResult.Events)
+	}
+
+	return poolIDs
+}
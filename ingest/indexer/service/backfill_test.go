@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	dbm "github.com/cosmos/cosmos-db"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/checkpoint"
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/poolextractor"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/pooltracker"
+	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	indexermocks "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain/mocks"
+	indexerservice "github.com/osmosis-labs/osmosis/v30/ingest/indexer/service"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs"
+	poolstransformer "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/transformer"
+	gammtypes "github.com/osmosis-labs/osmosis/v30/x/gamm/types"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+// backfillBlockLoaderMock loads empty blocks with the given block events at the state of the test context.
+type backfillBlockLoaderMock struct {
+	ctx    sdk.Context
+	events []abcitypes.Event
+	// failHeight is the height that fails to be loaded, if any.
+	failHeight    int64
+	loadedHeights []int64
+}
+
+var _ indexerservice.BackfillBlockLoader = &backfillBlockLoaderMock{}
+
+func (l *backfillBlockLoaderMock) LoadBlock(height int64) (sdk.Context, abcitypes.RequestFinalizeBlock, abcitypes.ResponseFinalizeBlock, error) {
+	if height == l.failHeight {
+		return sdk.Context{}, abcitypes.RequestFinalizeBlock{}, abcitypes.ResponseFinalizeBlock{}, errors.New("block not found")
+	}
+
+	l.loadedHeights = append(l.loadedHeights, height)
+	return l.ctx.WithBlockHeight(height), abcitypes.RequestFinalizeBlock{Height: height}, abcitypes.ResponseFinalizeBlock{Events: l.events}, nil
+}
+
+// Validates that the blocks of the range are published with the state of the pools referenced by their events,
+// and that an interrupted backfill resumes after the last published height.
+func (s *IndexerServiceTestSuite) TestBackfiller_Run() {
+	s.Setup()
+
+	pool := s.PrepareConcentratedPool()
+
+	keepers := commondomain.PoolExtractorKeepers{
+		GammKeeper:         s.App.GAMMKeeper,
+		ConcentratedKeeper: s.App.ConcentratedLiquidityKeeper,
+		WasmKeeper:         s.App.WasmKeeper,
+		BankKeeper:         s.App.BankKeeper,
+		ProtorevKeeper:     s.App.ProtoRevKeeper,
+		PoolManagerKeeper:  s.App.PoolManagerKeeper,
+		CosmWasmPoolKeeper: s.App.CosmwasmPoolKeeper,
+	}
+
+	publisherMock := &indexermocks.PublisherMock{}
+	poolTracker := pooltracker.NewMemory()
+	checkpointStore := checkpoint.NewStore(dbm.NewMemDB())
+
+	loader := &backfillBlockLoaderMock{
+		ctx: s.Ctx,
+		events: []abcitypes.Event{{
+			Type:       gammtypes.TypeEvtTokenSwapped,
+			Attributes: []abcitypes.EventAttribute{{Key: poolmanagertypes.AttributeKeyPoolId, Value: strconv.FormatUint(pool.GetId(), 10)}},
+		}},
+		failHeight: 12,
+	}
+
+	backfiller := indexerservice.NewBackfiller(
+		publisherMock,
+		poolextractor.New(keepers, poolTracker),
+		poolTracker,
+		poolstransformer.NewPoolTransformer(keepers, sqs.DefaultUSDCUOSMOPool, nil, nil),
+		indexerdomain.Keepers{BankKeeper: s.App.BankKeeper, PoolManagerKeeper: s.App.PoolManagerKeeper},
+		s.App.GetTxConfig().TxDecoder(),
+		defaultEventFilter,
+		loader,
+		checkpointStore,
+		s.App.Logger())
+
+	// The backfill stops at the block that fails to be loaded.
+	err := backfiller.Run(context.Background(), 10, 13)
+	s.Require().ErrorContains(err, "failed to load block 12")
+	s.Require().Equal(2, publisherMock.NumPublishBlockCalls)
+	s.Require().Equal(uint64(11), publisherMock.CalledWithBlock.Height)
+	s.Require().Equal(2, publisherMock.NumPublishPoolCalls)
+	s.Require().Equal(pool.GetId(), publisherMock.CalledWithPool.PoolID)
+	s.Require().Equal(uint64(11), publisherMock.CalledWithPool.Height)
+
+	// The backfill resumes after the last published height.
+	loader.failHeight = 0
+	s.Require().NoError(backfiller.Run(context.Background(), 10, 13))
+	s.Require().Equal([]int64{10, 11, 12, 13}, loader.loadedHeights)
+	s.Require().Equal(4, publisherMock.NumPublishBlockCalls)
+
+	backfillCheckpoint, ok, err := checkpointStore.Load(indexerservice.BackfillCheckpointSink(10, 13))
+	s.Require().NoError(err)
+	s.Require().True(ok)
+	s.Require().Equal(uint64(13), backfillCheckpoint.Height)
+
+	// A completed backfill publishes nothing.
+	s.Require().NoError(backfiller.Run(context.Background(), 10, 13))
+	s.Require().Equal(4, publisherMock.NumPublishBlockCalls)
+
+	// A cancelled backfill stops before the next block.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Require().ErrorIs(backfiller.Run(ctx, 20, 21), context.Canceled)
+	s.Require().Equal(4, publisherMock.NumPublishBlockCalls)
+
+	s.Require().Error(backfiller.Run(context.Background(), 13, 10))
+}
@@ -4,13 +4,22 @@ import (
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
 	commonservice "github.com/osmosis-labs/osmosis/v30/ingest/common/service"
 	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	sqsdomain "github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
 )
 
 // NewBlockProcessor creates a new block process strategy.
-func NewBlockProcessor(blockProcessStrategyManager commondomain.BlockProcessStrategyManager, client domain.Publisher, poolExtractor commondomain.PoolExtractor, keepers domain.Keepers, nodeStatusChecker commonservice.NodeStatusChecker, blockUpdateProcessUtils commondomain.BlockUpdateProcessUtilsI) commondomain.BlockProcessor {
+// If checkpointer is not nil, the published pools are checkpointed for warm restart.
+// If poolsTransformer is not nil, the state of the changed pools is published every block, and of all pools on cold start.
+func NewBlockProcessor(blockProcessStrategyManager commondomain.BlockProcessStrategyManager, client domain.Publisher, poolExtractor commondomain.PoolExtractor, keepers domain.Keepers, nodeStatusChecker commonservice.NodeStatusChecker, blockUpdateProcessUtils commondomain.BlockUpdateProcessUtilsI, checkpointer commondomain.Checkpointer, poolsTransformer sqsdomain.PoolsTransformer) commondomain.BlockProcessor {
 	// Initialize the pool pair publisher
 	poolPairPublisher := NewPairPublisher(client, keepers.PoolManagerKeeper)
 
+	// Initialize the pool publisher if enabled
+	var poolPublisher domain.PoolPublisher
+	if poolsTransformer != nil {
+		poolPublisher = NewPoolPublisher(client, poolsTransformer)
+	}
+
 	// If true, ingest all the data.
 	if blockProcessStrategyManager.ShouldPushAllData() {
 		return &fullIndexerBlockProcessStrategy{
@@ -18,7 +27,9 @@ func NewBlockProcessor(blockProcessStrategyManager commondomain.BlockProcessStra
 			keepers:           keepers,
 			poolExtractor:     poolExtractor,
 			poolPairPublisher: poolPairPublisher,
+			poolPublisher:     poolPublisher,
 			nodeStatusChecker: nodeStatusChecker,
+			checkpointer:      checkpointer,
 		}
 	}
 
@@ -26,6 +37,9 @@ func NewBlockProcessor(blockProcessStrategyManager commondomain.BlockProcessStra
 		client:                  client,
 		poolExtractor:           poolExtractor,
 		poolPairPublisher:       poolPairPublisher,
+		poolPublisher:           poolPublisher,
 		blockUpdateProcessUtils: blockUpdateProcessUtils,
+		checkpointer:            checkpointer,
+		poolManagerKeeper:       keepers.PoolManagerKeeper,
 	}
 }
@@ -59,7 +59,7 @@ func (suite *IndexerBlockProcessorTestSuite) TestNewBlockProcessor() {
 			nodeStatusCheckerMock := &commonmocks.NodeStatusCheckerMock{}
 
 			// System under test
-			newBlockProcessor := blockprocessor.NewBlockProcessor(blockStrategyManager, publisherMock, poolsExtracter, domain.Keepers{}, nodeStatusCheckerMock, nil)
+			newBlockProcessor := blockprocessor.NewBlockProcessor(blockStrategyManager, publisherMock, poolsExtracter, domain.Keepers{}, nodeStatusCheckerMock, nil, nil, nil)
 
 			// Check if the block processor is a full block processor
 			isFullBlockProcessor := newBlockProcessor.IsFullBlockProcessor()
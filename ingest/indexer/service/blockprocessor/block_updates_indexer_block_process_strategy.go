@@ -9,10 +9,17 @@ import (
 )
 
 type blockUpdatesIndexerBlockProcessStrategy struct {
-	client                  domain.Publisher
-	poolExtractor           commondomain.PoolExtractor
-	poolPairPublisher       domain.PairPublisher
+	client            domain.Publisher
+	poolExtractor     commondomain.PoolExtractor
+	poolPairPublisher domain.PairPublisher
+	// poolPublisher publishes the state of the changed pools. Nil if disabled.
+	poolPublisher           domain.PoolPublisher
 	blockUpdateProcessUtils commondomain.BlockUpdateProcessUtilsI
+
+	// checkpointer persists the published pools for warm restart. Nil if disabled.
+	checkpointer commondomain.Checkpointer
+	// poolManagerKeeper is used for hashing the pair data of the published pools.
+	poolManagerKeeper domain.PoolManagerKeeperI
 }
 
 var _ commondomain.BlockProcessor = &blockUpdatesIndexerBlockProcessStrategy{}
@@ -25,30 +32,66 @@ func (f *blockUpdatesIndexerBlockProcessStrategy) IsFullBlockProcessor() bool {
 // ProcessBlock implements commondomain.BlockProcessStrategy.
 func (f *blockUpdatesIndexerBlockProcessStrategy) ProcessBlock(ctx types.Context) error {
 	// Publish supplies
-	if err := f.publishCreatedPools(ctx); err != nil {
+	poolHashes, err := f.publishCreatedPools(ctx)
+	if err != nil {
 		return err
 	}
 
+	// Publish the state of the changed pools
+	if err := f.publishChangedPools(ctx); err != nil {
+		return err
+	}
+
+	// Commit the height of every processed block so that the checkpoint does not
+	// age out of warm restart when no pools are created for a while.
+	if f.checkpointer != nil {
+		if err := f.checkpointer.Commit(uint64(ctx.BlockHeight()), poolHashes, nil, false); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// publishChangedPools publishes the pools that were changed in the block.
-func (f *blockUpdatesIndexerBlockProcessStrategy) publishCreatedPools(ctx types.Context) error {
-	err := f.blockUpdateProcessUtils.ProcessBlockChangeSet()
+// publishChangedPools publishes the state of the pools that were changed in the block.
+// Must be called after publishCreatedPools, which processes the block change set.
+func (f *blockUpdatesIndexerBlockProcessStrategy) publishChangedPools(ctx types.Context) error {
+	if f.poolPublisher == nil {
+		return nil
+	}
+
+	blockPools, err := f.poolExtractor.ExtractChanged(ctx)
 	if err != nil {
 		return err
 	}
+
+	// Do nothing if no pools were changed
+	if len(blockPools.GetAll()) == 0 {
+		return nil
+	}
+
+	return f.poolPublisher.PublishPools(ctx, blockPools)
+}
+
+// publishCreatedPools publishes the pairs of the pools that were created in the block.
+// Returns the hashes of the published pool pairs to be committed if the checkpointer is enabled.
+// Returns nil hashes if no pools were published.
+func (f *blockUpdatesIndexerBlockProcessStrategy) publishCreatedPools(ctx types.Context) (map[uint64][]byte, error) {
+	err := f.blockUpdateProcessUtils.ProcessBlockChangeSet()
+	if err != nil {
+		return nil, err
+	}
 	// Extract the pools that were changed in the block
 	blockPools, createdPoolIDs, err := f.poolExtractor.ExtractCreated(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	pools := blockPools.GetAll()
 
 	// Do nothing if no pools were created, or pool metadata is nil
 	if len(createdPoolIDs) == 0 || len(pools) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	// Filter pools to include only those with pool IDs found in createdPoolIDs
@@ -61,13 +104,17 @@ func (f *blockUpdatesIndexerBlockProcessStrategy) publishCreatedPools(ctx types.
 
 	// Do nothing if no pools are left after filtering
 	if len(filteredPools) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	// Publish pool pairs
 	if err := f.poolPairPublisher.PublishPoolPairs(ctx, filteredPools, createdPoolIDs); err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	if f.checkpointer == nil {
+		return nil, nil
+	}
+
+	return hashPoolPairs(ctx, filteredPools, f.poolManagerKeeper)
 }
@@ -3,9 +3,11 @@ package blockprocessor_test
 import (
 	"testing"
 
+	dbm "github.com/cosmos/cosmos-db"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/osmosis-labs/osmosis/v30/app/apptesting"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/checkpoint"
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
 	commonmocks "github.com/osmosis-labs/osmosis/v30/ingest/common/domain/mocks"
 	indexermocks "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain/mocks"
@@ -113,7 +115,7 @@ func (s *BlockUpdateIndexerBlockProcessStrategyTestSuite) TestPublishCreatedPool
 			// Mock out pair publisher
 			pairPublisherMock := &indexermocks.MockPairPublisher{}
 
-			bprocess := blockprocessor.NewBlockUpdatesIndexerBlockProcessStrategy(blockUpdatesProcessUtilsMock, publisherMock, poolsExtracter, pairPublisherMock)
+			bprocess := blockprocessor.NewBlockUpdatesIndexerBlockProcessStrategy(blockUpdatesProcessUtilsMock, publisherMock, poolsExtracter, pairPublisherMock, nil, nil, nil)
 
 			err = bprocess.PublishCreatedPools(s.Ctx)
 			s.Require().NoError(err)
@@ -131,3 +133,102 @@ func (s *BlockUpdateIndexerBlockProcessStrategyTestSuite) TestPublishCreatedPool
 		})
 	}
 }
+
+func (s *BlockUpdateIndexerBlockProcessStrategyTestSuite) TestPublishChangedPools() {
+	s.Setup()
+
+	// Initialized chain pools
+	s.PrepareAllSupportedPools()
+
+	concentratedPools, err := s.App.ConcentratedLiquidityKeeper.GetPools(s.Ctx)
+	s.Require().NoError(err)
+	cfmmPools, err := s.App.GAMMKeeper.GetPools(s.Ctx)
+	s.Require().NoError(err)
+
+	// Mock out pool extractor with the changed pools
+	poolsExtracter := &commonmocks.PoolsExtractorMock{
+		BlockPools: commondomain.BlockPools{
+			ConcentratedPools: concentratedPools,
+			CFMMPools:         cfmmPools,
+		},
+	}
+
+	poolPublisherMock := &indexermocks.MockPoolPublisher{}
+
+	bprocess := blockprocessor.NewBlockUpdatesIndexerBlockProcessStrategy(&sqsmocks.BlockUpdateProcessUtilsMock{}, &indexermocks.PublisherMock{}, poolsExtracter, &indexermocks.MockPairPublisher{}, poolPublisherMock, nil, nil)
+
+	err = bprocess.PublishChangedPools(s.Ctx)
+	s.Require().NoError(err)
+	s.Require().True(poolsExtracter.IsProcessAllChangedDataCalled)
+	s.Require().Equal(3, poolPublisherMock.NumPoolsPublished)
+
+	// Nothing is published if no pools changed.
+	poolsExtracter.BlockPools = commondomain.BlockPools{}
+	poolPublisherMock = &indexermocks.MockPoolPublisher{}
+
+	bprocess = blockprocessor.NewBlockUpdatesIndexerBlockProcessStrategy(&sqsmocks.BlockUpdateProcessUtilsMock{}, &indexermocks.PublisherMock{}, poolsExtracter, &indexermocks.MockPairPublisher{}, poolPublisherMock, nil, nil)
+
+	err = bprocess.PublishChangedPools(s.Ctx)
+	s.Require().NoError(err)
+	s.Require().False(poolPublisherMock.PublishPoolsCalled)
+
+	// Nothing is published if the pool publisher is disabled.
+	poolsExtracter.IsProcessAllChangedDataCalled = false
+
+	bprocess = blockprocessor.NewBlockUpdatesIndexerBlockProcessStrategy(&sqsmocks.BlockUpdateProcessUtilsMock{}, &indexermocks.PublisherMock{}, poolsExtracter, &indexermocks.MockPairPublisher{}, nil, nil, nil)
+
+	err = bprocess.PublishChangedPools(s.Ctx)
+	s.Require().NoError(err)
+	s.Require().False(poolsExtracter.IsProcessAllChangedDataCalled)
+}
+
+// This test validates that the height is committed on every processed block,
+// including the blocks in which no pools were created.
+func (s *BlockUpdateIndexerBlockProcessStrategyTestSuite) TestProcessBlock_Checkpoint() {
+	s.Setup()
+
+	// Initialized chain pools
+	s.PrepareAllSupportedPools()
+
+	concentratedPools, err := s.App.ConcentratedLiquidityKeeper.GetPools(s.Ctx)
+	s.Require().NoError(err)
+
+	// Mock out pool extractor with a created pool
+	poolsExtracter := &commonmocks.PoolsExtractorMock{
+		BlockPools: commondomain.BlockPools{
+			ConcentratedPools: concentratedPools,
+		},
+		CreatedPoolIDs: map[uint64]commondomain.PoolCreation{
+			DefaultConcentratedPoolId: NewPoolCreation(DefaultConcentratedPoolId, DefaultConcentratedPoolHeight, DefaultConcentratedPoolTime, DefaultConcentratedPoolTxnHash),
+		},
+	}
+
+	checkpointStore := checkpoint.NewStore(dbm.NewMemDB())
+	checkpointer, err := checkpoint.NewCheckpointer(checkpointStore, "indexer", 100)
+	s.Require().NoError(err)
+
+	bprocess := blockprocessor.NewBlockUpdatesIndexerBlockProcessStrategy(&sqsmocks.BlockUpdateProcessUtilsMock{}, &indexermocks.PublisherMock{}, poolsExtracter, &indexermocks.MockPairPublisher{}, nil, checkpointer, s.App.PoolManagerKeeper)
+
+	err = bprocess.ProcessBlock(s.Ctx)
+	s.Require().NoError(err)
+
+	savedCheckpoint, found, err := checkpointStore.Load("indexer")
+	s.Require().NoError(err)
+	s.Require().True(found)
+	s.Require().Equal(uint64(s.Ctx.BlockHeight()), savedCheckpoint.Height)
+	s.Require().Contains(savedCheckpoint.PoolHashes, DefaultConcentratedPoolId)
+
+	// System under test: no pools are created in the next block.
+	poolsExtracter.CreatedPoolIDs = map[uint64]commondomain.PoolCreation{}
+	s.Ctx = s.Ctx.WithBlockHeight(s.Ctx.BlockHeight() + 1)
+
+	err = bprocess.ProcessBlock(s.Ctx)
+	s.Require().NoError(err)
+
+	// The height is committed and the hashes of the previously published pools are retained.
+	savedCheckpoint, found, err = checkpointStore.Load("indexer")
+	s.Require().NoError(err)
+	s.Require().True(found)
+	s.Require().Equal(uint64(s.Ctx.BlockHeight()), savedCheckpoint.Height)
+	s.Require().Contains(savedCheckpoint.PoolHashes, DefaultConcentratedPoolId)
+}
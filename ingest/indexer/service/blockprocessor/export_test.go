@@ -11,29 +11,39 @@ import (
 // Alias to BlockUpdatesIndexerBlockProcessStrategy to allow exporting private functions for testing.
 type BlockUpdatesIndexerBlockProcessStrategy = blockUpdatesIndexerBlockProcessStrategy
 
-func NewBlockUpdatesIndexerBlockProcessStrategy(blockUpdateProcessUtils commondomain.BlockUpdateProcessUtilsI, client indexerdomain.Publisher, poolExtractor commondomain.PoolExtractor, poolPairPublisher indexerdomain.PairPublisher) *blockUpdatesIndexerBlockProcessStrategy {
+func NewBlockUpdatesIndexerBlockProcessStrategy(blockUpdateProcessUtils commondomain.BlockUpdateProcessUtilsI, client indexerdomain.Publisher, poolExtractor commondomain.PoolExtractor, poolPairPublisher indexerdomain.PairPublisher, poolPublisher indexerdomain.PoolPublisher, checkpointer commondomain.Checkpointer, poolManagerKeeper indexerdomain.PoolManagerKeeperI) *blockUpdatesIndexerBlockProcessStrategy {
 	return &blockUpdatesIndexerBlockProcessStrategy{
 		blockUpdateProcessUtils: blockUpdateProcessUtils,
 		client:                  client,
 		poolExtractor:           poolExtractor,
 		poolPairPublisher:       poolPairPublisher,
+		poolPublisher:           poolPublisher,
+		checkpointer:            checkpointer,
+		poolManagerKeeper:       poolManagerKeeper,
 	}
 }
 
 func (s *blockUpdatesIndexerBlockProcessStrategy) PublishCreatedPools(ctx types.Context) error {
-	return s.publishCreatedPools(ctx)
+	_, err := s.publishCreatedPools(ctx)
+	return err
+}
+
+func (s *blockUpdatesIndexerBlockProcessStrategy) PublishChangedPools(ctx types.Context) error {
+	return s.publishChangedPools(ctx)
 }
 
 // Alias to FullIndexerBlockProcessStrategy to allow exporting private functions for testing.
 type FullIndexerBlockProcessStrategy = fullIndexerBlockProcessStrategy
 
-func NewFullIndexerBlockProcessStrategy(client indexerdomain.Publisher, keepers indexerdomain.Keepers, poolExtractor commondomain.PoolExtractor, poolPairPublisher indexerdomain.PairPublisher, nodeStatusChecker commonservice.NodeStatusChecker) *fullIndexerBlockProcessStrategy {
+func NewFullIndexerBlockProcessStrategy(client indexerdomain.Publisher, keepers indexerdomain.Keepers, poolExtractor commondomain.PoolExtractor, poolPairPublisher indexerdomain.PairPublisher, poolPublisher indexerdomain.PoolPublisher, nodeStatusChecker commonservice.NodeStatusChecker, checkpointer commondomain.Checkpointer) *fullIndexerBlockProcessStrategy {
 	return &fullIndexerBlockProcessStrategy{
 		client:            client,
 		keepers:           keepers,
 		poolExtractor:     poolExtractor,
 		poolPairPublisher: poolPairPublisher,
+		poolPublisher:     poolPublisher,
 		nodeStatusChecker: nodeStatusChecker,
+		checkpointer:      checkpointer,
 	}
 }
 
@@ -15,7 +15,12 @@ type fullIndexerBlockProcessStrategy struct {
 	keepers           domain.Keepers
 	poolExtractor     commondomain.PoolExtractor
 	poolPairPublisher domain.PairPublisher
+	// poolPublisher publishes the state of all the pools. Nil if disabled.
+	poolPublisher     domain.PoolPublisher
 	nodeStatusChecker commonservice.NodeStatusChecker
+
+	// checkpointer persists the published pools for warm restart. Nil if disabled.
+	checkpointer commondomain.Checkpointer
 }
 
 var _ commondomain.BlockProcessor = &fullIndexerBlockProcessStrategy{}
@@ -108,19 +113,46 @@ func (f *fullIndexerBlockProcessStrategy) publishAllSupplies(ctx sdk.Context) {
 }
 
 // processPools publishes all the pools in the block.
+// On warm restart, only the pools whose pair data changed since the checkpoint are published as pairs.
+// The state of all the pools is published regardless since it changes with every swap.
 func (f *fullIndexerBlockProcessStrategy) processPools(ctx sdk.Context) error {
 	blockPools, createdPoolIDs, err := f.poolExtractor.ExtractAll(ctx)
 	if err != nil {
 		return err
 	}
 
+	// Publish the state of all pools
+	if f.poolPublisher != nil {
+		if err := f.poolPublisher.PublishPools(ctx, blockPools); err != nil {
+			return err
+		}
+	}
+
 	// Extract pools
 	pools := blockPools.GetAll()
 
+	var poolHashes map[uint64][]byte
+	if f.checkpointer != nil {
+		poolHashes, err = hashPoolPairs(ctx, pools, f.keepers.PoolManagerKeeper)
+		if err != nil {
+			return err
+		}
+
+		if checkpoint, ok := f.checkpointer.WarmRestart(uint64(ctx.BlockHeight())); ok {
+			pools = filterChangedPools(pools, poolHashes, checkpoint.PoolHashes)
+		}
+	}
+
 	// Process pool pairs
 	if err := f.poolPairPublisher.PublishPoolPairs(ctx, pools, createdPoolIDs); err != nil {
 		return err
 	}
 
+	if f.checkpointer != nil {
+		if err := f.checkpointer.Commit(uint64(ctx.BlockHeight()), poolHashes, nil, true); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
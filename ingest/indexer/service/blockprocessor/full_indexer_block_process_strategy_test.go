@@ -5,9 +5,11 @@ import (
 	"testing"
 	"time"
 
+	dbm "github.com/cosmos/cosmos-db"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/osmosis-labs/osmosis/v30/app/apptesting"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/checkpoint"
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
 	commonmocks "github.com/osmosis-labs/osmosis/v30/ingest/common/domain/mocks"
 	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
@@ -176,7 +178,7 @@ func (s *FullIndexerBlockProcessStrategyTestSuite) TestProcessBlock() {
 				IsNodeSyncingError: test.isSyncingMockError,
 			}
 
-			blockProcessor := blockprocessor.NewFullIndexerBlockProcessStrategy(publisherMock, keepers, poolsExtracter, pairPublisherMock, nodeStatusCheckerMock)
+			blockProcessor := blockprocessor.NewFullIndexerBlockProcessStrategy(publisherMock, keepers, poolsExtracter, pairPublisherMock, nil, nodeStatusCheckerMock, nil)
 
 			err = blockProcessor.ProcessBlock(s.Ctx)
 			s.Require().Equal(test.expectedError, err)
@@ -264,7 +266,7 @@ func (s *FullIndexerBlockProcessStrategyTestSuite) TestPublishAllSupplies() {
 				BankKeeper:        s.App.BankKeeper,
 			}
 
-			blockProcessor := blockprocessor.NewFullIndexerBlockProcessStrategy(publisherMock, keepers, poolsExtracter, pairPublisherMock, nil)
+			blockProcessor := blockprocessor.NewFullIndexerBlockProcessStrategy(publisherMock, keepers, poolsExtracter, pairPublisherMock, nil, nil, nil)
 
 			blockProcessor.PublishAllSupplies(s.Ctx)
 			s.Require().Equal(test.expectedNumPublishTokenSupplyCalls, publisherMock.NumPublishTokenSupplyCalls)
@@ -364,7 +366,7 @@ func (s *FullIndexerBlockProcessStrategyTestSuite) TestProcessPools() {
 				BankKeeper:        s.App.BankKeeper,
 			}
 
-			blockProcessor := blockprocessor.NewFullIndexerBlockProcessStrategy(publisherMock, keepers, poolsExtracter, pairPublisherMock, nil)
+			blockProcessor := blockprocessor.NewFullIndexerBlockProcessStrategy(publisherMock, keepers, poolsExtracter, pairPublisherMock, nil, nil, nil)
 
 			err = blockProcessor.ProcessPools(s.Ctx)
 			s.Require().NoError(err)
@@ -384,3 +386,107 @@ func (s *FullIndexerBlockProcessStrategyTestSuite) TestProcessPools() {
 		})
 	}
 }
+
+// This test validates that on warm restart, only the pools whose pair data changed
+// since the checkpoint are published.
+func (s *FullIndexerBlockProcessStrategyTestSuite) TestProcessPools_WarmRestart() {
+	s.Setup()
+
+	// Initialized chain pools
+	s.PrepareAllSupportedPools()
+
+	poolsExtracter := &commonmocks.PoolsExtractorMock{
+		BlockPools: s.getAllBlockPools(),
+	}
+
+	keepers := indexerdomain.Keepers{
+		PoolManagerKeeper: s.App.PoolManagerKeeper,
+		BankKeeper:        s.App.BankKeeper,
+	}
+
+	checkpointStore := checkpoint.NewStore(dbm.NewMemDB())
+
+	// processPools mimics a node restart by creating a new block processor
+	// with a checkpointer loaded from the store.
+	processPools := func() *indexermocks.MockPairPublisher {
+		checkpointer, err := checkpoint.NewCheckpointer(checkpointStore, "indexer", 100)
+		s.Require().NoError(err)
+
+		pairPublisherMock := &indexermocks.MockPairPublisher{}
+		blockProcessor := blockprocessor.NewFullIndexerBlockProcessStrategy(&indexermocks.PublisherMock{}, keepers, poolsExtracter, pairPublisherMock, nil, nil, checkpointer)
+
+		err = blockProcessor.ProcessPools(s.Ctx)
+		s.Require().NoError(err)
+
+		return pairPublisherMock
+	}
+
+	// No checkpoint, all pools are published.
+	pairPublisherMock := processPools()
+	s.Require().Equal(5, pairPublisherMock.NumPoolPairPublished)
+
+	// System under test: warm restart with no changes.
+	s.Ctx = s.Ctx.WithBlockHeight(s.Ctx.BlockHeight() + 1)
+	pairPublisherMock = processPools()
+	s.Require().True(pairPublisherMock.PublishPoolPairsCalled)
+	s.Require().Zero(pairPublisherMock.NumPoolPairPublished)
+
+	// System under test: warm restart with a new pool.
+	s.PrepareBalancerPool()
+	poolsExtracter.BlockPools = s.getAllBlockPools()
+
+	s.Ctx = s.Ctx.WithBlockHeight(s.Ctx.BlockHeight() + 1)
+	pairPublisherMock = processPools()
+	s.Require().Equal(1, pairPublisherMock.NumPoolPairPublished)
+}
+
+// getAllBlockPools returns all chain pools as block pools.
+// This test validates that the state of all the pools is published, including on warm restart
+// when the pair data of no pool changed.
+func (s *FullIndexerBlockProcessStrategyTestSuite) TestProcessPools_PoolState() {
+	s.Setup()
+
+	// Initialized chain pools
+	s.PrepareAllSupportedPools()
+
+	poolsExtracter := &commonmocks.PoolsExtractorMock{
+		BlockPools: s.getAllBlockPools(),
+	}
+
+	keepers := indexerdomain.Keepers{
+		PoolManagerKeeper: s.App.PoolManagerKeeper,
+		BankKeeper:        s.App.BankKeeper,
+	}
+
+	checkpointStore := checkpoint.NewStore(dbm.NewMemDB())
+
+	for i := 0; i < 2; i++ {
+		checkpointer, err := checkpoint.NewCheckpointer(checkpointStore, "indexer", 100)
+		s.Require().NoError(err)
+
+		pairPublisherMock := &indexermocks.MockPairPublisher{}
+		poolPublisherMock := &indexermocks.MockPoolPublisher{}
+		blockProcessor := blockprocessor.NewFullIndexerBlockProcessStrategy(&indexermocks.PublisherMock{}, keepers, poolsExtracter, pairPublisherMock, poolPublisherMock, nil, checkpointer)
+
+		err = blockProcessor.ProcessPools(s.Ctx)
+		s.Require().NoError(err)
+		s.Require().Equal(5, poolPublisherMock.NumPoolsPublished)
+
+		s.Ctx = s.Ctx.WithBlockHeight(s.Ctx.BlockHeight() + 1)
+	}
+}
+
+func (s *FullIndexerBlockProcessStrategyTestSuite) getAllBlockPools() commondomain.BlockPools {
+	concentratedPools, err := s.App.ConcentratedLiquidityKeeper.GetPools(s.Ctx)
+	s.Require().NoError(err)
+	cfmmPools, err := s.App.GAMMKeeper.GetPools(s.Ctx)
+	s.Require().NoError(err)
+	cosmWasmPools, err := s.App.CosmwasmPoolKeeper.GetPoolsWithWasmKeeper(s.Ctx)
+	s.Require().NoError(err)
+
+	return commondomain.BlockPools{
+		ConcentratedPools: concentratedPools,
+		CFMMPools:         cfmmPools,
+		CosmWasmPools:     cosmWasmPools,
+	}
+}
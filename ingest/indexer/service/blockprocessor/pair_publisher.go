@@ -41,6 +41,8 @@ func NewPairPublisher(client domain.Publisher, poolManagerKeeper domain.PoolMana
 // Invalid denoms are skipped as per domain.ShouldFilterDenom function.
 // Returns error if at least one of the pairs failed to be published.
 // Nil otherwise.
+// Returns only once all the publishing goroutines are done so that no pair
+// is published after the block is committed or the publisher is closed.
 func (p PairPublisher) PublishPoolPairs(ctx sdk.Context, pools []poolmanagertypes.PoolI, createdPoolIDs map[uint64]commondomain.PoolCreation) error {
 	result := make(chan error, len(pools))
 
@@ -99,7 +101,8 @@ func (p PairPublisher) PublishPoolPairs(ctx sdk.Context, pools []poolmanagertype
 						mu.Unlock()
 						if err != nil {
 							// This error should not happen. As a result, we do not skip it
-							result <- err
+							curErrStr := errStr.Load()
+							errStr.Store(fmt.Sprintf("%s, %s", curErrStr, err.Error()))
 
 							// Continue to the next pair, if any
 							continue
@@ -168,13 +171,15 @@ func (p PairPublisher) PublishPoolPairs(ctx sdk.Context, pools []poolmanagertype
 		}(pool, ctx)
 	}
 
-	// Wait for all the results
+	// Wait for all the results, including after an error,
+	// so that no goroutine outlives the call.
+	var firstErr error
 	for i := 0; i < len(pools); i++ {
 		err := <-result
-		if err != nil {
-			return err
+		if err != nil && firstErr == nil {
+			firstErr = err
 		}
 	}
 
-	return nil
+	return firstErr
 }
@@ -1,6 +1,7 @@
 package blockprocessor_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -116,3 +117,32 @@ func (s *PairPublisherTestSuite) TestPublishPoolPairs() {
 		})
 	}
 }
+
+// TestPublishPoolPairs_Error validates that the error of a pair is returned only once all the pairs
+// were published so that no publishing goroutine outlives the call.
+func (s *PairPublisherTestSuite) TestPublishPoolPairs_Error() {
+	s.Setup()
+
+	s.PrepareAllSupportedPools()
+
+	concentratedPools, err := s.App.ConcentratedLiquidityKeeper.GetPools(s.Ctx)
+	s.Require().NoError(err)
+	cfmmPools, err := s.App.GAMMKeeper.GetPools(s.Ctx)
+	s.Require().NoError(err)
+	cosmWasmPools, err := s.App.CosmwasmPoolKeeper.GetPoolsWithWasmKeeper(s.Ctx)
+	s.Require().NoError(err)
+	blockPools := commondomain.BlockPools{
+		ConcentratedPools: concentratedPools,
+		CFMMPools:         cfmmPools,
+		CosmWasmPools:     cosmWasmPools,
+	}
+
+	mockError := errors.New("mock error")
+	publisherMock := &indexermocks.PublisherMock{ForcePairError: mockError}
+
+	pairPublisher := blockprocessor.NewPairPublisher(publisherMock, s.App.PoolManagerKeeper)
+
+	err = pairPublisher.PublishPoolPairs(s.Ctx, blockPools.GetAll(), map[uint64]commondomain.PoolCreation{})
+	s.Require().ErrorContains(err, mockError.Error())
+	s.Require().Equal(12, publisherMock.NumPublishPairCalls)
+}
@@ -0,0 +1,67 @@
+package blockprocessor
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+// hashPoolPairs returns the hashes of the pair data of the pools by pool ID.
+// The pair data of a pool consists of its denoms, its spread factor and the taker fees of its denom pairs.
+// These are the fields of the published pairs that may change after the pool is created.
+func hashPoolPairs(ctx sdk.Context, pools []poolmanagertypes.PoolI, poolManagerKeeper domain.PoolManagerKeeperI) (map[uint64][]byte, error) {
+	poolHashes := make(map[uint64][]byte, len(pools))
+	for _, pool := range pools {
+		hasher := sha256.New()
+
+		denoms := pool.GetPoolDenoms(ctx)
+		for _, denom := range denoms {
+			hasher.Write([]byte(denom))
+			hasher.Write([]byte(keySeparator))
+		}
+
+		hasher.Write([]byte(pool.GetSpreadFactor(ctx).String()))
+
+		for i, denomI := range denoms {
+			if domain.ShouldFilterDenom(denomI) {
+				continue
+			}
+
+			for j := i + 1; j < len(denoms); j++ {
+				if domain.ShouldFilterDenom(denoms[j]) {
+					continue
+				}
+
+				takerFee, err := poolManagerKeeper.GetTradingPairTakerFee(ctx, denomI, denoms[j])
+				if err != nil {
+					return nil, err
+				}
+
+				hasher.Write([]byte(keySeparator))
+				hasher.Write([]byte(takerFee.String()))
+			}
+		}
+
+		poolHashes[pool.GetId()] = hasher.Sum(nil)
+	}
+
+	return poolHashes, nil
+}
+
+// filterChangedPools returns the pools whose hash differs from the checkpointed one.
+func filterChangedPools(pools []poolmanagertypes.PoolI, poolHashes, checkpointPoolHashes map[uint64][]byte) []poolmanagertypes.PoolI {
+	changedPools := make([]poolmanagertypes.PoolI, 0, len(pools))
+	for _, pool := range pools {
+		if bytes.Equal(poolHashes[pool.GetId()], checkpointPoolHashes[pool.GetId()]) {
+			continue
+		}
+
+		changedPools = append(changedPools, pool)
+	}
+
+	return changedPools
+}
@@ -0,0 +1,76 @@
+package blockprocessor
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	sqsdomain "github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	concentratedtypes "github.com/osmosis-labs/osmosis/v30/x/concentrated-liquidity/types"
+)
+
+// PoolPublisher publishes the state of the pools changed in a block.
+type PoolPublisher struct {
+	client           domain.Publisher
+	poolsTransformer sqsdomain.PoolsTransformer
+}
+
+var _ domain.PoolPublisher = &PoolPublisher{}
+
+// NewPoolPublisher creates a new pool publisher.
+// The pools are instrumented with their balances and liquidity capitalization by the given SQS pools transformer
+// so that the published state matches the one ingested by SQS.
+func NewPoolPublisher(client domain.Publisher, poolsTransformer sqsdomain.PoolsTransformer) domain.PoolPublisher {
+	return &PoolPublisher{
+		client:           client,
+		poolsTransformer: poolsTransformer,
+	}
+}
+
+// PublishPools publishes the state of the given pools.
+// The pools that fail to be transformed are skipped, as for SQS.
+// Returns error if the pools failed to be transformed or if at least one of the pools failed to be published.
+func (p *PoolPublisher) PublishPools(ctx sdk.Context, blockPools commondomain.BlockPools) error {
+	pools, _, _, err := p.poolsTransformer.Transform(ctx, blockPools)
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools {
+		if err := p.client.PublishPool(ctx, newPool(ctx, pool)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newPool returns the published state of the transformed pool.
+func newPool(ctx sdk.Context, pool ingesttypes.PoolI) domain.Pool {
+	sqsModel := pool.GetSQSPoolModel()
+
+	// The transformer joins the errors of the UOSMO and USDC liquidity caps with a space.
+	liquidityCapError := strings.TrimSpace(sqsModel.PoolLiquidityCapError)
+
+	result := domain.Pool{
+		PoolID:            pool.GetId(),
+		Type:              pool.GetType().String(),
+		Denoms:            sqsModel.PoolDenoms,
+		Balances:          sqsModel.Balances,
+		SpreadFactor:      sqsModel.SpreadFactor,
+		LiquidityCap:      sqsModel.PoolLiquidityCap,
+		LiquidityCapError: liquidityCapError,
+		Height:            uint64(ctx.BlockHeight()),
+		BlockTime:         ctx.BlockTime().UTC(),
+	}
+
+	if concentratedPool, ok := pool.GetUnderlyingPool().(concentratedtypes.ConcentratedPoolExtension); ok {
+		result.CurrentTick = concentratedPool.GetCurrentTick()
+		result.CurrentSqrtPrice = concentratedPool.GetCurrentSqrtPrice().String()
+	}
+
+	return result
+}
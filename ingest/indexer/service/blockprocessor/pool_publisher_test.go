@@ -0,0 +1,84 @@
+package blockprocessor_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osmosis-labs/osmosis/v30/app/apptesting"
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	indexermocks "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain/mocks"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/service/blockprocessor"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs"
+	poolstransformer "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/transformer"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+type PoolPublisherTestSuite struct {
+	apptesting.ConcentratedKeeperTestHelper
+}
+
+// PoolPublisherTestSuite tests the pool publisher.
+// The test suite initializes all supported pools (concentrated, cfmm, cosmwasm) via s.App.PrepareAllSupportedPools(),
+// creating pool IDs 1-5, and validates that the state of every pool is published.
+func TestPoolPublisherTestSuite(t *testing.T) {
+	suite.Run(t, new(PoolPublisherTestSuite))
+}
+
+func (s *PoolPublisherTestSuite) TestPublishPools() {
+	s.Setup()
+
+	// Initialized chain pools
+	poolsData := s.PrepareAllSupportedPools()
+
+	concentratedPools, err := s.App.ConcentratedLiquidityKeeper.GetPools(s.Ctx)
+	s.Require().NoError(err)
+	cfmmPools, err := s.App.GAMMKeeper.GetPools(s.Ctx)
+	s.Require().NoError(err)
+	cosmWasmPools, err := s.App.CosmwasmPoolKeeper.GetPoolsWithWasmKeeper(s.Ctx)
+	s.Require().NoError(err)
+
+	keepers := commondomain.PoolExtractorKeepers{
+		GammKeeper:         s.App.GAMMKeeper,
+		ConcentratedKeeper: s.App.ConcentratedLiquidityKeeper,
+		WasmKeeper:         s.App.WasmKeeper,
+		BankKeeper:         s.App.BankKeeper,
+		ProtorevKeeper:     s.App.ProtoRevKeeper,
+		PoolManagerKeeper:  s.App.PoolManagerKeeper,
+		CosmWasmPoolKeeper: s.App.CosmwasmPoolKeeper,
+	}
+
+	// Mock out publisher
+	publisherMock := &indexermocks.PublisherMock{}
+
+	poolPublisher := blockprocessor.NewPoolPublisher(publisherMock, poolstransformer.NewPoolTransformer(keepers, sqs.DefaultUSDCUOSMOPool, nil, nil))
+
+	// The concentrated pool is published with its current tick and sqrt price.
+	err = poolPublisher.PublishPools(s.Ctx, commondomain.BlockPools{ConcentratedPools: concentratedPools})
+	s.Require().NoError(err)
+
+	concentratedPool, err := s.App.ConcentratedLiquidityKeeper.GetConcentratedPoolById(s.Ctx, poolsData.ConcentratedPoolID)
+	s.Require().NoError(err)
+
+	pool := publisherMock.CalledWithPool
+	s.Require().Equal(poolsData.ConcentratedPoolID, pool.PoolID)
+	s.Require().Equal(poolmanagertypes.Concentrated.String(), pool.Type)
+	s.Require().Equal(concentratedPool.GetCurrentTick(), pool.CurrentTick)
+	s.Require().Equal(concentratedPool.GetCurrentSqrtPrice().String(), pool.CurrentSqrtPrice)
+	s.Require().Equal(concentratedPool.GetSpreadFactor(s.Ctx), pool.SpreadFactor)
+	s.Require().False(pool.LiquidityCap.IsNil())
+	s.Require().Equal(uint64(s.Ctx.BlockHeight()), pool.Height)
+
+	// The state of all the pools is published.
+	publisherMock = &indexermocks.PublisherMock{}
+	poolPublisher = blockprocessor.NewPoolPublisher(publisherMock, poolstransformer.NewPoolTransformer(keepers, sqs.DefaultUSDCUOSMOPool, nil, nil))
+
+	err = poolPublisher.PublishPools(s.Ctx, commondomain.BlockPools{
+		ConcentratedPools: concentratedPools,
+		CFMMPools:         cfmmPools,
+		CosmWasmPools:     cosmWasmPools,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(5, publisherMock.NumPublishPoolCalls)
+	s.Require().Empty(publisherMock.CalledWithPool.CurrentSqrtPrice)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/encoding"
+)
+
+// KafkaProducer exposes kafkaProducer for testing.
+type KafkaProducer = kafkaProducer
+
+// NewKafkaClientWithProducer exposes newKafkaClient for testing.
+// Panics if the encoding of the config is unknown.
+func NewKafkaClientWithProducer(config KafkaConfig, producer KafkaProducer) *KafkaClient {
+	encoder, err := encoding.New(config.Encoding)
+	if err != nil {
+		panic(err)
+	}
+	return newKafkaClient(config, encoder, producer)
+}
+
+// SetFileClientNow sets the clock of the file client for testing.
+func SetFileClientNow(f *FileClient, now func() time.Time) {
+	f.now = now
+}
+
+// PubSubTopic exposes pubsubTopic for testing.
+type PubSubTopic = pubsubTopic
+
+// PubSubPublishResult exposes pubsubPublishResult for testing.
+type PubSubPublishResult = pubsubPublishResult
+
+// NewPubSubClientWithTopics creates a PubSubClient publishing JSON to the given topics for testing.
+func NewPubSubClientWithTopics(reliability PubSubReliabilityConfig, topics map[string]PubSubTopic) *PubSubClient {
+	encoder, err := encoding.New(encoding.JSON)
+	if err != nil {
+		panic(err)
+	}
+
+	client := NewPubSubCLient(0, "project", "block", "transaction", "pool", "token-supply", "token-supply-offset", "pair", reliability, encoder)
+	client.newTopic = func(ctx context.Context, topicId string) (pubsubTopic, error) {
+		topic, ok := topics[topicId]
+		if !ok {
+			return nil, fmt.Errorf("unknown topic %s", topicId)
+		}
+		return topic, nil
+	}
+	return client
+}
+
+// WaitPubSubBacklog waits until the background worker published or spooled the messages of the backlog.
+func WaitPubSubBacklog(p *PubSubClient) {
+	for {
+		p.mu.Lock()
+		isEmpty := len(p.backlog) == 0
+		p.mu.Unlock()
+
+		if isEmpty {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
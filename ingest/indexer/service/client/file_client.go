@@ -0,0 +1,656 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/parquet-go/parquet-go"
+
+	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+)
+
+const (
+	// FileFormatNDJSON writes one JSON message per line.
+	FileFormatNDJSON = "ndjson"
+	// FileFormatParquet writes one row per message in the columnar schema of the topic.
+	FileFormatParquet = "parquet"
+
+	// The topics of the file client. Each topic is written to its own directory.
+	FileTopicBlock             = "block"
+	FileTopicTransaction       = "transaction"
+	FileTopicPair              = "pair"
+	FileTopicPool              = "pool"
+	FileTopicTokenSupply       = "token_supply"
+	FileTopicTokenSupplyOffset = "token_supply_offset"
+
+	// partialFileSuffix is the suffix of the files that are being written.
+	partialFileSuffix = ".partial"
+	// ManifestFileSuffix is the suffix of the manifest written next to every closed file.
+	ManifestFileSuffix = ".manifest.json"
+
+	// fileTimeLayout is the layout of the opening time in the file names.
+	fileTimeLayout = "20060102T150405Z"
+)
+
+// FileConfig defines the config of the file client.
+type FileConfig struct {
+	// Dir is the directory the topic directories are created in.
+	Dir string
+	// Format is FileFormatNDJSON or FileFormatParquet.
+	Format string
+	// RotationInterval defines the time buckets of the files, e.g. an hour. Disabled if zero.
+	RotationInterval time.Duration
+	// RotationMaxBytes defines the size of the messages after which a file is rotated. Disabled if zero.
+	RotationMaxBytes int64
+}
+
+// FileManifest describes a closed file so that downstream jobs can load the files incrementally.
+type FileManifest struct {
+	File      string    `json:"file"`
+	Topic     string    `json:"topic"`
+	Format    string    `json:"format"`
+	MinHeight uint64    `json:"min_height"`
+	MaxHeight uint64    `json:"max_height"`
+	Records   uint64    `json:"records"`
+	SizeBytes uint64    `json:"size_bytes"`
+	SHA256    string    `json:"sha256"`
+	OpenedAt  time.Time `json:"opened_at"`
+	ClosedAt  time.Time `json:"closed_at"`
+	// Recovered is true if the file or its manifest was left open by a crash and closed on the next start,
+	// or if writing a block to the files of the topics failed part way.
+	// A block of the file may then be incomplete or duplicated, and its records must be deduplicated by message ID.
+	Recovered bool `json:"recovered,omitempty"`
+}
+
+// fileTopics are the topics of the file client.
+var fileTopics = []string{
+	FileTopicBlock,
+	FileTopicTransaction,
+	FileTopicPair,
+	FileTopicPool,
+	FileTopicTokenSupply,
+	FileTopicTokenSupplyOffset,
+}
+
+// fileRecord is a message of a topic at a height.
+type fileRecord struct {
+	height  uint64
+	message any
+	// data is the message encoded as JSON.
+	data []byte
+}
+
+// FileClient is a client for writing the indexed data to rolling local files.
+//
+// Every topic is written to <dir>/<topic>/ in files that are rotated when the rotation interval elapses
+// or the maximum size is reached. A file is written with the .partial suffix and renamed once closed,
+// after which its manifest is written next to it. Files without a manifest must be ignored.
+//
+// The messages of a block are buffered until the block is committed so that the files only contain
+// complete blocks and rotation happens at block boundaries.
+//
+// The files left open by a crash are closed on the next start: the NDJSON files are truncated
+// to their last complete message and get a manifest flagged as recovered, while the parquet files
+// cannot be read without their footer and are deleted. Their heights must be backfilled.
+type FileClient struct {
+	config FileConfig
+	now    func() time.Time
+
+	mu sync.Mutex
+	// blockHeight is the height of the last published block.
+	blockHeight uint64
+	// pending are the messages of the current block by topic.
+	pending map[string][]fileRecord
+	// files are the open files by topic.
+	files map[string]*rollingFile
+
+	// publishedBytes is the number of bytes published since the last PopPublishedBytes call.
+	publishedBytes uint64
+}
+
+var (
+	_ indexerdomain.Publisher             = &FileClient{}
+	_ indexerdomain.PublishedBytesCounter = &FileClient{}
+	_ indexerdomain.BlockCommitter        = &FileClient{}
+)
+
+// NewFileClient creates a new FileClient and recovers the files left open by a crash, if any.
+// Returns error if the config is invalid, the directory cannot be created or the recovery fails.
+func NewFileClient(config FileConfig) (*FileClient, error) {
+	if config.Dir == "" {
+		return nil, errors.New("file dir must be set")
+	}
+
+	if config.Format != FileFormatNDJSON && config.Format != FileFormatParquet {
+		return nil, fmt.Errorf("unsupported file format (%s), expected %s or %s", config.Format, FileFormatNDJSON, FileFormatParquet)
+	}
+
+	if config.RotationInterval < 0 || config.RotationMaxBytes < 0 {
+		return nil, errors.New("file rotation interval and max bytes must not be negative")
+	}
+
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	client := &FileClient{
+		config:  config,
+		now:     time.Now,
+		pending: make(map[string][]fileRecord),
+		files:   make(map[string]*rollingFile),
+	}
+
+	if err := client.recoverFiles(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// publish buffers the message until the block is committed.
+// Messages without a height are assigned the height of the committed block.
+func (f *FileClient) publish(topic string, height uint64, message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.pending[topic] = append(f.pending[topic], fileRecord{height: height, message: message, data: data})
+
+	atomic.AddUint64(&f.publishedBytes, uint64(len(data)))
+
+	return nil
+}
+
+// CommitBlock implements indexerdomain.BlockCommitter.
+// It rotates the open files that are due, including the ones of the topics without messages
+// in the block, and writes the messages of the block.
+func (f *FileClient) CommitBlock(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	height := f.blockHeight
+	if sdkCtx, ok := ctx.(sdk.Context); ok {
+		height = uint64(sdkCtx.BlockHeight())
+	}
+
+	now := f.now().UTC()
+
+	// Iterate the topics in order for determinism.
+	for _, topic := range sortedKeys(f.files) {
+		file := f.files[topic]
+		if !file.isRotationDue(f.config, now) {
+			continue
+		}
+
+		if err := file.close(now); err != nil {
+			return err
+		}
+		delete(f.files, topic)
+	}
+
+	// Open the files of all topics of the block before writing any of them so that
+	// a failure to open a file does not leave the block partially written.
+	// The files opened before the failure are empty and discarded.
+	topics := sortedKeys(f.pending)
+	openedTopics := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		records := f.pending[topic]
+		for i := range records {
+			if records[i].height == 0 {
+				records[i].height = height
+			}
+		}
+
+		if _, ok := f.files[topic]; ok {
+			continue
+		}
+
+		file, err := openRollingFile(f.config, topic, records[0].height, now)
+		if err != nil {
+			for _, openedTopic := range openedTopics {
+				err = errors.Join(err, f.files[openedTopic].discard())
+				delete(f.files, openedTopic)
+			}
+			return err
+		}
+		f.files[topic] = file
+		openedTopics = append(openedTopics, topic)
+	}
+
+	for i, topic := range topics {
+		if err := f.files[topic].write(f.pending[topic]); err != nil {
+			// The block is rolled back by the caller while the topics before this one are already written.
+			// The files of the block are flagged as recovered so that their records are deduplicated by message ID.
+			for _, writtenTopic := range topics[:i+1] {
+				f.files[writtenTopic].manifest.Recovered = true
+			}
+			return err
+		}
+	}
+
+	f.pending = make(map[string][]fileRecord)
+
+	return nil
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RollbackBlock implements indexerdomain.BlockCommitter.
+func (f *FileClient) RollbackBlock() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.pending = make(map[string][]fileRecord)
+	return nil
+}
+
+// Close discards the uncommitted block, if any, and closes the open files.
+func (f *FileClient) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.pending = make(map[string][]fileRecord)
+
+	now := f.now().UTC()
+
+	var err error
+	for topic, file := range f.files {
+		err = errors.Join(err, file.close(now))
+		delete(f.files, topic)
+	}
+	return err
+}
+
+// PopPublishedBytes implements indexerdomain.PublishedBytesCounter.
+func (f *FileClient) PopPublishedBytes() uint64 {
+	return atomic.SwapUint64(&f.publishedBytes, 0)
+}
+
+// PublishBlock implements indexerdomain.Publisher.
+func (f *FileClient) PublishBlock(ctx context.Context, block indexerdomain.Block) error {
+	f.mu.Lock()
+	f.blockHeight = block.Height
+	f.mu.Unlock()
+
+	block.IngestedAt = f.now().UTC()
+	return f.publish(FileTopicBlock, block.Height, block)
+}
+
+// PublishTransaction implements indexerdomain.Publisher.
+func (f *FileClient) PublishTransaction(ctx context.Context, txn indexerdomain.Transaction) error {
+	txn.IngestedAt = f.now().UTC()
+	return f.publish(FileTopicTransaction, txn.Height, txn)
+}
+
+// PublishTokenSupply implements indexerdomain.Publisher.
+func (f *FileClient) PublishTokenSupply(ctx context.Context, tokenSupply indexerdomain.TokenSupply) error {
+	tokenSupply.IngestedAt = f.now().UTC()
+	return f.publish(FileTopicTokenSupply, 0, tokenSupply)
+}
+
+// PublishTokenSupplyOffset implements indexerdomain.Publisher.
+func (f *FileClient) PublishTokenSupplyOffset(ctx context.Context, tokenSupplyOffset indexerdomain.TokenSupplyOffset) error {
+	tokenSupplyOffset.IngestedAt = f.now().UTC()
+	return f.publish(FileTopicTokenSupplyOffset, 0, tokenSupplyOffset)
+}
+
+// PublishPair implements indexerdomain.Publisher.
+func (f *FileClient) PublishPair(ctx context.Context, pair indexerdomain.Pair) error {
+	pair.IngestedAt = f.now().UTC()
+	return f.publish(FileTopicPair, 0, pair)
+}
+
+// PublishPool implements indexerdomain.Publisher.
+func (f *FileClient) PublishPool(ctx context.Context, pool indexerdomain.Pool) error {
+	pool.IngestedAt = f.now().UTC()
+	return f.publish(FileTopicPool, pool.Height, pool)
+}
+
+// recordWriter writes the records of a file in its format.
+type recordWriter interface {
+	write(records []fileRecord) error
+	// close flushes the buffered records. The underlying file is not closed.
+	close() error
+}
+
+// rollingFile is an open file of a topic.
+type rollingFile struct {
+	path     string
+	file     *os.File
+	hasher   hash.Hash
+	counter  *countingWriter
+	writer   recordWriter
+	manifest FileManifest
+	// dataBytes is the size of the written messages, used for the size-based rotation.
+	dataBytes int64
+}
+
+// openRollingFile creates a new file of the topic whose first record is at the given height.
+func openRollingFile(config FileConfig, topic string, height uint64, now time.Time) (*rollingFile, error) {
+	dir := filepath.Join(config.Dir, topic)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s-%012d-%s.%s", topic, height, now.Format(fileTimeLayout), config.Format)
+	path := filepath.Join(dir, name)
+
+	file, err := os.OpenFile(path+partialFileSuffix, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	counter := &countingWriter{writer: io.MultiWriter(file, hasher)}
+
+	var writer recordWriter
+	switch config.Format {
+	case FileFormatParquet:
+		writer = &parquetRecordWriter{writer: parquet.NewWriter(counter, parquetSchemas[topic])}
+	default:
+		writer = &ndjsonRecordWriter{writer: bufio.NewWriter(counter)}
+	}
+
+	return &rollingFile{
+		path:    path,
+		file:    file,
+		hasher:  hasher,
+		counter: counter,
+		writer:  writer,
+		manifest: FileManifest{
+			File:      name,
+			Topic:     topic,
+			Format:    config.Format,
+			MinHeight: height,
+			MaxHeight: height,
+			OpenedAt:  now,
+		},
+	}, nil
+}
+
+// write writes the records and updates the manifest.
+func (r *rollingFile) write(records []fileRecord) error {
+	if err := r.writer.write(records); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		r.manifest.MinHeight = min(r.manifest.MinHeight, record.height)
+		r.manifest.MaxHeight = max(r.manifest.MaxHeight, record.height)
+		r.dataBytes += int64(len(record.data))
+	}
+	r.manifest.Records += uint64(len(records))
+
+	return nil
+}
+
+// isRotationDue returns true if the rotation interval of the file elapsed or its maximum size is reached.
+func (r *rollingFile) isRotationDue(config FileConfig, now time.Time) bool {
+	if config.RotationInterval > 0 && !now.Truncate(config.RotationInterval).Equal(r.manifest.OpenedAt.Truncate(config.RotationInterval)) {
+		return true
+	}
+
+	return config.RotationMaxBytes > 0 && r.dataBytes >= config.RotationMaxBytes
+}
+
+// close flushes and closes the file, renames it to its final name and writes its manifest.
+func (r *rollingFile) close(now time.Time) error {
+	if err := r.writer.close(); err != nil {
+		return err
+	}
+
+	if err := r.file.Sync(); err != nil {
+		return err
+	}
+
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(r.path+partialFileSuffix, r.path); err != nil {
+		return err
+	}
+
+	r.manifest.SizeBytes = r.counter.count
+	r.manifest.SHA256 = hex.EncodeToString(r.hasher.Sum(nil))
+	r.manifest.ClosedAt = now
+
+	return writeManifest(r.path, r.manifest)
+}
+
+// discard closes and deletes the file without writing its manifest.
+func (r *rollingFile) discard() error {
+	return errors.Join(r.file.Close(), os.Remove(r.path+partialFileSuffix))
+}
+
+// writeManifest writes the manifest of the closed file at the given path.
+func writeManifest(path string, manifest FileManifest) error {
+	manifestBz, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write the manifest atomically so that a present manifest is always complete.
+	manifestPath := path + ManifestFileSuffix
+	if err := os.WriteFile(manifestPath+partialFileSuffix, manifestBz, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(manifestPath+partialFileSuffix, manifestPath)
+}
+
+// recoverFiles closes the files of the topics left open by a crash and writes the missing manifests.
+func (f *FileClient) recoverFiles() error {
+	now := f.now().UTC()
+
+	for _, topic := range fileTopics {
+		dir := filepath.Join(f.config.Dir, topic)
+
+		partials, err := filepath.Glob(filepath.Join(dir, "*"+partialFileSuffix))
+		if err != nil {
+			return err
+		}
+
+		for _, partial := range partials {
+			path := strings.TrimSuffix(partial, partialFileSuffix)
+			switch {
+			case strings.HasSuffix(path, ManifestFileSuffix):
+				// The file of the manifest is closed, so its manifest is written again below.
+				err = os.Remove(partial)
+			case strings.HasSuffix(path, "."+FileFormatNDJSON):
+				err = recoverNDJSONFile(partial, path)
+			default:
+				// The parquet footer is only written on close, so the file cannot be read.
+				err = os.Remove(partial)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, format := range []string{FileFormatNDJSON, FileFormatParquet} {
+			paths, err := filepath.Glob(filepath.Join(dir, "*."+format))
+			if err != nil {
+				return err
+			}
+
+			for _, path := range paths {
+				_, err := os.Stat(path + ManifestFileSuffix)
+				if err == nil {
+					continue
+				}
+				if !errors.Is(err, os.ErrNotExist) {
+					return err
+				}
+
+				if err := recoverManifest(path, topic, format, now); err != nil {
+					return fmt.Errorf("failed to recover the manifest of %s: %w", path, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// recoverNDJSONFile truncates the partial NDJSON file to its last complete message
+// and renames it to its final name. The file is deleted if it has no complete message.
+func recoverNDJSONFile(partial, path string) error {
+	bz, err := os.ReadFile(partial)
+	if err != nil {
+		return err
+	}
+
+	size := bytes.LastIndexByte(bz, '\n') + 1
+	if size == 0 {
+		return os.Remove(partial)
+	}
+
+	if err := os.Truncate(partial, int64(size)); err != nil {
+		return err
+	}
+
+	return os.Rename(partial, path)
+}
+
+// recoverManifest writes the manifest of the closed file, deriving the height range from its messages.
+// The messages without a height, e.g. the NDJSON pairs, are assumed to be within the range.
+func recoverManifest(path, topic, format string, now time.Time) error {
+	name := filepath.Base(path)
+
+	// The file name has the height of the first message and the opening time.
+	parts := strings.Split(strings.TrimSuffix(name, "."+format), "-")
+	if len(parts) != 3 {
+		return fmt.Errorf("unexpected file name %s", name)
+	}
+
+	height, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return err
+	}
+
+	openedAt, err := time.Parse(fileTimeLayout, parts[2])
+	if err != nil {
+		return err
+	}
+
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var heights []uint64
+	switch format {
+	case FileFormatParquet:
+		rows, err := parquet.Read[parquetHeightRow](bytes.NewReader(bz), int64(len(bz)))
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			heights = append(heights, row.Height)
+		}
+	default:
+		for _, line := range bytes.Split(bytes.TrimSuffix(bz, []byte{'\n'}), []byte{'\n'}) {
+			if len(line) == 0 {
+				continue
+			}
+
+			var message struct {
+				Height uint64 `json:"height"`
+			}
+			if err := json.Unmarshal(line, &message); err != nil {
+				return err
+			}
+			heights = append(heights, message.Height)
+		}
+	}
+
+	checksum := sha256.Sum256(bz)
+	manifest := FileManifest{
+		File:      name,
+		Topic:     topic,
+		Format:    format,
+		MinHeight: height,
+		MaxHeight: height,
+		Records:   uint64(len(heights)),
+		SizeBytes: uint64(len(bz)),
+		SHA256:    hex.EncodeToString(checksum[:]),
+		OpenedAt:  openedAt,
+		ClosedAt:  now,
+		Recovered: true,
+	}
+
+	for _, height := range heights {
+		if height == 0 {
+			continue
+		}
+		manifest.MinHeight = min(manifest.MinHeight, height)
+		manifest.MaxHeight = max(manifest.MaxHeight, height)
+	}
+
+	return writeManifest(path, manifest)
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	writer io.Writer
+	count  uint64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.count += uint64(n)
+	return n, err
+}
+
+// ndjsonRecordWriter writes one JSON message per line.
+type ndjsonRecordWriter struct {
+	writer *bufio.Writer
+}
+
+func (w *ndjsonRecordWriter) write(records []fileRecord) error {
+	for _, record := range records {
+		if _, err := w.writer.Write(record.data); err != nil {
+			return err
+		}
+		if err := w.writer.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+
+	// Flush so that the file contains every committed block.
+	return w.writer.Flush()
+}
+
+func (w *ndjsonRecordWriter) close() error {
+	return w.writer.Flush()
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/parquet-go/parquet-go"
+
+	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+)
+
+// The rows of the parquet files of the topics. Every row has the height it was committed at
+// so that the files can be partitioned by height downstream. The integer amounts are strings
+// since they do not fit 64 bits.
+
+type parquetCoin struct {
+	Denom  string `parquet:"denom"`
+	Amount string `parquet:"amount"`
+}
+
+type parquetBlock struct {
+	Height        uint64    `parquet:"height"`
+	ChainID       string    `parquet:"chain_id"`
+	Timestamp     time.Time `parquet:"timestamp"`
+	GasConsumed   uint64    `parquet:"gas_consumed"`
+	IngestedAt    time.Time `parquet:"ingested_at"`
+	MessageID     string    `parquet:"message_id"`
+	SchemaVersion int32     `parquet:"schema_version"`
+}
+
+type parquetEventAttribute struct {
+	Key   string `parquet:"key"`
+	Value string `parquet:"value"`
+}
+
+type parquetEvent struct {
+	EventIndex int64                   `parquet:"event_index"`
+	EventID    string                  `parquet:"event_id"`
+	Type       string                  `parquet:"type"`
+	Attributes []parquetEventAttribute `parquet:"attributes,list"`
+}
+
+type parquetTransactionMessage struct {
+	TypeURL string `parquet:"type_url"`
+	// Body is the message encoded as proto3 JSON.
+	Body string `parquet:"body"`
+}
+
+type parquetTransaction struct {
+	Height        uint64                      `parquet:"height"`
+	Timestamp     time.Time                   `parquet:"timestamp"`
+	TxHash        string                      `parquet:"tx_hash"`
+	TxIndexID     int64                       `parquet:"tx_index_id"`
+	GasWanted     uint64                      `parquet:"gas_wanted"`
+	GasUsed       uint64                      `parquet:"gas_used"`
+	Fees          []parquetCoin               `parquet:"fees,list"`
+	MsgType       string                      `parquet:"msg_type"`
+	Messages      []parquetTransactionMessage `parquet:"messages,list"`
+	Signers       []string                    `parquet:"signers,list"`
+	Memo          string                      `parquet:"memo"`
+	TimeoutHeight uint64                      `parquet:"timeout_height"`
+	FeePayer      string                      `parquet:"fee_payer"`
+	FeeGranter    string                      `parquet:"fee_granter"`
+	Status        string                      `parquet:"status"`
+	Code          uint32                      `parquet:"code"`
+	Codespace     string                      `parquet:"codespace"`
+	Log           string                      `parquet:"log"`
+	Events        []parquetEvent              `parquet:"events,list"`
+	IngestedAt    time.Time                   `parquet:"ingested_at"`
+	MessageID     string                      `parquet:"message_id"`
+	SchemaVersion int32                       `parquet:"schema_version"`
+}
+
+type parquetPair struct {
+	Height               uint64    `parquet:"height"`
+	PoolID               uint64    `parquet:"pool_id"`
+	MultiAsset           bool      `parquet:"multi_asset"`
+	Denom0               string    `parquet:"denom_0"`
+	IdxDenom0            int32     `parquet:"idx_denom_0"`
+	Denom1               string    `parquet:"denom_1"`
+	IdxDenom1            int32     `parquet:"idx_denom_1"`
+	FeeBps               uint64    `parquet:"fee_bps"`
+	PairCreatedAt        time.Time `parquet:"pair_created_at"`
+	PairCreatedAtHeight  uint64    `parquet:"pair_created_at_height"`
+	PairCreatedAtTxnHash string    `parquet:"pair_created_at_txn_hash"`
+	IngestedAt           time.Time `parquet:"ingested_at"`
+	MessageID            string    `parquet:"message_id"`
+	SchemaVersion        int32     `parquet:"schema_version"`
+}
+
+type parquetPool struct {
+	Height            uint64        `parquet:"height"`
+	PoolID            uint64        `parquet:"pool_id"`
+	Type              string        `parquet:"type"`
+	Denoms            []string      `parquet:"denoms,list"`
+	Balances          []parquetCoin `parquet:"balances,list"`
+	SpreadFactor      string        `parquet:"spread_factor"`
+	CurrentTick       int64         `parquet:"current_tick"`
+	CurrentSqrtPrice  string        `parquet:"current_sqrt_price"`
+	LiquidityCap      string        `parquet:"liquidity_cap"`
+	LiquidityCapError string        `parquet:"liquidity_cap_error"`
+	Timestamp         time.Time     `parquet:"timestamp"`
+	IngestedAt        time.Time     `parquet:"ingested_at"`
+	MessageID         string        `parquet:"message_id"`
+	SchemaVersion     int32         `parquet:"schema_version"`
+}
+
+type parquetTokenSupply struct {
+	Height        uint64    `parquet:"height"`
+	Denom         string    `parquet:"denom"`
+	Supply        string    `parquet:"supply"`
+	IngestedAt    time.Time `parquet:"ingested_at"`
+	MessageID     string    `parquet:"message_id"`
+	SchemaVersion int32     `parquet:"schema_version"`
+}
+
+type parquetTokenSupplyOffset struct {
+	Height        uint64    `parquet:"height"`
+	Denom         string    `parquet:"denom"`
+	SupplyOffset  string    `parquet:"supply_offset"`
+	IngestedAt    time.Time `parquet:"ingested_at"`
+	MessageID     string    `parquet:"message_id"`
+	SchemaVersion int32     `parquet:"schema_version"`
+}
+
+// parquetSchemas are the schemas of the parquet files by topic.
+var parquetSchemas = map[string]*parquet.Schema{
+	FileTopicBlock:             parquet.SchemaOf(parquetBlock{}),
+	FileTopicTransaction:       parquet.SchemaOf(parquetTransaction{}),
+	FileTopicPair:              parquet.SchemaOf(parquetPair{}),
+	FileTopicPool:              parquet.SchemaOf(parquetPool{}),
+	FileTopicTokenSupply:       parquet.SchemaOf(parquetTokenSupply{}),
+	FileTopicTokenSupplyOffset: parquet.SchemaOf(parquetTokenSupplyOffset{}),
+}
+
+// parquetHeightRow is the height column shared by the rows of all topics, used for recovering the manifests.
+type parquetHeightRow struct {
+	Height uint64 `parquet:"height"`
+}
+
+// toParquetRow converts the message committed at the given height to the parquet row of its topic.
+// Returns error if the message type is unknown.
+func toParquetRow(message any, height uint64) (any, error) {
+	switch m := message.(type) {
+	case indexerdomain.Block:
+		return parquetBlock{
+			Height:        height,
+			ChainID:       m.ChainId,
+			Timestamp:     m.BlockTime,
+			GasConsumed:   m.GasConsumed,
+			IngestedAt:    m.IngestedAt,
+			MessageID:     m.MessageID,
+			SchemaVersion: int32(m.SchemaVersion),
+		}, nil
+	case indexerdomain.Transaction:
+		messages := make([]parquetTransactionMessage, len(m.Messages))
+		for i, message := range m.Messages {
+			messages[i] = parquetTransactionMessage{TypeURL: message.TypeURL, Body: string(message.Body)}
+		}
+
+		events := make([]parquetEvent, len(m.Events))
+		for i, event := range m.Events {
+			attributes := make([]parquetEventAttribute, len(event.Event.Attributes))
+			for j, attribute := range event.Event.Attributes {
+				attributes[j] = parquetEventAttribute{Key: attribute.Key, Value: attribute.Value}
+			}
+			events[i] = parquetEvent{
+				EventIndex: int64(event.Index),
+				EventID:    event.EventID,
+				Type:       event.Event.Type,
+				Attributes: attributes,
+			}
+		}
+
+		return parquetTransaction{
+			Height:        height,
+			Timestamp:     m.BlockTime,
+			TxHash:        m.TransactionHash,
+			TxIndexID:     int64(m.TransactionIndexId),
+			GasWanted:     m.GasWanted,
+			GasUsed:       m.GasUsed,
+			Fees:          toParquetCoins(m.Fees),
+			MsgType:       m.MessageType,
+			Messages:      messages,
+			Signers:       m.Signers,
+			Memo:          m.Memo,
+			TimeoutHeight: m.TimeoutHeight,
+			FeePayer:      m.FeePayer,
+			FeeGranter:    m.FeeGranter,
+			Status:        m.Status,
+			Code:          m.Code,
+			Codespace:     m.Codespace,
+			Log:           m.Log,
+			Events:        events,
+			IngestedAt:    m.IngestedAt,
+			MessageID:     m.MessageID,
+			SchemaVersion: int32(m.SchemaVersion),
+		}, nil
+	case indexerdomain.Pair:
+		return parquetPair{
+			Height:               height,
+			PoolID:               m.PoolID,
+			MultiAsset:           m.MultiAsset,
+			Denom0:               m.Denom0,
+			IdxDenom0:            int32(m.IdxDenom0),
+			Denom1:               m.Denom1,
+			IdxDenom1:            int32(m.IdxDenom1),
+			FeeBps:               m.FeeBps,
+			PairCreatedAt:        m.PairCreatedAt,
+			PairCreatedAtHeight:  m.PairCreatedAtHeight,
+			PairCreatedAtTxnHash: m.PairCreatedAtTxnHash,
+			IngestedAt:           m.IngestedAt,
+			MessageID:            m.MessageID,
+			SchemaVersion:        int32(m.SchemaVersion),
+		}, nil
+	case indexerdomain.Pool:
+		spreadFactor := ""
+		if !m.SpreadFactor.IsNil() {
+			spreadFactor = m.SpreadFactor.String()
+		}
+
+		liquidityCap := ""
+		if !m.LiquidityCap.IsNil() {
+			liquidityCap = m.LiquidityCap.String()
+		}
+
+		return parquetPool{
+			Height:            height,
+			PoolID:            m.PoolID,
+			Type:              m.Type,
+			Denoms:            m.Denoms,
+			Balances:          toParquetCoins(m.Balances),
+			SpreadFactor:      spreadFactor,
+			CurrentTick:       m.CurrentTick,
+			CurrentSqrtPrice:  m.CurrentSqrtPrice,
+			LiquidityCap:      liquidityCap,
+			LiquidityCapError: m.LiquidityCapError,
+			Timestamp:         m.BlockTime,
+			IngestedAt:        m.IngestedAt,
+			MessageID:         m.MessageID,
+			SchemaVersion:     int32(m.SchemaVersion),
+		}, nil
+	case indexerdomain.TokenSupply:
+		supply := ""
+		if !m.Supply.IsNil() {
+			supply = m.Supply.String()
+		}
+
+		return parquetTokenSupply{
+			Height:        height,
+			Denom:         m.Denom,
+			Supply:        supply,
+			IngestedAt:    m.IngestedAt,
+			MessageID:     m.MessageID,
+			SchemaVersion: int32(m.SchemaVersion),
+		}, nil
+	case indexerdomain.TokenSupplyOffset:
+		supplyOffset := ""
+		if !m.SupplyOffset.IsNil() {
+			supplyOffset = m.SupplyOffset.String()
+		}
+
+		return parquetTokenSupplyOffset{
+			Height:        height,
+			Denom:         m.Denom,
+			SupplyOffset:  supplyOffset,
+			IngestedAt:    m.IngestedAt,
+			MessageID:     m.MessageID,
+			SchemaVersion: int32(m.SchemaVersion),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported parquet message type %T", message)
+	}
+}
+
+// toParquetCoins converts the coins to parquet coins.
+func toParquetCoins(coins sdk.Coins) []parquetCoin {
+	result := make([]parquetCoin, len(coins))
+	for i, coin := range coins {
+		result[i] = parquetCoin{Denom: coin.Denom, Amount: coin.Amount.String()}
+	}
+	return result
+}
+
+// parquetRecordWriter writes one row per message in the columnar schema of the topic.
+// The rows are buffered until the file is closed.
+type parquetRecordWriter struct {
+	writer *parquet.Writer
+}
+
+func (w *parquetRecordWriter) write(records []fileRecord) error {
+	for _, record := range records {
+		row, err := toParquetRow(record.message, record.height)
+		if err != nil {
+			return err
+		}
+
+		if err := w.writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *parquetRecordWriter) close() error {
+	return w.writer.Close()
+}
@@ -0,0 +1,427 @@
+package service_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+
+	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	service "github.com/osmosis-labs/osmosis/v30/ingest/indexer/service/client"
+)
+
+// readManifests returns the manifests of the closed files of the topic.
+func readManifests(t *testing.T, dir, topic string) []service.FileManifest {
+	paths, err := filepath.Glob(filepath.Join(dir, topic, "*"+service.ManifestFileSuffix))
+	require.NoError(t, err)
+
+	manifests := make([]service.FileManifest, 0, len(paths))
+	for _, path := range paths {
+		bz, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		var manifest service.FileManifest
+		require.NoError(t, json.Unmarshal(bz, &manifest))
+		manifests = append(manifests, manifest)
+	}
+	return manifests
+}
+
+// requireChecksum validates the size and checksum of the manifest against its file.
+func requireChecksum(t *testing.T, dir string, manifest service.FileManifest) []byte {
+	bz, err := os.ReadFile(filepath.Join(dir, manifest.Topic, manifest.File))
+	require.NoError(t, err)
+
+	checksum := sha256.Sum256(bz)
+	require.Equal(t, hex.EncodeToString(checksum[:]), manifest.SHA256)
+	require.Equal(t, uint64(len(bz)), manifest.SizeBytes)
+	return bz
+}
+
+// Validates that the committed blocks are written to hourly NDJSON files, that rolled back
+// blocks are discarded and that closed files have a manifest with their height range and checksum.
+func TestFileClient_NDJSON(t *testing.T) {
+	dir := t.TempDir()
+
+	client, err := service.NewFileClient(service.FileConfig{
+		Dir:              dir,
+		Format:           service.FileFormatNDJSON,
+		RotationInterval: time.Hour,
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	service.SetFileClientNow(client, func() time.Time { return now })
+
+	ctx := context.Background()
+	publishBlock := func(height uint64) {
+		require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{ChainId: "osmosis-1", Height: height}))
+		require.NoError(t, client.PublishTransaction(ctx, indexerdomain.Transaction{Height: height, TransactionHash: "hash"}))
+		require.NoError(t, client.PublishPair(ctx, indexerdomain.Pair{PoolID: 1, Denom0: "uosmo", Denom1: "uatom"}))
+		require.NoError(t, client.PublishPool(ctx, indexerdomain.Pool{PoolID: 1, Height: height}))
+	}
+
+	publishBlock(10)
+	require.NoError(t, client.CommitBlock(ctx))
+	require.NotZero(t, client.PopPublishedBytes())
+
+	// The rolled back block is not written.
+	publishBlock(11)
+	require.NoError(t, client.RollbackBlock())
+
+	publishBlock(11)
+	require.NoError(t, client.CommitBlock(ctx))
+
+	// The open files are partial and have no manifest.
+	partials, err := filepath.Glob(filepath.Join(dir, service.FileTopicBlock, "*.partial"))
+	require.NoError(t, err)
+	require.Len(t, partials, 1)
+	require.Empty(t, readManifests(t, dir, service.FileTopicBlock))
+
+	// The next hour rotates the files at the block boundary.
+	now = now.Add(time.Hour)
+	publishBlock(12)
+	require.NoError(t, client.CommitBlock(ctx))
+
+	manifests := readManifests(t, dir, service.FileTopicBlock)
+	require.Len(t, manifests, 1)
+	require.Equal(t, service.FileManifest{
+		File:      "block-000000000010-20240101T103000Z.ndjson",
+		Topic:     service.FileTopicBlock,
+		Format:    service.FileFormatNDJSON,
+		MinHeight: 10,
+		MaxHeight: 11,
+		Records:   2,
+		SizeBytes: manifests[0].SizeBytes,
+		SHA256:    manifests[0].SHA256,
+		OpenedAt:  time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC),
+		ClosedAt:  now,
+	}, manifests[0])
+
+	bz := requireChecksum(t, dir, manifests[0])
+
+	scanner := bufio.NewScanner(bytes.NewReader(bz))
+	var heights []uint64
+	for scanner.Scan() {
+		var block indexerdomain.Block
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &block))
+		heights = append(heights, block.Height)
+		// The ingestion time is taken from the clock of the client.
+		require.Equal(t, manifests[0].OpenedAt, block.IngestedAt)
+	}
+	require.Equal(t, []uint64{10, 11}, heights)
+
+	// The pairs are assigned the height of their block.
+	pairManifests := readManifests(t, dir, service.FileTopicPair)
+	require.Len(t, pairManifests, 1)
+	require.Equal(t, uint64(10), pairManifests[0].MinHeight)
+	require.Equal(t, uint64(11), pairManifests[0].MaxHeight)
+
+	poolManifests := readManifests(t, dir, service.FileTopicPool)
+	require.Len(t, poolManifests, 1)
+	require.Equal(t, uint64(2), poolManifests[0].Records)
+
+	// Closing the client closes the open files.
+	require.NoError(t, client.Close())
+
+	manifests = readManifests(t, dir, service.FileTopicTransaction)
+	require.Len(t, manifests, 2)
+	for _, manifest := range manifests {
+		requireChecksum(t, dir, manifest)
+	}
+
+	partials, err = filepath.Glob(filepath.Join(dir, "*", "*.partial"))
+	require.NoError(t, err)
+	require.Empty(t, partials)
+}
+
+// Validates that the files are rotated once the size of their messages reaches the maximum.
+func TestFileClient_RotationMaxBytes(t *testing.T) {
+	dir := t.TempDir()
+
+	client, err := service.NewFileClient(service.FileConfig{
+		Dir:              dir,
+		Format:           service.FileFormatNDJSON,
+		RotationMaxBytes: 1,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for height := uint64(1); height <= 3; height++ {
+		require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: height}))
+		require.NoError(t, client.CommitBlock(ctx))
+	}
+
+	// Every block but the last one was rotated.
+	manifests := readManifests(t, dir, service.FileTopicBlock)
+	require.Len(t, manifests, 2)
+	for _, manifest := range manifests {
+		require.Equal(t, manifest.MinHeight, manifest.MaxHeight)
+		require.Equal(t, uint64(1), manifest.Records)
+		requireChecksum(t, dir, manifest)
+	}
+
+	require.NoError(t, client.Close())
+	require.Len(t, readManifests(t, dir, service.FileTopicBlock), 3)
+}
+
+// Validates that the parquet files are complete once closed and have the columnar schema of their topic.
+func TestFileClient_Parquet(t *testing.T) {
+	dir := t.TempDir()
+
+	client, err := service.NewFileClient(service.FileConfig{
+		Dir:    dir,
+		Format: service.FileFormatParquet,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{ChainId: "osmosis-1", Height: 1, GasConsumed: 100}))
+	require.NoError(t, client.PublishTransaction(ctx, indexerdomain.Transaction{
+		Height:          1,
+		TransactionHash: "hash",
+		Fees:            sdk.NewCoins(sdk.NewInt64Coin("uosmo", 10)),
+		Signers:         []string{"osmo1signer"},
+	}))
+	require.NoError(t, client.CommitBlock(ctx))
+	require.NoError(t, client.Close())
+
+	manifests := readManifests(t, dir, service.FileTopicBlock)
+	require.Len(t, manifests, 1)
+	require.Equal(t, service.FileFormatParquet, manifests[0].Format)
+
+	bz := requireChecksum(t, dir, manifests[0])
+
+	// Parquet files start and end with the magic number.
+	require.Equal(t, "PAR1", string(bz[:4]))
+	require.Equal(t, "PAR1", string(bz[len(bz)-4:]))
+
+	type blockRow struct {
+		Height      uint64 `parquet:"height"`
+		ChainID     string `parquet:"chain_id"`
+		GasConsumed uint64 `parquet:"gas_consumed"`
+	}
+
+	blocks, err := parquet.Read[blockRow](bytes.NewReader(bz), int64(len(bz)))
+	require.NoError(t, err)
+	require.Equal(t, []blockRow{{Height: 1, ChainID: "osmosis-1", GasConsumed: 100}}, blocks)
+
+	type coinRow struct {
+		Denom  string `parquet:"denom"`
+		Amount string `parquet:"amount"`
+	}
+	type transactionRow struct {
+		Height  uint64    `parquet:"height"`
+		TxHash  string    `parquet:"tx_hash"`
+		Fees    []coinRow `parquet:"fees,list"`
+		Signers []string  `parquet:"signers,list"`
+	}
+
+	manifests = readManifests(t, dir, service.FileTopicTransaction)
+	require.Len(t, manifests, 1)
+	bz = requireChecksum(t, dir, manifests[0])
+
+	transactions, err := parquet.Read[transactionRow](bytes.NewReader(bz), int64(len(bz)))
+	require.NoError(t, err)
+	require.Equal(t, []transactionRow{{
+		Height:  1,
+		TxHash:  "hash",
+		Fees:    []coinRow{{Denom: "uosmo", Amount: "10"}},
+		Signers: []string{"osmo1signer"},
+	}}, transactions)
+}
+
+// Validates that the files of the topics without messages are rotated too.
+func TestFileClient_RotationIdleTopic(t *testing.T) {
+	dir := t.TempDir()
+
+	client, err := service.NewFileClient(service.FileConfig{
+		Dir:              dir,
+		Format:           service.FileFormatNDJSON,
+		RotationInterval: time.Hour,
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	service.SetFileClientNow(client, func() time.Time { return now })
+
+	ctx := context.Background()
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 10}))
+	require.NoError(t, client.PublishPair(ctx, indexerdomain.Pair{PoolID: 1}))
+	require.NoError(t, client.CommitBlock(ctx))
+
+	// No pairs are published in the next hour.
+	now = now.Add(time.Hour)
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 11}))
+	require.NoError(t, client.CommitBlock(ctx))
+
+	manifests := readManifests(t, dir, service.FileTopicPair)
+	require.Len(t, manifests, 1)
+	require.Equal(t, now, manifests[0].ClosedAt)
+	requireChecksum(t, dir, manifests[0])
+
+	partials, err := filepath.Glob(filepath.Join(dir, service.FileTopicPair, "*.partial"))
+	require.NoError(t, err)
+	require.Empty(t, partials)
+}
+
+// Validates that a block that fails to open the file of a topic is not written to the files of the other topics.
+func TestFileClient_CommitBlock_OpenFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	client, err := service.NewFileClient(service.FileConfig{
+		Dir:    dir,
+		Format: service.FileFormatNDJSON,
+	})
+	require.NoError(t, err)
+
+	// The directory of the pool topic cannot be created.
+	poolDir := filepath.Join(dir, service.FileTopicPool)
+	require.NoError(t, os.WriteFile(poolDir, nil, 0o644))
+
+	ctx := context.Background()
+	publishBlock := func(height uint64) {
+		require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: height}))
+		require.NoError(t, client.PublishPool(ctx, indexerdomain.Pool{PoolID: 1, Height: height}))
+	}
+
+	publishBlock(10)
+	require.Error(t, client.CommitBlock(ctx))
+	require.NoError(t, client.RollbackBlock())
+
+	// The file of the block topic opened before the failure is discarded.
+	partials, err := filepath.Glob(filepath.Join(dir, service.FileTopicBlock, "*.partial"))
+	require.NoError(t, err)
+	require.Empty(t, partials)
+
+	require.NoError(t, os.Remove(poolDir))
+
+	publishBlock(11)
+	require.NoError(t, client.CommitBlock(ctx))
+	require.NoError(t, client.Close())
+
+	// Only the committed block is written.
+	manifests := readManifests(t, dir, service.FileTopicBlock)
+	require.Len(t, manifests, 1)
+	require.Equal(t, uint64(11), manifests[0].MinHeight)
+	require.Equal(t, uint64(1), manifests[0].Records)
+	require.False(t, manifests[0].Recovered)
+
+	manifests = readManifests(t, dir, service.FileTopicPool)
+	require.Len(t, manifests, 1)
+	require.Equal(t, uint64(1), manifests[0].Records)
+}
+
+// Validates that the files left open by a crash are recovered on start: the NDJSON files are
+// truncated to their last complete message, the parquet files are deleted and the missing manifests are written.
+func TestNewFileClient_Recovery(t *testing.T) {
+	dir := t.TempDir()
+
+	client, err := service.NewFileClient(service.FileConfig{
+		Dir:    dir,
+		Format: service.FileFormatNDJSON,
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	service.SetFileClientNow(client, func() time.Time { return now })
+
+	ctx := context.Background()
+	for height := uint64(10); height <= 11; height++ {
+		require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: height}))
+		require.NoError(t, client.PublishPair(ctx, indexerdomain.Pair{PoolID: 1}))
+		require.NoError(t, client.CommitBlock(ctx))
+	}
+
+	// Crash while writing the next block.
+	partials, err := filepath.Glob(filepath.Join(dir, service.FileTopicBlock, "*.partial"))
+	require.NoError(t, err)
+	require.Len(t, partials, 1)
+
+	file, err := os.OpenFile(partials[0], os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"height":12,"chain`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	// A parquet file left open and a parquet file whose manifest was being written.
+	parquetClient, err := service.NewFileClient(service.FileConfig{
+		Dir:    dir,
+		Format: service.FileFormatParquet,
+	})
+	require.NoError(t, err)
+	service.SetFileClientNow(parquetClient, func() time.Time { return now })
+
+	require.NoError(t, parquetClient.PublishPool(ctx, indexerdomain.Pool{PoolID: 1, Height: 12}))
+	require.NoError(t, parquetClient.CommitBlock(ctx))
+	require.NoError(t, parquetClient.Close())
+
+	poolManifests := readManifests(t, dir, service.FileTopicPool)
+	require.Len(t, poolManifests, 1)
+	manifestPath := filepath.Join(dir, service.FileTopicPool, poolManifests[0].File+service.ManifestFileSuffix)
+	require.NoError(t, os.Rename(manifestPath, manifestPath+".partial"))
+
+	require.NoError(t, parquetClient.PublishPool(ctx, indexerdomain.Pool{PoolID: 1, Height: 13}))
+	require.NoError(t, parquetClient.CommitBlock(ctx))
+
+	// System under test
+	_, err = service.NewFileClient(service.FileConfig{
+		Dir:    dir,
+		Format: service.FileFormatNDJSON,
+	})
+	require.NoError(t, err)
+
+	partials, err = filepath.Glob(filepath.Join(dir, "*", "*.partial"))
+	require.NoError(t, err)
+	require.Empty(t, partials)
+
+	// The torn message is dropped.
+	manifests := readManifests(t, dir, service.FileTopicBlock)
+	require.Len(t, manifests, 1)
+	require.True(t, manifests[0].Recovered)
+	require.Equal(t, uint64(10), manifests[0].MinHeight)
+	require.Equal(t, uint64(11), manifests[0].MaxHeight)
+	require.Equal(t, uint64(2), manifests[0].Records)
+	require.Equal(t, now, manifests[0].OpenedAt)
+	requireChecksum(t, dir, manifests[0])
+
+	// The pairs have no height in their messages.
+	manifests = readManifests(t, dir, service.FileTopicPair)
+	require.Len(t, manifests, 1)
+	require.Equal(t, uint64(2), manifests[0].Records)
+	require.Equal(t, uint64(10), manifests[0].MinHeight)
+
+	// The open parquet file is deleted and the manifest of the closed one is written again.
+	manifests = readManifests(t, dir, service.FileTopicPool)
+	require.Len(t, manifests, 1)
+	require.True(t, manifests[0].Recovered)
+	require.Equal(t, uint64(12), manifests[0].MinHeight)
+	require.Equal(t, uint64(12), manifests[0].MaxHeight)
+	require.Equal(t, uint64(1), manifests[0].Records)
+	requireChecksum(t, dir, manifests[0])
+
+	files, err := filepath.Glob(filepath.Join(dir, service.FileTopicPool, "*.parquet"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+func TestNewFileClient_InvalidConfig(t *testing.T) {
+	_, err := service.NewFileClient(service.FileConfig{Format: service.FileFormatNDJSON})
+	require.Error(t, err)
+
+	_, err = service.NewFileClient(service.FileConfig{Dir: t.TempDir(), Format: "csv"})
+	require.Error(t, err)
+
+	_, err = service.NewFileClient(service.FileConfig{Dir: t.TempDir(), Format: service.FileFormatNDJSON, RotationMaxBytes: -1})
+	require.Error(t, err)
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/grpcsecurity"
+	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/encoding"
+)
+
+const (
+	// SASLMechanismPlain is the SASL/PLAIN mechanism.
+	SASLMechanismPlain = "PLAIN"
+	// SASLMechanismScramSHA256 is the SASL/SCRAM-SHA-256 mechanism.
+	SASLMechanismScramSHA256 = "SCRAM-SHA-256"
+	// SASLMechanismScramSHA512 is the SASL/SCRAM-SHA-512 mechanism.
+	SASLMechanismScramSHA512 = "SCRAM-SHA-512"
+)
+
+// KafkaConfig defines the config of the Kafka client.
+type KafkaConfig struct {
+	// Brokers are the seed brokers of the cluster.
+	Brokers []string
+	// ClientID is the client ID reported to the brokers. The default of the Kafka library is used if empty.
+	ClientID string
+
+	// TLS is the TLS configuration of the broker connections.
+	TLS grpcsecurity.TLSConfig
+
+	// SASLMechanism is one of the SASLMechanism* constants. SASL is disabled if empty.
+	SASLMechanism string
+	SASLUsername  string
+	SASLPassword  string
+
+	// Encoding is the encoding of the messages, one of the encoding package constants. JSON is used if empty.
+	Encoding string
+
+	// The topics of the published messages.
+	BlockTopic             string
+	TransactionTopic       string
+	TokenSupplyTopic       string
+	TokenSupplyOffsetTopic string
+	PairTopic              string
+	PoolTopic              string
+}
+
+// kafkaProducer is the part of the Kafka client used for publishing.
+type kafkaProducer interface {
+	Produce(ctx context.Context, record *kgo.Record, promise func(*kgo.Record, error))
+	Flush(ctx context.Context) error
+	Close()
+}
+
+// KafkaClient is a client for publishing messages to Kafka topics.
+// It works with any broker speaking the Kafka protocol such as Redpanda.
+//
+// Messages are keyed for the partition ordering: blocks and transactions by height,
+// pairs and pools by pool ID and token supplies by denom.
+// Messages are produced asynchronously. On commit, the produced messages of the block are flushed
+// and a failure to produce any of them is returned so that the indexer observes it.
+type KafkaClient struct {
+	config   KafkaConfig
+	encoder  encoding.Encoder
+	producer kafkaProducer
+
+	// publishedBytes is the number of bytes published since the last PopPublishedBytes call.
+	// It is updated atomically since pairs are published concurrently.
+	publishedBytes uint64
+
+	mu sync.Mutex
+	// produceErr is the first produce failure of the block that was not yet returned.
+	produceErr error
+}
+
+var (
+	_ io.Closer                           = &KafkaClient{}
+	_ indexerdomain.Publisher             = &KafkaClient{}
+	_ indexerdomain.PublishedBytesCounter = &KafkaClient{}
+	_ indexerdomain.BlockCommitter        = &KafkaClient{}
+)
+
+// NewKafkaClient creates a new KafkaClient.
+// Returns error if the config is invalid. The brokers are connected to lazily.
+func NewKafkaClient(config KafkaConfig) (*KafkaClient, error) {
+	if len(config.Brokers) == 0 {
+		return nil, errors.New("kafka brokers must be set")
+	}
+
+	encoder, err := encoding.New(config.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(config.Brokers...),
+	}
+
+	if config.ClientID != "" {
+		opts = append(opts, kgo.ClientID(config.ClientID))
+	}
+
+	tlsConfig, err := grpcsecurity.NewClientTLSConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig))
+	}
+
+	switch config.SASLMechanism {
+	case "":
+	case SASLMechanismPlain:
+		opts = append(opts, kgo.SASL(plain.Auth{User: config.SASLUsername, Pass: config.SASLPassword}.AsMechanism()))
+	case SASLMechanismScramSHA256:
+		opts = append(opts, kgo.SASL(scram.Auth{User: config.SASLUsername, Pass: config.SASLPassword}.AsSha256Mechanism()))
+	case SASLMechanismScramSHA512:
+		opts = append(opts, kgo.SASL(scram.Auth{User: config.SASLUsername, Pass: config.SASLPassword}.AsSha512Mechanism()))
+	default:
+		return nil, fmt.Errorf("unsupported kafka sasl mechanism (%s), expected one of %s, %s, %s", config.SASLMechanism, SASLMechanismPlain, SASLMechanismScramSHA256, SASLMechanismScramSHA512)
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newKafkaClient(config, encoder, client), nil
+}
+
+func newKafkaClient(config KafkaConfig, encoder encoding.Encoder, producer kafkaProducer) *KafkaClient {
+	return &KafkaClient{
+		config:   config,
+		encoder:  encoder,
+		producer: producer,
+	}
+}
+
+// publish produces a message with the given key to the topic with the message ID, schema version and encoding as headers.
+// The failure to produce the message is returned from the next CommitBlock call.
+func (k *KafkaClient) publish(ctx context.Context, message any, topic string, key string, messageID string) error {
+	msgBytes, err := k.encoder.Encode(message)
+	if err != nil {
+		return err
+	}
+
+	attributes := indexerdomain.MessageAttributes(messageID, k.encoder.Encoding())
+	headers := []kgo.RecordHeader{
+		{Key: indexerdomain.MessageIDAttribute, Value: []byte(attributes[indexerdomain.MessageIDAttribute])},
+		{Key: indexerdomain.SchemaVersionAttribute, Value: []byte(attributes[indexerdomain.SchemaVersionAttribute])},
+		{Key: indexerdomain.EncodingAttribute, Value: []byte(attributes[indexerdomain.EncodingAttribute])},
+	}
+
+	k.producer.Produce(ctx, &kgo.Record{
+		Topic:   topic,
+		Key:     []byte(key),
+		Value:   msgBytes,
+		Headers: headers,
+	}, func(record *kgo.Record, err error) {
+		if err != nil {
+			k.setProduceErr(fmt.Errorf("failed to produce to kafka topic %s: %w", record.Topic, err))
+		}
+	})
+
+	atomic.AddUint64(&k.publishedBytes, uint64(len(msgBytes)))
+
+	return nil
+}
+
+// setProduceErr records the produce failure unless an earlier one is pending.
+func (k *KafkaClient) setProduceErr(err error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.produceErr == nil {
+		k.produceErr = err
+	}
+}
+
+// popProduceErr returns the pending produce failure, if any, and clears it.
+func (k *KafkaClient) popProduceErr() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	err := k.produceErr
+	k.produceErr = nil
+	return err
+}
+
+// CommitBlock implements indexerdomain.BlockCommitter.
+// It awaits the delivery of the messages produced for the block.
+// Returns error if the flush was cancelled or if any of the messages failed to be produced.
+func (k *KafkaClient) CommitBlock(ctx context.Context) error {
+	if err := k.producer.Flush(ctx); err != nil {
+		return err
+	}
+
+	return k.popProduceErr()
+}
+
+// RollbackBlock implements indexerdomain.BlockCommitter.
+// The produced messages cannot be withdrawn, so only the produce failure of the block, if any, is discarded.
+func (k *KafkaClient) RollbackBlock() error {
+	k.popProduceErr()
+	return nil
+}
+
+// Close implements io.Closer.
+// It awaits the delivery of the produced messages and closes the connections to the brokers.
+// Returns error if any of the messages failed to be produced.
+func (k *KafkaClient) Close() error {
+	err := k.producer.Flush(context.Background())
+	k.producer.Close()
+
+	return errors.Join(err, k.popProduceErr())
+}
+
+// PopPublishedBytes implements indexerdomain.PublishedBytesCounter.
+func (k *KafkaClient) PopPublishedBytes() uint64 {
+	return atomic.SwapUint64(&k.publishedBytes, 0)
+}
+
+// PublishBlock implements indexerdomain.Publisher.
+func (k *KafkaClient) PublishBlock(ctx context.Context, block indexerdomain.Block) error {
+	if k.config.BlockTopic == "" {
+		return errors.New("kafka block topic must be set")
+	}
+	block.IngestedAt = time.Now().UTC()
+	return k.publish(ctx, block, k.config.BlockTopic, strconv.FormatUint(block.Height, 10), block.MessageID)
+}
+
+// PublishTransaction implements indexerdomain.Publisher.
+func (k *KafkaClient) PublishTransaction(ctx context.Context, txn indexerdomain.Transaction) error {
+	if k.config.TransactionTopic == "" {
+		return errors.New("kafka transaction topic must be set")
+	}
+	txn.IngestedAt = time.Now().UTC()
+	return k.publish(ctx, txn, k.config.TransactionTopic, strconv.FormatUint(txn.Height, 10), txn.MessageID)
+}
+
+// PublishTokenSupply implements indexerdomain.Publisher.
+func (k *KafkaClient) PublishTokenSupply(ctx context.Context, tokenSupply indexerdomain.TokenSupply) error {
+	if k.config.TokenSupplyTopic == "" {
+		return errors.New("kafka token supply topic must be set")
+	}
+	tokenSupply.IngestedAt = time.Now().UTC()
+	return k.publish(ctx, tokenSupply, k.config.TokenSupplyTopic, tokenSupply.Denom, tokenSupply.MessageID)
+}
+
+// PublishTokenSupplyOffset implements indexerdomain.Publisher.
+func (k *KafkaClient) PublishTokenSupplyOffset(ctx context.Context, tokenSupplyOffset indexerdomain.TokenSupplyOffset) error {
+	if k.config.TokenSupplyOffsetTopic == "" {
+		return errors.New("kafka token supply offset topic must be set")
+	}
+	tokenSupplyOffset.IngestedAt = time.Now().UTC()
+	return k.publish(ctx, tokenSupplyOffset, k.config.TokenSupplyOffsetTopic, tokenSupplyOffset.Denom, tokenSupplyOffset.MessageID)
+}
+
+// PublishPair implements indexerdomain.Publisher.
+func (k *KafkaClient) PublishPair(ctx context.Context, pair indexerdomain.Pair) error {
+	if k.config.PairTopic == "" {
+		return errors.New("kafka pair topic must be set")
+	}
+	pair.IngestedAt = time.Now().UTC()
+	return k.publish(ctx, pair, k.config.PairTopic, strconv.FormatUint(pair.PoolID, 10), pair.MessageID)
+}
+
+// PublishPool implements indexerdomain.Publisher.
+func (k *KafkaClient) PublishPool(ctx context.Context, pool indexerdomain.Pool) error {
+	if k.config.PoolTopic == "" {
+		return errors.New("kafka pool topic must be set")
+	}
+	pool.IngestedAt = time.Now().UTC()
+	return k.publish(ctx, pool, k.config.PoolTopic, strconv.FormatUint(pool.PoolID, 10), pool.MessageID)
+}
@@ -0,0 +1,209 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/encoding"
+	service "github.com/osmosis-labs/osmosis/v30/ingest/indexer/service/client"
+	indexertypes "github.com/osmosis-labs/osmosis/v30/ingest/indexer/types/proto/types"
+)
+
+// producerMock records the produced records and fails them with err.
+// The promises are called on Flush.
+type producerMock struct {
+	records  []*kgo.Record
+	promises []func(*kgo.Record, error)
+	err      error
+	flushErr error
+	closed   bool
+}
+
+var _ service.KafkaProducer = &producerMock{}
+
+func (p *producerMock) Produce(ctx context.Context, record *kgo.Record, promise func(*kgo.Record, error)) {
+	p.records = append(p.records, record)
+	p.promises = append(p.promises, promise)
+}
+
+func (p *producerMock) Flush(ctx context.Context) error {
+	if p.flushErr != nil {
+		return p.flushErr
+	}
+
+	for i, promise := range p.promises {
+		promise(p.records[len(p.records)-len(p.promises)+i], p.err)
+	}
+	p.promises = nil
+	return nil
+}
+
+func (p *producerMock) Close() {
+	p.closed = true
+}
+
+var defaultKafkaConfig = service.KafkaConfig{
+	Brokers:                []string{"localhost:9092"},
+	BlockTopic:             "blocks",
+	TransactionTopic:       "transactions",
+	TokenSupplyTopic:       "token-supplies",
+	TokenSupplyOffsetTopic: "token-supply-offsets",
+	PairTopic:              "pairs",
+	PoolTopic:              "pools",
+}
+
+// Validates that the messages are produced to the configured topics keyed by height, pool ID or denom.
+func TestKafkaClient_Publish(t *testing.T) {
+	producer := &producerMock{}
+	client := service.NewKafkaClientWithProducer(defaultKafkaConfig, producer)
+
+	ctx := context.Background()
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 10, MessageID: "osmosis-1/10"}))
+	require.NoError(t, client.PublishTransaction(ctx, indexerdomain.Transaction{Height: 10}))
+	require.NoError(t, client.PublishPair(ctx, indexerdomain.Pair{PoolID: 1066}))
+	require.NoError(t, client.PublishPool(ctx, indexerdomain.Pool{PoolID: 1066, Height: 10}))
+	require.NoError(t, client.PublishTokenSupply(ctx, indexerdomain.TokenSupply{Denom: "uosmo"}))
+	require.NoError(t, client.PublishTokenSupplyOffset(ctx, indexerdomain.TokenSupplyOffset{Denom: "uatom"}))
+
+	expected := []struct {
+		topic string
+		key   string
+	}{
+		{topic: "blocks", key: "10"},
+		{topic: "transactions", key: "10"},
+		{topic: "pairs", key: "1066"},
+		{topic: "pools", key: "1066"},
+		{topic: "token-supplies", key: "uosmo"},
+		{topic: "token-supply-offsets", key: "uatom"},
+	}
+
+	require.Len(t, producer.records, len(expected))
+
+	var totalBytes uint64
+	for i, record := range producer.records {
+		require.Equal(t, expected[i].topic, record.Topic)
+		require.Equal(t, expected[i].key, string(record.Key))
+		require.True(t, json.Valid(record.Value))
+		totalBytes += uint64(len(record.Value))
+	}
+
+	// The message ID, schema version and encoding are set as headers.
+	require.Equal(t, []kgo.RecordHeader{
+		{Key: indexerdomain.MessageIDAttribute, Value: []byte("osmosis-1/10")},
+		{Key: indexerdomain.SchemaVersionAttribute, Value: []byte("2")},
+		{Key: indexerdomain.EncodingAttribute, Value: []byte("json")},
+	}, producer.records[0].Headers)
+
+	var block indexerdomain.Block
+	require.NoError(t, json.Unmarshal(producer.records[0].Value, &block))
+	require.Equal(t, uint64(10), block.Height)
+	require.False(t, block.IngestedAt.IsZero())
+
+	require.Equal(t, totalBytes, client.PopPublishedBytes())
+	require.Zero(t, client.PopPublishedBytes())
+}
+
+// Validates that the messages are always produced and that a produce failure
+// is returned from the commit of the block only.
+func TestKafkaClient_CommitBlock(t *testing.T) {
+	producer := &producerMock{err: errors.New("broker unavailable")}
+	client := service.NewKafkaClientWithProducer(defaultKafkaConfig, producer)
+
+	ctx := context.Background()
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 10}))
+	require.ErrorContains(t, client.CommitBlock(ctx), "broker unavailable")
+
+	// The next block is produced and committed.
+	producer.err = nil
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 11}))
+	require.Len(t, producer.records, 2)
+	require.NoError(t, client.CommitBlock(ctx))
+
+	// The flush failure is returned.
+	producer.flushErr = context.DeadlineExceeded
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 12}))
+	require.ErrorIs(t, client.CommitBlock(ctx), context.DeadlineExceeded)
+
+	// The produce failure of a rolled back block is discarded.
+	producer.flushErr = nil
+	producer.err = errors.New("broker unavailable")
+	require.NoError(t, producer.Flush(ctx))
+	require.NoError(t, client.RollbackBlock())
+
+	producer.err = nil
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 12}))
+	require.NoError(t, client.CommitBlock(ctx))
+	require.Len(t, producer.records, 4)
+}
+
+// Validates that publishing to an unset topic fails.
+func TestKafkaClient_UnsetTopic(t *testing.T) {
+	producer := &producerMock{}
+	client := service.NewKafkaClientWithProducer(service.KafkaConfig{Brokers: []string{"localhost:9092"}}, producer)
+
+	require.Error(t, client.PublishPair(context.Background(), indexerdomain.Pair{PoolID: 1}))
+	require.Error(t, client.PublishPool(context.Background(), indexerdomain.Pool{PoolID: 1}))
+	require.Empty(t, producer.records)
+}
+
+func TestNewKafkaClient(t *testing.T) {
+	_, err := service.NewKafkaClient(defaultKafkaConfig)
+	require.NoError(t, err)
+
+	_, err = service.NewKafkaClient(service.KafkaConfig{})
+	require.Error(t, err)
+
+	for _, mechanism := range []string{service.SASLMechanismPlain, service.SASLMechanismScramSHA256, service.SASLMechanismScramSHA512} {
+		config := defaultKafkaConfig
+		config.SASLMechanism = mechanism
+		config.SASLUsername = "user"
+		config.SASLPassword = "password"
+		_, err = service.NewKafkaClient(config)
+		require.NoError(t, err, mechanism)
+	}
+
+	config := defaultKafkaConfig
+	config.SASLMechanism = "GSSAPI"
+	_, err = service.NewKafkaClient(config)
+	require.Error(t, err)
+
+	config = defaultKafkaConfig
+	config.Encoding = "xml"
+	_, err = service.NewKafkaClient(config)
+	require.Error(t, err)
+}
+
+// Validates that the messages are encoded with the configured encoding.
+func TestKafkaClient_Encoding(t *testing.T) {
+	producer := &producerMock{}
+	config := defaultKafkaConfig
+	config.Encoding = encoding.Protobuf
+	client := service.NewKafkaClientWithProducer(config, producer)
+
+	require.NoError(t, client.PublishBlock(context.Background(), indexerdomain.Block{Height: 10}))
+	require.Len(t, producer.records, 1)
+
+	var block indexertypes.Block
+	require.NoError(t, proto.Unmarshal(producer.records[0].Value, &block))
+	require.Equal(t, uint64(10), block.Height)
+	require.Contains(t, producer.records[0].Headers, kgo.RecordHeader{Key: indexerdomain.EncodingAttribute, Value: []byte(encoding.Protobuf)})
+}
+
+// Validates that the produced messages are flushed and their failure returned once closed.
+func TestKafkaClient_Close(t *testing.T) {
+	producer := &producerMock{err: errors.New("broker unavailable")}
+	client := service.NewKafkaClientWithProducer(defaultKafkaConfig, producer)
+
+	require.NoError(t, client.PublishBlock(context.Background(), indexerdomain.Block{Height: 10}))
+
+	require.ErrorContains(t, client.Close(), "broker unavailable")
+	require.Empty(t, producer.promises)
+	require.True(t, producer.closed)
+}
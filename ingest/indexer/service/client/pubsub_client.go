@@ -4,14 +4,109 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"cloud.google.com/go/pubsub"
 
 	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/encoding"
 )
 
+const (
+	// spoolFileSuffix is the suffix of the messages in the dead-letter spool.
+	spoolFileSuffix = ".json"
+	// maxSpoolReplayMessages is the maximum number of spooled messages replayed at once
+	// so that a large spool does not stall the retries of the backlog.
+	maxSpoolReplayMessages = 1000
+)
+
+// PubSubReliabilityConfig defines how the PubSubClient makes sure that no message is lost.
+type PubSubReliabilityConfig struct {
+	// OrderingEnabled defines if the messages are published with ordering keys: blocks and transactions
+	// by height, pairs and pools by pool ID and token supplies by denom. The subscriptions must enable message ordering.
+	OrderingEnabled bool
+	// PublishTimeout is the maximum time the results of the messages of a block are awaited.
+	// The messages whose result is not ready in time are spooled. The results are awaited until the
+	// context is done if zero.
+	PublishTimeout time.Duration
+	// MaxRetries is the number of times a failed message is republished before it is spooled.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry. It doubles with every retry.
+	RetryBackoff time.Duration
+	// SpoolDir is the directory of the dead-letter spool of the messages that time out or still fail after the retries.
+	// If empty, the messages are not spooled and the next block fails.
+	SpoolDir string
+	// SpoolReplayInterval is the minimum interval between the replays of the spooled messages.
+	SpoolReplayInterval time.Duration
+}
+
+// pubsubTopic is the part of the PubSub topic used for publishing.
+type pubsubTopic interface {
+	Publish(ctx context.Context, msg *pubsub.Message) pubsubPublishResult
+	ResumePublish(orderingKey string)
+	Flush()
+	Stop()
+}
+
+// pubsubPublishResult is the result of a published message.
+type pubsubPublishResult interface {
+	Get(ctx context.Context) (serverID string, err error)
+}
+
+// pubsubTopicAdapter adapts *pubsub.Topic to pubsubTopic.
+type pubsubTopicAdapter struct {
+	*pubsub.Topic
+}
+
+// Publish implements pubsubTopic.
+func (t pubsubTopicAdapter) Publish(ctx context.Context, msg *pubsub.Message) pubsubPublishResult {
+	return t.Topic.Publish(ctx, msg)
+}
+
+// pendingPubSubMessage is a message to be published or whose result was not yet awaited.
+type pendingPubSubMessage struct {
+	topicId string
+	message *pubsub.Message
+	result  pubsubPublishResult
+	// attempts is the number of times the message was published.
+	attempts int
+}
+
+// orderingKey returns the ordering key of the message within its topic.
+func (m pendingPubSubMessage) orderingKey() pubsubOrderingKey {
+	return pubsubOrderingKey{topicId: m.topicId, key: m.message.OrderingKey}
+}
+
+// pubsubOrderingKey is an ordering key of a topic. The messages of an ordering key are delivered in order.
+type pubsubOrderingKey struct {
+	topicId string
+	key     string
+}
+
+// spooledPubSubMessage is a message in the dead-letter spool.
+type spooledPubSubMessage struct {
+	TopicId     string            `json:"topic_id"`
+	OrderingKey string            `json:"ordering_key,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	Data        []byte            `json:"data"`
+}
+
 // PubSubClient is a client for publishing messages to a PubSub topic.
+//
+// The results of the messages published for a block are awaited when the block is committed,
+// at most for the publish timeout. The messages that time out are spooled to disk. The failed messages
+// are republished with backoff in the background and spooled if they still fail. The spooled messages
+// are replayed in the background when a later block is committed.
+//
+// The messages of an ordering key stay in order: while an ordering key has messages being retried
+// or spooled, its new messages are published in the background after them.
 type PubSubClient struct {
 	maxPublishDelay          int
 	projectId                string
@@ -21,12 +116,57 @@ type PubSubClient struct {
 	tokenSupplyTopicId       string
 	tokenSupplyOffsetTopicId string
 	pairTopicId              string
-	pubsubClient             *pubsub.Client
+	reliability              PubSubReliabilityConfig
+	encoder                  encoding.Encoder
+
+	// newTopic creates the topic with the given id.
+	newTopic func(ctx context.Context, topicId string) (pubsubTopic, error)
+
+	mu           sync.Mutex
+	pubsubClient *pubsub.Client
+	// topics are the topics by id. They are created once so that their publish settings are kept.
+	topics map[string]pubsubTopic
+	// pending are the messages published since the last CommitBlock call.
+	pending []pendingPubSubMessage
+	// backlog are the messages published in order by the background worker: the failed messages
+	// and the new messages of the ordering keys with queued messages.
+	backlog []pendingPubSubMessage
+	// queuedKeys is the number of messages in the backlog or the spool by ordering key.
+	queuedKeys map[pubsubOrderingKey]int
+	// spooledKeys is the number of messages in the spool by ordering key.
+	spooledKeys map[pubsubOrderingKey]int
+	// backgroundErr is the first failure of the background worker that was not yet returned.
+	backgroundErr error
+	// stopWorker stops the background worker. Nil if the worker is not started.
+	stopWorker context.CancelFunc
+
+	// spoolSeq orders the messages spooled within the same nanosecond.
+	spoolSeq uint64
+	// lastSpoolReplay is the time of the last replay of the spooled messages, initially the creation of the client.
+	// Only used by the background worker.
+	lastSpoolReplay time.Time
+
+	startWorkerOnce sync.Once
+	// wakeWorker wakes the background worker up once a block is committed.
+	wakeWorker chan struct{}
+	// workerDone is closed once the background worker stopped.
+	workerDone chan struct{}
+
+	// publishedBytes is the number of bytes published since the last PopPublishedBytes call.
+	// It is updated atomically since pairs are published concurrently.
+	publishedBytes uint64
 }
 
+var (
+	_ indexerdomain.Publisher             = &PubSubClient{}
+	_ indexerdomain.PublishedBytesCounter = &PubSubClient{}
+	_ indexerdomain.BlockCommitter        = &PubSubClient{}
+)
+
 // NewPubSubCLient creates a new PubSubClient.
-func NewPubSubCLient(maxPublishDelay int, projectId, blockTopicId, transactionTopicId, poolTopicId, tokenSupplyTopicId, tokenSupplyOffsetTopicId, pairTopicID string) *PubSubClient {
-	return &PubSubClient{
+// The ordering keys of the messages left in the spool are loaded so that their new messages are published after them.
+func NewPubSubCLient(maxPublishDelay int, projectId, blockTopicId, transactionTopicId, poolTopicId, tokenSupplyTopicId, tokenSupplyOffsetTopicId, pairTopicID string, reliability PubSubReliabilityConfig, encoder encoding.Encoder) *PubSubClient {
+	client := &PubSubClient{
 		maxPublishDelay:          maxPublishDelay,
 		projectId:                projectId,
 		blockTopicId:             blockTopicId,
@@ -35,39 +175,598 @@ func NewPubSubCLient(maxPublishDelay int, projectId, blockTopicId, transactionTo
 		tokenSupplyTopicId:       tokenSupplyTopicId,
 		tokenSupplyOffsetTopicId: tokenSupplyOffsetTopicId,
 		pairTopicId:              pairTopicID,
+		reliability:              reliability,
+		encoder:                  encoder,
+		topics:                   make(map[string]pubsubTopic),
+		queuedKeys:               make(map[pubsubOrderingKey]int),
+		spooledKeys:              make(map[pubsubOrderingKey]int),
+		wakeWorker:               make(chan struct{}, 1),
+		workerDone:               make(chan struct{}),
+		lastSpoolReplay:          time.Now(),
 	}
+	client.newTopic = client.newPubSubTopic
+	client.loadSpooledKeys()
+	return client
 }
 
-// publish publishes a message to the PubSub topic.
-func (p *PubSubClient) publish(ctx context.Context, message any, topicId string) error {
-	// Create PubSub client if it doesn't exist
+// newPubSubTopic creates the PubSub topic with the given id, creating the PubSub client if it doesn't exist.
+// Must be called with the mutex held.
+func (p *PubSubClient) newPubSubTopic(ctx context.Context, topicId string) (pubsubTopic, error) {
 	if p.pubsubClient == nil {
 		client, err := pubsub.NewClient(ctx, p.projectId)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		p.pubsubClient = client
 	}
 
-	// Marshal message to bytes
-	msgBytes, err := p.marshal(message)
-	if err != nil {
-		return err
-	}
-
-	// Publish message to the topic. When the message publishing rate is very low, messages may remain pending and stale within the Pub/Sub SDK.
+	// When the message publishing rate is very low, messages may remain pending and stale within the Pub/Sub SDK.
 	// For example, if only one message is published over a span of several minutes, the default DelayThreshold and CountThreshold values
 	// are high enough that the message may seem undelivered or lost.
 	// To mitigate this, it's essential to reduce the DelayThreshold to a lower value, such as 4 seconds, to ensure timely delivery.
 	topic := p.pubsubClient.Topic(topicId)
 	topic.PublishSettings.DelayThreshold = time.Duration(p.maxPublishDelay) * time.Second
-	topic.Publish(ctx, &pubsub.Message{
-		Data: msgBytes,
-	})
+	topic.EnableMessageOrdering = p.reliability.OrderingEnabled
+
+	return pubsubTopicAdapter{Topic: topic}, nil
+}
+
+// topic returns the topic with the given id, creating it if it doesn't exist.
+func (p *PubSubClient) topic(ctx context.Context, topicId string) (pubsubTopic, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.topicLocked(ctx, topicId)
+}
+
+// topicLocked is topic with the mutex held.
+func (p *PubSubClient) topicLocked(ctx context.Context, topicId string) (pubsubTopic, error) {
+	if topic, ok := p.topics[topicId]; ok {
+		return topic, nil
+	}
+
+	topic, err := p.newTopic(ctx, topicId)
+	if err != nil {
+		return nil, err
+	}
+	p.topics[topicId] = topic
+
+	return topic, nil
+}
+
+// publish publishes a message to the PubSub topic with the message ID, schema version and encoding as attributes.
+// The result is awaited when the block is committed.
+func (p *PubSubClient) publish(ctx context.Context, message any, topicId string, orderingKey string, messageID string) error {
+	// Encode message to bytes
+	msgBytes, err := p.encoder.Encode(message)
+	if err != nil {
+		return err
+	}
+
+	if !p.reliability.OrderingEnabled {
+		orderingKey = ""
+	}
+
+	if err := p.publishBytes(ctx, topicId, &pubsub.Message{Data: msgBytes, OrderingKey: orderingKey, Attributes: indexerdomain.MessageAttributes(messageID, p.encoder.Encoding())}); err != nil {
+		return err
+	}
+
+	atomic.AddUint64(&p.publishedBytes, uint64(len(msgBytes)))
 
 	return nil
 }
 
+// publishBytes publishes the message to the topic and adds it to the pending messages.
+// If its ordering key has queued messages, the message is added to the backlog instead
+// so that it is published after them.
+func (p *PubSubClient) publishBytes(ctx context.Context, topicId string, message *pubsub.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	topic, err := p.topicLocked(ctx, topicId)
+	if err != nil {
+		return err
+	}
+
+	pending := pendingPubSubMessage{topicId: topicId, message: message}
+	if message.OrderingKey != "" && p.queuedKeys[pending.orderingKey()] > 0 {
+		p.enqueueLocked(pending)
+		return nil
+	}
+
+	pending.result = topic.Publish(ctx, message)
+	pending.attempts = 1
+	p.pending = append(p.pending, pending)
+
+	return nil
+}
+
+// CommitBlock implements indexerdomain.BlockCommitter.
+// It awaits the results of the messages published for the block for at most the publish timeout.
+// The messages that time out are spooled while the failed messages are retried in the background.
+// Returns error if a message cannot be spooled, including the messages that failed in the background
+// since the last commit.
+func (p *PubSubClient) CommitBlock(ctx context.Context) error {
+	p.startWorkerOnce.Do(p.startWorker)
+
+	failed, timedOut := p.awaitPending(ctx)
+
+	err := p.popBackgroundErr()
+	if len(timedOut) > 0 {
+		err = errors.Join(err, p.spool(timedOut, errors.New("publish timed out")))
+	}
+
+	p.mu.Lock()
+	for _, message := range failed {
+		p.enqueueLocked(message)
+	}
+	p.mu.Unlock()
+
+	// Retry the failed messages and replay the spool in the background.
+	select {
+	case p.wakeWorker <- struct{}{}:
+	default:
+	}
+
+	return err
+}
+
+// RollbackBlock implements indexerdomain.BlockCommitter.
+// The messages handed to PubSub cannot be withdrawn, so they are kept and awaited with the next committed block.
+func (p *PubSubClient) RollbackBlock() error {
+	return nil
+}
+
+// Close stops the background worker, sends the pending messages and stops the topics and the PubSub client.
+// The pending messages that fail and the messages left in the backlog are spooled.
+func (p *PubSubClient) Close() error {
+	p.mu.Lock()
+	stopWorker := p.stopWorker
+	p.mu.Unlock()
+
+	if stopWorker != nil {
+		stopWorker()
+		<-p.workerDone
+	}
+
+	p.mu.Lock()
+	topics := p.topics
+	p.topics = make(map[string]pubsubTopic)
+	backlog := p.backlog
+	p.backlog = nil
+	p.mu.Unlock()
+
+	// Stopping the topics sends the remaining messages so that their results are ready.
+	for _, topic := range topics {
+		topic.Stop()
+	}
+
+	failed, timedOut := p.awaitPending(context.Background())
+
+	var err error
+	if unsent := append(append(backlog, failed...), timedOut...); len(unsent) > 0 {
+		err = p.spool(unsent, errors.New("client closed"))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.pubsubClient != nil {
+		err = errors.Join(err, p.pubsubClient.Close())
+		p.pubsubClient = nil
+	}
+
+	return err
+}
+
+// awaitPending flushes the topics of the pending messages and awaits their results for at most the publish timeout.
+// Returns the failed messages and the messages whose result is not ready in time. Once a message of an ordering key
+// failed or timed out, the next messages of the key that are not published are returned with it so that they stay in order.
+func (p *PubSubClient) awaitPending(ctx context.Context) (failed []pendingPubSubMessage, timedOut []pendingPubSubMessage) {
+	p.mu.Lock()
+	pending := p.pending
+	p.pending = nil
+
+	// Send the batched messages right away instead of waiting for the delay threshold.
+	topics := make(map[string]pubsubTopic)
+	for _, message := range pending {
+		if topic, ok := p.topics[message.topicId]; ok {
+			topics[message.topicId] = topic
+		}
+	}
+	p.mu.Unlock()
+
+	for _, topic := range topics {
+		topic.Flush()
+	}
+
+	awaitCtx, cancel := p.withPublishTimeout(ctx)
+	defer cancel()
+
+	// isTimedOutKey is whether the first message of the ordering key that is not published timed out.
+	isTimedOutKey := make(map[pubsubOrderingKey]bool)
+	for _, message := range pending {
+		_, err := message.result.Get(awaitCtx)
+		if err == nil {
+			continue
+		}
+
+		isTimedOut := awaitCtx.Err() != nil
+		if message.message.OrderingKey != "" {
+			if isKeyTimedOut, ok := isTimedOutKey[message.orderingKey()]; ok {
+				isTimedOut = isKeyTimedOut
+			}
+			isTimedOutKey[message.orderingKey()] = isTimedOut
+		}
+
+		if isTimedOut {
+			timedOut = append(timedOut, message)
+		} else {
+			failed = append(failed, message)
+		}
+	}
+
+	return failed, timedOut
+}
+
+// withPublishTimeout returns the context bounded by the publish timeout, if any.
+func (p *PubSubClient) withPublishTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.reliability.PublishTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.reliability.PublishTimeout)
+}
+
+// enqueueLocked adds the message to the backlog. Must be called with the mutex held.
+func (p *PubSubClient) enqueueLocked(message pendingPubSubMessage) {
+	p.backlog = append(p.backlog, message)
+	if message.message.OrderingKey != "" {
+		p.queuedKeys[message.orderingKey()]++
+	}
+}
+
+// dequeueLocked removes the message at the head of the backlog. Must be called with the mutex held.
+func (p *PubSubClient) dequeueLocked() {
+	message := p.backlog[0]
+	p.backlog = p.backlog[1:]
+	if message.message.OrderingKey != "" {
+		p.decrementKeyLocked(p.queuedKeys, message.orderingKey())
+	}
+}
+
+// decrementKeyLocked decrements the count of the ordering key, deleting it once zero. Must be called with the mutex held.
+func (p *PubSubClient) decrementKeyLocked(counts map[pubsubOrderingKey]int, key pubsubOrderingKey) {
+	counts[key]--
+	if counts[key] <= 0 {
+		delete(counts, key)
+	}
+}
+
+// setBackgroundErr records the failure of the background worker unless an earlier one is pending.
+func (p *PubSubClient) setBackgroundErr(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.backgroundErr == nil {
+		p.backgroundErr = err
+	}
+}
+
+// popBackgroundErr returns the pending failure of the background worker, if any, and clears it.
+func (p *PubSubClient) popBackgroundErr() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	err := p.backgroundErr
+	p.backgroundErr = nil
+	return err
+}
+
+// startWorker starts the background worker that publishes the backlog and replays the spool
+// every time a block is committed.
+func (p *PubSubClient) startWorker() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p.mu.Lock()
+	p.stopWorker = cancel
+	p.mu.Unlock()
+
+	go func() {
+		defer close(p.workerDone)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.wakeWorker:
+			}
+
+			// The spool is replayed first since the backlog only has newer messages of the spooled ordering keys.
+			p.replaySpool(ctx)
+			p.publishBacklog(ctx)
+		}
+	}()
+}
+
+// publishBacklog publishes the messages of the backlog in order, retrying the failed ones with backoff
+// and spooling the ones that still fail. The messages of the ordering keys with spooled messages are spooled
+// after them. The messages that are not published when the worker is stopped are left in the backlog.
+func (p *PubSubClient) publishBacklog(ctx context.Context) {
+	for {
+		p.mu.Lock()
+		if len(p.backlog) == 0 {
+			p.mu.Unlock()
+			return
+		}
+		message := p.backlog[0]
+		isSpooledKey := message.message.OrderingKey != "" && p.spooledKeys[message.orderingKey()] > 0
+		p.mu.Unlock()
+
+		var err error
+		if isSpooledKey {
+			err = errors.New("ordering key has spooled messages")
+		} else {
+			err = p.publishWithRetries(ctx, message)
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		// The message is spooled before it is removed from the backlog so that
+		// its ordering key stays queued in between.
+		if err != nil {
+			if spoolErr := p.spool([]pendingPubSubMessage{message}, err); spoolErr != nil {
+				p.setBackgroundErr(spoolErr)
+			}
+		}
+
+		p.mu.Lock()
+		p.dequeueLocked()
+		p.mu.Unlock()
+	}
+}
+
+// publishWithRetries publishes the message until it succeeds or it was published max retries plus one times.
+// The retries back off, doubling the delay with every retry. Returns the last failure.
+func (p *PubSubClient) publishWithRetries(ctx context.Context, message pendingPubSubMessage) error {
+	err := errors.New("max retries reached")
+	for attempt := message.attempts; attempt <= p.reliability.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(p.reliability.RetryBackoff << (attempt - 1)):
+			}
+		}
+
+		if err = p.publishAndAwait(ctx, message.topicId, message.message); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// publishAndAwait publishes the message and awaits its result for at most the publish timeout.
+func (p *PubSubClient) publishAndAwait(ctx context.Context, topicId string, message *pubsub.Message) error {
+	topic, err := p.topic(ctx, topicId)
+	if err != nil {
+		return err
+	}
+
+	// Publishing with an ordering key is paused after a failure until resumed.
+	if message.OrderingKey != "" {
+		topic.ResumePublish(message.OrderingKey)
+	}
+
+	result := topic.Publish(ctx, &pubsub.Message{Data: message.Data, OrderingKey: message.OrderingKey, Attributes: message.Attributes})
+	topic.Flush()
+
+	awaitCtx, cancel := p.withPublishTimeout(ctx)
+	defer cancel()
+
+	_, err = result.Get(awaitCtx)
+	return err
+}
+
+// spool writes the messages to the dead-letter spool in order and queues their ordering keys.
+// Returns error including the publish failure if the spool is disabled or cannot be written.
+func (p *PubSubClient) spool(messages []pendingPubSubMessage, cause error) error {
+	if p.reliability.SpoolDir == "" {
+		return fmt.Errorf("failed to publish %d messages to pubsub: %w", len(messages), cause)
+	}
+
+	if err := os.MkdirAll(p.reliability.SpoolDir, 0o755); err != nil {
+		return fmt.Errorf("failed to spool %d messages that failed to publish to pubsub (%w): %w", len(messages), cause, err)
+	}
+
+	for _, message := range messages {
+		bz, err := json.Marshal(spooledPubSubMessage{
+			TopicId:     message.topicId,
+			OrderingKey: message.message.OrderingKey,
+			Attributes:  message.message.Attributes,
+			Data:        message.message.Data,
+		})
+		if err != nil {
+			return err
+		}
+
+		// The names sort in the order the messages were spooled.
+		seq := atomic.AddUint64(&p.spoolSeq, 1)
+		path := filepath.Join(p.reliability.SpoolDir, fmt.Sprintf("%020d-%010d%s", time.Now().UnixNano(), seq, spoolFileSuffix))
+
+		// Write the message atomically so that a spooled message is always complete.
+		if err := os.WriteFile(path+".tmp", bz, 0o644); err != nil {
+			return fmt.Errorf("failed to spool message that failed to publish to pubsub (%w): %w", cause, err)
+		}
+		if err := os.Rename(path+".tmp", path); err != nil {
+			return fmt.Errorf("failed to spool message that failed to publish to pubsub (%w): %w", cause, err)
+		}
+
+		if message.message.OrderingKey != "" {
+			p.mu.Lock()
+			p.queuedKeys[message.orderingKey()]++
+			p.spooledKeys[message.orderingKey()]++
+			p.mu.Unlock()
+		}
+	}
+
+	return nil
+}
+
+// spoolPaths returns the paths of the spooled messages in the order they were spooled, at most limit if positive.
+func (p *PubSubClient) spoolPaths(limit int) ([]string, error) {
+	entries, err := os.ReadDir(p.reliability.SpoolDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), spoolFileSuffix) {
+			continue
+		}
+		// The entries are sorted by name, which is the order the messages were spooled.
+		paths = append(paths, filepath.Join(p.reliability.SpoolDir, entry.Name()))
+		if len(paths) == limit {
+			break
+		}
+	}
+
+	return paths, nil
+}
+
+// readSpooledMessage reads the spooled message at the given path.
+func readSpooledMessage(path string) (spooledPubSubMessage, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return spooledPubSubMessage{}, err
+	}
+
+	var spooled spooledPubSubMessage
+	if err := json.Unmarshal(bz, &spooled); err != nil {
+		return spooledPubSubMessage{}, err
+	}
+
+	return spooled, nil
+}
+
+// loadSpooledKeys queues the ordering keys of the spooled messages left by a previous run.
+// The messages that cannot be read are skipped, as they are by the replay.
+func (p *PubSubClient) loadSpooledKeys() {
+	if p.reliability.SpoolDir == "" {
+		return
+	}
+
+	paths, err := p.spoolPaths(0)
+	if err != nil {
+		return
+	}
+
+	for _, path := range paths {
+		spooled, err := readSpooledMessage(path)
+		if err != nil || spooled.OrderingKey == "" {
+			continue
+		}
+
+		key := pubsubOrderingKey{topicId: spooled.TopicId, key: spooled.OrderingKey}
+		p.queuedKeys[key]++
+		p.spooledKeys[key]++
+	}
+}
+
+// replaySpool republishes the spooled messages in the order they were spooled if the replay interval elapsed.
+// A replayed message is removed from the spool once published. The messages that fail again are kept
+// and replayed after the replay interval. Once a message of an ordering key fails, the next messages
+// of the key are kept too so that they stay in order.
+func (p *PubSubClient) replaySpool(ctx context.Context) {
+	if p.reliability.SpoolDir == "" || time.Since(p.lastSpoolReplay) < p.reliability.SpoolReplayInterval {
+		return
+	}
+	p.lastSpoolReplay = time.Now()
+
+	paths, err := p.spoolPaths(maxSpoolReplayMessages)
+	if err != nil {
+		return
+	}
+
+	type replayedMessage struct {
+		path    string
+		topicId string
+		message *pubsub.Message
+		result  pubsubPublishResult
+	}
+
+	replayed := make([]replayedMessage, 0, len(paths))
+	resumed := make(map[pubsubOrderingKey]struct{})
+	for _, path := range paths {
+		spooled, err := readSpooledMessage(path)
+		if err != nil {
+			return
+		}
+
+		topic, err := p.topic(ctx, spooled.TopicId)
+		if err != nil {
+			return
+		}
+
+		// Publishing with an ordering key is paused after a failure until resumed.
+		key := pubsubOrderingKey{topicId: spooled.TopicId, key: spooled.OrderingKey}
+		if _, ok := resumed[key]; !ok && spooled.OrderingKey != "" {
+			topic.ResumePublish(spooled.OrderingKey)
+			resumed[key] = struct{}{}
+		}
+
+		message := &pubsub.Message{Data: spooled.Data, OrderingKey: spooled.OrderingKey, Attributes: spooled.Attributes}
+		replayed = append(replayed, replayedMessage{path: path, topicId: spooled.TopicId, message: message, result: topic.Publish(ctx, message)})
+	}
+
+	flushed := make(map[string]struct{})
+	for _, message := range replayed {
+		if _, ok := flushed[message.topicId]; ok {
+			continue
+		}
+		flushed[message.topicId] = struct{}{}
+
+		if topic, err := p.topic(ctx, message.topicId); err == nil {
+			topic.Flush()
+		}
+	}
+
+	awaitCtx, cancel := p.withPublishTimeout(ctx)
+	defer cancel()
+
+	failedKeys := make(map[pubsubOrderingKey]struct{})
+	for _, message := range replayed {
+		key := pubsubOrderingKey{topicId: message.topicId, key: message.message.OrderingKey}
+		if _, ok := failedKeys[key]; ok && key.key != "" {
+			continue
+		}
+
+		if _, err := message.result.Get(awaitCtx); err != nil {
+			failedKeys[key] = struct{}{}
+			continue
+		}
+
+		if err := os.Remove(message.path); err != nil {
+			continue
+		}
+
+		if key.key != "" {
+			p.mu.Lock()
+			p.decrementKeyLocked(p.spooledKeys, key)
+			p.decrementKeyLocked(p.queuedKeys, key)
+			p.mu.Unlock()
+		}
+	}
+}
+
+// PopPublishedBytes implements indexerdomain.PublishedBytesCounter.
+func (p *PubSubClient) PopPublishedBytes() uint64 {
+	return atomic.SwapUint64(&p.publishedBytes, 0)
+}
+
 // Publish implements PubSubClient.PublishBlock
 func (p *PubSubClient) PublishBlock(ctx context.Context, block indexerdomain.Block) error {
 	// Check if project id and topic id are set
@@ -75,7 +774,7 @@ func (p *PubSubClient) PublishBlock(ctx context.Context, block indexerdomain.Blo
 		return errors.New("project id and block topic id must be set")
 	}
 	block.IngestedAt = time.Now().UTC()
-	return p.publish(ctx, block, p.blockTopicId)
+	return p.publish(ctx, block, p.blockTopicId, strconv.FormatUint(block.Height, 10), block.MessageID)
 }
 
 // PublishTransaction implements PubSubClient.PublishTransaction
@@ -85,7 +784,7 @@ func (p *PubSubClient) PublishTransaction(ctx context.Context, txn indexerdomain
 		return errors.New("project id and transaction topic id must be set")
 	}
 	txn.IngestedAt = time.Now().UTC()
-	return p.publish(ctx, txn, p.transactionTopicId)
+	return p.publish(ctx, txn, p.transactionTopicId, strconv.FormatUint(txn.Height, 10), txn.MessageID)
 }
 
 // PublishTokenSupply implements domain.PubSubClient.
@@ -95,7 +794,7 @@ func (p *PubSubClient) PublishTokenSupply(ctx context.Context, tokenSupply index
 		return errors.New("project id and token supply topic id must be set")
 	}
 	tokenSupply.IngestedAt = time.Now().UTC()
-	return p.publish(ctx, tokenSupply, p.tokenSupplyTopicId)
+	return p.publish(ctx, tokenSupply, p.tokenSupplyTopicId, tokenSupply.Denom, tokenSupply.MessageID)
 }
 
 // PublishTokenSupplyOffset implements domain.PubSubClient.
@@ -105,7 +804,7 @@ func (p *PubSubClient) PublishTokenSupplyOffset(ctx context.Context, tokenSupply
 		return errors.New("project id and token supply offset topic id must be set")
 	}
 	tokenSupplyOffset.IngestedAt = time.Now().UTC()
-	return p.publish(ctx, tokenSupplyOffset, p.tokenSupplyOffsetTopicId)
+	return p.publish(ctx, tokenSupplyOffset, p.tokenSupplyOffsetTopicId, tokenSupplyOffset.Denom, tokenSupplyOffset.MessageID)
 }
 
 // PublishPair implements PubSubClient.PublishPair
@@ -116,14 +815,16 @@ func (p *PubSubClient) PublishPair(ctx context.Context, pair indexerdomain.Pair)
 	}
 
 	pair.IngestedAt = time.Now().UTC()
-	return p.publish(ctx, pair, p.pairTopicId)
+	return p.publish(ctx, pair, p.pairTopicId, strconv.FormatUint(pair.PoolID, 10), pair.MessageID)
 }
 
-// marshal marshals a message to bytes.
-func (p *PubSubClient) marshal(message any) ([]byte, error) {
-	data, err := json.Marshal(message)
-	if err != nil {
-		return nil, err
+// PublishPool implements PubSubClient.PublishPool
+func (p *PubSubClient) PublishPool(ctx context.Context, pool indexerdomain.Pool) error {
+	// Check if project id and topic id are set
+	if p.projectId == "" || p.poolTopicId == "" {
+		return errors.New("project id and pool topic id must be set")
 	}
-	return data, nil
+
+	pool.IngestedAt = time.Now().UTC()
+	return p.publish(ctx, pool, p.poolTopicId, strconv.FormatUint(pool.PoolID, 10), pool.MessageID)
 }
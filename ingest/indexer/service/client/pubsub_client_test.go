@@ -0,0 +1,307 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/stretchr/testify/require"
+
+	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	service "github.com/osmosis-labs/osmosis/v30/ingest/indexer/service/client"
+)
+
+// publishResultMock is a PubSubPublishResult returning the given error.
+// If hang is set, the result is never ready.
+type publishResultMock struct {
+	err  error
+	hang bool
+}
+
+func (r publishResultMock) Get(ctx context.Context) (string, error) {
+	if r.hang {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+	return "id", r.err
+}
+
+// pubSubTopicMock records the published messages, hangs the next hangs publishes
+// and fails the next publishes with the given errors.
+type pubSubTopicMock struct {
+	mu        sync.Mutex
+	published []*pubsub.Message
+	errs      []error
+	hangs     int
+	resumed   []string
+	flushes   int
+	stopped   bool
+}
+
+func (t *pubSubTopicMock) Publish(ctx context.Context, msg *pubsub.Message) service.PubSubPublishResult {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.published = append(t.published, msg)
+
+	if t.hangs > 0 {
+		t.hangs--
+		return publishResultMock{hang: true}
+	}
+
+	var err error
+	if len(t.errs) > 0 {
+		err, t.errs = t.errs[0], t.errs[1:]
+	}
+	return publishResultMock{err: err}
+}
+
+func (t *pubSubTopicMock) ResumePublish(orderingKey string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.resumed = append(t.resumed, orderingKey)
+}
+
+func (t *pubSubTopicMock) Flush() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.flushes++
+}
+
+func (t *pubSubTopicMock) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stopped = true
+}
+
+// publishedMessages returns a copy of the published messages.
+func (t *pubSubTopicMock) publishedMessages() []*pubsub.Message {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]*pubsub.Message(nil), t.published...)
+}
+
+// resumedKeys returns a copy of the resumed ordering keys.
+func (t *pubSubTopicMock) resumedKeys() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]string(nil), t.resumed...)
+}
+
+// spooledCount returns the number of messages in the spool.
+func spooledCount(t *testing.T, spoolDir string) int {
+	entries, err := os.ReadDir(spoolDir)
+	require.NoError(t, err)
+	return len(entries)
+}
+
+var errPublish = errors.New("publish failed")
+
+// Validates that the results are awaited at commit and that the messages are published with ordering keys.
+func TestPubSubClient_CommitBlock(t *testing.T) {
+	blockTopic, pairTopic, poolTopic := &pubSubTopicMock{}, &pubSubTopicMock{}, &pubSubTopicMock{}
+	client := service.NewPubSubClientWithTopics(service.PubSubReliabilityConfig{OrderingEnabled: true}, map[string]service.PubSubTopic{
+		"block": blockTopic,
+		"pair":  pairTopic,
+		"pool":  poolTopic,
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 10, MessageID: "osmosis-1/10"}))
+	require.NoError(t, client.PublishPair(ctx, indexerdomain.Pair{PoolID: 1}))
+	require.NoError(t, client.PublishPair(ctx, indexerdomain.Pair{PoolID: 2}))
+	require.NoError(t, client.PublishPool(ctx, indexerdomain.Pool{PoolID: 3, Height: 10}))
+	require.NotZero(t, client.PopPublishedBytes())
+
+	require.NoError(t, client.CommitBlock(ctx))
+
+	require.Equal(t, 1, blockTopic.flushes)
+	require.Equal(t, 1, pairTopic.flushes)
+	require.Equal(t, "10", blockTopic.published[0].OrderingKey)
+	require.Equal(t, "1", pairTopic.published[0].OrderingKey)
+	require.Equal(t, "2", pairTopic.published[1].OrderingKey)
+	require.Equal(t, "3", poolTopic.published[0].OrderingKey)
+
+	// The message ID, schema version and encoding are set as attributes.
+	require.Equal(t, map[string]string{
+		indexerdomain.MessageIDAttribute:     "osmosis-1/10",
+		indexerdomain.SchemaVersionAttribute: "2",
+		indexerdomain.EncodingAttribute:      "json",
+	}, blockTopic.published[0].Attributes)
+
+	// The topics are stopped once closed.
+	require.NoError(t, client.Close())
+	require.True(t, blockTopic.stopped)
+	require.True(t, pairTopic.stopped)
+}
+
+// Validates that failed messages are republished in the background and resume their ordering key.
+func TestPubSubClient_CommitBlock_Retry(t *testing.T) {
+	pairTopic := &pubSubTopicMock{errs: []error{errPublish, errPublish}}
+	client := service.NewPubSubClientWithTopics(service.PubSubReliabilityConfig{
+		OrderingEnabled: true,
+		MaxRetries:      2,
+		RetryBackoff:    time.Millisecond,
+	}, map[string]service.PubSubTopic{
+		"pair": pairTopic,
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.PublishPair(ctx, indexerdomain.Pair{PoolID: 1}))
+	require.NoError(t, client.CommitBlock(ctx))
+	service.WaitPubSubBacklog(client)
+
+	published := pairTopic.publishedMessages()
+	require.Len(t, published, 3)
+	require.Equal(t, published[0].Data, published[2].Data)
+	require.Equal(t, []string{"1", "1"}, pairTopic.resumedKeys())
+
+	// The retried message succeeded so the next block does not fail.
+	require.NoError(t, client.CommitBlock(ctx))
+	require.NoError(t, client.Close())
+}
+
+// Validates that the next block fails if a message still fails after the retries and the spool is disabled.
+func TestPubSubClient_CommitBlock_NoSpool(t *testing.T) {
+	blockTopic := &pubSubTopicMock{errs: []error{errPublish, errPublish}}
+	client := service.NewPubSubClientWithTopics(service.PubSubReliabilityConfig{
+		MaxRetries:   1,
+		RetryBackoff: time.Millisecond,
+	}, map[string]service.PubSubTopic{
+		"block": blockTopic,
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 10}))
+	require.NoError(t, client.CommitBlock(ctx))
+	service.WaitPubSubBacklog(client)
+
+	require.Len(t, blockTopic.publishedMessages(), 2)
+	require.ErrorContains(t, client.CommitBlock(ctx), "failed to publish 1 messages to pubsub")
+
+	// Messages without ordering keys are not resumed.
+	require.Empty(t, blockTopic.resumedKeys())
+	require.NoError(t, client.Close())
+}
+
+// Validates that the messages whose result is not ready within the publish timeout are spooled at commit.
+func TestPubSubClient_CommitBlock_Timeout(t *testing.T) {
+	spoolDir := t.TempDir()
+
+	blockTopic := &pubSubTopicMock{hangs: 1}
+	client := service.NewPubSubClientWithTopics(service.PubSubReliabilityConfig{
+		PublishTimeout:      10 * time.Millisecond,
+		SpoolDir:            spoolDir,
+		SpoolReplayInterval: time.Hour,
+	}, map[string]service.PubSubTopic{
+		"block": blockTopic,
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 10}))
+	require.NoError(t, client.CommitBlock(ctx))
+
+	require.Equal(t, 1, spooledCount(t, spoolDir))
+	require.NoError(t, client.Close())
+}
+
+// Validates that the messages that still fail are spooled and replayed after a later commit.
+func TestPubSubClient_Spool(t *testing.T) {
+	spoolDir := t.TempDir()
+
+	blockTopic := &pubSubTopicMock{errs: []error{errPublish}}
+	client := service.NewPubSubClientWithTopics(service.PubSubReliabilityConfig{
+		SpoolDir: spoolDir,
+	}, map[string]service.PubSubTopic{
+		"block": blockTopic,
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 10}))
+	require.NoError(t, client.CommitBlock(ctx))
+	service.WaitPubSubBacklog(client)
+
+	require.Equal(t, 1, spooledCount(t, spoolDir))
+
+	// The spooled message is replayed in the background once the next block is committed.
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 11}))
+	require.NoError(t, client.CommitBlock(ctx))
+	require.Eventually(t, func() bool {
+		return spooledCount(t, spoolDir) == 0
+	}, time.Second, time.Millisecond)
+
+	published := blockTopic.publishedMessages()
+	require.Len(t, published, 3)
+	require.Equal(t, published[0].Data, published[2].Data)
+	require.Equal(t, published[0].Attributes, published[2].Attributes)
+	require.NoError(t, client.Close())
+}
+
+// Validates that the spooled messages of an ordering key, including the ones left by a previous run,
+// are replayed before the new messages of the key.
+func TestPubSubClient_Spool_Ordering(t *testing.T) {
+	spoolDir := t.TempDir()
+	reliability := service.PubSubReliabilityConfig{
+		OrderingEnabled: true,
+		SpoolDir:        spoolDir,
+	}
+
+	pairTopic := &pubSubTopicMock{errs: []error{errPublish}}
+	client := service.NewPubSubClientWithTopics(reliability, map[string]service.PubSubTopic{
+		"pair": pairTopic,
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.PublishPair(ctx, indexerdomain.Pair{PoolID: 1, PairCreatedAtHeight: 10}))
+	require.NoError(t, client.CommitBlock(ctx))
+	service.WaitPubSubBacklog(client)
+	require.NoError(t, client.Close())
+	require.Equal(t, 1, spooledCount(t, spoolDir))
+
+	pairTopic = &pubSubTopicMock{}
+	client = service.NewPubSubClientWithTopics(reliability, map[string]service.PubSubTopic{
+		"pair": pairTopic,
+	})
+
+	// The new message of the spooled key is held back while the other keys are published right away.
+	require.NoError(t, client.PublishPair(ctx, indexerdomain.Pair{PoolID: 1, PairCreatedAtHeight: 11}))
+	require.NoError(t, client.PublishPair(ctx, indexerdomain.Pair{PoolID: 2, PairCreatedAtHeight: 11}))
+	require.Len(t, pairTopic.publishedMessages(), 1)
+
+	require.NoError(t, client.CommitBlock(ctx))
+	service.WaitPubSubBacklog(client)
+
+	published := pairTopic.publishedMessages()
+	require.Len(t, published, 3)
+	require.Equal(t, "2", published[0].OrderingKey)
+	require.Contains(t, string(published[1].Data), `"pair_created_at_height":10`)
+	require.Contains(t, string(published[2].Data), `"pair_created_at_height":11`)
+	require.Equal(t, 0, spooledCount(t, spoolDir))
+	require.NoError(t, client.Close())
+}
+
+// Validates that the messages of a rolled back block are awaited with the next committed block.
+func TestPubSubClient_RollbackBlock(t *testing.T) {
+	blockTopic := &pubSubTopicMock{errs: []error{errPublish}}
+	client := service.NewPubSubClientWithTopics(service.PubSubReliabilityConfig{}, map[string]service.PubSubTopic{
+		"block": blockTopic,
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{Height: 10}))
+	require.NoError(t, client.RollbackBlock())
+
+	require.NoError(t, client.CommitBlock(ctx))
+	service.WaitPubSubBacklog(client)
+	require.Error(t, client.CommitBlock(ctx))
+	require.NoError(t, client.Close())
+}
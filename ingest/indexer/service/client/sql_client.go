@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	// Register the database/sql drivers of SQLDialectSQLite and SQLDialectPostgres.
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+
+	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+)
+
+const (
+	// SQLDialectSQLite is the dialect of an SQLite database file.
+	SQLDialectSQLite = "sqlite3"
+	// SQLDialectPostgres is the dialect of a Postgres database.
+	SQLDialectPostgres = "postgres"
+)
+
+// sqlMigrations are the statements of the schema migrations, applied in order.
+// The schema version is the number of applied migrations.
+// The statements must be valid in both SQLite and Postgres. Released migrations must not be changed.
+var sqlMigrations = [][]string{
+	// 1: initial schema
+	{
+		`CREATE TABLE blocks (
+			height BIGINT PRIMARY KEY,
+			chain_id TEXT NOT NULL,
+			block_time TEXT NOT NULL,
+			gas_consumed BIGINT NOT NULL,
+			ingested_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE txs (
+			tx_hash TEXT PRIMARY KEY,
+			height BIGINT NOT NULL,
+			tx_index INTEGER NOT NULL,
+			block_time TEXT NOT NULL,
+			gas_wanted BIGINT NOT NULL,
+			gas_used BIGINT NOT NULL,
+			fees TEXT NOT NULL,
+			msg_type TEXT NOT NULL,
+			ingested_at TEXT NOT NULL
+		)`,
+		`CREATE INDEX txs_height ON txs (height)`,
+		`CREATE TABLE events (
+			tx_hash TEXT NOT NULL,
+			event_index INTEGER NOT NULL,
+			height BIGINT NOT NULL,
+			type TEXT NOT NULL,
+			attributes TEXT NOT NULL,
+			PRIMARY KEY (tx_hash, event_index)
+		)`,
+		`CREATE INDEX events_type_height ON events (type, height)`,
+		`CREATE TABLE pairs (
+			pool_id BIGINT NOT NULL,
+			denom_0 TEXT NOT NULL,
+			idx_denom_0 INTEGER NOT NULL,
+			denom_1 TEXT NOT NULL,
+			idx_denom_1 INTEGER NOT NULL,
+			multi_asset BOOLEAN NOT NULL,
+			fee_bps BIGINT NOT NULL,
+			pair_created_at TEXT NOT NULL,
+			pair_created_at_height BIGINT NOT NULL,
+			pair_created_at_tx_hash TEXT NOT NULL,
+			ingested_at TEXT NOT NULL,
+			PRIMARY KEY (pool_id, denom_0, denom_1)
+		)`,
+		`CREATE TABLE token_supply (
+			denom TEXT PRIMARY KEY,
+			supply TEXT NOT NULL,
+			ingested_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE supply_offset (
+			denom TEXT PRIMARY KEY,
+			supply_offset TEXT NOT NULL,
+			ingested_at TEXT NOT NULL
+		)`,
+	},
+	// 2: transaction messages, signers and results
+	{
+		`ALTER TABLE txs ADD COLUMN messages TEXT NOT NULL DEFAULT '[]'`,
+		`ALTER TABLE txs ADD COLUMN signers TEXT NOT NULL DEFAULT '[]'`,
+		`ALTER TABLE txs ADD COLUMN memo TEXT NOT NULL DEFAULT ''`,
+		`ALTER TABLE txs ADD COLUMN timeout_height BIGINT NOT NULL DEFAULT 0`,
+		`ALTER TABLE txs ADD COLUMN fee_payer TEXT NOT NULL DEFAULT ''`,
+		`ALTER TABLE txs ADD COLUMN fee_granter TEXT NOT NULL DEFAULT ''`,
+		`ALTER TABLE txs ADD COLUMN status TEXT NOT NULL DEFAULT 'success'`,
+		`ALTER TABLE txs ADD COLUMN code BIGINT NOT NULL DEFAULT 0`,
+		`ALTER TABLE txs ADD COLUMN codespace TEXT NOT NULL DEFAULT ''`,
+		`ALTER TABLE txs ADD COLUMN log TEXT NOT NULL DEFAULT ''`,
+		`CREATE INDEX txs_status_height ON txs (status, height)`,
+	},
+	// 3: pool states
+	{
+		`CREATE TABLE pools (
+			pool_id BIGINT PRIMARY KEY,
+			type TEXT NOT NULL,
+			denoms TEXT NOT NULL,
+			balances TEXT NOT NULL,
+			spread_factor TEXT NOT NULL,
+			current_tick BIGINT NOT NULL,
+			current_sqrt_price TEXT NOT NULL,
+			liquidity_cap TEXT NOT NULL,
+			liquidity_cap_error TEXT NOT NULL,
+			height BIGINT NOT NULL,
+			block_time TEXT NOT NULL,
+			ingested_at TEXT NOT NULL
+		)`,
+	},
+}
+
+// SQLClient is a client for writing the indexed data to an SQLite or Postgres database.
+//
+// The schema is created and migrated on creation. The data published for a block is written
+// in a single database transaction that is committed by CommitBlock so that the database
+// is always consistent at a height.
+// Blocks, pairs, pools and supplies are upserted and transactions and events are written once so that
+// republishing a block is idempotent.
+// Times are stored as RFC 3339 text and amounts as decimal text.
+type SQLClient struct {
+	db *sql.DB
+
+	mu sync.Mutex
+	// tx is the transaction of the current block. It is begun by the first write of the block.
+	tx *sql.Tx
+
+	// publishedBytes is the size of the text values written since the last PopPublishedBytes call.
+	publishedBytes uint64
+}
+
+var (
+	_ indexerdomain.Publisher             = &SQLClient{}
+	_ indexerdomain.PublishedBytesCounter = &SQLClient{}
+	_ indexerdomain.BlockCommitter        = &SQLClient{}
+)
+
+// NewSQLClient opens the database with the given dialect and data source name and migrates its schema.
+// The data source name is the path of the database file for SQLDialectSQLite and the connection string for SQLDialectPostgres.
+// Returns error if the database fails to open or its schema is newer than supported.
+func NewSQLClient(dialect, dataSourceName string) (*SQLClient, error) {
+	if dialect != SQLDialectSQLite && dialect != SQLDialectPostgres {
+		return nil, fmt.Errorf("unsupported sql dialect (%s), expected %s or %s", dialect, SQLDialectSQLite, SQLDialectPostgres)
+	}
+
+	if dataSourceName == "" {
+		return nil, errors.New("sql data source name must be set")
+	}
+
+	db, err := sql.Open(dialect, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if dialect == SQLDialectSQLite {
+		// SQLite allows a single writer.
+		db.SetMaxOpenConns(1)
+	}
+
+	if err := migrateSQLSchema(context.Background(), db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLClient{
+		db: db,
+	}, nil
+}
+
+// migrateSQLSchema applies the migrations newer than the schema version of the database.
+// Each migration is applied in its own transaction.
+func migrateSQLSchema(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var version int
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if version > len(sqlMigrations) {
+		return fmt.Errorf("sql schema version (%d) is newer than supported (%d)", version, len(sqlMigrations))
+	}
+
+	for i := version; i < len(sqlMigrations); i++ {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, statement := range sqlMigrations[i] {
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				_ = tx.Rollback()
+				return fmt.Errorf("failed to apply sql schema migration %d: %w", i+1, err)
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, applied_at) VALUES ($1, $2)`, i+1, formatSQLTime(time.Now())); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit sql schema migration %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// exec executes the statement in the transaction of the current block, beginning it if needed.
+// It is safe for concurrent use since pairs are published concurrently.
+func (c *SQLClient) exec(ctx context.Context, query string, args ...any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tx == nil {
+		// The transaction spans the publish calls of the block, so it is not bound to the context of this call.
+		tx, err := c.db.BeginTx(context.Background(), nil)
+		if err != nil {
+			return err
+		}
+		c.tx = tx
+	}
+
+	if _, err := c.tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	for _, arg := range args {
+		if value, ok := arg.(string); ok {
+			atomic.AddUint64(&c.publishedBytes, uint64(len(value)))
+		}
+	}
+
+	return nil
+}
+
+// CommitBlock implements indexerdomain.BlockCommitter.
+func (c *SQLClient) CommitBlock(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tx == nil {
+		return nil
+	}
+
+	err := c.tx.Commit()
+	c.tx = nil
+	return err
+}
+
+// RollbackBlock implements indexerdomain.BlockCommitter.
+func (c *SQLClient) RollbackBlock() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tx == nil {
+		return nil
+	}
+
+	err := c.tx.Rollback()
+	c.tx = nil
+	return err
+}
+
+// Close rolls back the uncommitted block, if any, and closes the database.
+func (c *SQLClient) Close() error {
+	return errors.Join(c.RollbackBlock(), c.db.Close())
+}
+
+// PopPublishedBytes implements indexerdomain.PublishedBytesCounter.
+func (c *SQLClient) PopPublishedBytes() uint64 {
+	return atomic.SwapUint64(&c.publishedBytes, 0)
+}
+
+// PublishBlock implements indexerdomain.Publisher.
+func (c *SQLClient) PublishBlock(ctx context.Context, block indexerdomain.Block) error {
+	return c.exec(ctx, `INSERT INTO blocks (height, chain_id, block_time, gas_consumed, ingested_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (height) DO UPDATE SET
+			chain_id = excluded.chain_id,
+			block_time = excluded.block_time,
+			gas_consumed = excluded.gas_consumed,
+			ingested_at = excluded.ingested_at`,
+		int64(block.Height), block.ChainId, formatSQLTime(block.BlockTime), int64(block.GasConsumed), formatSQLTime(time.Now()))
+}
+
+// PublishTransaction implements indexerdomain.Publisher.
+// The messages and signers are written as JSON and the events to the events table.
+func (c *SQLClient) PublishTransaction(ctx context.Context, txn indexerdomain.Transaction) error {
+	messages, err := json.Marshal(txn.Messages)
+	if err != nil {
+		return err
+	}
+
+	signers, err := json.Marshal(txn.Signers)
+	if err != nil {
+		return err
+	}
+
+	if err := c.exec(ctx, `INSERT INTO txs (tx_hash, height, tx_index, block_time, gas_wanted, gas_used, fees, msg_type, ingested_at,
+			messages, signers, memo, timeout_height, fee_payer, fee_granter, status, code, codespace, log)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		ON CONFLICT (tx_hash) DO NOTHING`,
+		txn.TransactionHash, int64(txn.Height), txn.TransactionIndexId, formatSQLTime(txn.BlockTime), int64(txn.GasWanted), int64(txn.GasUsed), txn.Fees.String(), txn.MessageType, formatSQLTime(time.Now()),
+		string(messages), string(signers), txn.Memo, int64(txn.TimeoutHeight), txn.FeePayer, txn.FeeGranter, txn.Status, int64(txn.Code), txn.Codespace, txn.Log); err != nil {
+		return err
+	}
+
+	for _, event := range txn.Events {
+		attributes, err := json.Marshal(event.Event.Attributes)
+		if err != nil {
+			return err
+		}
+
+		if err := c.exec(ctx, `INSERT INTO events (tx_hash, event_index, height, type, attributes)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (tx_hash, event_index) DO NOTHING`,
+			txn.TransactionHash, event.Index, int64(txn.Height), event.Event.Type, string(attributes)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PublishTokenSupply implements indexerdomain.Publisher.
+func (c *SQLClient) PublishTokenSupply(ctx context.Context, tokenSupply indexerdomain.TokenSupply) error {
+	return c.exec(ctx, `INSERT INTO token_supply (denom, supply, ingested_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (denom) DO UPDATE SET
+			supply = excluded.supply,
+			ingested_at = excluded.ingested_at`,
+		tokenSupply.Denom, tokenSupply.Supply.String(), formatSQLTime(time.Now()))
+}
+
+// PublishTokenSupplyOffset implements indexerdomain.Publisher.
+func (c *SQLClient) PublishTokenSupplyOffset(ctx context.Context, tokenSupplyOffset indexerdomain.TokenSupplyOffset) error {
+	return c.exec(ctx, `INSERT INTO supply_offset (denom, supply_offset, ingested_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (denom) DO UPDATE SET
+			supply_offset = excluded.supply_offset,
+			ingested_at = excluded.ingested_at`,
+		tokenSupplyOffset.Denom, tokenSupplyOffset.SupplyOffset.String(), formatSQLTime(time.Now()))
+}
+
+// PublishPair implements indexerdomain.Publisher.
+func (c *SQLClient) PublishPair(ctx context.Context, pair indexerdomain.Pair) error {
+	return c.exec(ctx, `INSERT INTO pairs (pool_id, denom_0, idx_denom_0, denom_1, idx_denom_1, multi_asset, fee_bps,
+			pair_created_at, pair_created_at_height, pair_created_at_tx_hash, ingested_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (pool_id, denom_0, denom_1) DO UPDATE SET
+			idx_denom_0 = excluded.idx_denom_0,
+			idx_denom_1 = excluded.idx_denom_1,
+			multi_asset = excluded.multi_asset,
+			fee_bps = excluded.fee_bps,
+			pair_created_at = excluded.pair_created_at,
+			pair_created_at_height = excluded.pair_created_at_height,
+			pair_created_at_tx_hash = excluded.pair_created_at_tx_hash,
+			ingested_at = excluded.ingested_at`,
+		int64(pair.PoolID), pair.Denom0, int(pair.IdxDenom0), pair.Denom1, int(pair.IdxDenom1), pair.MultiAsset, int64(pair.FeeBps),
+		formatSQLTime(pair.PairCreatedAt), int64(pair.PairCreatedAtHeight), pair.PairCreatedAtTxnHash, formatSQLTime(time.Now()))
+}
+
+// PublishPool implements indexerdomain.Publisher.
+// The latest state of the pool is kept. The denoms are written as JSON.
+func (c *SQLClient) PublishPool(ctx context.Context, pool indexerdomain.Pool) error {
+	denoms, err := json.Marshal(pool.Denoms)
+	if err != nil {
+		return err
+	}
+
+	return c.exec(ctx, `INSERT INTO pools (pool_id, type, denoms, balances, spread_factor, current_tick, current_sqrt_price,
+			liquidity_cap, liquidity_cap_error, height, block_time, ingested_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (pool_id) DO UPDATE SET
+			type = excluded.type,
+			denoms = excluded.denoms,
+			balances = excluded.balances,
+			spread_factor = excluded.spread_factor,
+			current_tick = excluded.current_tick,
+			current_sqrt_price = excluded.current_sqrt_price,
+			liquidity_cap = excluded.liquidity_cap,
+			liquidity_cap_error = excluded.liquidity_cap_error,
+			height = excluded.height,
+			block_time = excluded.block_time,
+			ingested_at = excluded.ingested_at`,
+		int64(pool.PoolID), pool.Type, string(denoms), pool.Balances.String(), pool.SpreadFactor.String(), pool.CurrentTick, pool.CurrentSqrtPrice,
+		pool.LiquidityCap.String(), pool.LiquidityCapError, int64(pool.Height), formatSQLTime(pool.BlockTime), formatSQLTime(time.Now()))
+}
+
+// formatSQLTime formats the time as RFC 3339 text in UTC.
+func formatSQLTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
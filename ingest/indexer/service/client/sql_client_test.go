@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	indexerdomain "github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	service "github.com/osmosis-labs/osmosis/v30/ingest/indexer/service/client"
+)
+
+// countRows returns the number of rows of the table.
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM "+table).Scan(&count))
+	return count
+}
+
+// Validates that the data of a block is visible only once committed, that a rolled back block
+// is discarded and that republishing a block is idempotent.
+func TestSQLClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "indexer.db")
+
+	client, err := service.NewSQLClient(service.SQLDialectSQLite, path)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	db, err := sql.Open(service.SQLDialectSQLite, path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	blockTime := time.Unix(1_700_000_000, 0)
+
+	publishBlock := func(height uint64) {
+		require.NoError(t, client.PublishBlock(ctx, indexerdomain.Block{ChainId: "osmosis-1", Height: height, BlockTime: blockTime}))
+		require.NoError(t, client.PublishTransaction(ctx, indexerdomain.Transaction{
+			Height:          height,
+			BlockTime:       blockTime,
+			Fees:            sdk.NewCoins(sdk.NewInt64Coin("uosmo", 1000)),
+			MessageType:     "/osmosis.poolmanager.v1beta1.MsgSwapExactAmountIn",
+			TransactionHash: "hash" + strconv.FormatUint(height, 10),
+			Messages:        []indexerdomain.TransactionMessage{{TypeURL: "/osmosis.poolmanager.v1beta1.MsgSwapExactAmountIn", Body: []byte(`{"sender":"osmo1"}`)}},
+			Signers:         []string{"osmo1"},
+			Status:          indexerdomain.TransactionStatusSuccess,
+			Events: []indexerdomain.EventWrapper{
+				{Index: 0, Event: abcitypes.Event{Type: "token_swapped", Attributes: []abcitypes.EventAttribute{{Key: "pool_id", Value: "1"}}}},
+				{Index: 1, Event: abcitypes.Event{Type: "message"}},
+			},
+		}))
+		require.NoError(t, client.PublishPair(ctx, indexerdomain.Pair{PoolID: 1, Denom0: "uosmo", Denom1: "uatom", IdxDenom1: 1, FeeBps: 20 + height}))
+		require.NoError(t, client.PublishPool(ctx, indexerdomain.Pool{
+			PoolID:       1,
+			Type:         "Concentrated",
+			Denoms:       []string{"uatom", "uosmo"},
+			Balances:     sdk.NewCoins(sdk.NewInt64Coin("uosmo", int64(height))),
+			SpreadFactor: osmomath.NewDecWithPrec(2, 3),
+			CurrentTick:  -100,
+			LiquidityCap: osmomath.NewInt(1000),
+			Height:       height,
+			BlockTime:    blockTime,
+		}))
+		require.NoError(t, client.PublishTokenSupply(ctx, indexerdomain.TokenSupply{Denom: "uosmo", Supply: osmomath.NewIntFromUint64(height)}))
+		require.NoError(t, client.PublishTokenSupplyOffset(ctx, indexerdomain.TokenSupplyOffset{Denom: "uosmo", SupplyOffset: osmomath.NewInt(-1)}))
+	}
+
+	// The block is not visible until committed.
+	publishBlock(10)
+	require.Zero(t, countRows(t, db, "blocks"))
+
+	require.NoError(t, client.CommitBlock(ctx))
+	require.Equal(t, 1, countRows(t, db, "blocks"))
+	require.Equal(t, 1, countRows(t, db, "txs"))
+	require.Equal(t, 2, countRows(t, db, "events"))
+	require.Equal(t, 1, countRows(t, db, "pairs"))
+	require.Equal(t, 1, countRows(t, db, "pools"))
+	require.Equal(t, 1, countRows(t, db, "token_supply"))
+	require.Equal(t, 1, countRows(t, db, "supply_offset"))
+	require.NotZero(t, client.PopPublishedBytes())
+
+	var attributes string
+	require.NoError(t, db.QueryRow("SELECT attributes FROM events WHERE type = 'token_swapped'").Scan(&attributes))
+	require.JSONEq(t, `[{"key":"pool_id","value":"1"}]`, attributes)
+
+	var messages, signers, status string
+	require.NoError(t, db.QueryRow("SELECT messages, signers, status FROM txs").Scan(&messages, &signers, &status))
+	require.JSONEq(t, `[{"type_url":"/osmosis.poolmanager.v1beta1.MsgSwapExactAmountIn","body":{"sender":"osmo1"}}]`, messages)
+	require.JSONEq(t, `["osmo1"]`, signers)
+	require.Equal(t, indexerdomain.TransactionStatusSuccess, status)
+
+	// A rolled back block is discarded.
+	publishBlock(11)
+	require.NoError(t, client.RollbackBlock())
+	require.Equal(t, 1, countRows(t, db, "blocks"))
+
+	// Republishing the block is idempotent and the latest pair and supply are kept.
+	publishBlock(11)
+	publishBlock(11)
+	require.NoError(t, client.CommitBlock(ctx))
+	require.Equal(t, 2, countRows(t, db, "blocks"))
+	require.Equal(t, 2, countRows(t, db, "txs"))
+	require.Equal(t, 4, countRows(t, db, "events"))
+	require.Equal(t, 1, countRows(t, db, "pairs"))
+
+	var feeBps uint64
+	require.NoError(t, db.QueryRow("SELECT fee_bps FROM pairs WHERE pool_id = 1").Scan(&feeBps))
+	require.Equal(t, uint64(31), feeBps)
+
+	var poolHeight uint64
+	var denoms, balances string
+	require.NoError(t, db.QueryRow("SELECT height, denoms, balances FROM pools WHERE pool_id = 1").Scan(&poolHeight, &denoms, &balances))
+	require.Equal(t, uint64(11), poolHeight)
+	require.JSONEq(t, `["uatom","uosmo"]`, denoms)
+	require.Equal(t, "11uosmo", balances)
+
+	var supply string
+	require.NoError(t, db.QueryRow("SELECT supply FROM token_supply WHERE denom = 'uosmo'").Scan(&supply))
+	require.Equal(t, "11", supply)
+
+	// Committing without published data is a no-op.
+	require.NoError(t, client.CommitBlock(ctx))
+}
+
+// Validates that the schema is migrated once and that a newer schema is refused.
+func TestNewSQLClient_Migrations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "indexer.db")
+
+	client, err := service.NewSQLClient(service.SQLDialectSQLite, path)
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+
+	// Reopening does not reapply the migrations.
+	client, err = service.NewSQLClient(service.SQLDialectSQLite, path)
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+
+	db, err := sql.Open(service.SQLDialectSQLite, path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.Equal(t, 3, countRows(t, db, "schema_migrations"))
+
+	_, err = db.Exec("INSERT INTO schema_migrations (version, applied_at) VALUES (1000, '')")
+	require.NoError(t, err)
+
+	_, err = service.NewSQLClient(service.SQLDialectSQLite, path)
+	require.ErrorContains(t, err, "newer than supported")
+
+	_, err = service.NewSQLClient("mysql", path)
+	require.Error(t, err)
+
+	_, err = service.NewSQLClient(service.SQLDialectSQLite, "")
+	require.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
+	gammtypes "github.com/osmosis-labs/osmosis/v30/x/gamm/types"
+)
+
+// EventEnricher adds data to the selected events of the successful transactions before they are published,
+// e.g. the liquidity of the pool of a swap.
+type EventEnricher struct {
+	// Name identifies the enricher in the logs. It must be unique.
+	Name string
+	// EventType is the type of the enriched events. All events are enriched if empty.
+	EventType string
+	// Required defines if a failure to enrich an event fails the block.
+	// Otherwise, the failure is logged and the event is not published.
+	Required bool
+	// Enrich adds data to the event, e.g. attributes.
+	Enrich func(ctx context.Context, keepers domain.Keepers, event *abci.Event) error
+}
+
+var (
+	eventEnrichersMu sync.RWMutex
+	// eventEnrichers are the registered enrichers, applied after the built-in ones in the order of registration.
+	eventEnrichers []EventEnricher
+)
+
+// RegisterEventEnricher registers an enricher applied to the events of every indexer streaming service
+// created afterwards, after the built-in enrichers.
+// Panics if an enricher with the same name is already registered.
+func RegisterEventEnricher(enricher EventEnricher) {
+	eventEnrichersMu.Lock()
+	defer eventEnrichersMu.Unlock()
+
+	for _, registered := range eventEnrichers {
+		if registered.Name == enricher.Name {
+			panic(fmt.Sprintf("indexer event enricher (%s) is already registered", enricher.Name))
+		}
+	}
+
+	eventEnrichers = append(eventEnrichers, enricher)
+}
+
+// newEventEnrichers returns the built-in enrichers of the service followed by the registered ones.
+func (s *indexerStreamingService) newEventEnrichers() []EventEnricher {
+	enrichers := []EventEnricher{
+		{
+			Name:      "token_liquidity",
+			EventType: gammtypes.TypeEvtTokenSwapped,
+			Required:  true,
+			Enrich: func(ctx context.Context, _ domain.Keepers, event *abci.Event) error {
+				return s.addTokenLiquidity(ctx, event)
+			},
+		},
+		{
+			Name:      "spread_factor",
+			EventType: gammtypes.TypeEvtTokenSwapped,
+			Enrich: func(ctx context.Context, _ domain.Keepers, event *abci.Event) error {
+				return s.adjustTokenInAmountBySpreadFactor(ctx, event)
+			},
+		},
+		{
+			Name:      "spot_price",
+			EventType: gammtypes.TypeEvtTokenSwapped,
+			Enrich: func(ctx context.Context, _ domain.Keepers, event *abci.Event) error {
+				return s.setSpotPrice(ctx, event)
+			},
+		},
+	}
+
+	eventEnrichersMu.RLock()
+	defer eventEnrichersMu.RUnlock()
+
+	return append(enrichers, eventEnrichers...)
+}
+
+// enrichEvent applies the enrichers to the event.
+// Returns false if an enricher that is not required failed, in which case the event must not be published.
+// Returns error if a required enricher failed.
+func (s *indexerStreamingService) enrichEvent(ctx context.Context, event *abci.Event) (bool, error) {
+	for _, enricher := range s.eventEnrichers {
+		if enricher.EventType != "" && enricher.EventType != event.Type {
+			continue
+		}
+
+		if err := enricher.Enrich(ctx, s.keepers, event); err != nil {
+			s.logger.Error("Error enriching event", "enricher", enricher.Name, "event_type", event.Type, "error", err)
+			if enricher.Required {
+				return false, err
+			}
+			return false, nil
+		}
+	}
+	return true, nil
+}
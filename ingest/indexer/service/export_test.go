@@ -5,6 +5,7 @@ import (
 	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 type IndexerStreamingService = indexerStreamingService
@@ -24,3 +25,19 @@ func (s *indexerStreamingService) TrackCreatedPoolID(event abci.Event, blockHeig
 func (s *indexerStreamingService) SetSpotPrice(ctx context.Context, event *abci.Event) error {
 	return s.setSpotPrice(ctx, event)
 }
+
+func (s *indexerStreamingService) RecordStatus(ctx sdk.Context, err error) {
+	s.recordStatus(ctx, err)
+}
+
+func (s *indexerStreamingService) EndBlock(ctx context.Context, err error) error {
+	return s.endBlock(ctx, err)
+}
+
+func (s *indexerStreamingService) PublishTxn(ctx context.Context, req abci.RequestFinalizeBlock, res abci.ResponseFinalizeBlock) error {
+	return s.publishTxn(ctx, req, res)
+}
+
+func (s *indexerStreamingService) LastPublishedHeight() uint64 {
+	return s.lastPublishedHeight.Load()
+}
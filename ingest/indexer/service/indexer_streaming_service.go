@@ -5,9 +5,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"cosmossdk.io/log"
@@ -16,6 +18,7 @@ import (
 	"github.com/cosmos/gogoproto/proto"
 
 	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/osmosis-labs/osmosis/osmomath"
@@ -24,6 +27,7 @@ import (
 	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
 
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/lifecycle"
 	commonservice "github.com/osmosis-labs/osmosis/v30/ingest/common/service"
 	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/domain"
 	"github.com/osmosis-labs/osmosis/v30/ingest/indexer/service/blockprocessor"
@@ -32,6 +36,7 @@ import (
 
 var (
 	_      storetypes.ABCIListener = (*indexerStreamingService)(nil)
+	_      lifecycle.Shutdowner    = (*indexerStreamingService)(nil)
 	oneDec                         = osmomath.OneDec()
 )
 
@@ -51,10 +56,38 @@ type indexerStreamingService struct {
 
 	poolTracker sqsdomain.BlockPoolUpdateTracker
 
+	// poolsTransformer instruments the published pool states with their balances and liquidity capitalization.
+	// Nil if the pool states are not published.
+	poolsTransformer sqsdomain.PoolsTransformer
+
 	nodeStatusChecker commonservice.NodeStatusChecker
 
 	txDecoder sdk.TxDecoder
 
+	// eventFilter selects the published events of the transactions.
+	eventFilter domain.EventFilter
+	// eventEnrichers enrich the selected events before they are published.
+	eventEnrichers []EventEnricher
+
+	// checkpointer persists the published pools for warm restart. Nil if disabled.
+	checkpointer commondomain.Checkpointer
+
+	// statusRecorder records the ingest status of the indexer. Nil if disabled.
+	statusRecorder commondomain.StatusRecorder
+	// lastPublishedHeight is the last height whose block, transactions and pools were published.
+	// It is read on shutdown, possibly while a block is in flight.
+	lastPublishedHeight atomic.Uint64
+	// finalizeBlockErr is the error of publishing the block and the transactions of the current block.
+	// It is recorded together with the outcome of ListenCommit.
+	finalizeBlockErr error
+	// isBlockPending is true between ListenFinalizeBlock and ListenCommit of a published block.
+	isBlockPending bool
+
+	// drainer tracks the listener calls in flight so that the shutdown waits for them.
+	drainer      lifecycle.Drainer
+	shutdownOnce sync.Once
+	shutdownErr  error
+
 	logger log.Logger
 }
 
@@ -62,15 +95,21 @@ type indexerStreamingService struct {
 // writeListeners is a map of store keys to write listeners.
 // sqsIngester is an ingester that ingests the block data into SQS.
 // poolTracker is a tracker that tracks the pools that were changed in the block.
+// poolsTransformer instruments the published pool states. The pool states are not published if nil.
 // nodeStatusChecker is a checker that checks if the node is syncing.
-func New(blockUpdatesProcessUtils commondomain.BlockUpdateProcessUtilsI, blockProcessStrategyManager commondomain.BlockProcessStrategyManager, client domain.Publisher, storeKeyMap map[string]storetypes.StoreKey, poolExtractor commondomain.PoolExtractor, poolTracker sqsdomain.BlockPoolUpdateTracker, keepers domain.Keepers, txDecoder sdk.TxDecoder, nodeStatusChecker commonservice.NodeStatusChecker, logger log.Logger) *indexerStreamingService {
-	return &indexerStreamingService{
+// eventFilter selects the published events of the transactions. They are enriched by the built-in and registered enrichers.
+// checkpointer persists the published pools so that only the changed pools are published on warm restart. Nil if disabled.
+// statusRecorder records the outcome of every block for the status server. Nil if disabled.
+func New(blockUpdatesProcessUtils commondomain.BlockUpdateProcessUtilsI, blockProcessStrategyManager commondomain.BlockProcessStrategyManager, client domain.Publisher, storeKeyMap map[string]storetypes.StoreKey, poolExtractor commondomain.PoolExtractor, poolTracker sqsdomain.BlockPoolUpdateTracker, poolsTransformer sqsdomain.PoolsTransformer, keepers domain.Keepers, txDecoder sdk.TxDecoder, eventFilter domain.EventFilter, nodeStatusChecker commonservice.NodeStatusChecker, checkpointer commondomain.Checkpointer, statusRecorder commondomain.StatusRecorder, logger log.Logger) *indexerStreamingService {
+	s := &indexerStreamingService{
 		blockProcessStrategyManager: blockProcessStrategyManager,
 
 		poolExtractor: poolExtractor,
 
 		poolTracker: poolTracker,
 
+		poolsTransformer: poolsTransformer,
+
 		client: client,
 
 		keepers: keepers,
@@ -79,14 +118,67 @@ func New(blockUpdatesProcessUtils commondomain.BlockUpdateProcessUtilsI, blockPr
 
 		txDecoder: txDecoder,
 
+		eventFilter: eventFilter,
+
 		nodeStatusChecker: nodeStatusChecker,
 
+		checkpointer: checkpointer,
+
+		statusRecorder: statusRecorder,
+
 		logger: logger,
 	}
+	s.eventEnrichers = s.newEventEnrichers()
+	return s
 }
 
 // Close implements baseapp.StreamingService.
+// It shuts the service down within the default shutdown timeout.
 func (s *indexerStreamingService) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), lifecycle.DefaultConfig.ShutdownTimeout())
+	defer cancel()
+	return s.Shutdown(ctx)
+}
+
+// Shutdown implements lifecycle.Shutdowner.
+// Once the listener call in flight returns, the data of a block published in ListenFinalizeBlock
+// but not committed is rolled back if the publisher writes blocks atomically, and the publisher
+// is flushed and closed. The last fully published height is logged so that the blocks after it
+// can be backfilled. Subsequent calls return the result of the first one.
+func (s *indexerStreamingService) Shutdown(ctx context.Context) error {
+	s.shutdownOnce.Do(func() {
+		s.shutdownErr = s.shutdown(ctx)
+	})
+	return s.shutdownErr
+}
+
+func (s *indexerStreamingService) shutdown(ctx context.Context) error {
+	if err := s.drainer.Drain(ctx); err != nil {
+		s.logger.Error("Indexer shut down with a block in flight", "last_published_height", s.lastPublishedHeight.Load(), "err", err)
+		return err
+	}
+
+	lastPublishedHeight := s.lastPublishedHeight.Load()
+
+	var err error
+	if s.isBlockPending {
+		if committer, ok := s.client.(domain.BlockCommitter); ok {
+			err = committer.RollbackBlock()
+		} else {
+			s.logger.Error("Indexer shut down with a partially published block", "height", lastPublishedHeight+1)
+		}
+	}
+
+	if closer, ok := s.client.(io.Closer); ok {
+		err = errors.Join(err, lifecycle.CloseWithin(ctx, closer))
+	}
+
+	if err != nil {
+		s.logger.Error("Error shutting down indexer", "last_published_height", lastPublishedHeight, "err", err)
+		return err
+	}
+
+	s.logger.Info("Indexer shut down", "last_published_height", lastPublishedHeight)
 	return nil
 }
 
@@ -185,49 +277,77 @@ func (s *indexerStreamingService) publishTxn(ctx context.Context, req abci.Reque
 
 		// Message type
 		txMessages := tx.GetMsgs()
-		msgType := proto.MessageName(txMessages[0])
+		var msgType string
+		if len(txMessages) > 0 {
+			msgType = proto.MessageName(txMessages[0])
+		}
 
-		// Looping through the transaction results, each result has a list of events to be looped through
-		var includedEvents []domain.EventWrapper
+		// The transaction is published without its messages or signers if they cannot be decoded,
+		// so that one transaction does not fail the whole block.
+		messages, err := newTransactionMessages(txMessages)
+		if err != nil {
+			s.logger.Error("Error marshaling transaction messages, published without messages", "tx_hash", txHash, "err", err)
+			messages = nil
+		}
+
+		signers, err := txSigners(tx)
+		if err != nil {
+			s.logger.Error("Error getting transaction signers, published without signers", "tx_hash", txHash, "err", err)
+			signers = nil
+		}
+
+		txn := domain.Transaction{
+			Height:             uint64(sdkCtx.BlockHeight()),
+			BlockTime:          sdkCtx.BlockTime().UTC(),
+			GasWanted:          uint64(gasWanted),
+			GasUsed:            uint64(gasUsed),
+			Fees:               fee,
+			MessageType:        msgType,
+			TransactionHash:    txHash,
+			TransactionIndexId: txnIndex,
+			Messages:           messages,
+			Signers:            signers,
+			FeePayer:           accAddressString(feeTx.FeePayer()),
+			FeeGranter:         accAddressString(feeTx.FeeGranter()),
+			Status:             domain.TransactionStatusSuccess,
+		}
+		if memoTx, ok := tx.(sdk.TxWithMemo); ok {
+			txn.Memo = memoTx.GetMemo()
+		}
+		if timeoutTx, ok := tx.(sdk.TxWithTimeoutHeight); ok {
+			txn.TimeoutHeight = timeoutTx.GetTimeoutHeight()
+		}
 
 		txnResult := res.TxResults[txnIndex]
 		if txnResult.IsErr() {
-			// Skip if the transaction is not successful, so that its corresponding events are not included in the publishing and not counted in by the dexscreener
+			// Publish the failed transaction with its result but without its events, so that its corresponding events are not counted in by the dexscreener
+			txn.Status = domain.TransactionStatusFailed
+			txn.Code = txnResult.Code
+			txn.Codespace = txnResult.Codespace
+			txn.Log = txnResult.Log
+			if err := s.client.PublishTransaction(sdkCtx, txn); err != nil {
+				return err
+			}
 			continue
 		}
+
+		// Looping through the transaction results, each result has a list of events to be looped through
+		var includedEvents []domain.EventWrapper
 		events := txnResult.GetEvents()
 
 		// Iterate through the events in the transaction
-		// Include these events only:
-		// - token_swapped
-		// - pool_joined
-		// - pool_exited
-		// - create_position
-		// - withdraw_position
+		// Include the events selected by the event filter only, enriched by the event enrichers
 		for i, event := range events {
-			clonedEvent := deepCloneEvent(&event)
-			// Add the token liquidity to the event
-			err := s.addTokenLiquidity(ctx, clonedEvent)
-			if err != nil {
-				s.logger.Error("Error adding token liquidity to event", "error", err)
-				return err
-			}
-			err = s.adjustTokenInAmountBySpreadFactor(ctx, clonedEvent)
-			if err != nil {
-				s.logger.Error("Error adjusting amount by spread factor", "error", err)
-				continue
-			}
-			eventType := clonedEvent.Type
-			if eventType == gammtypes.TypeEvtTokenSwapped {
-				// Set the spot price for the token swapped event in the event's attributes map
-				err := s.setSpotPrice(ctx, clonedEvent)
+			eventType := event.Type
+			if s.eventFilter.Match(event) {
+				clonedEvent := deepCloneEvent(&event)
+				include, err := s.enrichEvent(ctx, clonedEvent)
 				if err != nil {
-					s.logger.Error("Error setting spot price", "error", err)
-					continue
+					return err
+				}
+				if include {
+					includedEvents = append(includedEvents, domain.EventWrapper{Index: i, Event: *clonedEvent})
 				}
-			}
-			if eventType == gammtypes.TypeEvtTokenSwapped || eventType == gammtypes.TypeEvtPoolJoined || eventType == gammtypes.TypeEvtPoolExited || eventType == concentratedliquiditytypes.TypeEvtCreatePosition || eventType == concentratedliquiditytypes.TypeEvtWithdrawPosition {
-				includedEvents = append(includedEvents, domain.EventWrapper{Index: i, Event: *clonedEvent})
 			}
 			// Track the newly created pool ID
 			// IMPORTANT NOTE:
@@ -243,17 +363,7 @@ func (s *indexerStreamingService) publishTxn(ctx context.Context, req abci.Reque
 			}
 		}
 		// Publish the transaction
-		txn := domain.Transaction{
-			Height:             uint64(sdkCtx.BlockHeight()),
-			BlockTime:          sdkCtx.BlockTime().UTC(),
-			GasWanted:          uint64(gasWanted),
-			GasUsed:            uint64(gasUsed),
-			Fees:               fee,
-			MessageType:        msgType,
-			TransactionHash:    txHash,
-			TransactionIndexId: txnIndex,
-			Events:             includedEvents,
-		}
+		txn.Events = includedEvents
 		err = s.client.PublishTransaction(sdkCtx, txn)
 		if err != nil {
 			// if there is an error in publishing the transaction, return the error
@@ -358,6 +468,19 @@ func (s *indexerStreamingService) adjustTokenInAmountBySpreadFactor(ctx context.
 
 // ListenFinalizeBlock updates the streaming service with the latest FinalizeBlock messages
 func (s *indexerStreamingService) ListenFinalizeBlock(ctx context.Context, req abci.RequestFinalizeBlock, res abci.ResponseFinalizeBlock) error {
+	// Once shut down, the blocks are no longer published.
+	if !s.drainer.Begin() {
+		return nil
+	}
+	defer s.drainer.End()
+
+	// While paused by the operator, the block is skipped without publishing.
+	if s.blockProcessStrategyManager.IsPaused() {
+		return nil
+	}
+
+	s.isBlockPending = true
+
 	// Log the status only for the first block
 	// Avoid subsequent blocks to avoid spamming the logs
 	if s.blockProcessStrategyManager.ShouldPushAllData() {
@@ -374,12 +497,14 @@ func (s *indexerStreamingService) ListenFinalizeBlock(ctx context.Context, req a
 	err = s.publishBlock(ctx, req)
 	if err != nil {
 		s.logger.Error("Error publishing block data by indexer", err)
+		s.finalizeBlockErr = err
 		return err
 	}
 	// Iterate through the transactions in the block and publish them
 	err = s.publishTxn(ctx, req, res)
 	if err != nil {
 		s.logger.Error("Error publishing transaction data by indexer", err)
+		s.finalizeBlockErr = err
 		return err
 	}
 	return nil
@@ -387,8 +512,34 @@ func (s *indexerStreamingService) ListenFinalizeBlock(ctx context.Context, req a
 
 // ListenCommit updates the steaming service with the latest Commit messages and state changes
 func (s *indexerStreamingService) ListenCommit(ctx context.Context, res abci.ResponseCommit, changeSet []*storetypes.StoreKVPair) error {
+	// Once shut down, the blocks are no longer published.
+	// The data of a block published in ListenFinalizeBlock is rolled back on shutdown.
+	if !s.drainer.Begin() {
+		return nil
+	}
+	defer s.drainer.End()
+
+	// The data of the block is either committed or rolled back below.
+	defer func() {
+		s.isBlockPending = false
+	}()
+
 	sdkCtx := sdk.UnwrapSDKContext(ctx)
 
+	// While paused by the operator, the block is skipped without publishing.
+	// All pools are published once resumed.
+	if s.blockProcessStrategyManager.IsPaused() {
+		s.poolTracker.Reset()
+		// Discard the data of the block published before pausing, if any.
+		if committer, ok := s.client.(domain.BlockCommitter); ok {
+			if err := committer.RollbackBlock(); err != nil {
+				s.logger.Error("Error rolling back block data by indexer", "err", err)
+			}
+		}
+		s.recordPausedStatus(sdkCtx)
+		return nil
+	}
+
 	// Log the status only for the first block
 	// Avoid subsequent blocks to avoid spamming the logs
 	if s.blockProcessStrategyManager.ShouldPushAllData() {
@@ -415,12 +566,13 @@ func (s *indexerStreamingService) ListenCommit(ctx context.Context, res abci.Res
 	// Note the returned block processor can be either full or incremental depending on the strategy
 	// When node is syncing, it will be a full block processor
 	// When node is already synced, it will be an incremental block processor
-	blockProcessor := blockprocessor.NewBlockProcessor(s.blockProcessStrategyManager, s.client, s.poolExtractor, s.keepers, s.nodeStatusChecker, s.blockUpdatesProcessUtils)
+	blockProcessor := blockprocessor.NewBlockProcessor(s.blockProcessStrategyManager, s.client, s.poolExtractor, s.keepers, s.nodeStatusChecker, s.blockUpdatesProcessUtils, s.checkpointer, s.poolsTransformer)
 
 	// Process block.
-	if err := blockProcessor.ProcessBlock(sdkCtx); err != nil {
+	if err := s.endBlock(sdkCtx, blockProcessor.ProcessBlock(sdkCtx)); err != nil {
 		// In the case of full block processor, if any error is returned, including node is syncing or sync check fails,
 		// data is not marked as ingested and will be retried in the next block
+		s.recordStatus(sdkCtx, err)
 		return err
 	}
 
@@ -429,9 +581,94 @@ func (s *indexerStreamingService) ListenCommit(ctx context.Context, res abci.Res
 		s.blockProcessStrategyManager.MarkInitialDataIngested()
 	}
 
+	s.recordStatus(sdkCtx, nil)
+
 	return nil
 }
 
+// endBlock commits the data published for the block if the publisher writes blocks atomically.
+// If publishing the block or committing it failed, the data of the block is rolled back
+// and all pools are published in the next block. Returns the error of the block, if any.
+func (s *indexerStreamingService) endBlock(ctx context.Context, err error) error {
+	committer, ok := s.client.(domain.BlockCommitter)
+	if !ok {
+		return err
+	}
+
+	if err == nil && s.finalizeBlockErr == nil {
+		if err = committer.CommitBlock(ctx); err == nil {
+			return nil
+		}
+	}
+
+	if rollbackErr := committer.RollbackBlock(); rollbackErr != nil {
+		s.logger.Error("Error rolling back block data by indexer", "err", rollbackErr)
+	}
+
+	// The pool data of the block was discarded.
+	s.blockProcessStrategyManager.MarkErrorObserved()
+
+	if err == nil {
+		err = errors.New("block data rolled back since publishing in ListenFinalizeBlock failed")
+	}
+
+	return err
+}
+
+// recordPausedStatus records that the block was skipped while paused if the status recorder is enabled.
+// The error of ListenFinalizeBlock, if published before pausing, is discarded along with the block data.
+func (s *indexerStreamingService) recordPausedStatus(ctx sdk.Context) {
+	s.finalizeBlockErr = nil
+
+	if s.statusRecorder == nil {
+		return
+	}
+
+	s.statusRecorder.RecordBlock(commondomain.BlockStatus{
+		Height:              uint64(ctx.BlockHeight()),
+		LastPushedHeight:    s.lastPublishedHeight.Load(),
+		IsNextBlockFullPush: s.blockProcessStrategyManager.ShouldPushAllData(),
+		IsPaused:            true,
+	})
+}
+
+// recordStatus records the outcome of publishing the block if the status recorder is enabled.
+// The block fails if either ListenFinalizeBlock or ListenCommit failed.
+// The error of ListenFinalizeBlock is reset for the next block.
+func (s *indexerStreamingService) recordStatus(ctx sdk.Context, err error) {
+	if s.finalizeBlockErr != nil {
+		err = errors.Join(s.finalizeBlockErr, err)
+		s.finalizeBlockErr = nil
+	}
+
+	height := uint64(ctx.BlockHeight())
+	if err == nil {
+		s.lastPublishedHeight.Store(height)
+	}
+
+	if s.statusRecorder == nil {
+		return
+	}
+
+	blockStatus := commondomain.BlockStatus{
+		Height:              height,
+		LastPushedHeight:    s.lastPublishedHeight.Load(),
+		Err:                 err,
+		IsNextBlockFullPush: s.blockProcessStrategyManager.ShouldPushAllData(),
+	}
+
+	// The published bytes are popped regardless of the outcome so that
+	// they are not attributed to the next block.
+	if counter, ok := s.client.(domain.PublishedBytesCounter); ok {
+		publishedBytes := counter.PopPublishedBytes()
+		if err == nil {
+			blockStatus.PayloadSizeBytes = publishedBytes
+		}
+	}
+
+	s.statusRecorder.RecordBlock(blockStatus)
+}
+
 // Stream implements baseapp.StreamingService.
 func (s *indexerStreamingService) Stream(wg *sync.WaitGroup) error {
 	return nil
@@ -445,6 +682,51 @@ func deepCloneEvent(event *abci.Event) *abci.Event {
 	return &clone
 }
 
+// newTransactionMessages returns the type URLs and proto3 JSON bodies of the messages of a transaction.
+func newTransactionMessages(msgs []sdk.Msg) ([]domain.TransactionMessage, error) {
+	messages := make([]domain.TransactionMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		body, err := codec.ProtoMarshalJSON(msg, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal message %s: %w", sdk.MsgTypeURL(msg), err)
+		}
+
+		messages = append(messages, domain.TransactionMessage{
+			TypeURL: sdk.MsgTypeURL(msg),
+			Body:    body,
+		})
+	}
+	return messages, nil
+}
+
+// txSigners returns the bech32 addresses of the signers of a transaction.
+// Returns nil if the transaction does not expose its signers.
+func txSigners(tx sdk.Tx) ([]string, error) {
+	sigTx, ok := tx.(interface{ GetSigners() ([][]byte, error) })
+	if !ok {
+		return nil, nil
+	}
+
+	signerBytes, err := sigTx.GetSigners()
+	if err != nil {
+		return nil, err
+	}
+
+	signers := make([]string, 0, len(signerBytes))
+	for _, signer := range signerBytes {
+		signers = append(signers, sdk.AccAddress(signer).String())
+	}
+	return signers, nil
+}
+
+// accAddressString returns the bech32 address, empty if the address is empty.
+func accAddressString(address []byte) string {
+	if len(address) == 0 {
+		return ""
+	}
+	return sdk.AccAddress(address).String()
+}
+
 // trackCreatedPoolID tracks the created pool ID.
 // If the pool ID is not found in the event attributes, it logs an error.
 // If the pool ID is found, it parses the pool ID to uint64 and tracks it.
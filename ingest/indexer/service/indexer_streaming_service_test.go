@@ -1,6 +1,8 @@
 package service_test
 
 import (
+	"context"
+	"errors"
 	"strconv"
 	"testing"
 	"time"
@@ -9,6 +11,7 @@ import (
 	storetypes "cosmossdk.io/store/types"
 	abcitypes "github.com/cometbft/cometbft/abci/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/exp/rand"
 
@@ -27,6 +30,7 @@ import (
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
 	commonmocks "github.com/osmosis-labs/osmosis/v30/ingest/common/domain/mocks"
 	"github.com/osmosis-labs/osmosis/v30/ingest/common/pooltracker"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/status"
 )
 
 var (
@@ -38,6 +42,7 @@ var (
 	defaultUnfoundTokenIn    = "0"
 	defaultTokenInAmount     = 1000000000
 	liquidityAttributePrefix = "liquidity_"
+	defaultEventFilter, _    = indexerdomain.ParseEventFilter(indexerdomain.DefaultEventAllowlist)
 )
 
 type IndexerServiceTestSuite struct {
@@ -189,9 +194,13 @@ func (s *IndexerServiceTestSuite) TestAdjustTokenInAmountBySpreadFactor() {
 				emptyStoreKeyMap,
 				poolExtractorMock,
 				emptyPoolTracker,
+				nil,
 				keepers,
 				txDecoder,
+				defaultEventFilter,
 				nodeStatusCheckerMock,
+				nil,
+				nil,
 				logger)
 
 			// Create the event based on the test cases attributes
@@ -381,9 +390,13 @@ func (s *IndexerServiceTestSuite) TestAddTokenLiquidity() {
 				emptyStoreKeyMap,
 				poolExtractorMock,
 				emptyPoolTracker,
+				nil,
 				keepers,
 				txDecoder,
+				defaultEventFilter,
 				nodeStatusCheckerMock,
+				nil,
+				nil,
 				logger)
 
 			// Create the event based on the test cases attributes
@@ -536,9 +549,13 @@ func (s *IndexerServiceTestSuite) TestSetSpotPrice() {
 				emptyStoreKeyMap,
 				poolExtractorMock,
 				emptyPoolTracker,
+				nil,
 				keepers,
 				txDecoder,
+				defaultEventFilter,
 				nodeStatusCheckerMock,
+				nil,
+				nil,
 				logger)
 
 			// Create the event based on the test cases attributes
@@ -738,9 +755,13 @@ func (s *IndexerServiceTestSuite) TestTrackCreatedPoolID() {
 				emptyStoreKeyMap,
 				poolExtractorMock,
 				poolTracker,
+				nil,
 				keepers,
 				txDecoder,
+				defaultEventFilter,
 				nodeStatusCheckerMock,
+				nil,
+				nil,
 				logger)
 
 			// Create the event based on the test cases attributes
@@ -784,6 +805,216 @@ func (s *IndexerServiceTestSuite) TestTrackCreatedPoolID() {
 	}
 }
 
+// TestRecordStatus validates that the outcome of publishing every block is recorded for the status server
+// and that a failure in ListenFinalizeBlock fails the block.
+func (s *IndexerServiceTestSuite) TestRecordStatus() {
+	s.Setup()
+
+	const blockHeight = 10
+
+	registry := status.NewRegistry(status.DefaultConfig.StatusMaxLagBlocks, status.DefaultConfig.StatusMaxConsecutiveFailures)
+
+	publisherMock := &indexermocks.PublisherMock{
+		ForceBlockError: errors.New("mock block error"),
+	}
+
+	indexerStreamingService := indexerservice.New(
+		&sqsmocks.BlockUpdateProcessUtilsMock{},
+		commondomain.NewBlockProcessStrategyManager(),
+		publisherMock,
+		emptyStoreKeyMap,
+		&sqsmocks.PoolsExtractorMock{},
+		pooltracker.NewMemory(),
+		nil,
+		indexerdomain.Keepers{PoolManagerKeeper: s.App.PoolManagerKeeper},
+		s.App.GetTxConfig().TxDecoder(),
+		defaultEventFilter,
+		&commonmocks.NodeStatusCheckerMock{},
+		nil,
+		registry.Sink("indexer"),
+		s.App.Logger())
+
+	// Failure in ListenFinalizeBlock.
+	ctx := s.Ctx.WithBlockHeight(blockHeight)
+	err := indexerStreamingService.ListenFinalizeBlock(ctx, abcitypes.RequestFinalizeBlock{Height: blockHeight}, abcitypes.ResponseFinalizeBlock{})
+	s.Require().Error(err)
+	publisherMock.PublishedBytes = 50
+
+	// System under test.
+	indexerStreamingService.RecordStatus(ctx, nil)
+
+	actualStatus := registry.Statuses()[0]
+	s.Require().Equal(uint64(blockHeight), actualStatus.Height)
+	s.Require().Zero(actualStatus.LastPushedHeight)
+	s.Require().Equal(uint64(1), actualStatus.ConsecutiveFailures)
+	s.Require().Equal("mock block error", actualStatus.LastError)
+	s.Require().True(actualStatus.IsNextBlockFullPush)
+	s.Require().False(actualStatus.IsReady)
+	// The bytes of the failed block are discarded.
+	s.Require().Zero(publisherMock.PublishedBytes)
+	s.Require().Zero(actualStatus.AvgPayloadSizeBytes)
+
+	// Success in the next block.
+	publisherMock.PublishedBytes = 200
+
+	// System under test.
+	indexerStreamingService.RecordStatus(ctx.WithBlockHeight(blockHeight+1), nil)
+
+	actualStatus = registry.Statuses()[0]
+	s.Require().Equal(uint64(blockHeight+1), actualStatus.LastPushedHeight)
+	s.Require().Zero(actualStatus.ConsecutiveFailures)
+	s.Require().Equal(uint64(200), actualStatus.AvgPayloadSizeBytes)
+	s.Require().True(actualStatus.IsReady)
+}
+
+// TestEndBlock validates that the data of a block is committed if the block is published successfully
+// and rolled back otherwise, with all pools published in the next block.
+func (s *IndexerServiceTestSuite) TestEndBlock() {
+	const blockHeight = 10
+
+	mockError := errors.New("mock error")
+
+	tests := []struct {
+		name string
+
+		finalizeBlockErr  error
+		processBlockErr   error
+		forceCommitErr    error
+		expectedCommits   int
+		expectedRollbacks int
+		expectError       bool
+	}{
+		{
+			name:            "committed",
+			expectedCommits: 1,
+		},
+		{
+			name:              "process block failure",
+			processBlockErr:   mockError,
+			expectedRollbacks: 1,
+			expectError:       true,
+		},
+		{
+			name:              "ListenFinalizeBlock failure",
+			finalizeBlockErr:  mockError,
+			expectedRollbacks: 1,
+			expectError:       true,
+		},
+		{
+			name:              "commit failure",
+			forceCommitErr:    mockError,
+			expectedCommits:   1,
+			expectedRollbacks: 1,
+			expectError:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.Setup()
+
+			publisherMock := &indexermocks.BlockCommitterPublisherMock{
+				PublisherMock:         indexermocks.PublisherMock{ForceBlockError: tc.finalizeBlockErr},
+				ForceCommitBlockError: tc.forceCommitErr,
+			}
+
+			blockProcessStrategyManager := commondomain.NewBlockProcessStrategyManager()
+			blockProcessStrategyManager.MarkInitialDataIngested()
+
+			indexerStreamingService := indexerservice.New(
+				&sqsmocks.BlockUpdateProcessUtilsMock{},
+				blockProcessStrategyManager,
+				publisherMock,
+				emptyStoreKeyMap,
+				&sqsmocks.PoolsExtractorMock{},
+				pooltracker.NewMemory(),
+				nil,
+				indexerdomain.Keepers{PoolManagerKeeper: s.App.PoolManagerKeeper},
+				s.App.GetTxConfig().TxDecoder(),
+				defaultEventFilter,
+				&commonmocks.NodeStatusCheckerMock{},
+				nil,
+				nil,
+				s.App.Logger())
+
+			ctx := s.Ctx.WithBlockHeight(blockHeight)
+			err := indexerStreamingService.ListenFinalizeBlock(ctx, abcitypes.RequestFinalizeBlock{Height: blockHeight}, abcitypes.ResponseFinalizeBlock{})
+			s.Require().Equal(tc.finalizeBlockErr, err)
+
+			// System under test.
+			err = indexerStreamingService.EndBlock(ctx, tc.processBlockErr)
+
+			if tc.expectError {
+				s.Require().Error(err)
+			} else {
+				s.Require().NoError(err)
+			}
+			s.Require().Equal(tc.expectedCommits, publisherMock.NumCommitBlockCalls)
+			s.Require().Equal(tc.expectedRollbacks, publisherMock.NumRollbackBlockCalls)
+			// All pools are published in the next block if the block was rolled back.
+			s.Require().Equal(tc.expectedRollbacks > 0, blockProcessStrategyManager.ShouldPushAllData())
+		})
+	}
+}
+
+// TestShutdown validates that the data of a block published in ListenFinalizeBlock but not committed
+// is rolled back on shutdown, that the publisher is closed once and that no block is published afterwards.
+func (s *IndexerServiceTestSuite) TestShutdown() {
+	s.Setup()
+
+	const blockHeight = 10
+
+	publisherMock := &indexermocks.BlockCommitterPublisherMock{}
+
+	blockProcessStrategyManager := commondomain.NewBlockProcessStrategyManager()
+	blockProcessStrategyManager.MarkInitialDataIngested()
+
+	indexerStreamingService := indexerservice.New(
+		&sqsmocks.BlockUpdateProcessUtilsMock{},
+		blockProcessStrategyManager,
+		publisherMock,
+		emptyStoreKeyMap,
+		&sqsmocks.PoolsExtractorMock{},
+		pooltracker.NewMemory(),
+		nil,
+		indexerdomain.Keepers{PoolManagerKeeper: s.App.PoolManagerKeeper},
+		s.App.GetTxConfig().TxDecoder(),
+		defaultEventFilter,
+		&commonmocks.NodeStatusCheckerMock{},
+		nil,
+		nil,
+		s.App.Logger())
+
+	ctx := s.Ctx.WithBlockHeight(blockHeight)
+
+	// The previous block was fully published.
+	indexerStreamingService.RecordStatus(ctx.WithBlockHeight(blockHeight-1), nil)
+
+	err := indexerStreamingService.ListenFinalizeBlock(ctx, abcitypes.RequestFinalizeBlock{Height: blockHeight}, abcitypes.ResponseFinalizeBlock{})
+	s.Require().NoError(err)
+	s.Require().Equal(1, publisherMock.NumPublishBlockCalls)
+
+	// System under test.
+	err = indexerStreamingService.Shutdown(context.Background())
+	s.Require().NoError(err)
+
+	s.Require().Equal(1, publisherMock.NumRollbackBlockCalls)
+	s.Require().Equal(1, publisherMock.NumCloseCalls)
+	s.Require().Equal(uint64(blockHeight-1), indexerStreamingService.LastPublishedHeight())
+
+	// No block is published once shut down.
+	err = indexerStreamingService.ListenCommit(ctx, abcitypes.ResponseCommit{}, nil)
+	s.Require().NoError(err)
+	err = indexerStreamingService.ListenFinalizeBlock(ctx.WithBlockHeight(blockHeight+1), abcitypes.RequestFinalizeBlock{Height: blockHeight + 1}, abcitypes.ResponseFinalizeBlock{})
+	s.Require().NoError(err)
+	s.Require().Equal(1, publisherMock.NumPublishBlockCalls)
+	s.Require().Zero(publisherMock.NumCommitBlockCalls)
+
+	// Closing again does not close the publisher again.
+	s.Require().NoError(indexerStreamingService.Close())
+	s.Require().Equal(1, publisherMock.NumCloseCalls)
+}
+
 // checkIfLiquidityAttributeExists checks if the liquidity attribute exists in the event attributes
 // as they should be appended by the AddTokenLiquidity method in the indexer streaming service.
 // i.e. "liquidity_{denom}" must exist in the event.Attributes where {denom} is the pool denoms
@@ -816,3 +1047,159 @@ func checkIfSpotPriceAttributeExists(event abcitypes.Event) bool {
 	}
 	return false
 }
+
+// TestPublishTxn validates that all the messages, the signers, the memo and the fee payer are published
+// and that failed transactions are published with their result.
+func (s *IndexerServiceTestSuite) TestPublishTxn() {
+	s.Setup()
+
+	sender, recipient, granter := s.TestAccs[0], s.TestAccs[1], s.TestAccs[2]
+
+	txBuilder := s.App.GetTxConfig().NewTxBuilder()
+	s.Require().NoError(txBuilder.SetMsgs(
+		banktypes.NewMsgSend(sender, recipient, sdk.NewCoins(sdk.NewInt64Coin("uosmo", 1))),
+		banktypes.NewMsgSend(sender, granter, sdk.NewCoins(sdk.NewInt64Coin("uosmo", 2))),
+	))
+	txBuilder.SetMemo("memo")
+	txBuilder.SetTimeoutHeight(100)
+	txBuilder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin("uosmo", 1000)))
+	txBuilder.SetFeeGranter(granter)
+
+	txBytes, err := s.App.GetTxConfig().TxEncoder()(txBuilder.GetTx())
+	s.Require().NoError(err)
+
+	testCases := []struct {
+		name     string
+		txResult abcitypes.ExecTxResult
+	}{
+		{
+			name:     "success",
+			txResult: abcitypes.ExecTxResult{},
+		},
+		{
+			name:     "failed",
+			txResult: abcitypes.ExecTxResult{Code: 5, Codespace: "sdk", Log: "insufficient funds"},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			publisherMock := &indexermocks.PublisherMock{}
+
+			indexerStreamingService := indexerservice.New(
+				&sqsmocks.BlockUpdateProcessUtilsMock{},
+				commondomain.NewBlockProcessStrategyManager(),
+				publisherMock,
+				emptyStoreKeyMap,
+				&sqsmocks.PoolsExtractorMock{},
+				pooltracker.NewMemory(),
+				nil,
+				indexerdomain.Keepers{PoolManagerKeeper: s.App.PoolManagerKeeper},
+				s.App.GetTxConfig().TxDecoder(),
+				defaultEventFilter,
+				&commonmocks.NodeStatusCheckerMock{},
+				nil,
+				nil,
+				s.App.Logger())
+
+			// System under test.
+			err := indexerStreamingService.PublishTxn(s.Ctx, abcitypes.RequestFinalizeBlock{Txs: [][]byte{txBytes}}, abcitypes.ResponseFinalizeBlock{TxResults: []*abcitypes.ExecTxResult{&tc.txResult}})
+			s.Require().NoError(err)
+
+			txn := publisherMock.CalledWithTransaction
+			s.Require().Equal("cosmos.bank.v1beta1.MsgSend", txn.MessageType)
+			s.Require().Len(txn.Messages, 2)
+			s.Require().Equal("/cosmos.bank.v1beta1.MsgSend", txn.Messages[1].TypeURL)
+			s.Require().Contains(string(txn.Messages[1].Body), granter.String())
+			s.Require().Equal([]string{sender.String()}, txn.Signers)
+			s.Require().Equal("memo", txn.Memo)
+			s.Require().Equal(uint64(100), txn.TimeoutHeight)
+			s.Require().Equal(sender.String(), txn.FeePayer)
+			s.Require().Equal(granter.String(), txn.FeeGranter)
+
+			if tc.txResult.IsErr() {
+				s.Require().Equal(indexerdomain.TransactionStatusFailed, txn.Status)
+			} else {
+				s.Require().Equal(indexerdomain.TransactionStatusSuccess, txn.Status)
+			}
+			s.Require().Equal(tc.txResult.Code, txn.Code)
+			s.Require().Equal(tc.txResult.Codespace, txn.Codespace)
+			s.Require().Equal(tc.txResult.Log, txn.Log)
+		})
+	}
+}
+
+// Validates that only the events selected by the event filter are published, enriched by the registered enrichers,
+// and that an event whose optional enricher failed is skipped.
+func (s *IndexerServiceTestSuite) TestPublishTxn_EventFilter() {
+	s.Setup()
+
+	indexerservice.RegisterEventEnricher(indexerservice.EventEnricher{
+		Name:      "test_wasm",
+		EventType: "wasm",
+		Enrich: func(ctx context.Context, keepers indexerdomain.Keepers, event *abcitypes.Event) error {
+			event.Attributes = append(event.Attributes, abcitypes.EventAttribute{Key: "enriched", Value: "true"})
+			return nil
+		},
+	})
+	indexerservice.RegisterEventEnricher(indexerservice.EventEnricher{
+		Name:      "test_transfer",
+		EventType: "transfer",
+		Enrich: func(ctx context.Context, keepers indexerdomain.Keepers, event *abcitypes.Event) error {
+			return errors.New("enrichment failed")
+		},
+	})
+
+	// Registering an enricher with the same name panics.
+	s.Require().Panics(func() {
+		indexerservice.RegisterEventEnricher(indexerservice.EventEnricher{Name: "test_wasm"})
+	})
+
+	eventFilter, err := indexerdomain.ParseEventFilter("transfer,wasm[action=swap]")
+	s.Require().NoError(err)
+
+	publisherMock := &indexermocks.PublisherMock{}
+
+	indexerStreamingService := indexerservice.New(
+		&sqsmocks.BlockUpdateProcessUtilsMock{},
+		commondomain.NewBlockProcessStrategyManager(),
+		publisherMock,
+		emptyStoreKeyMap,
+		&sqsmocks.PoolsExtractorMock{},
+		pooltracker.NewMemory(),
+		nil,
+		indexerdomain.Keepers{PoolManagerKeeper: s.App.PoolManagerKeeper},
+		s.App.GetTxConfig().TxDecoder(),
+		eventFilter,
+		&commonmocks.NodeStatusCheckerMock{},
+		nil,
+		nil,
+		s.App.Logger())
+
+	txBuilder := s.App.GetTxConfig().NewTxBuilder()
+	s.Require().NoError(txBuilder.SetMsgs(banktypes.NewMsgSend(s.TestAccs[0], s.TestAccs[1], sdk.NewCoins(sdk.NewInt64Coin("uosmo", 1)))))
+
+	txBytes, err := s.App.GetTxConfig().TxEncoder()(txBuilder.GetTx())
+	s.Require().NoError(err)
+
+	txResult := abcitypes.ExecTxResult{
+		Events: []abcitypes.Event{
+			{Type: "transfer"},
+			{Type: "wasm", Attributes: []abcitypes.EventAttribute{{Key: "action", Value: "swap"}}},
+			{Type: "wasm", Attributes: []abcitypes.EventAttribute{{Key: "action", Value: "claim"}}},
+			{Type: "message"},
+		},
+	}
+
+	// System under test.
+	err = indexerStreamingService.PublishTxn(s.Ctx, abcitypes.RequestFinalizeBlock{Txs: [][]byte{txBytes}}, abcitypes.ResponseFinalizeBlock{TxResults: []*abcitypes.ExecTxResult{&txResult}})
+	s.Require().NoError(err)
+
+	events := publisherMock.CalledWithTransaction.Events
+	s.Require().Len(events, 1)
+	s.Require().Equal(1, events[0].Index)
+	s.Require().Equal([]abcitypes.EventAttribute{{Key: "action", Value: "swap"}, {Key: "enriched", Value: "true"}}, events[0].Event.Attributes)
+
+	// The published events are copies of the events of the transaction result.
+	s.Require().Len(txResult.Events[1].Attributes, 1)
+}
@@ -0,0 +1,37 @@
+package quote
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+)
+
+// cfmmPool is the subset of the balancer and stableswap pool methods used for quoting.
+// The pool math does not depend on the context.
+type cfmmPool interface {
+	CalcOutAmtGivenIn(ctx sdk.Context, tokenIn sdk.Coins, tokenOutDenom string, spreadFactor osmomath.Dec) (sdk.Coin, error)
+	CalcInAmtGivenOut(ctx sdk.Context, tokenOut sdk.Coins, tokenInDenom string, spreadFactor osmomath.Dec) (sdk.Coin, error)
+}
+
+// calcCFMMOutAmtGivenIn returns the amount of token out for the given token in
+// using the pool math of the chain model.
+func calcCFMMOutAmtGivenIn(pool ingesttypes.PoolI, tokenIn sdk.Coin, tokenOutDenom string) (sdk.Coin, error) {
+	chainPool, ok := pool.GetUnderlyingPool().(cfmmPool)
+	if !ok {
+		return sdk.Coin{}, UnsupportedPoolError{PoolId: pool.GetId(), PoolType: pool.GetType()}
+	}
+
+	return chainPool.CalcOutAmtGivenIn(sdk.Context{}, sdk.NewCoins(tokenIn), tokenOutDenom, pool.GetSQSPoolModel().SpreadFactor)
+}
+
+// calcCFMMInAmtGivenOut returns the amount of token in for the given token out
+// using the pool math of the chain model.
+func calcCFMMInAmtGivenOut(pool ingesttypes.PoolI, tokenOut sdk.Coin, tokenInDenom string) (sdk.Coin, error) {
+	chainPool, ok := pool.GetUnderlyingPool().(cfmmPool)
+	if !ok {
+		return sdk.Coin{}, UnsupportedPoolError{PoolId: pool.GetId(), PoolType: pool.GetType()}
+	}
+
+	return chainPool.CalcInAmtGivenOut(sdk.Context{}, sdk.NewCoins(tokenOut), tokenInDenom, pool.GetSQSPoolModel().SpreadFactor)
+}
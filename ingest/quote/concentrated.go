@@ -0,0 +1,149 @@
+package quote
+
+import (
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+
+	clmath "github.com/osmosis-labs/osmosis/v30/x/concentrated-liquidity/math"
+	"github.com/osmosis-labs/osmosis/v30/x/concentrated-liquidity/swapstrategy"
+	concentratedtypes "github.com/osmosis-labs/osmosis/v30/x/concentrated-liquidity/types"
+)
+
+// calcConcentratedOutAmtGivenIn returns the amount of token out for the given token in.
+// It swaps through the liquidity ranges of the tick model starting from the current one
+// with the same swap strategy as the chain.
+func calcConcentratedOutAmtGivenIn(pool ingesttypes.PoolI, tokenIn sdk.Coin, tokenOutDenom string) (sdk.Coin, error) {
+	concentratedPool, tickModel, err := getConcentratedPoolAndTickModel(pool, tokenIn.Denom, tokenOutDenom)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	isZeroForOne := tokenIn.Denom == concentratedPool.GetToken0()
+	swapStrategy := swapstrategy.New(isZeroForOne, osmomath.ZeroBigDec(), &storetypes.KVStoreKey{}, pool.GetSQSPoolModel().SpreadFactor)
+
+	var (
+		currentTickIndex    = tickModel.CurrentTickIndex
+		currentSqrtPrice    = concentratedPool.GetCurrentSqrtPrice()
+		amountRemainingIn   = tokenIn.Amount.ToLegacyDec()
+		amountOutCalculated = osmomath.ZeroDec()
+	)
+
+	for amountRemainingIn.IsPositive() {
+		if currentTickIndex < 0 || currentTickIndex >= int64(len(tickModel.Ticks)) {
+			return sdk.Coin{}, NotEnoughLiquidityError{PoolId: pool.GetId(), Denom: tokenOutDenom}
+		}
+
+		currentRange := tickModel.Ticks[currentTickIndex]
+
+		sqrtPriceTarget, err := getSqrtPriceTarget(swapStrategy, currentRange, isZeroForOne)
+		if err != nil {
+			return sdk.Coin{}, err
+		}
+
+		sqrtPriceNext, amountIn, amountOut, spreadRewardCharge := swapStrategy.ComputeSwapWithinBucketOutGivenIn(currentSqrtPrice, sqrtPriceTarget, currentRange.LiquidityAmount, amountRemainingIn)
+
+		amountRemainingIn = amountRemainingIn.Sub(amountIn).Sub(spreadRewardCharge)
+		amountOutCalculated = amountOutCalculated.Add(amountOut)
+		currentSqrtPrice = sqrtPriceNext
+
+		currentTickIndex = nextTickIndex(currentTickIndex, isZeroForOne)
+	}
+
+	return sdk.Coin{Denom: tokenOutDenom, Amount: amountOutCalculated.TruncateInt()}, nil
+}
+
+// calcConcentratedInAmtGivenOut returns the amount of token in for the given token out.
+// It is the exact out counterpart of calcConcentratedOutAmtGivenIn.
+func calcConcentratedInAmtGivenOut(pool ingesttypes.PoolI, tokenOut sdk.Coin, tokenInDenom string) (sdk.Coin, error) {
+	concentratedPool, tickModel, err := getConcentratedPoolAndTickModel(pool, tokenInDenom, tokenOut.Denom)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	isZeroForOne := tokenInDenom == concentratedPool.GetToken0()
+	swapStrategy := swapstrategy.New(isZeroForOne, osmomath.ZeroBigDec(), &storetypes.KVStoreKey{}, pool.GetSQSPoolModel().SpreadFactor)
+
+	var (
+		currentTickIndex   = tickModel.CurrentTickIndex
+		currentSqrtPrice   = concentratedPool.GetCurrentSqrtPrice()
+		amountRemainingOut = tokenOut.Amount.ToLegacyDec()
+		amountInCalculated = osmomath.ZeroDec()
+	)
+
+	for amountRemainingOut.IsPositive() {
+		if currentTickIndex < 0 || currentTickIndex >= int64(len(tickModel.Ticks)) {
+			return sdk.Coin{}, NotEnoughLiquidityError{PoolId: pool.GetId(), Denom: tokenOut.Denom}
+		}
+
+		currentRange := tickModel.Ticks[currentTickIndex]
+
+		sqrtPriceTarget, err := getSqrtPriceTarget(swapStrategy, currentRange, isZeroForOne)
+		if err != nil {
+			return sdk.Coin{}, err
+		}
+
+		sqrtPriceNext, amountOut, amountIn, spreadRewardCharge := swapStrategy.ComputeSwapWithinBucketInGivenOut(currentSqrtPrice, sqrtPriceTarget, currentRange.LiquidityAmount, amountRemainingOut)
+
+		amountRemainingOut = amountRemainingOut.Sub(amountOut)
+		amountInCalculated = amountInCalculated.Add(amountIn).Add(spreadRewardCharge)
+		currentSqrtPrice = sqrtPriceNext
+
+		currentTickIndex = nextTickIndex(currentTickIndex, isZeroForOne)
+	}
+
+	return sdk.Coin{Denom: tokenInDenom, Amount: amountInCalculated.Ceil().TruncateInt()}, nil
+}
+
+// getConcentratedPoolAndTickModel returns the chain model and the tick model of the given concentrated pool.
+// Returns error if the pool does not contain both denoms or if it has no liquidity.
+func getConcentratedPoolAndTickModel(pool ingesttypes.PoolI, tokenInDenom, tokenOutDenom string) (concentratedtypes.ConcentratedPoolExtension, *ingesttypes.TickModel, error) {
+	concentratedPool, ok := pool.GetUnderlyingPool().(concentratedtypes.ConcentratedPoolExtension)
+	if !ok {
+		return nil, nil, UnsupportedPoolError{PoolId: pool.GetId(), PoolType: pool.GetType()}
+	}
+
+	for _, denom := range []string{tokenInDenom, tokenOutDenom} {
+		if denom != concentratedPool.GetToken0() && denom != concentratedPool.GetToken1() {
+			return nil, nil, DenomNotInPoolError{PoolId: pool.GetId(), Denom: denom}
+		}
+	}
+
+	tickModel, err := pool.GetTickModel()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if tickModel.HasNoLiquidity {
+		return nil, nil, NotEnoughLiquidityError{PoolId: pool.GetId(), Denom: tokenOutDenom}
+	}
+
+	return concentratedPool, tickModel, nil
+}
+
+// getSqrtPriceTarget returns the sqrt price of the boundary of the given liquidity range
+// towards which the price moves in the swap direction.
+func getSqrtPriceTarget(swapStrategy swapstrategy.SwapStrategy, liquidityRange ingesttypes.LiquidityDepthsWithRange, isZeroForOne bool) (osmomath.BigDec, error) {
+	nextInitializedTick := liquidityRange.UpperTick
+	if isZeroForOne {
+		nextInitializedTick = liquidityRange.LowerTick
+	}
+
+	nextInitializedTickSqrtPrice, err := clmath.TickToSqrtPrice(nextInitializedTick)
+	if err != nil {
+		return osmomath.BigDec{}, err
+	}
+
+	return swapStrategy.GetSqrtTargetPrice(nextInitializedTickSqrtPrice), nil
+}
+
+// nextTickIndex returns the index of the next liquidity range in the swap direction.
+// Swapping token zero for token one decreases the price.
+func nextTickIndex(currentTickIndex int64, isZeroForOne bool) int64 {
+	if isZeroForOne {
+		return currentTickIndex - 1
+	}
+	return currentTickIndex + 1
+}
@@ -0,0 +1,48 @@
+package quote
+
+import (
+	"fmt"
+
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+type PoolNotFoundError struct {
+	PoolId uint64
+}
+
+func (e PoolNotFoundError) Error() string {
+	return fmt.Sprintf("pool (%d) is not found", e.PoolId)
+}
+
+type UnsupportedPoolError struct {
+	PoolId   uint64
+	PoolType poolmanagertypes.PoolType
+}
+
+func (e UnsupportedPoolError) Error() string {
+	return fmt.Sprintf("pool (%d) with type (%d) is not supported for quoting", e.PoolId, e.PoolType)
+}
+
+type DenomNotInPoolError struct {
+	PoolId uint64
+	Denom  string
+}
+
+func (e DenomNotInPoolError) Error() string {
+	return fmt.Sprintf("denom (%s) is not in pool (%d)", e.Denom, e.PoolId)
+}
+
+type NotEnoughLiquidityError struct {
+	PoolId uint64
+	Denom  string
+}
+
+func (e NotEnoughLiquidityError) Error() string {
+	return fmt.Sprintf("not enough liquidity of denom (%s) in pool (%d) to complete the swap", e.Denom, e.PoolId)
+}
+
+type EmptyRouteError struct{}
+
+func (e EmptyRouteError) Error() string {
+	return "route has no hops"
+}
@@ -0,0 +1,109 @@
+package quote
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	"github.com/osmosis-labs/osmosis/v30/ingest/types/cosmwasmpool"
+
+	clmath "github.com/osmosis-labs/osmosis/v30/x/concentrated-liquidity/math"
+)
+
+// calcOrderbookOutAmtGivenIn returns the amount of token out for the given token in.
+// It fills the orders on the opposite side of the orderbook starting from the best tick.
+func calcOrderbookOutAmtGivenIn(pool ingesttypes.PoolI, orderbookData *cosmwasmpool.OrderbookData, tokenIn sdk.Coin, tokenOutDenom string) (sdk.Coin, error) {
+	direction, err := orderbookData.GetDirection(tokenIn.Denom, tokenOutDenom)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	amountInToExhaustLiquidity := orderbookData.AskAmountToExhaustBidLiquidity
+	if *direction == cosmwasmpool.BID {
+		amountInToExhaustLiquidity = orderbookData.BidAmountToExhaustAskLiquidity
+	}
+
+	amountInRemaining := osmomath.BigDecFromSDKInt(tokenIn.Amount)
+	if amountInRemaining.GT(amountInToExhaustLiquidity) {
+		return sdk.Coin{}, NotEnoughLiquidityError{PoolId: pool.GetId(), Denom: tokenOutDenom}
+	}
+
+	directionOut := direction.Opposite()
+	iterationStep, err := directionOut.IterationStep()
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	tickIndex, err := orderbookData.GetStartTickIndex(directionOut)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	amountOut := osmomath.ZeroBigDec()
+	for amountInRemaining.IsPositive() && tickIndex >= 0 && tickIndex < len(orderbookData.Ticks) {
+		tick := orderbookData.Ticks[tickIndex]
+
+		tickPrice, err := clmath.TickToPrice(tick.TickId)
+		if err != nil {
+			return sdk.Coin{}, err
+		}
+
+		// Convert the remaining amount in to the amount out at the tick price
+		// and fill as much of it as the tick liquidity allows.
+		amountOutNeeded := cosmwasmpool.OrderbookValueInOppositeDirection(amountInRemaining, tickPrice, *direction, cosmwasmpool.ROUND_DOWN)
+		amountOutFilled := tick.TickLiquidity.GetFillableAmount(amountOutNeeded, directionOut)
+		amountInFilled := cosmwasmpool.OrderbookValueInOppositeDirection(amountOutFilled, tickPrice, directionOut, cosmwasmpool.ROUND_UP)
+
+		amountOut = amountOut.Add(amountOutFilled)
+		amountInRemaining = amountInRemaining.Sub(amountInFilled)
+
+		tickIndex += iterationStep
+	}
+
+	return sdk.Coin{Denom: tokenOutDenom, Amount: amountOut.Dec().TruncateInt()}, nil
+}
+
+// calcOrderbookInAmtGivenOut returns the amount of token in for the given token out.
+// It is the exact out counterpart of calcOrderbookOutAmtGivenIn.
+func calcOrderbookInAmtGivenOut(pool ingesttypes.PoolI, orderbookData *cosmwasmpool.OrderbookData, tokenOut sdk.Coin, tokenInDenom string) (sdk.Coin, error) {
+	direction, err := orderbookData.GetDirection(tokenInDenom, tokenOut.Denom)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	directionOut := direction.Opposite()
+	iterationStep, err := directionOut.IterationStep()
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	tickIndex, err := orderbookData.GetStartTickIndex(directionOut)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	amountOutRemaining := osmomath.BigDecFromSDKInt(tokenOut.Amount)
+	amountIn := osmomath.ZeroBigDec()
+	for amountOutRemaining.IsPositive() && tickIndex >= 0 && tickIndex < len(orderbookData.Ticks) {
+		tick := orderbookData.Ticks[tickIndex]
+
+		tickPrice, err := clmath.TickToPrice(tick.TickId)
+		if err != nil {
+			return sdk.Coin{}, err
+		}
+
+		amountOutFilled := tick.TickLiquidity.GetFillableAmount(amountOutRemaining, directionOut)
+		amountInFilled := cosmwasmpool.OrderbookValueInOppositeDirection(amountOutFilled, tickPrice, directionOut, cosmwasmpool.ROUND_UP)
+
+		amountIn = amountIn.Add(amountInFilled)
+		amountOutRemaining = amountOutRemaining.Sub(amountOutFilled)
+
+		tickIndex += iterationStep
+	}
+
+	if amountOutRemaining.IsPositive() {
+		return sdk.Coin{}, NotEnoughLiquidityError{PoolId: pool.GetId(), Denom: tokenOut.Denom}
+	}
+
+	return sdk.Coin{Denom: tokenInDenom, Amount: amountIn.Ceil().Dec().TruncateInt()}, nil
+}
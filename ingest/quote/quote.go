@@ -0,0 +1,219 @@
+// Package quote quotes swaps against the ingest pool models without access to the chain state.
+//
+// It supports balancer, stableswap and concentrated pools as well as the orderbook and
+// alloyed transmuter CosmWasm pools. Quotes are computed per pool and across multi-hop
+// routes, including the taker fees.
+package quote
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+// Quote is the result of quoting a swap across a route.
+type Quote struct {
+	// TokenIn is the amount of token in including the taker fees.
+	TokenIn sdk.Coin
+	// TokenOut is the amount of token out.
+	TokenOut sdk.Coin
+	// Hops are the quotes of every hop of the route in the swap order.
+	Hops []HopQuote
+}
+
+// HopQuote is the result of quoting a single hop of a route.
+type HopQuote struct {
+	PoolId uint64
+	// TokenIn is the amount of token in including the taker fee.
+	TokenIn  sdk.Coin
+	TokenOut sdk.Coin
+	TakerFee sdk.Coin
+}
+
+// Engine quotes swaps across routes of ingest pool models.
+type Engine interface {
+	// QuoteExactAmountIn quotes swapping the given token in across the route.
+	QuoteExactAmountIn(tokenIn sdk.Coin, route []poolmanagertypes.SwapAmountInRoute) (Quote, error)
+	// QuoteExactAmountOut quotes swapping for the given token out across the route.
+	QuoteExactAmountOut(route []poolmanagertypes.SwapAmountOutRoute, tokenOut sdk.Coin) (Quote, error)
+}
+
+// quoteEngine quotes swaps against the ingest pool models.
+// The quotes mirror the on-chain swap math, including the spread factor of every pool
+// and the taker fee of every denom pair. The rate limiters of the CosmWasm pools are not enforced.
+type quoteEngine struct {
+	pools       map[uint64]ingesttypes.PoolI
+	takerFeeMap ingesttypes.TakerFeeMap
+}
+
+var _ Engine = &quoteEngine{}
+
+// New returns a new quote engine over the given pools and taker fees.
+func New(pools []ingesttypes.PoolI, takerFeeMap ingesttypes.TakerFeeMap) Engine {
+	poolsByID := make(map[uint64]ingesttypes.PoolI, len(pools))
+	for _, pool := range pools {
+		poolsByID[pool.GetId()] = pool
+	}
+
+	return &quoteEngine{
+		pools:       poolsByID,
+		takerFeeMap: takerFeeMap,
+	}
+}
+
+// QuoteExactAmountIn quotes swapping the given token in across the route.
+// The taker fee is deducted from the token in of every hop before swapping against the pool.
+// Returns error if any of the pools is not found or fails to quote.
+func (q *quoteEngine) QuoteExactAmountIn(tokenIn sdk.Coin, route []poolmanagertypes.SwapAmountInRoute) (Quote, error) {
+	if len(route) == 0 {
+		return Quote{}, EmptyRouteError{}
+	}
+
+	hops := make([]HopQuote, 0, len(route))
+
+	currentTokenIn := tokenIn
+	for _, hop := range route {
+		pool, ok := q.pools[hop.PoolId]
+		if !ok {
+			return Quote{}, PoolNotFoundError{PoolId: hop.PoolId}
+		}
+
+		takerFee := q.takerFeeMap.GetTakerFee(currentTokenIn.Denom, hop.TokenOutDenom)
+		tokenInAfterTakerFee, takerFeeCoin := CalcTakerFeeExactIn(currentTokenIn, takerFee)
+
+		tokenOut, err := CalcOutAmtGivenIn(pool, tokenInAfterTakerFee, hop.TokenOutDenom)
+		if err != nil {
+			return Quote{}, err
+		}
+
+		hops = append(hops, HopQuote{
+			PoolId:   hop.PoolId,
+			TokenIn:  currentTokenIn,
+			TokenOut: tokenOut,
+			TakerFee: takerFeeCoin,
+		})
+
+		currentTokenIn = tokenOut
+	}
+
+	return Quote{
+		TokenIn:  tokenIn,
+		TokenOut: currentTokenIn,
+		Hops:     hops,
+	}, nil
+}
+
+// QuoteExactAmountOut quotes swapping for the given token out across the route.
+// The hops are quoted in reverse order and the taker fee is added to the token in of every hop.
+// Returns error if any of the pools is not found or fails to quote.
+func (q *quoteEngine) QuoteExactAmountOut(route []poolmanagertypes.SwapAmountOutRoute, tokenOut sdk.Coin) (Quote, error) {
+	if len(route) == 0 {
+		return Quote{}, EmptyRouteError{}
+	}
+
+	hops := make([]HopQuote, len(route))
+
+	currentTokenOut := tokenOut
+	for i := len(route) - 1; i >= 0; i-- {
+		hop := route[i]
+
+		pool, ok := q.pools[hop.PoolId]
+		if !ok {
+			return Quote{}, PoolNotFoundError{PoolId: hop.PoolId}
+		}
+
+		tokenIn, err := CalcInAmtGivenOut(pool, currentTokenOut, hop.TokenInDenom)
+		if err != nil {
+			return Quote{}, err
+		}
+
+		takerFee := q.takerFeeMap.GetTakerFee(hop.TokenInDenom, currentTokenOut.Denom)
+		tokenInAfterTakerFee, takerFeeCoin := CalcTakerFeeExactOut(tokenIn, takerFee)
+
+		hops[i] = HopQuote{
+			PoolId:   hop.PoolId,
+			TokenIn:  tokenInAfterTakerFee,
+			TokenOut: currentTokenOut,
+			TakerFee: takerFeeCoin,
+		}
+
+		currentTokenOut = tokenInAfterTakerFee
+	}
+
+	return Quote{
+		TokenIn:  currentTokenOut,
+		TokenOut: tokenOut,
+		Hops:     hops,
+	}, nil
+}
+
+// CalcOutAmtGivenIn returns the amount of token out for swapping the given token in against the pool.
+// The spread factor is applied while the taker fee is not.
+func CalcOutAmtGivenIn(pool ingesttypes.PoolI, tokenIn sdk.Coin, tokenOutDenom string) (sdk.Coin, error) {
+	switch pool.GetType() {
+	case poolmanagertypes.Balancer, poolmanagertypes.Stableswap:
+		return calcCFMMOutAmtGivenIn(pool, tokenIn, tokenOutDenom)
+	case poolmanagertypes.Concentrated:
+		return calcConcentratedOutAmtGivenIn(pool, tokenIn, tokenOutDenom)
+	case poolmanagertypes.CosmWasm:
+		cosmWasmPoolModel := pool.GetSQSPoolModel().CosmWasmPoolModel
+		if cosmWasmPoolModel == nil {
+			break
+		}
+
+		if cosmWasmPoolModel.IsOrderbook() && cosmWasmPoolModel.Data.Orderbook != nil {
+			return calcOrderbookOutAmtGivenIn(pool, cosmWasmPoolModel.Data.Orderbook, tokenIn, tokenOutDenom)
+		}
+
+		if cosmWasmPoolModel.IsAlloyTransmuter() && cosmWasmPoolModel.Data.AlloyTransmuter != nil {
+			return calcAlloyTransmuterOutAmtGivenIn(pool, cosmWasmPoolModel.Data.AlloyTransmuter, tokenIn, tokenOutDenom)
+		}
+	}
+
+	return sdk.Coin{}, UnsupportedPoolError{PoolId: pool.GetId(), PoolType: pool.GetType()}
+}
+
+// CalcInAmtGivenOut returns the amount of token in for swapping against the pool for the given token out.
+// The spread factor is applied while the taker fee is not.
+func CalcInAmtGivenOut(pool ingesttypes.PoolI, tokenOut sdk.Coin, tokenInDenom string) (sdk.Coin, error) {
+	switch pool.GetType() {
+	case poolmanagertypes.Balancer, poolmanagertypes.Stableswap:
+		return calcCFMMInAmtGivenOut(pool, tokenOut, tokenInDenom)
+	case poolmanagertypes.Concentrated:
+		return calcConcentratedInAmtGivenOut(pool, tokenOut, tokenInDenom)
+	case poolmanagertypes.CosmWasm:
+		cosmWasmPoolModel := pool.GetSQSPoolModel().CosmWasmPoolModel
+		if cosmWasmPoolModel == nil {
+			break
+		}
+
+		if cosmWasmPoolModel.IsOrderbook() && cosmWasmPoolModel.Data.Orderbook != nil {
+			return calcOrderbookInAmtGivenOut(pool, cosmWasmPoolModel.Data.Orderbook, tokenOut, tokenInDenom)
+		}
+
+		if cosmWasmPoolModel.IsAlloyTransmuter() && cosmWasmPoolModel.Data.AlloyTransmuter != nil {
+			return calcAlloyTransmuterInAmtGivenOut(pool, cosmWasmPoolModel.Data.AlloyTransmuter, tokenOut, tokenInDenom)
+		}
+	}
+
+	return sdk.Coin{}, UnsupportedPoolError{PoolId: pool.GetId(), PoolType: pool.GetType()}
+}
+
+// CalcTakerFeeExactIn returns the token in after deducting the taker fee and the taker fee itself.
+// It matches the rounding of the pool manager.
+func CalcTakerFeeExactIn(tokenIn sdk.Coin, takerFee osmomath.Dec) (sdk.Coin, sdk.Coin) {
+	amountInAfterTakerFee := tokenIn.Amount.ToLegacyDec().MulTruncate(osmomath.OneDec().Sub(takerFee)).TruncateInt()
+
+	return sdk.Coin{Denom: tokenIn.Denom, Amount: amountInAfterTakerFee}, sdk.Coin{Denom: tokenIn.Denom, Amount: tokenIn.Amount.Sub(amountInAfterTakerFee)}
+}
+
+// CalcTakerFeeExactOut returns the token in after adding the taker fee and the taker fee itself.
+// It matches the rounding of the pool manager.
+func CalcTakerFeeExactOut(tokenIn sdk.Coin, takerFee osmomath.Dec) (sdk.Coin, sdk.Coin) {
+	amountInAfterTakerFee := tokenIn.Amount.ToLegacyDec().Quo(osmomath.OneDec().Sub(takerFee)).Ceil().TruncateInt()
+
+	return sdk.Coin{Denom: tokenIn.Denom, Amount: amountInAfterTakerFee}, sdk.Coin{Denom: tokenIn.Denom, Amount: amountInAfterTakerFee.Sub(tokenIn.Amount)}
+}
@@ -0,0 +1,437 @@
+package quote_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v30/app/apptesting"
+	"github.com/osmosis-labs/osmosis/v30/ingest/quote"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	"github.com/osmosis-labs/osmosis/v30/ingest/types/cosmwasmpool"
+
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+const (
+	UOSMO = "uosmo"
+
+	alloyedDenom = "alloyed"
+)
+
+var defaultTakerFee = osmomath.MustNewDecFromStr("0.002")
+
+type QuoteTestSuite struct {
+	apptesting.ConcentratedKeeperTestHelper
+}
+
+func TestQuoteTestSuite(t *testing.T) {
+	suite.Run(t, new(QuoteTestSuite))
+}
+
+// testPools are the pools created for quoting against the chain.
+type testPools struct {
+	concentratedPoolID uint64
+	balancerPoolID     uint64
+	stableswapPoolID   uint64
+}
+
+// prepareTestPools creates a concentrated ETH/USDC pool with a full range and a narrow position,
+// a balancer USDC/UOSMO pool and a FOO/BAR/BAZ stableswap pool. Sets a custom taker fee for the ETH/USDC pair.
+func (s *QuoteTestSuite) prepareTestPools() testPools {
+	concentratedPool := s.PrepareConcentratedPoolWithCoinsAndFullRangePosition(apptesting.ETH, apptesting.USDC)
+	s.SetupDefaultPosition(concentratedPool.GetId())
+
+	balancerPoolID := s.PrepareBalancerPoolWithCoins(sdk.NewCoin(apptesting.USDC, osmomath.NewInt(1_000_000_000_000)), sdk.NewCoin(UOSMO, osmomath.NewInt(2_000_000_000_000)))
+
+	stableswapPoolID := s.PrepareBasicStableswapPool()
+
+	s.App.PoolManagerKeeper.SetDenomPairTakerFee(s.Ctx, apptesting.ETH, apptesting.USDC, defaultTakerFee)
+	s.App.PoolManagerKeeper.SetDenomPairTakerFee(s.Ctx, apptesting.USDC, apptesting.ETH, defaultTakerFee)
+
+	return testPools{
+		concentratedPoolID: concentratedPool.GetId(),
+		balancerPoolID:     balancerPoolID,
+		stableswapPoolID:   stableswapPoolID,
+	}
+}
+
+// newQuoteEngine returns a quote engine over the ingest models of the given chain pools
+// with the taker fees of all their denom pairs.
+func (s *QuoteTestSuite) newQuoteEngine(poolIDs ...uint64) quote.Engine {
+	pools := make([]ingesttypes.PoolI, 0, len(poolIDs))
+	takerFeeMap := ingesttypes.TakerFeeMap{}
+
+	for _, poolID := range poolIDs {
+		pool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, poolID)
+		s.Require().NoError(err)
+
+		balances := s.App.BankKeeper.GetAllBalances(s.Ctx, pool.GetAddress())
+
+		ingestPool := ingesttypes.NewPool(pool, pool.GetSpreadFactor(s.Ctx), balances)
+
+		if pool.GetType() == poolmanagertypes.Concentrated {
+			ticks, currentTickIndex, err := s.App.ConcentratedLiquidityKeeper.GetTickLiquidityForFullRange(s.Ctx, poolID)
+			s.Require().NoError(err)
+
+			ingestPool.TickModel = &ingesttypes.TickModel{
+				Ticks:            ticks,
+				CurrentTickIndex: currentTickIndex,
+			}
+		}
+
+		pools = append(pools, ingestPool)
+
+		denoms, err := s.App.PoolManagerKeeper.RouteGetPoolDenoms(s.Ctx, poolID)
+		s.Require().NoError(err)
+
+		for _, denomIn := range denoms {
+			for _, denomOut := range denoms {
+				if denomIn == denomOut {
+					continue
+				}
+
+				takerFee, err := s.App.PoolManagerKeeper.GetTradingPairTakerFee(s.Ctx, denomIn, denomOut)
+				s.Require().NoError(err)
+
+				takerFeeMap.SetTakerFee(denomIn, denomOut, takerFee)
+			}
+		}
+	}
+
+	return quote.New(pools, takerFeeMap)
+}
+
+// This test validates that the exact in quotes match the amounts swapped on chain.
+func (s *QuoteTestSuite) TestQuoteExactAmountIn() {
+	testCases := []struct {
+		name    string
+		tokenIn sdk.Coin
+		route   func(testPools) []poolmanagertypes.SwapAmountInRoute
+	}{
+		{
+			name:    "concentrated - zero for one",
+			tokenIn: sdk.NewCoin(apptesting.ETH, osmomath.NewInt(1_000_000_000_000_000_000)),
+			route: func(p testPools) []poolmanagertypes.SwapAmountInRoute {
+				return []poolmanagertypes.SwapAmountInRoute{{PoolId: p.concentratedPoolID, TokenOutDenom: apptesting.USDC}}
+			},
+		},
+		{
+			name:    "concentrated - one for zero",
+			tokenIn: sdk.NewCoin(apptesting.USDC, osmomath.NewInt(1_000_000_000)),
+			route: func(p testPools) []poolmanagertypes.SwapAmountInRoute {
+				return []poolmanagertypes.SwapAmountInRoute{{PoolId: p.concentratedPoolID, TokenOutDenom: apptesting.ETH}}
+			},
+		},
+		{
+			name:    "balancer",
+			tokenIn: sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000)),
+			route: func(p testPools) []poolmanagertypes.SwapAmountInRoute {
+				return []poolmanagertypes.SwapAmountInRoute{{PoolId: p.balancerPoolID, TokenOutDenom: apptesting.USDC}}
+			},
+		},
+		{
+			name:    "stableswap",
+			tokenIn: sdk.NewCoin(apptesting.FOO, osmomath.NewInt(1_000)),
+			route: func(p testPools) []poolmanagertypes.SwapAmountInRoute {
+				return []poolmanagertypes.SwapAmountInRoute{{PoolId: p.stableswapPoolID, TokenOutDenom: apptesting.BAR}}
+			},
+		},
+		{
+			name:    "balancer to concentrated",
+			tokenIn: sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000)),
+			route: func(p testPools) []poolmanagertypes.SwapAmountInRoute {
+				return []poolmanagertypes.SwapAmountInRoute{
+					{PoolId: p.balancerPoolID, TokenOutDenom: apptesting.USDC},
+					{PoolId: p.concentratedPoolID, TokenOutDenom: apptesting.ETH},
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Setup()
+
+			pools := s.prepareTestPools()
+			route := tc.route(pools)
+
+			quoteEngine := s.newQuoteEngine(pools.concentratedPoolID, pools.balancerPoolID, pools.stableswapPoolID)
+
+			// System under test
+			actualQuote, err := quoteEngine.QuoteExactAmountIn(tc.tokenIn, route)
+			s.Require().NoError(err)
+
+			s.Require().Equal(tc.tokenIn, actualQuote.TokenIn)
+			s.Require().Len(actualQuote.Hops, len(route))
+			s.Require().Equal(route[len(route)-1].TokenOutDenom, actualQuote.TokenOut.Denom)
+
+			// Swap on chain
+			s.FundAcc(s.TestAccs[1], sdk.NewCoins(tc.tokenIn))
+			expectedAmountOut, err := s.App.PoolManagerKeeper.RouteExactAmountIn(s.Ctx, s.TestAccs[1], route, tc.tokenIn, osmomath.OneInt())
+			s.Require().NoError(err)
+
+			s.Require().Equal(expectedAmountOut.String(), actualQuote.TokenOut.Amount.String())
+		})
+	}
+}
+
+// This test validates that the exact out quotes match the amounts swapped on chain.
+func (s *QuoteTestSuite) TestQuoteExactAmountOut() {
+	testCases := []struct {
+		name     string
+		tokenOut sdk.Coin
+		route    func(testPools) []poolmanagertypes.SwapAmountOutRoute
+	}{
+		{
+			name:     "concentrated - zero for one",
+			tokenOut: sdk.NewCoin(apptesting.USDC, osmomath.NewInt(1_000_000_000)),
+			route: func(p testPools) []poolmanagertypes.SwapAmountOutRoute {
+				return []poolmanagertypes.SwapAmountOutRoute{{PoolId: p.concentratedPoolID, TokenInDenom: apptesting.ETH}}
+			},
+		},
+		{
+			name:     "concentrated - one for zero",
+			tokenOut: sdk.NewCoin(apptesting.ETH, osmomath.NewInt(1_000_000)),
+			route: func(p testPools) []poolmanagertypes.SwapAmountOutRoute {
+				return []poolmanagertypes.SwapAmountOutRoute{{PoolId: p.concentratedPoolID, TokenInDenom: apptesting.USDC}}
+			},
+		},
+		{
+			name:     "balancer",
+			tokenOut: sdk.NewCoin(apptesting.USDC, osmomath.NewInt(1_000_000)),
+			route: func(p testPools) []poolmanagertypes.SwapAmountOutRoute {
+				return []poolmanagertypes.SwapAmountOutRoute{{PoolId: p.balancerPoolID, TokenInDenom: UOSMO}}
+			},
+		},
+		{
+			name:     "stableswap",
+			tokenOut: sdk.NewCoin(apptesting.FOO, osmomath.NewInt(1_000)),
+			route: func(p testPools) []poolmanagertypes.SwapAmountOutRoute {
+				return []poolmanagertypes.SwapAmountOutRoute{{PoolId: p.stableswapPoolID, TokenInDenom: apptesting.BAR}}
+			},
+		},
+		{
+			name:     "balancer to concentrated",
+			tokenOut: sdk.NewCoin(apptesting.ETH, osmomath.NewInt(1_000_000)),
+			route: func(p testPools) []poolmanagertypes.SwapAmountOutRoute {
+				return []poolmanagertypes.SwapAmountOutRoute{
+					{PoolId: p.balancerPoolID, TokenInDenom: UOSMO},
+					{PoolId: p.concentratedPoolID, TokenInDenom: apptesting.USDC},
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Setup()
+
+			pools := s.prepareTestPools()
+			route := tc.route(pools)
+
+			quoteEngine := s.newQuoteEngine(pools.concentratedPoolID, pools.balancerPoolID, pools.stableswapPoolID)
+
+			// System under test
+			actualQuote, err := quoteEngine.QuoteExactAmountOut(route, tc.tokenOut)
+			s.Require().NoError(err)
+
+			s.Require().Equal(tc.tokenOut, actualQuote.TokenOut)
+			s.Require().Len(actualQuote.Hops, len(route))
+			s.Require().Equal(route[0].TokenInDenom, actualQuote.TokenIn.Denom)
+
+			// Swap on chain
+			s.FundAcc(s.TestAccs[1], sdk.NewCoins(actualQuote.TokenIn))
+			expectedAmountIn, err := s.App.PoolManagerKeeper.RouteExactAmountOut(s.Ctx, s.TestAccs[1], route, actualQuote.TokenIn.Amount, tc.tokenOut)
+			s.Require().NoError(err)
+
+			s.Require().Equal(expectedAmountIn.String(), actualQuote.TokenIn.Amount.String())
+		})
+	}
+}
+
+// This test validates the quote errors.
+func (s *QuoteTestSuite) TestQuote_Errors() {
+	s.Setup()
+
+	pools := s.prepareTestPools()
+
+	quoteEngine := s.newQuoteEngine(pools.concentratedPoolID)
+
+	// Pool not found
+	_, err := quoteEngine.QuoteExactAmountIn(sdk.NewCoin(apptesting.ETH, osmomath.OneInt()), []poolmanagertypes.SwapAmountInRoute{{PoolId: pools.balancerPoolID, TokenOutDenom: apptesting.USDC}})
+	s.Require().ErrorIs(err, quote.PoolNotFoundError{PoolId: pools.balancerPoolID})
+
+	// Empty route
+	_, err = quoteEngine.QuoteExactAmountOut(nil, sdk.NewCoin(apptesting.ETH, osmomath.OneInt()))
+	s.Require().ErrorIs(err, quote.EmptyRouteError{})
+
+	// Denom not in pool
+	_, err = quoteEngine.QuoteExactAmountIn(sdk.NewCoin(UOSMO, osmomath.OneInt()), []poolmanagertypes.SwapAmountInRoute{{PoolId: pools.concentratedPoolID, TokenOutDenom: apptesting.USDC}})
+	s.Require().ErrorIs(err, quote.DenomNotInPoolError{PoolId: pools.concentratedPoolID, Denom: UOSMO})
+
+	// Not enough liquidity
+	_, err = quoteEngine.QuoteExactAmountOut([]poolmanagertypes.SwapAmountOutRoute{{PoolId: pools.concentratedPoolID, TokenInDenom: apptesting.ETH}}, sdk.NewCoin(apptesting.USDC, osmomath.NewInt(1).MulRaw(1_000_000_000_000_000_000).MulRaw(1_000_000)))
+	s.Require().ErrorIs(err, quote.NotEnoughLiquidityError{PoolId: pools.concentratedPoolID, Denom: apptesting.USDC})
+}
+
+// This test validates the alloyed transmuter quotes against the normalization factors and the pool balances.
+func (s *QuoteTestSuite) TestCalcOutAmtGivenIn_AlloyTransmuter() {
+	s.Setup()
+
+	pools := s.prepareTestPools()
+
+	// The chain model is only used for the ID and the type.
+	chainPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, pools.balancerPoolID)
+	s.Require().NoError(err)
+
+	newTransmuterPool := func(balances sdk.Coins) ingesttypes.PoolI {
+		return &ingesttypes.PoolWrapper{
+			ChainModel: &cosmWasmChainPool{PoolI: chainPool},
+			SQSModel: ingesttypes.SQSPool{
+				Balances: balances,
+				CosmWasmPoolModel: cosmwasmpool.NewCWPoolModel(cosmwasmpool.ALLOY_TRANSMUTER_CONTRACT_NAME, cosmwasmpool.ALLOY_TRANSMUTER_MIN_CONTRACT_VERSION, cosmwasmpool.CosmWasmPoolData{
+					AlloyTransmuter: &cosmwasmpool.AlloyTransmuterData{
+						AlloyedDenom: alloyedDenom,
+						AssetConfigs: []cosmwasmpool.TransmuterAssetConfig{
+							{Denom: apptesting.ETH, NormalizationFactor: osmomath.NewInt(1)},
+							{Denom: apptesting.USDC, NormalizationFactor: osmomath.NewInt(100)},
+							{Denom: alloyedDenom, NormalizationFactor: osmomath.NewInt(10)},
+						},
+					},
+				}),
+			},
+		}
+	}
+
+	transmuterPool := newTransmuterPool(sdk.NewCoins(sdk.NewCoin(apptesting.ETH, osmomath.NewInt(1_000)), sdk.NewCoin(apptesting.USDC, osmomath.NewInt(1_000))))
+
+	testCases := []struct {
+		name          string
+		tokenIn       sdk.Coin
+		tokenOutDenom string
+
+		expectedTokenOut sdk.Coin
+		expectedErr      error
+	}{
+		{
+			name:             "eth to usdc",
+			tokenIn:          sdk.NewCoin(apptesting.ETH, osmomath.NewInt(5)),
+			tokenOutDenom:    apptesting.USDC,
+			expectedTokenOut: sdk.NewCoin(apptesting.USDC, osmomath.NewInt(500)),
+		},
+		{
+			name:             "usdc to eth rounds down",
+			tokenIn:          sdk.NewCoin(apptesting.USDC, osmomath.NewInt(199)),
+			tokenOutDenom:    apptesting.ETH,
+			expectedTokenOut: sdk.NewCoin(apptesting.ETH, osmomath.NewInt(1)),
+		},
+		{
+			name:             "alloyed is minted regardless of the balance",
+			tokenIn:          sdk.NewCoin(apptesting.ETH, osmomath.NewInt(1_000_000)),
+			tokenOutDenom:    alloyedDenom,
+			expectedTokenOut: sdk.NewCoin(alloyedDenom, osmomath.NewInt(10_000_000)),
+		},
+		{
+			name:          "not enough balance",
+			tokenIn:       sdk.NewCoin(apptesting.ETH, osmomath.NewInt(11)),
+			tokenOutDenom: apptesting.USDC,
+			expectedErr:   quote.NotEnoughLiquidityError{PoolId: chainPool.GetId(), Denom: apptesting.USDC},
+		},
+		{
+			name:          "denom not in pool",
+			tokenIn:       sdk.NewCoin(UOSMO, osmomath.NewInt(1)),
+			tokenOutDenom: apptesting.USDC,
+			expectedErr:   quote.DenomNotInPoolError{PoolId: chainPool.GetId(), Denom: UOSMO},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			// System under test
+			tokenOut, err := quote.CalcOutAmtGivenIn(transmuterPool, tc.tokenIn, tc.tokenOutDenom)
+
+			if tc.expectedErr != nil {
+				s.Require().ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedTokenOut, tokenOut)
+
+			// Exact out is rounded up.
+			tokenIn, err := quote.CalcInAmtGivenOut(transmuterPool, tokenOut, tc.tokenIn.Denom)
+			s.Require().NoError(err)
+			s.Require().True(tokenIn.Amount.LTE(tc.tokenIn.Amount))
+		})
+	}
+}
+
+// This test validates the orderbook quotes by filling the ticks on the opposite side of the orderbook.
+func (s *QuoteTestSuite) TestCalcOutAmtGivenIn_Orderbook() {
+	s.Setup()
+
+	pools := s.prepareTestPools()
+
+	// The chain model is only used for the ID and the type.
+	chainPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, pools.balancerPoolID)
+	s.Require().NoError(err)
+
+	// Asks of 100 base at price 1 and 100 base at price 2. No bids.
+	ticks := []cosmwasmpool.OrderbookTick{
+		{TickId: 0, TickLiquidity: cosmwasmpool.OrderbookTickLiquidity{AskLiquidity: osmomath.NewBigDec(100), BidLiquidity: osmomath.ZeroBigDec()}},
+		{TickId: 1_000_000, TickLiquidity: cosmwasmpool.OrderbookTickLiquidity{AskLiquidity: osmomath.NewBigDec(100), BidLiquidity: osmomath.ZeroBigDec()}},
+	}
+
+	bidAmountToExhaustAskLiquidity, err := cosmwasmpool.CalcAmountInToExhaustOrderbookLiquidity(cosmwasmpool.BID, 0, ticks)
+	s.Require().NoError(err)
+
+	orderbookPool := &ingesttypes.PoolWrapper{
+		ChainModel: &cosmWasmChainPool{PoolI: chainPool},
+		SQSModel: ingesttypes.SQSPool{
+			CosmWasmPoolModel: cosmwasmpool.NewCWPoolModel(cosmwasmpool.ORDERBOOK_CONTRACT_NAME, cosmwasmpool.ORDERBOOK_MIN_CONTRACT_VERSION, cosmwasmpool.CosmWasmPoolData{
+				Orderbook: &cosmwasmpool.OrderbookData{
+					QuoteDenom:                     apptesting.USDC,
+					BaseDenom:                      apptesting.ETH,
+					NextBidTickIndex:               -1,
+					NextAskTickIndex:               0,
+					BidAmountToExhaustAskLiquidity: bidAmountToExhaustAskLiquidity,
+					AskAmountToExhaustBidLiquidity: osmomath.ZeroBigDec(),
+					Ticks:                          ticks,
+				},
+			}),
+		},
+	}
+
+	// System under test
+	// 100 USDC fill the first tick and 150 USDC fill 75 ETH at the second tick.
+	tokenOut, err := quote.CalcOutAmtGivenIn(orderbookPool, sdk.NewCoin(apptesting.USDC, osmomath.NewInt(250)), apptesting.ETH)
+	s.Require().NoError(err)
+	s.Require().Equal(sdk.NewCoin(apptesting.ETH, osmomath.NewInt(175)), tokenOut)
+
+	tokenIn, err := quote.CalcInAmtGivenOut(orderbookPool, tokenOut, apptesting.USDC)
+	s.Require().NoError(err)
+	s.Require().Equal(sdk.NewCoin(apptesting.USDC, osmomath.NewInt(250)), tokenIn)
+
+	// More than the ask liquidity
+	_, err = quote.CalcOutAmtGivenIn(orderbookPool, sdk.NewCoin(apptesting.USDC, osmomath.NewInt(301)), apptesting.ETH)
+	s.Require().ErrorIs(err, quote.NotEnoughLiquidityError{PoolId: chainPool.GetId(), Denom: apptesting.ETH})
+
+	_, err = quote.CalcInAmtGivenOut(orderbookPool, sdk.NewCoin(apptesting.ETH, osmomath.NewInt(201)), apptesting.USDC)
+	s.Require().ErrorIs(err, quote.NotEnoughLiquidityError{PoolId: chainPool.GetId(), Denom: apptesting.ETH})
+
+	// No bids
+	_, err = quote.CalcOutAmtGivenIn(orderbookPool, sdk.NewCoin(apptesting.ETH, osmomath.NewInt(1)), apptesting.USDC)
+	s.Require().ErrorIs(err, quote.NotEnoughLiquidityError{PoolId: chainPool.GetId(), Denom: apptesting.USDC})
+}
+
+// cosmWasmChainPool overrides the type of the wrapped chain pool to CosmWasm.
+type cosmWasmChainPool struct {
+	poolmanagertypes.PoolI
+}
+
+func (p *cosmWasmChainPool) GetType() poolmanagertypes.PoolType {
+	return poolmanagertypes.CosmWasm
+}
@@ -0,0 +1,87 @@
+package quote
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	"github.com/osmosis-labs/osmosis/v30/ingest/types/cosmwasmpool"
+)
+
+// calcAlloyTransmuterOutAmtGivenIn returns the amount of token out for the given token in.
+// The assets are exchanged at the ratio of their normalization factors. The alloyed asset is minted,
+// while any other asset token out must be covered by the pool balance.
+// Note that the rate limiters are not enforced.
+func calcAlloyTransmuterOutAmtGivenIn(pool ingesttypes.PoolI, transmuterData *cosmwasmpool.AlloyTransmuterData, tokenIn sdk.Coin, tokenOutDenom string) (sdk.Coin, error) {
+	tokenInNormFactor, tokenOutNormFactor, err := getNormalizationFactors(pool, transmuterData, tokenIn.Denom, tokenOutDenom)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	amountOut := osmomath.BigDecFromSDKInt(tokenIn.Amount).Mul(osmomath.BigDecFromSDKInt(tokenOutNormFactor)).QuoTruncate(osmomath.BigDecFromSDKInt(tokenInNormFactor)).Dec().TruncateInt()
+
+	tokenOut := sdk.Coin{Denom: tokenOutDenom, Amount: amountOut}
+	if err := validateTransmuterBalance(pool, transmuterData, tokenOut); err != nil {
+		return sdk.Coin{}, err
+	}
+
+	return tokenOut, nil
+}
+
+// calcAlloyTransmuterInAmtGivenOut returns the amount of token in for the given token out.
+// It is the exact out counterpart of calcAlloyTransmuterOutAmtGivenIn.
+func calcAlloyTransmuterInAmtGivenOut(pool ingesttypes.PoolI, transmuterData *cosmwasmpool.AlloyTransmuterData, tokenOut sdk.Coin, tokenInDenom string) (sdk.Coin, error) {
+	tokenInNormFactor, tokenOutNormFactor, err := getNormalizationFactors(pool, transmuterData, tokenInDenom, tokenOut.Denom)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	if err := validateTransmuterBalance(pool, transmuterData, tokenOut); err != nil {
+		return sdk.Coin{}, err
+	}
+
+	amountIn := osmomath.BigDecFromSDKInt(tokenOut.Amount).Mul(osmomath.BigDecFromSDKInt(tokenInNormFactor)).QuoRoundUp(osmomath.BigDecFromSDKInt(tokenOutNormFactor)).Ceil().Dec().TruncateInt()
+
+	return sdk.Coin{Denom: tokenInDenom, Amount: amountIn}, nil
+}
+
+// getNormalizationFactors returns the normalization factors of the token in and token out denoms.
+func getNormalizationFactors(pool ingesttypes.PoolI, transmuterData *cosmwasmpool.AlloyTransmuterData, tokenInDenom, tokenOutDenom string) (osmomath.Int, osmomath.Int, error) {
+	if tokenInDenom == tokenOutDenom {
+		return osmomath.Int{}, osmomath.Int{}, cosmwasmpool.DuplicatedDenomError{Denom: tokenInDenom}
+	}
+
+	var tokenInNormFactor, tokenOutNormFactor osmomath.Int
+	for _, assetConfig := range transmuterData.AssetConfigs {
+		switch assetConfig.Denom {
+		case tokenInDenom:
+			tokenInNormFactor = assetConfig.NormalizationFactor
+		case tokenOutDenom:
+			tokenOutNormFactor = assetConfig.NormalizationFactor
+		}
+	}
+
+	if tokenInNormFactor.IsNil() || !tokenInNormFactor.IsPositive() {
+		return osmomath.Int{}, osmomath.Int{}, DenomNotInPoolError{PoolId: pool.GetId(), Denom: tokenInDenom}
+	}
+
+	if tokenOutNormFactor.IsNil() || !tokenOutNormFactor.IsPositive() {
+		return osmomath.Int{}, osmomath.Int{}, DenomNotInPoolError{PoolId: pool.GetId(), Denom: tokenOutDenom}
+	}
+
+	return tokenInNormFactor, tokenOutNormFactor, nil
+}
+
+// validateTransmuterBalance returns error if the pool balance does not cover the token out.
+// The alloyed asset is minted, so its balance is not checked.
+func validateTransmuterBalance(pool ingesttypes.PoolI, transmuterData *cosmwasmpool.AlloyTransmuterData, tokenOut sdk.Coin) error {
+	if tokenOut.Denom == transmuterData.AlloyedDenom {
+		return nil
+	}
+
+	if pool.GetSQSPoolModel().Balances.AmountOf(tokenOut.Denom).LT(tokenOut.Amount) {
+		return NotEnoughLiquidityError{PoolId: pool.GetId(), Denom: tokenOut.Denom}
+	}
+
+	return nil
+}
@@ -2,10 +2,14 @@ package domain
 
 import (
 	"context"
+	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/osmosis-labs/osmosis/osmomath"
 	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	sqscosmwasmpool "github.com/osmosis-labs/osmosis/v30/ingest/types/cosmwasmpool"
+	"github.com/osmosis-labs/osmosis/v30/ingest/types/passthroughdomain"
 
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
 )
@@ -13,9 +17,53 @@ import (
 // PoolsTransformer is an interface that defines the methods for the pool transformer
 type PoolsTransformer interface {
 	// Transform processes the pool state, returning pools instrumented with all the necessary chain data.
-	// Additionally, returns the taker fee map for every pool denom pair.
+	// Additionally, returns the IDs of the pools removed by the pool filter and
+	// the taker fee map for every pool denom pair.
+	// If the taker fee params changed, the taker fee map includes the denom pairs of all pools
+	// transformed so far rather than only the ones of the given pools.
 	// Returns error if the transformer fails to process pool data.
-	Transform(ctx sdk.Context, blockPools commondomain.BlockPools) ([]ingesttypes.PoolI, ingesttypes.TakerFeeMap, error)
+	Transform(ctx sdk.Context, blockPools commondomain.BlockPools) ([]ingesttypes.PoolI, []uint64, ingesttypes.TakerFeeMap, error)
+
+	// PopAPRUpdates returns the APR and fees data refreshed during the last Transform call
+	// for the pools transformed before that were not part of the call. The updates are cleared on return.
+	// Returns nil if no refresh was due.
+	PopAPRUpdates() []ingesttypes.PoolAPRUpdate
+}
+
+// PoolAPRComputer computes the APR and fees data of the pools from chain state.
+type PoolAPRComputer interface {
+	// IsRefreshDue returns true if the APR and fees data is due to be recomputed in the current block.
+	IsRefreshDue(ctx sdk.Context) bool
+
+	// Refresh recomputes and caches the APR and fees data of the given pools.
+	// Pools for which the data was computed before but that are not given are dropped.
+	// valueInUSDC is used to convert the volume and incentives to USDC.
+	Refresh(ctx sdk.Context, pools []ingesttypes.PoolI, valueInUSDC CoinsValuer)
+
+	// Get returns the last computed APR and fees data of the pool.
+	// Returns false if the data was never computed for the pool.
+	Get(poolID uint64) (passthroughdomain.PoolAPRDataStatusWrap, passthroughdomain.PoolFeesDataStatusWrap, bool)
+}
+
+// CoinsValuer returns the value of the given coins in USDC.
+// Returns error if any of the coins cannot be valued.
+type CoinsValuer func(ctx sdk.Context, coins sdk.Coins) (osmomath.Dec, error)
+
+// PoolFilter determines which of the transformed pools are pushed to SQS.
+type PoolFilter interface {
+	// ShouldRemove returns true if the pool must not be pushed to SQS.
+	// Such pools are pushed as removals so that SQS drops them.
+	ShouldRemove(pool ingesttypes.PoolI) bool
+}
+
+// CosmWasmPoolHandler instruments the model of a CosmWasm pool with contract specific data.
+// Handlers are registered by cw2 contract name and version constraint.
+type CosmWasmPoolHandler interface {
+	// UpdateModel queries the pool contract and attaches the contract specific data to the model.
+	// Denoms that are tradable via the pool but are not part of its liquidity (e.g. alloyed denom)
+	// may be appended to poolDenoms.
+	// Returns error if the contract cannot be queried or its response cannot be parsed.
+	UpdateModel(ctx sdk.Context, wasmKeeper commondomain.WasmKeeper, poolId uint64, contractAddress sdk.AccAddress, model *sqscosmwasmpool.CosmWasmPoolModel, poolDenoms *[]string) error
 }
 
 // SQSGRPClient is an interface that defines the methods for the graceful SQS GRPC client.
@@ -27,5 +75,43 @@ type SQSGRPClient interface {
 	// On status.Unavailable, it closes the connection and attempts to re-establish it during the next GRPC call.
 	// Note: while there are built-in mechanisms to handle retry such as exponential backoff, they are no suitable for our context.
 	// In our context, we would rather continue attempting to repush the data in the next block instead of blocking the system.
-	PushData(ctx context.Context, height uint64, pools []ingesttypes.PoolI, takerFeesMap ingesttypes.TakerFeeMap) error
+	// removedPoolIDs are the IDs of the pools that SQS must drop.
+	// aprUpdates are the refreshed APR and fees data of the pools that are not pushed in full.
+	// isFullSnapshot signifies that the pools are a full snapshot rather than an update on top of
+	// the last height acknowledged by SQS.
+	PushData(ctx context.Context, height uint64, pools []ingesttypes.PoolI, removedPoolIDs []uint64, takerFeesMap ingesttypes.TakerFeeMap, aprUpdates []ingesttypes.PoolAPRUpdate, isFullSnapshot bool) error
+
+	// GetSinkAck returns the acknowledgement from the last successful push.
+	GetSinkAck() SinkAck
+
+	// PopTickSnapshotRequests returns the IDs of the concentrated pools for which SQS requested
+	// a full tick model in its replies since the last call. The requests are cleared on return.
+	PopTickSnapshotRequests() []uint64
+
+	// GetLastPayloadSizeBytes returns the size of the data sent in the last successful push in bytes.
+	GetLastPayloadSizeBytes() uint64
+}
+
+// SinkAck is the acknowledgement of the pushed data by SQS.
+type SinkAck struct {
+	// LastAppliedHeight is the last height applied by SQS.
+	// Zero if no data was pushed successfully yet.
+	LastAppliedHeight uint64
+	// Resync is true if SQS requested a full snapshot.
+	Resync bool
+}
+
+// PushDataError is returned when the data fails to be pushed to SQS.
+// Unlike other errors, it does not require the next block to push all data since
+// the missing updates are replayed based on the acknowledged height.
+type PushDataError struct {
+	Err error
+}
+
+func (e *PushDataError) Error() string {
+	return fmt.Sprintf("failed to push data: %v", e.Err)
+}
+
+func (e *PushDataError) Unwrap() error {
+	return e.Err
 }
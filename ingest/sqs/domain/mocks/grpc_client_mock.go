@@ -9,11 +9,53 @@ import (
 
 type GRPCClientMock struct {
 	Error error
+
+	// SinkAck is the acknowledgement to return when GetSinkAck is called.
+	SinkAck domain.SinkAck
+	// CalledWithIsFullSnapshot is the isFullSnapshot flag of the last PushData call.
+	CalledWithIsFullSnapshot bool
+	// CalledWithAPRUpdates are the APR updates of the last PushData call.
+	CalledWithAPRUpdates []ingesttypes.PoolAPRUpdate
+
+	// TickSnapshotRequests are the pool IDs to return when PopTickSnapshotRequests is called.
+	TickSnapshotRequests []uint64
+
+	// LastPayloadSizeBytes is the size to return when GetLastPayloadSizeBytes is called.
+	LastPayloadSizeBytes uint64
+
+	NumPushDataCalls int
+	NumCloseCalls    int
 }
 
 var _ domain.SQSGRPClient = &GRPCClientMock{}
 
 // PushData implements domain.SQSGRPClient.
-func (g *GRPCClientMock) PushData(ctx context.Context, height uint64, pools []ingesttypes.PoolI, takerFeesMap ingesttypes.TakerFeeMap) error {
+func (g *GRPCClientMock) PushData(ctx context.Context, height uint64, pools []ingesttypes.PoolI, removedPoolIDs []uint64, takerFeesMap ingesttypes.TakerFeeMap, aprUpdates []ingesttypes.PoolAPRUpdate, isFullSnapshot bool) error {
+	g.CalledWithIsFullSnapshot = isFullSnapshot
+	g.CalledWithAPRUpdates = aprUpdates
+	g.NumPushDataCalls++
 	return g.Error
 }
+
+// GetSinkAck implements domain.SQSGRPClient.
+func (g *GRPCClientMock) GetSinkAck() domain.SinkAck {
+	return g.SinkAck
+}
+
+// PopTickSnapshotRequests implements domain.SQSGRPClient.
+func (g *GRPCClientMock) PopTickSnapshotRequests() []uint64 {
+	tickSnapshotRequests := g.TickSnapshotRequests
+	g.TickSnapshotRequests = nil
+	return tickSnapshotRequests
+}
+
+// GetLastPayloadSizeBytes implements domain.SQSGRPClient.
+func (g *GRPCClientMock) GetLastPayloadSizeBytes() uint64 {
+	return g.LastPayloadSizeBytes
+}
+
+// Close implements io.Closer.
+func (g *GRPCClientMock) Close() error {
+	g.NumCloseCalls++
+	return nil
+}
@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	"github.com/osmosis-labs/osmosis/v30/ingest/types/passthroughdomain"
+)
+
+type PoolAPRComputerMock struct {
+	IsRefreshDueReturn bool
+
+	// APRData is the APR data to return by pool ID once refreshed.
+	APRData map[uint64]passthroughdomain.PoolAPRDataStatusWrap
+
+	// CalledWithPools are the pools of the last Refresh call.
+	CalledWithPools []ingesttypes.PoolI
+
+	refreshedPoolIDs map[uint64]struct{}
+}
+
+var _ domain.PoolAPRComputer = &PoolAPRComputerMock{}
+
+// IsRefreshDue implements domain.PoolAPRComputer.
+func (p *PoolAPRComputerMock) IsRefreshDue(ctx sdk.Context) bool {
+	return p.IsRefreshDueReturn
+}
+
+// Refresh implements domain.PoolAPRComputer.
+func (p *PoolAPRComputerMock) Refresh(ctx sdk.Context, pools []ingesttypes.PoolI, valueInUSDC domain.CoinsValuer) {
+	p.CalledWithPools = pools
+
+	p.refreshedPoolIDs = make(map[uint64]struct{}, len(pools))
+	for _, pool := range pools {
+		p.refreshedPoolIDs[pool.GetId()] = struct{}{}
+	}
+}
+
+// Get implements domain.PoolAPRComputer.
+func (p *PoolAPRComputerMock) Get(poolID uint64) (passthroughdomain.PoolAPRDataStatusWrap, passthroughdomain.PoolFeesDataStatusWrap, bool) {
+	if _, ok := p.refreshedPoolIDs[poolID]; !ok {
+		return passthroughdomain.PoolAPRDataStatusWrap{}, passthroughdomain.PoolFeesDataStatusWrap{}, false
+	}
+
+	return p.APRData[poolID], passthroughdomain.PoolFeesDataStatusWrap{}, true
+}
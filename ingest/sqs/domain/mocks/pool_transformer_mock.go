@@ -9,14 +9,24 @@ import (
 )
 
 type PoolsTransformerMock struct {
-	PoolReturn     []ingesttypes.PoolI
-	TakerFeeReturn ingesttypes.TakerFeeMap
-	ErrReturn      error
+	PoolReturn           []ingesttypes.PoolI
+	RemovedPoolIDsReturn []uint64
+	TakerFeeReturn       ingesttypes.TakerFeeMap
+	ErrReturn            error
+
+	APRUpdatesReturn []ingesttypes.PoolAPRUpdate
 }
 
 var _ domain.PoolsTransformer = &PoolsTransformerMock{}
 
 // Transform implements domain.PoolsTransformer.
-func (p *PoolsTransformerMock) Transform(ctx sdk.Context, blockPools commondomain.BlockPools) ([]ingesttypes.PoolI, ingesttypes.TakerFeeMap, error) {
-	return p.PoolReturn, p.TakerFeeReturn, p.ErrReturn
+func (p *PoolsTransformerMock) Transform(ctx sdk.Context, blockPools commondomain.BlockPools) ([]ingesttypes.PoolI, []uint64, ingesttypes.TakerFeeMap, error) {
+	return p.PoolReturn, p.RemovedPoolIDsReturn, p.TakerFeeReturn, p.ErrReturn
+}
+
+// PopAPRUpdates implements domain.PoolsTransformer.
+func (p *PoolsTransformerMock) PopAPRUpdates() []ingesttypes.PoolAPRUpdate {
+	aprUpdates := p.APRUpdatesReturn
+	p.APRUpdatesReturn = nil
+	return aprUpdates
 }
@@ -4,6 +4,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
 	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
 )
 
@@ -22,6 +23,20 @@ type BlockPoolUpdateTracker interface {
 	// if at least one tick change was applied within the block.
 	TrackConcentratedPoolIDTickChange(poolID uint64)
 
+	// TrackConcentratedTickChange tracks the index of a concentrated pool tick that
+	// was written to within the block. It also tracks the pool ID tick change so that
+	// the pool is read at the end of the block.
+	TrackConcentratedTickChange(poolID uint64, tickIndex int64)
+
+	// TrackConcentratedPoolIDTickSnapshot tracks the concentrated pool ID for which
+	// the full tick model must be pushed instead of the tick delta.
+	// It also tracks the pool ID tick change so that the pool is read at the end of the block.
+	TrackConcentratedPoolIDTickSnapshot(poolID uint64)
+
+	// TrackReplayedPoolID tracks the ID of a pool that was updated in a block that SQS missed.
+	// The pool is read from the state at the end of the block and pushed in full.
+	TrackReplayedPoolID(poolID uint64)
+
 	// TrackCFMM tracks the CFMM pool.
 	TrackCFMM(pool poolmanagertypes.PoolI)
 
@@ -36,12 +51,29 @@ type BlockPoolUpdateTracker interface {
 	// by poolmanagertypes.TypeEvtPoolCreated
 	TrackCreatedPoolID(commondomain.PoolCreation)
 
+	// TrackTakerFeeDenomPair tracks the denom pair whose taker fee override was written
+	// within the block. The order of the denoms is the order of the override (token in, token out).
+	TrackTakerFeeDenomPair(denom0, denom1 string)
+
+	// TrackTakerFeeParamsChange tracks that the poolmanager params were written within the block.
+	// Since the default taker fee might have changed, the taker fees of all denom pairs must be pushed.
+	TrackTakerFeeParamsChange()
+
 	// GetConcentratedPools returns the tracked concentrated pools.
 	GetConcentratedPools() []poolmanagertypes.PoolI
 
 	// GetConcentratedPoolIDTickChange returns the tracked concentrated pool ID tick change.
 	GetConcentratedPoolIDTickChange() map[uint64]struct{}
 
+	// GetConcentratedPoolIDChangedTicks returns the tracked concentrated pool ID to the changed tick indexes.
+	GetConcentratedPoolIDChangedTicks() map[uint64]map[int64]struct{}
+
+	// GetConcentratedPoolIDTickSnapshot returns the tracked concentrated pool IDs that require a full tick model.
+	GetConcentratedPoolIDTickSnapshot() map[uint64]struct{}
+
+	// GetReplayedPoolIDs returns the tracked IDs of the pools to replay.
+	GetReplayedPoolIDs() map[uint64]struct{}
+
 	// GetCFMMPools returns the tracked CFMM pools.
 	GetCFMMPools() []poolmanagertypes.PoolI
 
@@ -54,6 +86,12 @@ type BlockPoolUpdateTracker interface {
 	// GetCreatedPoolIDs returns the tracked pool IDs that were created in the block.
 	GetCreatedPoolIDs() map[uint64]commondomain.PoolCreation
 
+	// GetTakerFeeDenomPairs returns the tracked denom pairs whose taker fee override was written.
+	GetTakerFeeDenomPairs() map[ingesttypes.DenomPair]struct{}
+
+	// IsTakerFeeParamsChanged returns true if the poolmanager params were written within the block.
+	IsTakerFeeParamsChanged() bool
+
 	// Reset clears the internal state.
 	Reset()
 }
@@ -44,4 +44,25 @@ var (
 	// * err - the error returned
 	// * height - the height of the block being processed
 	SQSGRPCConnectionErrorMetricName = "sqs_grpc_connection_error"
+
+	// sqs_grpc_transport_fallback
+	//
+	// counter that is increased if the receiver does not support the configured
+	// ingest transport and the client falls back to a more conservative one.
+	//
+	// Has the following labels:
+	// * from - the transport that is not supported
+	// * to - the transport that is used instead
+	SQSGRPCTransportFallbackMetricName = "sqs_grpc_transport_fallback"
+
+	// sqs_sink_gap
+	//
+	// counter that is increased if SQS did not apply all pushed blocks or requested a resync.
+	//
+	// Has the following labels:
+	// * height - the height of the block being processed
+	// * last_applied_height - the last height applied by SQS
+	// * resync - whether SQS requested a resync
+	// * replayed - whether the missed updates are replayed rather than all data pushed
+	SQSSinkGapMetricName = "sqs_sink_gap"
 )
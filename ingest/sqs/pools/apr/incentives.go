@@ -0,0 +1,190 @@
+package poolsapr
+
+import (
+	"errors"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	incentivestypes "github.com/osmosis-labs/osmosis/v30/x/incentives/types"
+	lockuptypes "github.com/osmosis-labs/osmosis/v30/x/lockup/types"
+	poolincentivestypes "github.com/osmosis-labs/osmosis/v30/x/pool-incentives/types"
+)
+
+// getAnnualIncentives returns the annualized internal and external incentives by pool ID.
+// - Internal incentives are the share of the pool incentives minted every epoch that is directed
+// to the pool by the pool incentives distribution records.
+// - External incentives are the remaining coins of the active non-perpetual gauges
+// spread evenly over their remaining epochs.
+// Gauges of groups are split between the pools of the group by their current weights.
+func (c *poolAPRComputer) getAnnualIncentives(ctx sdk.Context) (map[uint64]sdk.Coins, map[uint64]sdk.Coins, error) {
+	if c.keepers.IncentivesKeeper == nil || c.keepers.PoolIncentivesKeeper == nil || c.keepers.MintKeeper == nil || c.keepers.EpochsKeeper == nil {
+		return nil, nil, errors.New("incentives keepers are not set")
+	}
+
+	incentivesEpochDuration := c.keepers.IncentivesKeeper.GetEpochInfo(ctx).Duration
+	if incentivesEpochDuration <= 0 {
+		return nil, nil, errors.New("incentives epoch duration must be positive")
+	}
+
+	longestLockableDuration, err := c.keepers.PoolIncentivesKeeper.GetLongestLockableDuration(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mintEpochsPerYear, err := c.getMintEpochsPerYear(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mintParams := c.keepers.MintKeeper.GetParams(ctx)
+	annualPoolIncentives := c.keepers.MintKeeper.GetMinter(ctx).EpochProvisions.Mul(mintParams.DistributionProportions.PoolIncentives).Mul(mintEpochsPerYear)
+
+	internalIncentives := make(map[uint64]sdk.DecCoins)
+
+	distrInfo := c.keepers.PoolIncentivesKeeper.GetDistrInfo(ctx)
+	if distrInfo.TotalWeight.IsPositive() {
+		for _, record := range distrInfo.Records {
+			// Gauge with ID zero goes to community pool.
+			if record.GaugeId == poolincentivestypes.CommunityPoolDistributionGaugeID {
+				continue
+			}
+
+			gauge, err := c.keepers.IncentivesKeeper.GetGaugeByID(ctx, record.GaugeId)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			poolShares, err := c.getInternalGaugePoolShares(ctx, gauge, incentivesEpochDuration, longestLockableDuration)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			gaugeIncentives := annualPoolIncentives.MulInt(record.Weight).QuoInt(distrInfo.TotalWeight)
+			addPoolShares(internalIncentives, poolShares, sdk.NewDecCoins(sdk.NewDecCoinFromDec(uosmo, gaugeIncentives)))
+		}
+	}
+
+	incentivesEpochsPerYear := osmomath.NewDec(int64(year)).QuoInt64(int64(incentivesEpochDuration))
+
+	externalIncentives := make(map[uint64]sdk.DecCoins)
+	for _, gauge := range c.keepers.IncentivesKeeper.GetActiveGauges(ctx) {
+		// Internal gauges are perpetual and funded every epoch from the distribution records.
+		if gauge.IsPerpetual || gauge.NumEpochsPaidOver <= gauge.FilledEpochs {
+			continue
+		}
+
+		remainingCoins, hasNeg := gauge.Coins.SafeSub(gauge.DistributedCoins...)
+		if hasNeg || remainingCoins.IsZero() {
+			continue
+		}
+
+		poolShares, err := c.getExternalGaugePoolShares(ctx, &gauge, incentivesEpochDuration)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		remainingEpochs := osmomath.NewDec(int64(gauge.NumEpochsPaidOver - gauge.FilledEpochs))
+		annualGaugeIncentives := sdk.NewDecCoinsFromCoins(remainingCoins...).QuoDec(remainingEpochs).MulDec(incentivesEpochsPerYear)
+
+		addPoolShares(externalIncentives, poolShares, annualGaugeIncentives)
+	}
+
+	return truncateIncentives(internalIncentives), truncateIncentives(externalIncentives), nil
+}
+
+// getInternalGaugePoolShares returns the shares of the pools that the internal gauge distributes to.
+// Internal gauges are registered in pool incentives for the incentives epoch duration (NoLock)
+// or the longest lockable duration (ByDuration).
+func (c *poolAPRComputer) getInternalGaugePoolShares(ctx sdk.Context, gauge *incentivestypes.Gauge, incentivesEpochDuration, longestLockableDuration time.Duration) (map[uint64]osmomath.Dec, error) {
+	switch gauge.DistributeTo.LockQueryType {
+	case lockuptypes.NoLock:
+		poolID, err := c.keepers.PoolIncentivesKeeper.GetPoolIdFromGaugeId(ctx, gauge.Id, incentivesEpochDuration)
+		if err != nil {
+			return nil, err
+		}
+		return map[uint64]osmomath.Dec{poolID: osmomath.OneDec()}, nil
+	case lockuptypes.ByDuration:
+		poolID, err := c.keepers.PoolIncentivesKeeper.GetPoolIdFromGaugeId(ctx, gauge.Id, longestLockableDuration)
+		if err != nil {
+			return nil, err
+		}
+		return map[uint64]osmomath.Dec{poolID: osmomath.OneDec()}, nil
+	case lockuptypes.ByGroup:
+		return c.getGroupGaugePoolShares(ctx, gauge, incentivesEpochDuration)
+	default:
+		return map[uint64]osmomath.Dec{}, nil
+	}
+}
+
+// getExternalGaugePoolShares returns the shares of the pools that the external gauge distributes to.
+// External gauges are not registered in pool incentives. As a result, the pool ID is parsed
+// from the share denom (ByDuration) or the NoLock gauge denom.
+func (c *poolAPRComputer) getExternalGaugePoolShares(ctx sdk.Context, gauge *incentivestypes.Gauge, incentivesEpochDuration time.Duration) (map[uint64]osmomath.Dec, error) {
+	switch gauge.DistributeTo.LockQueryType {
+	case lockuptypes.NoLock, lockuptypes.ByDuration:
+		poolID, ok := parsePoolIDFromDenom(gauge.DistributeTo.Denom, noLockExternalGaugePrefix, gammSharePrefix)
+		if !ok {
+			return map[uint64]osmomath.Dec{}, nil
+		}
+		return map[uint64]osmomath.Dec{poolID: osmomath.OneDec()}, nil
+	case lockuptypes.ByGroup:
+		return c.getGroupGaugePoolShares(ctx, gauge, incentivesEpochDuration)
+	default:
+		return map[uint64]osmomath.Dec{}, nil
+	}
+}
+
+// getGroupGaugePoolShares returns the shares of the pools of the group by the current weights
+// of their internal NoLock gauges.
+func (c *poolAPRComputer) getGroupGaugePoolShares(ctx sdk.Context, gauge *incentivestypes.Gauge, incentivesEpochDuration time.Duration) (map[uint64]osmomath.Dec, error) {
+	group, err := c.keepers.IncentivesKeeper.GetGroupByGaugeID(ctx, gauge.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	poolShares := make(map[uint64]osmomath.Dec, len(group.InternalGaugeInfo.GaugeRecords))
+
+	totalWeight := group.InternalGaugeInfo.TotalWeight
+	if totalWeight.IsNil() || !totalWeight.IsPositive() {
+		return poolShares, nil
+	}
+
+	for _, record := range group.InternalGaugeInfo.GaugeRecords {
+		poolID, err := c.keepers.PoolIncentivesKeeper.GetPoolIdFromGaugeId(ctx, record.GaugeId, incentivesEpochDuration)
+		if err != nil {
+			return nil, err
+		}
+
+		poolShares[poolID] = record.CurrentWeight.ToLegacyDec().QuoInt(totalWeight)
+	}
+
+	return poolShares, nil
+}
+
+// getMintEpochsPerYear returns the number of mint epochs per year.
+func (c *poolAPRComputer) getMintEpochsPerYear(ctx sdk.Context) (osmomath.Dec, error) {
+	mintEpochDuration := c.keepers.EpochsKeeper.GetEpochInfo(ctx, c.keepers.MintKeeper.GetParams(ctx).EpochIdentifier).Duration
+	if mintEpochDuration <= 0 {
+		return osmomath.Dec{}, errors.New("mint epoch duration must be positive")
+	}
+
+	return osmomath.NewDec(int64(year)).QuoInt64(int64(mintEpochDuration)), nil
+}
+
+// addPoolShares adds the pool shares of the incentives to the incentives by pool ID.
+func addPoolShares(incentivesByPoolID map[uint64]sdk.DecCoins, poolShares map[uint64]osmomath.Dec, incentives sdk.DecCoins) {
+	for poolID, share := range poolShares {
+		incentivesByPoolID[poolID] = incentivesByPoolID[poolID].Add(incentives.MulDec(share)...)
+	}
+}
+
+// truncateIncentives truncates the incentives by pool ID to integer amounts.
+func truncateIncentives(incentivesByPoolID map[uint64]sdk.DecCoins) map[uint64]sdk.Coins {
+	result := make(map[uint64]sdk.Coins, len(incentivesByPoolID))
+	for poolID, incentives := range incentivesByPoolID {
+		result[poolID], _ = incentives.TruncateDecimal()
+	}
+	return result
+}
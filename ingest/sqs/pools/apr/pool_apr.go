@@ -0,0 +1,406 @@
+package poolsapr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	"github.com/osmosis-labs/osmosis/v30/ingest/types/passthroughdomain"
+)
+
+const (
+	uosmo = "uosmo"
+
+	// Share denom prefixes of the CFMM and concentrated pools.
+	gammSharePrefix         = "gamm/pool/"
+	concentratedSharePrefix = "cl/pool/"
+	// Denom prefix of the external NoLock gauges of the concentrated pools.
+	noLockExternalGaugePrefix = "no-lock/e/"
+
+	day  = 24 * time.Hour
+	week = 7 * day
+	year = 365 * day
+)
+
+var (
+	oneHundredDec = osmomath.NewDec(100)
+)
+
+// volumeSnapshot is the cumulative OSMO volume of a pool at the given time.
+type volumeSnapshot struct {
+	time   time.Time
+	volume osmomath.Int
+}
+
+// poolData is the last computed APR and fees data of a pool.
+type poolData struct {
+	aprData  passthroughdomain.PoolAPRDataStatusWrap
+	feesData passthroughdomain.PoolFeesDataStatusWrap
+}
+
+// poolAPRComputer computes the APR and fees data of the pools from chain state.
+// - Volume is the difference of the cumulative OSMO volume tracked by the poolmanager
+// (valued with TWAP at the time of each swap) between the current and the snapshotted
+// volume 24h and 7d ago. Snapshots are taken on every refresh. Unless they are persisted
+// in a volume snapshot store, they are kept in memory only, and the volume and fees of a
+// window are unavailable, i.e. zero and marked stale, until the node has been running for the full window.
+// - Swap fee APR is the annualized 7d fees over the pool liquidity. It is unavailable
+// until the 7d volume is available.
+// - Osmosis APR is the annualized internal OSMO incentives from the pool incentives
+// distribution records over the pool liquidity.
+// - Boost APR is the annualized external incentives of the active non-perpetual gauges
+// over the pool liquidity.
+// - Superfluid APR is the staking APR scaled by the risk adjusted OSMO value of the pool
+// over the pool liquidity. It is only set for pools whose shares are superfluid assets.
+// All components are in percent. Upper and lower bounds of the ranges are equal.
+type poolAPRComputer struct {
+	keepers commondomain.PoolAPRKeepers
+
+	refreshIntervalBlocks int64
+	lastRefreshHeight     int64
+
+	volumeSnapshots map[uint64][]volumeSnapshot
+	data            map[uint64]poolData
+
+	// snapshotStore persists the volume snapshots under snapshotSink. Nil if they are kept in memory only.
+	snapshotStore commondomain.VolumeSnapshotStore
+	snapshotSink  string
+}
+
+var _ domain.PoolAPRComputer = &poolAPRComputer{}
+
+// New returns a new pool APR computer that recomputes the APR and fees data
+// every refreshIntervalBlocks blocks.
+// The volume requires the poolmanager keeper. The other keepers are optional, and
+// the respective APR components are flagged as errors if they are not set.
+// Returns error if refreshIntervalBlocks is zero or the poolmanager keeper is not set.
+func New(keepers commondomain.PoolAPRKeepers, refreshIntervalBlocks uint64) (domain.PoolAPRComputer, error) {
+	if refreshIntervalBlocks == 0 {
+		return nil, fmt.Errorf("APR refresh interval must be positive")
+	}
+
+	if keepers.PoolManagerKeeper == nil {
+		return nil, fmt.Errorf("poolmanager keeper is required for computing the pool volume")
+	}
+
+	return &poolAPRComputer{
+		keepers: keepers,
+
+		refreshIntervalBlocks: int64(refreshIntervalBlocks),
+
+		volumeSnapshots: make(map[uint64][]volumeSnapshot),
+		data:            make(map[uint64]poolData),
+	}, nil
+}
+
+// NewWithVolumeSnapshotStore returns a new pool APR computer like New that persists
+// the volume snapshots in the given store under the given sink, and resumes from the
+// snapshots previously persisted there so that the volume windows survive node restarts.
+func NewWithVolumeSnapshotStore(keepers commondomain.PoolAPRKeepers, refreshIntervalBlocks uint64, snapshotStore commondomain.VolumeSnapshotStore, sink string) (domain.PoolAPRComputer, error) {
+	computer, err := New(keepers, refreshIntervalBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	storedSnapshots, err := snapshotStore.LoadVolumeSnapshots(sink)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the volume snapshots of (%s): %w", sink, err)
+	}
+
+	c := computer.(*poolAPRComputer)
+	c.snapshotStore = snapshotStore
+	c.snapshotSink = sink
+
+	for _, snapshot := range storedSnapshots {
+		c.volumeSnapshots[snapshot.PoolID] = append(c.volumeSnapshots[snapshot.PoolID], volumeSnapshot{
+			time:   snapshot.Time,
+			volume: snapshot.Volume,
+		})
+	}
+
+	return c, nil
+}
+
+// IsRefreshDue implements domain.PoolAPRComputer.
+func (c *poolAPRComputer) IsRefreshDue(ctx sdk.Context) bool {
+	return c.lastRefreshHeight == 0 || ctx.BlockHeight()-c.lastRefreshHeight >= c.refreshIntervalBlocks
+}
+
+// Refresh implements domain.PoolAPRComputer.
+// Errors in the individual components are logged and flagged in the data
+// rather than failing the refresh.
+func (c *poolAPRComputer) Refresh(ctx sdk.Context, pools []ingesttypes.PoolI, valueInUSDC domain.CoinsValuer) {
+	now := ctx.BlockTime()
+
+	refreshedPoolIDs := make(map[uint64]struct{}, len(pools))
+
+	// The changes to the volume snapshots that are persisted after the refresh.
+	var addedSnapshots, deletedSnapshots []commondomain.VolumeSnapshot
+
+	internalIncentives, externalIncentives, incentivesErr := c.getAnnualIncentives(ctx)
+	if incentivesErr != nil {
+		ctx.Logger().Error("failed to compute pool incentives", "error", incentivesErr)
+	}
+
+	stakingAPR, superfluidPoolIDs, superfluidErr := c.getSuperfluidInfo(ctx)
+	if superfluidErr != nil {
+		ctx.Logger().Error("failed to compute superfluid APR", "error", superfluidErr)
+	}
+
+	for _, pool := range pools {
+		poolID := pool.GetId()
+		refreshedPoolIDs[poolID] = struct{}{}
+
+		feesData, swapFeesAPR, deleted := c.computeFeesData(ctx, pool, now, valueInUSDC)
+
+		addedSnapshots = append(addedSnapshots, toStoredSnapshot(poolID, c.volumeSnapshots[poolID][len(c.volumeSnapshots[poolID])-1]))
+		for _, snapshot := range deleted {
+			deletedSnapshots = append(deletedSnapshots, toStoredSnapshot(poolID, snapshot))
+		}
+
+		aprData := passthroughdomain.PoolAPRDataStatusWrap{
+			PoolAPR: passthroughdomain.PoolAPR{
+				PoolID:   poolID,
+				SwapFees: newPoolDataRange(swapFeesAPR),
+			},
+			IsStale: feesData.IsStale,
+			IsError: feesData.IsError || incentivesErr != nil || superfluidErr != nil,
+		}
+
+		poolLiquidityCap := pool.GetPoolLiquidityCap()
+
+		if incentivesErr == nil {
+			osmosisAPR, err := computeAPR(ctx, internalIncentives[poolID], poolLiquidityCap, valueInUSDC)
+			if err != nil {
+				aprData.IsError = true
+			}
+			aprData.OsmosisAPR = newPoolDataRange(osmosisAPR)
+
+			boostAPR, err := computeAPR(ctx, externalIncentives[poolID], poolLiquidityCap, valueInUSDC)
+			if err != nil {
+				aprData.IsError = true
+			}
+			aprData.BoostAPR = newPoolDataRange(boostAPR)
+		}
+
+		if _, isSuperfluid := superfluidPoolIDs[poolID]; superfluidErr == nil && isSuperfluid {
+			riskAdjustedOSMO := c.keepers.SuperfluidKeeper.GetRiskAdjustedOsmoValue(ctx, pool.GetSQSPoolModel().Balances.AmountOf(uosmo))
+
+			// The staking APR is earned on the risk adjusted OSMO value of the pool.
+			superfluidAPR, err := computeAPR(ctx, sdk.NewCoins(sdk.NewCoin(uosmo, riskAdjustedOSMO)), poolLiquidityCap, valueInUSDC)
+			if err != nil {
+				aprData.IsError = true
+			}
+			aprData.SuperfluidAPR = newPoolDataRange(superfluidAPR * stakingAPR / 100)
+		}
+
+		aprData.TotalAPR = newPoolDataRange(aprData.SwapFees.Lower + aprData.OsmosisAPR.Lower + aprData.BoostAPR.Lower + aprData.SuperfluidAPR.Lower)
+
+		c.data[poolID] = poolData{
+			aprData:  aprData,
+			feesData: feesData,
+		}
+	}
+
+	// Drop the data of the pools that are no longer refreshed.
+	removedPoolIDs := []uint64{}
+	for poolID := range c.volumeSnapshots {
+		if _, ok := refreshedPoolIDs[poolID]; !ok {
+			delete(c.data, poolID)
+			delete(c.volumeSnapshots, poolID)
+			removedPoolIDs = append(removedPoolIDs, poolID)
+		}
+	}
+
+	if c.snapshotStore != nil {
+		if err := c.snapshotStore.SaveVolumeSnapshots(c.snapshotSink, addedSnapshots, deletedSnapshots, removedPoolIDs); err != nil {
+			ctx.Logger().Error("failed to persist pool volume snapshots", "error", err)
+		}
+	}
+
+	c.lastRefreshHeight = ctx.BlockHeight()
+}
+
+// Get implements domain.PoolAPRComputer.
+func (c *poolAPRComputer) Get(poolID uint64) (passthroughdomain.PoolAPRDataStatusWrap, passthroughdomain.PoolFeesDataStatusWrap, bool) {
+	data, ok := c.data[poolID]
+	return data.aprData, data.feesData, ok
+}
+
+// computeFeesData snapshots the cumulative volume of the pool and computes its
+// 24h and 7d volume and fees. Additionally, returns the swap fee APR and the snapshots
+// that were pruned.
+// The volume and fees of a window that is not fully covered by the snapshots are left unset
+// and the data is marked stale. The swap fee APR is zero unless the 7d window is covered.
+func (c *poolAPRComputer) computeFeesData(ctx sdk.Context, pool ingesttypes.PoolI, now time.Time, valueInUSDC domain.CoinsValuer) (passthroughdomain.PoolFeesDataStatusWrap, float64, []volumeSnapshot) {
+	poolID := pool.GetId()
+
+	cumulativeVolume := c.keepers.PoolManagerKeeper.GetOsmoVolumeForPool(ctx, poolID)
+	snapshots, pruned := c.snapshotVolume(poolID, now, cumulativeVolume)
+
+	volume24h, isComplete24h, _ := volumeSince(snapshots, now.Add(-day))
+	volume7d, isComplete7d, elapsed7d := volumeSince(snapshots, now.Add(-week))
+
+	spreadFactor := pool.GetSQSPoolModel().SpreadFactor
+	if spreadFactor.IsNil() {
+		spreadFactor = osmomath.ZeroDec()
+	}
+
+	feesData := passthroughdomain.PoolFeesDataStatusWrap{
+		PoolFee: passthroughdomain.PoolFee{
+			PoolID:         strconv.FormatUint(poolID, 10),
+			FeesPercentage: formatPercentage(spreadFactor),
+		},
+		IsStale: !isComplete24h || !isComplete7d,
+	}
+
+	if isComplete24h {
+		volume24hUSDC, err := valueInUSDC(ctx, sdk.NewCoins(sdk.NewCoin(uosmo, volume24h)))
+		if err != nil {
+			feesData.IsError = true
+			return feesData, 0, pruned
+		}
+
+		feesData.Volume24h = volume24hUSDC.MustFloat64()
+		feesData.FeesSpent24h = volume24hUSDC.Mul(spreadFactor).MustFloat64()
+	}
+
+	if !isComplete7d {
+		return feesData, 0, pruned
+	}
+
+	volume7dUSDC, err := valueInUSDC(ctx, sdk.NewCoins(sdk.NewCoin(uosmo, volume7d)))
+	if err != nil {
+		feesData.IsError = true
+		return feesData, 0, pruned
+	}
+
+	fees7dUSDC := volume7dUSDC.Mul(spreadFactor)
+
+	feesData.Volume7d = volume7dUSDC.MustFloat64()
+	feesData.FeesSpent7d = fees7dUSDC.MustFloat64()
+
+	// Annualize the fees over the elapsed window which is at least 7d.
+	poolLiquidityCap := pool.GetPoolLiquidityCap()
+	if poolLiquidityCap.IsNil() || !poolLiquidityCap.IsPositive() {
+		return feesData, 0, pruned
+	}
+
+	annualFeesUSDC := fees7dUSDC.MulInt64(int64(year)).QuoInt64(int64(elapsed7d))
+
+	return feesData, annualFeesUSDC.Quo(poolLiquidityCap.ToLegacyDec()).Mul(oneHundredDec).MustFloat64(), pruned
+}
+
+// snapshotVolume appends the cumulative volume snapshot of the pool and prunes
+// the snapshots that are no longer needed for the 7d window.
+// Snapshots that are not before the given time, e.g. persisted ahead of a rollback, are replaced.
+// Returns the snapshots of the pool in ascending order of time and the pruned snapshots.
+func (c *poolAPRComputer) snapshotVolume(poolID uint64, now time.Time, cumulativeVolume osmomath.Int) ([]volumeSnapshot, []volumeSnapshot) {
+	snapshots := c.volumeSnapshots[poolID]
+
+	var pruned []volumeSnapshot
+	for len(snapshots) > 0 && !snapshots[len(snapshots)-1].time.Before(now) {
+		pruned = append(pruned, snapshots[len(snapshots)-1])
+		snapshots = snapshots[:len(snapshots)-1]
+	}
+
+	snapshots = append(snapshots, volumeSnapshot{time: now, volume: cumulativeVolume})
+
+	// Keep the latest snapshot at or before the start of the 7d window.
+	windowStart := now.Add(-week)
+	firstKept := 0
+	for i := 1; i < len(snapshots) && !snapshots[i].time.After(windowStart); i++ {
+		firstKept = i
+	}
+	pruned = append(pruned, snapshots[:firstKept]...)
+	snapshots = snapshots[firstKept:]
+
+	c.volumeSnapshots[poolID] = snapshots
+	return snapshots, pruned
+}
+
+// volumeSince returns the volume since the given time based on the snapshots in ascending order of time.
+// The last snapshot is the current cumulative volume.
+// If no snapshot is at or before the given time, the volume since the oldest snapshot is returned
+// and the window is reported incomplete. Additionally, returns the elapsed duration of the window.
+func volumeSince(snapshots []volumeSnapshot, since time.Time) (osmomath.Int, bool, time.Duration) {
+	current := snapshots[len(snapshots)-1]
+
+	start := snapshots[0]
+	isComplete := !start.time.After(since)
+	for _, snapshot := range snapshots[1:] {
+		if snapshot.time.After(since) {
+			break
+		}
+		start = snapshot
+	}
+
+	// The cumulative volume is expected to be non-decreasing.
+	volume := current.volume.Sub(start.volume)
+	if volume.IsNegative() {
+		volume = osmomath.ZeroInt()
+	}
+
+	return volume, isComplete, current.time.Sub(start.time)
+}
+
+// toStoredSnapshot converts the volume snapshot of the pool into its persisted form.
+func toStoredSnapshot(poolID uint64, snapshot volumeSnapshot) commondomain.VolumeSnapshot {
+	return commondomain.VolumeSnapshot{
+		PoolID: poolID,
+		Time:   snapshot.time,
+		Volume: snapshot.volume,
+	}
+}
+
+// computeAPR returns the APR in percent of the annual rewards over the pool liquidity capitalization.
+// Returns zero if the pool has no liquidity.
+func computeAPR(ctx sdk.Context, annualRewards sdk.Coins, poolLiquidityCap osmomath.Int, valueInUSDC domain.CoinsValuer) (float64, error) {
+	if annualRewards.IsZero() || poolLiquidityCap.IsNil() || !poolLiquidityCap.IsPositive() {
+		return 0, nil
+	}
+
+	annualRewardsUSDC, err := valueInUSDC(ctx, annualRewards)
+	if err != nil {
+		return 0, err
+	}
+
+	return annualRewardsUSDC.Quo(poolLiquidityCap.ToLegacyDec()).Mul(oneHundredDec).MustFloat64(), nil
+}
+
+// formatPercentage formats the decimal as a percentage, e.g. 0.002 as "0.2%".
+func formatPercentage(dec osmomath.Dec) string {
+	return strconv.FormatFloat(dec.Mul(oneHundredDec).MustFloat64(), 'f', -1, 64) + "%"
+}
+
+func newPoolDataRange(value float64) passthroughdomain.PoolDataRange {
+	return passthroughdomain.PoolDataRange{
+		Lower: value,
+		Upper: value,
+	}
+}
+
+// parsePoolIDFromDenom parses the pool ID from the denom with one of the given prefixes.
+// Returns false if the denom has none of the prefixes.
+func parsePoolIDFromDenom(denom string, prefixes ...string) (uint64, bool) {
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(denom, prefix) {
+			continue
+		}
+
+		poolID, err := strconv.ParseUint(strings.TrimPrefix(denom, prefix), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return poolID, true
+	}
+
+	return 0, false
+}
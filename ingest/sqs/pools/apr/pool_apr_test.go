@@ -0,0 +1,435 @@
+package poolsapr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	dbm "github.com/cosmos/cosmos-db"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/checkpoint"
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	poolsapr "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/apr"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	incentivestypes "github.com/osmosis-labs/osmosis/v30/x/incentives/types"
+	lockuptypes "github.com/osmosis-labs/osmosis/v30/x/lockup/types"
+	minttypes "github.com/osmosis-labs/osmosis/v30/x/mint/types"
+	poolincentivestypes "github.com/osmosis-labs/osmosis/v30/x/pool-incentives/types"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+	superfluidtypes "github.com/osmosis-labs/osmosis/v30/x/superfluid/types"
+	epochstypes "github.com/osmosis-labs/osmosis/x/epochs/types"
+)
+
+const (
+	UOSMO = "uosmo"
+
+	defaultPoolID        = uint64(1)
+	internalGaugeID      = uint64(10)
+	externalGaugeID      = uint64(11)
+	mintEpochIdentifier  = "day"
+	refreshIntervalBlock = uint64(10)
+
+	day = 24 * time.Hour
+)
+
+var (
+	defaultStartTime = time.Unix(1_700_000_000, 0).UTC()
+
+	defaultPoolLiquidityCap = osmomath.NewInt(1_000)
+	defaultSpreadFactor     = osmomath.MustNewDecFromStr("0.002")
+
+	// 1 uosmo is valued at 1 USDC.
+	uosmoValuer = func(ctx sdk.Context, coins sdk.Coins) (osmomath.Dec, error) {
+		return coins.AmountOf(UOSMO).ToLegacyDec(), nil
+	}
+
+	errValuation = errors.New("valuation error")
+)
+
+// mockChainPool is a chain pool model that only implements the ID and the type.
+type mockChainPool struct {
+	poolmanagertypes.PoolI
+
+	id uint64
+}
+
+func (p *mockChainPool) GetId() uint64 {
+	return p.id
+}
+
+func (p *mockChainPool) GetType() poolmanagertypes.PoolType {
+	return poolmanagertypes.Balancer
+}
+
+type mockPoolVolumeKeeper struct {
+	volumes map[uint64]osmomath.Int
+}
+
+func (k *mockPoolVolumeKeeper) GetOsmoVolumeForPool(ctx sdk.Context, poolId uint64) osmomath.Int {
+	volume, ok := k.volumes[poolId]
+	if !ok {
+		return osmomath.ZeroInt()
+	}
+	return volume
+}
+
+type mockIncentivesKeeper struct {
+	gauges       map[uint64]*incentivestypes.Gauge
+	activeGauges []incentivestypes.Gauge
+}
+
+func (k *mockIncentivesKeeper) GetActiveGauges(ctx sdk.Context) []incentivestypes.Gauge {
+	return k.activeGauges
+}
+
+func (k *mockIncentivesKeeper) GetGaugeByID(ctx sdk.Context, gaugeID uint64) (*incentivestypes.Gauge, error) {
+	gauge, ok := k.gauges[gaugeID]
+	if !ok {
+		return nil, errors.New("gauge not found")
+	}
+	return gauge, nil
+}
+
+func (k *mockIncentivesKeeper) GetGroupByGaugeID(ctx sdk.Context, gaugeID uint64) (incentivestypes.Group, error) {
+	return incentivestypes.Group{}, errors.New("group not found")
+}
+
+func (k *mockIncentivesKeeper) GetEpochInfo(ctx sdk.Context) epochstypes.EpochInfo {
+	return epochstypes.EpochInfo{Duration: day}
+}
+
+type mockPoolIncentivesKeeper struct {
+	distrInfo poolincentivestypes.DistrInfo
+}
+
+func (k *mockPoolIncentivesKeeper) GetDistrInfo(ctx sdk.Context) poolincentivestypes.DistrInfo {
+	return k.distrInfo
+}
+
+func (k *mockPoolIncentivesKeeper) GetPoolIdFromGaugeId(ctx sdk.Context, gaugeId uint64, lockableDuration time.Duration) (uint64, error) {
+	if gaugeId != internalGaugeID || lockableDuration != day {
+		return 0, errors.New("pool not found")
+	}
+	return defaultPoolID, nil
+}
+
+func (k *mockPoolIncentivesKeeper) GetLongestLockableDuration(ctx sdk.Context) (time.Duration, error) {
+	return 14 * day, nil
+}
+
+type mockSuperfluidKeeper struct{}
+
+func (k *mockSuperfluidKeeper) GetAllSuperfluidAssets(ctx sdk.Context) []superfluidtypes.SuperfluidAsset {
+	return []superfluidtypes.SuperfluidAsset{{Denom: "gamm/pool/1"}}
+}
+
+// GetRiskAdjustedOsmoValue applies a 50% risk factor.
+func (k *mockSuperfluidKeeper) GetRiskAdjustedOsmoValue(ctx sdk.Context, amount osmomath.Int) osmomath.Int {
+	return amount.QuoRaw(2)
+}
+
+type mockMintKeeper struct{}
+
+func (k *mockMintKeeper) GetMinter(ctx sdk.Context) minttypes.Minter {
+	return minttypes.Minter{EpochProvisions: osmomath.NewDec(1_000)}
+}
+
+func (k *mockMintKeeper) GetParams(ctx sdk.Context) minttypes.Params {
+	return minttypes.Params{
+		EpochIdentifier: mintEpochIdentifier,
+		DistributionProportions: minttypes.DistributionProportions{
+			Staking:        osmomath.MustNewDecFromStr("0.5"),
+			PoolIncentives: osmomath.MustNewDecFromStr("0.5"),
+		},
+	}
+}
+
+type mockEpochsKeeper struct{}
+
+func (k *mockEpochsKeeper) GetEpochInfo(ctx sdk.Context, identifier string) epochstypes.EpochInfo {
+	return epochstypes.EpochInfo{Identifier: identifier, Duration: day}
+}
+
+type mockStakingKeeper struct{}
+
+func (k *mockStakingKeeper) TotalBondedTokens(ctx context.Context) (osmomath.Int, error) {
+	return osmomath.NewInt(1_825_000), nil
+}
+
+func newPool(id uint64, poolLiquidityCap osmomath.Int, balances sdk.Coins) *ingesttypes.PoolWrapper {
+	return &ingesttypes.PoolWrapper{
+		ChainModel: &mockChainPool{id: id},
+		SQSModel: ingesttypes.SQSPool{
+			PoolLiquidityCap: poolLiquidityCap,
+			SpreadFactor:     defaultSpreadFactor,
+			Balances:         balances,
+		},
+	}
+}
+
+func newContext(height int64, blockTime time.Time) sdk.Context {
+	return sdk.Context{}.WithLogger(log.NewNopLogger()).WithBlockHeight(height).WithBlockTime(blockTime)
+}
+
+// newKeepers returns the keepers such that:
+// - The annual pool incentives and staking rewards are 182,500 uosmo each.
+// - Half of the pool incentives go to the internal NoLock gauge of the default pool.
+// - The external gauge of the default pool distributes 10 uosmo over a single remaining epoch.
+// - The shares of the default pool are a superfluid asset and the staking APR is 10%.
+func newKeepers(volumeKeeper *mockPoolVolumeKeeper) commondomain.PoolAPRKeepers {
+	return commondomain.PoolAPRKeepers{
+		PoolManagerKeeper: volumeKeeper,
+		IncentivesKeeper: &mockIncentivesKeeper{
+			gauges: map[uint64]*incentivestypes.Gauge{
+				internalGaugeID: {
+					Id:          internalGaugeID,
+					IsPerpetual: true,
+					DistributeTo: lockuptypes.QueryCondition{
+						LockQueryType: lockuptypes.NoLock,
+					},
+				},
+			},
+			activeGauges: []incentivestypes.Gauge{
+				{
+					Id: externalGaugeID,
+					DistributeTo: lockuptypes.QueryCondition{
+						LockQueryType: lockuptypes.ByDuration,
+						Denom:         "gamm/pool/1",
+					},
+					Coins:             sdk.NewCoins(sdk.NewCoin(UOSMO, osmomath.NewInt(10))),
+					NumEpochsPaidOver: 1,
+				},
+			},
+		},
+		PoolIncentivesKeeper: &mockPoolIncentivesKeeper{
+			distrInfo: poolincentivestypes.DistrInfo{
+				TotalWeight: osmomath.NewInt(2),
+				Records: []poolincentivestypes.DistrRecord{
+					{GaugeId: poolincentivestypes.CommunityPoolDistributionGaugeID, Weight: osmomath.OneInt()},
+					{GaugeId: internalGaugeID, Weight: osmomath.OneInt()},
+				},
+			},
+		},
+		SuperfluidKeeper: &mockSuperfluidKeeper{},
+		MintKeeper:       &mockMintKeeper{},
+		EpochsKeeper:     &mockEpochsKeeper{},
+		StakingKeeper:    &mockStakingKeeper{},
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := poolsapr.New(newKeepers(&mockPoolVolumeKeeper{}), 0)
+	require.Error(t, err)
+
+	_, err = poolsapr.New(commondomain.PoolAPRKeepers{}, refreshIntervalBlock)
+	require.Error(t, err)
+
+	_, err = poolsapr.New(newKeepers(&mockPoolVolumeKeeper{}), refreshIntervalBlock)
+	require.NoError(t, err)
+}
+
+func TestIsRefreshDue(t *testing.T) {
+	computer, err := poolsapr.New(newKeepers(&mockPoolVolumeKeeper{}), refreshIntervalBlock)
+	require.NoError(t, err)
+
+	// Never refreshed
+	require.True(t, computer.IsRefreshDue(newContext(5, defaultStartTime)))
+
+	computer.Refresh(newContext(5, defaultStartTime), nil, uosmoValuer)
+
+	require.False(t, computer.IsRefreshDue(newContext(5, defaultStartTime)))
+	require.False(t, computer.IsRefreshDue(newContext(5+int64(refreshIntervalBlock)-1, defaultStartTime)))
+	require.True(t, computer.IsRefreshDue(newContext(5+int64(refreshIntervalBlock), defaultStartTime)))
+}
+
+func TestRefresh(t *testing.T) {
+	volumeKeeper := &mockPoolVolumeKeeper{volumes: map[uint64]osmomath.Int{}}
+
+	computer, err := poolsapr.New(newKeepers(volumeKeeper), refreshIntervalBlock)
+	require.NoError(t, err)
+
+	pool := newPool(defaultPoolID, defaultPoolLiquidityCap, sdk.NewCoins(sdk.NewCoin(UOSMO, osmomath.NewInt(500))))
+
+	_, _, ok := computer.Get(defaultPoolID)
+	require.False(t, ok)
+
+	// First refresh has no volume history.
+	computer.Refresh(newContext(1, defaultStartTime), []ingesttypes.PoolI{pool}, uosmoValuer)
+
+	aprData, feesData, ok := computer.Get(defaultPoolID)
+	require.True(t, ok)
+
+	require.True(t, feesData.IsStale)
+	require.False(t, feesData.IsError)
+	require.Equal(t, "1", feesData.PoolID)
+	require.Equal(t, "0.2%", feesData.FeesPercentage)
+	require.Zero(t, feesData.Volume24h)
+	require.Zero(t, feesData.Volume7d)
+
+	require.True(t, aprData.IsStale)
+	require.False(t, aprData.IsError)
+	require.Equal(t, defaultPoolID, aprData.PoolID)
+	require.Zero(t, aprData.SwapFees.Lower)
+	// 91,250 uosmo of annual internal incentives over 1,000 USDC of liquidity.
+	require.InDelta(t, 9125, aprData.OsmosisAPR.Lower, 1e-9)
+	require.Equal(t, aprData.OsmosisAPR.Lower, aprData.OsmosisAPR.Upper)
+	// 3,650 uosmo of annual external incentives over 1,000 USDC of liquidity.
+	require.InDelta(t, 365, aprData.BoostAPR.Lower, 1e-9)
+	// 10% staking APR on 250 risk adjusted uosmo over 1,000 USDC of liquidity.
+	require.InDelta(t, 2.5, aprData.SuperfluidAPR.Lower, 1e-9)
+	require.InDelta(t, 9125+365+2.5, aprData.TotalAPR.Lower, 1e-9)
+
+	// Second refresh 8 days later has the full history.
+	volumeKeeper.volumes[defaultPoolID] = osmomath.NewInt(1_000_000)
+	computer.Refresh(newContext(1+int64(refreshIntervalBlock), defaultStartTime.Add(8*day)), []ingesttypes.PoolI{pool}, uosmoValuer)
+
+	aprData, feesData, ok = computer.Get(defaultPoolID)
+	require.True(t, ok)
+
+	require.False(t, feesData.IsStale)
+	require.False(t, feesData.IsError)
+	require.InDelta(t, 1_000_000, feesData.Volume24h, 1e-9)
+	require.InDelta(t, 1_000_000, feesData.Volume7d, 1e-9)
+	require.InDelta(t, 2_000, feesData.FeesSpent24h, 1e-9)
+	require.InDelta(t, 2_000, feesData.FeesSpent7d, 1e-9)
+
+	require.False(t, aprData.IsStale)
+	// 2,000 USDC of fees over 8 days annualized over 1,000 USDC of liquidity.
+	require.InDelta(t, 2_000*365.0/8/1_000*100, aprData.SwapFees.Lower, 1e-6)
+}
+
+// Validates that the volume of a window is unavailable until the snapshots cover it.
+func TestRefresh_PartialVolumeHistory(t *testing.T) {
+	volumeKeeper := &mockPoolVolumeKeeper{volumes: map[uint64]osmomath.Int{}}
+
+	computer, err := poolsapr.New(newKeepers(volumeKeeper), refreshIntervalBlock)
+	require.NoError(t, err)
+
+	pool := newPool(defaultPoolID, defaultPoolLiquidityCap, sdk.NewCoins(sdk.NewCoin(UOSMO, osmomath.NewInt(500))))
+
+	computer.Refresh(newContext(1, defaultStartTime), []ingesttypes.PoolI{pool}, uosmoValuer)
+
+	// Two days later, only the 24h window is covered.
+	volumeKeeper.volumes[defaultPoolID] = osmomath.NewInt(1_000_000)
+	computer.Refresh(newContext(1+int64(refreshIntervalBlock), defaultStartTime.Add(2*day)), []ingesttypes.PoolI{pool}, uosmoValuer)
+
+	aprData, feesData, ok := computer.Get(defaultPoolID)
+	require.True(t, ok)
+
+	require.True(t, feesData.IsStale)
+	require.InDelta(t, 1_000_000, feesData.Volume24h, 1e-9)
+	require.InDelta(t, 2_000, feesData.FeesSpent24h, 1e-9)
+	// The 7d volume and the swap fee APR are not extrapolated from the partial history.
+	require.Zero(t, feesData.Volume7d)
+	require.Zero(t, feesData.FeesSpent7d)
+	require.True(t, aprData.IsStale)
+	require.Zero(t, aprData.SwapFees.Lower)
+}
+
+// Validates that the volume snapshots persisted in the store are resumed from
+// after a restart, and that the snapshots of dropped pools are deleted.
+func TestRefresh_VolumeSnapshotStore(t *testing.T) {
+	const sink = "sqs/localhost:50051"
+
+	volumeKeeper := &mockPoolVolumeKeeper{volumes: map[uint64]osmomath.Int{}}
+	snapshotStore := checkpoint.NewStore(dbm.NewMemDB())
+
+	computer, err := poolsapr.NewWithVolumeSnapshotStore(newKeepers(volumeKeeper), refreshIntervalBlock, snapshotStore, sink)
+	require.NoError(t, err)
+
+	pool := newPool(defaultPoolID, defaultPoolLiquidityCap, sdk.NewCoins(sdk.NewCoin(UOSMO, osmomath.NewInt(500))))
+
+	computer.Refresh(newContext(1, defaultStartTime), []ingesttypes.PoolI{pool}, uosmoValuer)
+
+	snapshots, err := snapshotStore.LoadVolumeSnapshots(sink)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+
+	// Restart 8 days later resumes from the persisted snapshot.
+	computer, err = poolsapr.NewWithVolumeSnapshotStore(newKeepers(volumeKeeper), refreshIntervalBlock, snapshotStore, sink)
+	require.NoError(t, err)
+
+	volumeKeeper.volumes[defaultPoolID] = osmomath.NewInt(1_000_000)
+	computer.Refresh(newContext(1+int64(refreshIntervalBlock), defaultStartTime.Add(8*day)), []ingesttypes.PoolI{pool}, uosmoValuer)
+
+	_, feesData, ok := computer.Get(defaultPoolID)
+	require.True(t, ok)
+	require.False(t, feesData.IsStale)
+	require.InDelta(t, 1_000_000, feesData.Volume7d, 1e-9)
+
+	// Both snapshots are kept since the first one is the latest at the start of the 7d window.
+	snapshots, err = snapshotStore.LoadVolumeSnapshots(sink)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+
+	// The snapshots of the pools that are no longer refreshed are deleted.
+	computer.Refresh(newContext(1+2*int64(refreshIntervalBlock), defaultStartTime.Add(9*day)), nil, uosmoValuer)
+
+	snapshots, err = snapshotStore.LoadVolumeSnapshots(sink)
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+}
+
+// Validates that the data of the pools that are no longer refreshed is dropped.
+func TestRefresh_DropsUnknownPools(t *testing.T) {
+	computer, err := poolsapr.New(newKeepers(&mockPoolVolumeKeeper{volumes: map[uint64]osmomath.Int{}}), refreshIntervalBlock)
+	require.NoError(t, err)
+
+	pool := newPool(defaultPoolID, defaultPoolLiquidityCap, sdk.NewCoins(sdk.NewCoin(UOSMO, osmomath.NewInt(500))))
+
+	computer.Refresh(newContext(1, defaultStartTime), []ingesttypes.PoolI{pool}, uosmoValuer)
+
+	_, _, ok := computer.Get(defaultPoolID)
+	require.True(t, ok)
+
+	computer.Refresh(newContext(1+int64(refreshIntervalBlock), defaultStartTime), nil, uosmoValuer)
+
+	_, _, ok = computer.Get(defaultPoolID)
+	require.False(t, ok)
+}
+
+func TestRefresh_Errors(t *testing.T) {
+	pool := newPool(defaultPoolID, defaultPoolLiquidityCap, sdk.NewCoins(sdk.NewCoin(UOSMO, osmomath.NewInt(500))))
+
+	t.Run("missing incentives and superfluid keepers", func(t *testing.T) {
+		computer, err := poolsapr.New(commondomain.PoolAPRKeepers{PoolManagerKeeper: &mockPoolVolumeKeeper{}}, refreshIntervalBlock)
+		require.NoError(t, err)
+
+		computer.Refresh(newContext(1, defaultStartTime), []ingesttypes.PoolI{pool}, uosmoValuer)
+
+		aprData, feesData, ok := computer.Get(defaultPoolID)
+		require.True(t, ok)
+		require.True(t, aprData.IsError)
+		require.False(t, feesData.IsError)
+		require.Zero(t, aprData.TotalAPR.Lower)
+	})
+
+	t.Run("valuation error", func(t *testing.T) {
+		computer, err := poolsapr.New(newKeepers(&mockPoolVolumeKeeper{}), refreshIntervalBlock)
+		require.NoError(t, err)
+
+		failingValuer := func(ctx sdk.Context, coins sdk.Coins) (osmomath.Dec, error) {
+			return osmomath.Dec{}, errValuation
+		}
+
+		computer.Refresh(newContext(1, defaultStartTime), []ingesttypes.PoolI{pool}, failingValuer)
+
+		// The volume is not valued until the window is covered.
+		aprData, feesData, ok := computer.Get(defaultPoolID)
+		require.True(t, ok)
+		require.True(t, aprData.IsError)
+		require.False(t, feesData.IsError)
+
+		computer.Refresh(newContext(1+int64(refreshIntervalBlock), defaultStartTime.Add(8*day)), []ingesttypes.PoolI{pool}, failingValuer)
+
+		aprData, feesData, ok = computer.Get(defaultPoolID)
+		require.True(t, ok)
+		require.True(t, aprData.IsError)
+		require.True(t, feesData.IsError)
+	})
+}
@@ -0,0 +1,46 @@
+package poolsapr
+
+import (
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// getSuperfluidInfo returns the staking APR in percent and the IDs of the pools
+// whose shares are superfluid assets.
+// The staking APR is the annualized staking share of the minted OSMO over the bonded tokens.
+// Note that it does not account for the validator commission and the transaction fees.
+func (c *poolAPRComputer) getSuperfluidInfo(ctx sdk.Context) (float64, map[uint64]struct{}, error) {
+	if c.keepers.SuperfluidKeeper == nil || c.keepers.StakingKeeper == nil || c.keepers.MintKeeper == nil || c.keepers.EpochsKeeper == nil {
+		return 0, nil, errors.New("superfluid keepers are not set")
+	}
+
+	superfluidPoolIDs := make(map[uint64]struct{})
+	for _, asset := range c.keepers.SuperfluidKeeper.GetAllSuperfluidAssets(ctx) {
+		poolID, ok := parsePoolIDFromDenom(asset.Denom, gammSharePrefix, concentratedSharePrefix)
+		if !ok {
+			continue
+		}
+		superfluidPoolIDs[poolID] = struct{}{}
+	}
+
+	totalBondedTokens, err := c.keepers.StakingKeeper.TotalBondedTokens(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !totalBondedTokens.IsPositive() {
+		return 0, superfluidPoolIDs, nil
+	}
+
+	mintEpochsPerYear, err := c.getMintEpochsPerYear(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	mintParams := c.keepers.MintKeeper.GetParams(ctx)
+	annualStakingRewards := c.keepers.MintKeeper.GetMinter(ctx).EpochProvisions.Mul(mintParams.DistributionProportions.Staking).Mul(mintEpochsPerYear)
+
+	stakingAPR := annualStakingRewards.QuoInt(totalBondedTokens).Mul(oneHundredDec).MustFloat64()
+
+	return stakingAPR, superfluidPoolIDs, nil
+}
@@ -0,0 +1,242 @@
+package poolsdump
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+// Pool is a pool as it would be pushed to SQS.
+// The models are serialized the same way as by the SQS gRPC client.
+// The APR and fees data are not dumped since they depend on the refresh history of the node.
+type Pool struct {
+	ID         uint64          `json:"id"`
+	ChainModel json.RawMessage `json:"chain_model"`
+	SQSModel   json.RawMessage `json:"sqs_model"`
+	TickModel  json.RawMessage `json:"tick_model,omitempty"`
+}
+
+// Dump is the output of the SQS transformer at a height.
+type Dump struct {
+	Height    int64                   `json:"height"`
+	Pools     []Pool                  `json:"pools"`
+	TakerFees ingesttypes.TakerFeeMap `json:"taker_fees"`
+}
+
+// New returns a dump of the given transformed pools and taker fees sorted by pool ID.
+// If poolIDs is non-empty, only the pools with the given IDs are dumped.
+func New(appCodec codec.Codec, height int64, pools []ingesttypes.PoolI, takerFees ingesttypes.TakerFeeMap, poolIDs []uint64) (Dump, error) {
+	selectedPoolIDs := make(map[uint64]struct{}, len(poolIDs))
+	for _, poolID := range poolIDs {
+		selectedPoolIDs[poolID] = struct{}{}
+	}
+
+	dump := Dump{
+		Height:    height,
+		Pools:     make([]Pool, 0, len(pools)),
+		TakerFees: takerFees,
+	}
+
+	for _, pool := range pools {
+		if _, ok := selectedPoolIDs[pool.GetId()]; len(selectedPoolIDs) > 0 && !ok {
+			continue
+		}
+
+		dumpedPool, err := newPool(appCodec, pool)
+		if err != nil {
+			return Dump{}, fmt.Errorf("failed to dump pool %d: %w", pool.GetId(), err)
+		}
+
+		dump.Pools = append(dump.Pools, dumpedPool)
+	}
+
+	sort.Slice(dump.Pools, func(i, j int) bool {
+		return dump.Pools[i].ID < dump.Pools[j].ID
+	})
+
+	return dump, nil
+}
+
+// newPool serializes the models of the given pool.
+func newPool(appCodec codec.Codec, pool ingesttypes.PoolI) (Pool, error) {
+	chainModelBz, err := appCodec.MarshalInterfaceJSON(pool.GetUnderlyingPool())
+	if err != nil {
+		return Pool{}, err
+	}
+
+	sqsModelBz, err := json.Marshal(pool.GetSQSPoolModel())
+	if err != nil {
+		return Pool{}, err
+	}
+
+	var tickModelBz []byte
+	if pool.GetType() == poolmanagertypes.Concentrated {
+		tickModel, err := pool.GetTickModel()
+		if err != nil {
+			return Pool{}, err
+		}
+
+		tickModelBz, err = json.Marshal(tickModel)
+		if err != nil {
+			return Pool{}, err
+		}
+	}
+
+	return Pool{
+		ID:         pool.GetId(),
+		ChainModel: chainModelBz,
+		SQSModel:   sqsModelBz,
+		TickModel:  tickModelBz,
+	}, nil
+}
+
+// ReadFile reads a dump previously written to the given file.
+func ReadFile(path string) (Dump, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return Dump{}, err
+	}
+
+	// TakerFeeMap.UnmarshalJSON writes into the existing map.
+	dump := Dump{TakerFees: ingesttypes.TakerFeeMap{}}
+	if err := json.Unmarshal(bz, &dump); err != nil {
+		return Dump{}, fmt.Errorf("failed to unmarshal pools dump %s: %w", path, err)
+	}
+
+	return dump, nil
+}
+
+// Diff is the difference between two dumps.
+type Diff struct {
+	FromHeight int64 `json:"from_height"`
+	ToHeight   int64 `json:"to_height"`
+
+	AddedPoolIDs   []uint64 `json:"added_pool_ids"`
+	RemovedPoolIDs []uint64 `json:"removed_pool_ids"`
+	ChangedPoolIDs []uint64 `json:"changed_pool_ids"`
+
+	AddedTakerFees   []string `json:"added_taker_fees"`
+	RemovedTakerFees []string `json:"removed_taker_fees"`
+	ChangedTakerFees []string `json:"changed_taker_fees"`
+}
+
+// IsEmpty returns true if the dumps have the same pools and taker fees.
+func (d Diff) IsEmpty() bool {
+	return len(d.AddedPoolIDs) == 0 && len(d.RemovedPoolIDs) == 0 && len(d.ChangedPoolIDs) == 0 &&
+		len(d.AddedTakerFees) == 0 && len(d.RemovedTakerFees) == 0 && len(d.ChangedTakerFees) == 0
+}
+
+// NewDiff returns the difference from the previous to the current dump.
+// Pools are compared by their serialized models regardless of the JSON formatting.
+// Taker fees are identified by "denom0|denom1".
+func NewDiff(previous, current Dump) (Diff, error) {
+	diff := Diff{
+		FromHeight: previous.Height,
+		ToHeight:   current.Height,
+	}
+
+	previousPools := make(map[uint64]Pool, len(previous.Pools))
+	for _, pool := range previous.Pools {
+		previousPools[pool.ID] = pool
+	}
+
+	currentPoolIDs := make(map[uint64]struct{}, len(current.Pools))
+	for _, pool := range current.Pools {
+		currentPoolIDs[pool.ID] = struct{}{}
+
+		previousPool, ok := previousPools[pool.ID]
+		if !ok {
+			diff.AddedPoolIDs = append(diff.AddedPoolIDs, pool.ID)
+			continue
+		}
+
+		isEqual, err := isPoolEqual(previousPool, pool)
+		if err != nil {
+			return Diff{}, fmt.Errorf("failed to compare pool %d: %w", pool.ID, err)
+		}
+
+		if !isEqual {
+			diff.ChangedPoolIDs = append(diff.ChangedPoolIDs, pool.ID)
+		}
+	}
+
+	for _, pool := range previous.Pools {
+		if _, ok := currentPoolIDs[pool.ID]; !ok {
+			diff.RemovedPoolIDs = append(diff.RemovedPoolIDs, pool.ID)
+		}
+	}
+
+	for denomPair, takerFee := range current.TakerFees {
+		previousTakerFee, ok := previous.TakerFees[denomPair]
+		if !ok {
+			diff.AddedTakerFees = append(diff.AddedTakerFees, takerFeeKey(denomPair))
+		} else if !previousTakerFee.Equal(takerFee) {
+			diff.ChangedTakerFees = append(diff.ChangedTakerFees, takerFeeKey(denomPair))
+		}
+	}
+
+	for denomPair := range previous.TakerFees {
+		if _, ok := current.TakerFees[denomPair]; !ok {
+			diff.RemovedTakerFees = append(diff.RemovedTakerFees, takerFeeKey(denomPair))
+		}
+	}
+
+	sortUint64s(diff.AddedPoolIDs)
+	sortUint64s(diff.RemovedPoolIDs)
+	sortUint64s(diff.ChangedPoolIDs)
+	sort.Strings(diff.AddedTakerFees)
+	sort.Strings(diff.RemovedTakerFees)
+	sort.Strings(diff.ChangedTakerFees)
+
+	return diff, nil
+}
+
+// isPoolEqual returns true if the serialized models of the pools are equal.
+func isPoolEqual(a, b Pool) (bool, error) {
+	for _, models := range [][2]json.RawMessage{
+		{a.ChainModel, b.ChainModel},
+		{a.SQSModel, b.SQSModel},
+		{a.TickModel, b.TickModel},
+	} {
+		isEqual, err := isJSONEqual(models[0], models[1])
+		if err != nil || !isEqual {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// isJSONEqual returns true if a and b are the same JSON regardless of insignificant whitespace.
+func isJSONEqual(a, b json.RawMessage) (bool, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b), nil
+	}
+
+	var compactA, compactB bytes.Buffer
+	if err := json.Compact(&compactA, a); err != nil {
+		return false, err
+	}
+	if err := json.Compact(&compactB, b); err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(compactA.Bytes(), compactB.Bytes()), nil
+}
+
+func takerFeeKey(denomPair ingesttypes.DenomPair) string {
+	return denomPair.Denom0 + "|" + denomPair.Denom1
+}
+
+func sortUint64s(values []uint64) {
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] < values[j]
+	})
+}
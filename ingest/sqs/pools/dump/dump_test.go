@@ -0,0 +1,108 @@
+package poolsdump_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	poolsdump "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/dump"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+)
+
+var (
+	uosmoUSDC = ingesttypes.DenomPair{Denom0: "uosmo", Denom1: "usdc"}
+	usdcUOSMO = ingesttypes.DenomPair{Denom0: "usdc", Denom1: "uosmo"}
+	uosmoATOM = ingesttypes.DenomPair{Denom0: "uosmo", Denom1: "uatom"}
+)
+
+func newPool(id uint64, sqsModel string) poolsdump.Pool {
+	return poolsdump.Pool{
+		ID:         id,
+		ChainModel: json.RawMessage(fmt.Sprintf(`{"id":"%d"}`, id)),
+		SQSModel:   json.RawMessage(sqsModel),
+	}
+}
+
+// Validates that the added, removed and changed pools and taker fees are reported.
+func TestNewDiff(t *testing.T) {
+	previous := poolsdump.Dump{
+		Height: 10,
+		Pools: []poolsdump.Pool{
+			newPool(1, `{"balances":[]}`),
+			newPool(2, `{"balances":[]}`),
+			newPool(3, `{"balances":[]}`),
+		},
+		TakerFees: ingesttypes.TakerFeeMap{
+			uosmoUSDC: osmomath.MustNewDecFromStr("0.001"),
+			usdcUOSMO: osmomath.MustNewDecFromStr("0.001"),
+			uosmoATOM: osmomath.MustNewDecFromStr("0.002"),
+		},
+	}
+
+	current := poolsdump.Dump{
+		Height: 11,
+		Pools: []poolsdump.Pool{
+			// Formatting only
+			newPool(1, "{\n  \"balances\": []\n}"),
+			newPool(3, `{"balances":[{"denom":"uosmo","amount":"1"}]}`),
+			newPool(4, `{"balances":[]}`),
+		},
+		TakerFees: ingesttypes.TakerFeeMap{
+			uosmoUSDC: osmomath.MustNewDecFromStr("0.001"),
+			usdcUOSMO: osmomath.MustNewDecFromStr("0.0015"),
+		},
+	}
+
+	diff, err := poolsdump.NewDiff(previous, current)
+	require.NoError(t, err)
+	require.False(t, diff.IsEmpty())
+
+	require.Equal(t, poolsdump.Diff{
+		FromHeight: 10,
+		ToHeight:   11,
+
+		AddedPoolIDs:   []uint64{4},
+		RemovedPoolIDs: []uint64{2},
+		ChangedPoolIDs: []uint64{3},
+
+		RemovedTakerFees: []string{"uosmo|uatom"},
+		ChangedTakerFees: []string{"usdc|uosmo"},
+	}, diff)
+
+	diff, err = poolsdump.NewDiff(current, current)
+	require.NoError(t, err)
+	require.True(t, diff.IsEmpty())
+}
+
+// Validates that a written dump is read back with its taker fees.
+func TestReadFile(t *testing.T) {
+	dump := poolsdump.Dump{
+		Height: 10,
+		Pools: []poolsdump.Pool{
+			newPool(1, `{"balances":[]}`),
+		},
+		TakerFees: ingesttypes.TakerFeeMap{
+			uosmoUSDC: osmomath.MustNewDecFromStr("0.001"),
+		},
+	}
+
+	bz, err := json.MarshalIndent(dump, "", "  ")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "pools.json")
+	require.NoError(t, os.WriteFile(path, bz, 0o644))
+
+	readDump, err := poolsdump.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, dump.Height, readDump.Height)
+	require.Equal(t, dump.TakerFees, readDump.TakerFees)
+
+	diff, err := poolsdump.NewDiff(dump, readDump)
+	require.NoError(t, err)
+	require.True(t, diff.IsEmpty())
+}
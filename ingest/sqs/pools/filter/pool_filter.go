@@ -0,0 +1,145 @@
+package poolsfilter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+// Config defines the rules for filtering the pools pushed to SQS.
+// Zero values disable the respective rule.
+type Config struct {
+	// MinPoolLiquidityCap is the minimum pool liquidity capitalization (in USDC) of the pushed pools.
+	MinPoolLiquidityCap uint64
+	// AllowedPoolIDs are the IDs of the pools that are pushed regardless of the other rules
+	// except for the denylist.
+	AllowedPoolIDs []uint64
+	// DeniedPoolIDs are the IDs of the pools that are never pushed.
+	DeniedPoolIDs []uint64
+	// AllowedPoolTypes are the names of the pushed pool types (e.g. "Balancer", "Concentrated").
+	// If empty, all pool types are pushed.
+	AllowedPoolTypes []string
+	// DeniedDenoms are the denoms such that pools containing any of them are not pushed.
+	DeniedDenoms []string
+}
+
+// poolFilter is a domain.PoolFilter that filters pools based on the rules in Config.
+type poolFilter struct {
+	minPoolLiquidityCap osmomath.Int
+	allowedPoolIDs      map[uint64]struct{}
+	deniedPoolIDs       map[uint64]struct{}
+	allowedPoolTypes    map[poolmanagertypes.PoolType]struct{}
+	deniedDenoms        map[string]struct{}
+}
+
+var _ domain.PoolFilter = &poolFilter{}
+
+// New returns a new pool filter for the given config.
+// Returns error if the config is invalid.
+func New(config Config) (domain.PoolFilter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	allowedPoolTypes := make(map[poolmanagertypes.PoolType]struct{}, len(config.AllowedPoolTypes))
+	for _, poolTypeStr := range config.AllowedPoolTypes {
+		// Validated above
+		poolType, _ := parsePoolType(poolTypeStr)
+		allowedPoolTypes[poolType] = struct{}{}
+	}
+
+	deniedDenoms := make(map[string]struct{}, len(config.DeniedDenoms))
+	for _, denom := range config.DeniedDenoms {
+		deniedDenoms[denom] = struct{}{}
+	}
+
+	return &poolFilter{
+		minPoolLiquidityCap: osmomath.NewIntFromUint64(config.MinPoolLiquidityCap),
+		allowedPoolIDs:      toPoolIDSet(config.AllowedPoolIDs),
+		deniedPoolIDs:       toPoolIDSet(config.DeniedPoolIDs),
+		allowedPoolTypes:    allowedPoolTypes,
+		deniedDenoms:        deniedDenoms,
+	}, nil
+}
+
+// Validate validates the config.
+// Returns error if a pool type is unknown or if a pool ID is both allowed and denied.
+func (c Config) Validate() error {
+	for _, poolTypeStr := range c.AllowedPoolTypes {
+		if _, err := parsePoolType(poolTypeStr); err != nil {
+			return err
+		}
+	}
+
+	deniedPoolIDs := toPoolIDSet(c.DeniedPoolIDs)
+	for _, poolID := range c.AllowedPoolIDs {
+		if _, ok := deniedPoolIDs[poolID]; ok {
+			return fmt.Errorf("pool (%d) is both allowed and denied", poolID)
+		}
+	}
+
+	return nil
+}
+
+// ShouldRemove implements domain.PoolFilter.
+// The rules are evaluated in the following order:
+// - denied pool IDs are removed.
+// - allowed pool IDs are kept.
+// - pools of types that are not allowed are removed.
+// - pools containing a denied denom are removed.
+// - pools with liquidity capitalization below the minimum are removed.
+func (f *poolFilter) ShouldRemove(pool ingesttypes.PoolI) bool {
+	poolID := pool.GetId()
+
+	if _, ok := f.deniedPoolIDs[poolID]; ok {
+		return true
+	}
+
+	if _, ok := f.allowedPoolIDs[poolID]; ok {
+		return false
+	}
+
+	if len(f.allowedPoolTypes) > 0 {
+		if _, ok := f.allowedPoolTypes[pool.GetType()]; !ok {
+			return true
+		}
+	}
+
+	for _, denom := range pool.GetPoolDenoms() {
+		if _, ok := f.deniedDenoms[denom]; ok {
+			return true
+		}
+	}
+
+	if f.minPoolLiquidityCap.IsZero() {
+		return false
+	}
+
+	// Pools whose liquidity capitalization failed to compute are treated as having none.
+	poolLiquidityCap := pool.GetPoolLiquidityCap()
+	return poolLiquidityCap.IsNil() || poolLiquidityCap.LT(f.minPoolLiquidityCap)
+}
+
+// parsePoolType parses the case-insensitive name of the pool type.
+func parsePoolType(poolTypeStr string) (poolmanagertypes.PoolType, error) {
+	for name, value := range poolmanagertypes.PoolType_value {
+		if strings.EqualFold(name, poolTypeStr) {
+			return poolmanagertypes.PoolType(value), nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown pool type (%s)", poolTypeStr)
+}
+
+// toPoolIDSet converts the pool IDs to a set.
+func toPoolIDSet(poolIDs []uint64) map[uint64]struct{} {
+	result := make(map[uint64]struct{}, len(poolIDs))
+	for _, poolID := range poolIDs {
+		result[poolID] = struct{}{}
+	}
+	return result
+}
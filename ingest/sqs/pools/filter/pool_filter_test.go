@@ -0,0 +1,192 @@
+package poolsfilter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	poolsfilter "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/filter"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+const (
+	UOSMO = "uosmo"
+	USDC  = "usdc"
+	SCAM  = "scam"
+)
+
+// mockChainPool is a chain pool model that only implements the ID and the type.
+type mockChainPool struct {
+	poolmanagertypes.PoolI
+
+	id       uint64
+	poolType poolmanagertypes.PoolType
+}
+
+func (p *mockChainPool) GetId() uint64 {
+	return p.id
+}
+
+func (p *mockChainPool) GetType() poolmanagertypes.PoolType {
+	return p.poolType
+}
+
+func newPool(id uint64, poolType poolmanagertypes.PoolType, poolLiquidityCap osmomath.Int, denoms ...string) ingesttypes.PoolI {
+	return &ingesttypes.PoolWrapper{
+		ChainModel: &mockChainPool{id: id, poolType: poolType},
+		SQSModel: ingesttypes.SQSPool{
+			PoolLiquidityCap: poolLiquidityCap,
+			PoolDenoms:       denoms,
+		},
+	}
+}
+
+func TestShouldRemove(t *testing.T) {
+	var (
+		defaultLiquidityCap = osmomath.NewInt(1_000)
+
+		balancerPool     = newPool(1, poolmanagertypes.Balancer, defaultLiquidityCap, UOSMO, USDC)
+		concentratedPool = newPool(2, poolmanagertypes.Concentrated, defaultLiquidityCap, UOSMO, USDC)
+		scamPool         = newPool(3, poolmanagertypes.Balancer, defaultLiquidityCap, UOSMO, SCAM)
+		illiquidPool     = newPool(4, poolmanagertypes.Balancer, osmomath.NewInt(999), UOSMO, USDC)
+		noCapPool        = newPool(5, poolmanagertypes.Balancer, osmomath.Int{}, UOSMO, USDC)
+	)
+
+	tests := []struct {
+		name   string
+		config poolsfilter.Config
+		pool   ingesttypes.PoolI
+
+		expectedShouldRemove bool
+	}{
+		{
+			name:   "empty config keeps all pools",
+			config: poolsfilter.Config{},
+			pool:   noCapPool,
+
+			expectedShouldRemove: false,
+		},
+		{
+			name:   "denied pool ID",
+			config: poolsfilter.Config{DeniedPoolIDs: []uint64{balancerPool.GetId()}},
+			pool:   balancerPool,
+
+			expectedShouldRemove: true,
+		},
+		{
+			name:   "pool type not allowed",
+			config: poolsfilter.Config{AllowedPoolTypes: []string{"balancer"}},
+			pool:   concentratedPool,
+
+			expectedShouldRemove: true,
+		},
+		{
+			name:   "pool type allowed",
+			config: poolsfilter.Config{AllowedPoolTypes: []string{"Balancer", "Concentrated"}},
+			pool:   concentratedPool,
+
+			expectedShouldRemove: false,
+		},
+		{
+			name:   "denied denom",
+			config: poolsfilter.Config{DeniedDenoms: []string{SCAM}},
+			pool:   scamPool,
+
+			expectedShouldRemove: true,
+		},
+		{
+			name:   "liquidity cap below minimum",
+			config: poolsfilter.Config{MinPoolLiquidityCap: 1_000},
+			pool:   illiquidPool,
+
+			expectedShouldRemove: true,
+		},
+		{
+			name:   "liquidity cap equal to minimum",
+			config: poolsfilter.Config{MinPoolLiquidityCap: 1_000},
+			pool:   balancerPool,
+
+			expectedShouldRemove: false,
+		},
+		{
+			name:   "no liquidity cap with minimum",
+			config: poolsfilter.Config{MinPoolLiquidityCap: 1},
+			pool:   noCapPool,
+
+			expectedShouldRemove: true,
+		},
+		{
+			name: "allowed pool ID bypasses other filters",
+			config: poolsfilter.Config{
+				MinPoolLiquidityCap: 1_000,
+				AllowedPoolIDs:      []uint64{scamPool.GetId(), illiquidPool.GetId()},
+				AllowedPoolTypes:    []string{"Concentrated"},
+				DeniedDenoms:        []string{SCAM},
+			},
+			pool: scamPool,
+
+			expectedShouldRemove: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			poolFilter, err := poolsfilter.New(tc.config)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedShouldRemove, poolFilter.ShouldRemove(tc.pool))
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config poolsfilter.Config
+
+		expectError bool
+	}{
+		{
+			name:   "empty config",
+			config: poolsfilter.Config{},
+		},
+		{
+			name: "valid config",
+			config: poolsfilter.Config{
+				MinPoolLiquidityCap: 1_000,
+				AllowedPoolIDs:      []uint64{1},
+				DeniedPoolIDs:       []uint64{2},
+				AllowedPoolTypes:    []string{"stableswap", "CosmWasm"},
+				DeniedDenoms:        []string{SCAM},
+			},
+		},
+		{
+			name:   "unknown pool type",
+			config: poolsfilter.Config{AllowedPoolTypes: []string{"orderbook"}},
+
+			expectError: true,
+		},
+		{
+			name: "pool ID both allowed and denied",
+			config: poolsfilter.Config{
+				AllowedPoolIDs: []uint64{1, 2},
+				DeniedPoolIDs:  []uint64{2},
+			},
+
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
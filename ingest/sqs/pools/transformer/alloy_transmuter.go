@@ -8,6 +8,7 @@ import (
 	sqscosmwasmpool "github.com/osmosis-labs/osmosis/v30/ingest/types/cosmwasmpool"
 
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
 )
 
 const (
@@ -16,29 +17,35 @@ const (
 	listLimitersQueryString     = `{"list_limiters":{}}`
 )
 
-// updateAlloyTransmuterInfo updates cosmwasmPoolModel with alloyed transmuter specific info.
+// alloyTransmuterHandler is the CosmWasm pool handler for alloyed transmuter contracts.
+type alloyTransmuterHandler struct{}
+
+var _ domain.CosmWasmPoolHandler = alloyTransmuterHandler{}
+
+// UpdateModel implements domain.CosmWasmPoolHandler.
 // - It queries alloyed transmuter contract asset configs and share denom, the construct
 // `AlloyTransmuterData`. Share denom for alloyed transmuter is the alloyed denom.
 // - append the alloyed denom to pool denoms.
-func (pi *poolTransformer) updateAlloyTransmuterInfo(
+func (alloyTransmuterHandler) UpdateModel(
 	ctx sdk.Context,
+	wasmKeeper commondomain.WasmKeeper,
 	poolId uint64,
 	contractAddress sdk.AccAddress,
 	cosmWasmPoolModel *sqscosmwasmpool.CosmWasmPoolModel,
 	poolDenoms *[]string,
 ) error {
-	assetConfigs, err := alloyTransmuterListAssetConfig(ctx, pi.wasmKeeper, poolId, contractAddress)
+	assetConfigs, err := alloyTransmuterListAssetConfig(ctx, wasmKeeper, poolId, contractAddress)
 	if err != nil {
 		return err
 	}
 
 	// share denom of alloy transmuter pool is an alloyed denom
-	alloyedDenom, err := alloyTransmuterGetShareDenom(ctx, pi.wasmKeeper, poolId, contractAddress)
+	alloyedDenom, err := alloyTransmuterGetShareDenom(ctx, wasmKeeper, poolId, contractAddress)
 	if err != nil {
 		return err
 	}
 
-	rateLimiterData, err := alloyTransmuterListLimiters(ctx, pi.wasmKeeper, poolId, contractAddress)
+	rateLimiterData, err := alloyTransmuterListLimiters(ctx, wasmKeeper, poolId, contractAddress)
 	if err != nil {
 		return err
 	}
@@ -66,17 +66,15 @@ func (s *PoolTransformerTestSuite) TestUpdateAlloyedTransmuterPool() {
 		Moderator:                       s.TestAccs[1].String(),
 	})
 
-	// Create OSMO / USDC pool
-	// Note that spot price is 1 OSMO = 2 USDC
-	usdcOsmoPoolID := s.PrepareBalancerPoolWithCoins(sdk.NewCoin(USDC, defaultAmount), sdk.NewCoin(UOSMO, halfDefaultAmount))
-
-	// Initialize the pool ingester
-	poolIngester := s.initializePoolIngester(usdcOsmoPoolID)
+	// The handler registered for alloyed transmuter pools by default.
+	handler, found := poolstransformer.DefaultCosmWasmPoolHandlerRegistry().Get(sqscosmwasmpool.ContractInfo{Contract: sqscosmwasmpool.ALLOY_TRANSMUTER_CONTRACT_NAME, Version: sqscosmwasmpool.ALLOY_TRANSMUTER_MIN_CONTRACT_VERSION})
+	s.Require().True(found)
 
 	cosmWasmPoolModel := sqscosmwasmpool.CosmWasmPoolModel{}
 	poolDenoms := []string{apptesting.DefaultTransmuterDenomA, apptesting.DefaultTransmuterDenomB}
 
-	poolIngester.UpdateAlloyTransmuterInfo(s.Ctx, pool.GetId(), pool.GetAddress(), &cosmWasmPoolModel, &poolDenoms)
+	err := handler.UpdateModel(s.Ctx, s.App.WasmKeeper, pool.GetId(), pool.GetAddress(), &cosmWasmPoolModel, &poolDenoms)
+	s.Require().NoError(err)
 
 	alloyedDenom := fmt.Sprintf("factory/%s/alloyed/%s", pool.GetAddress(), apptesting.DefaultAlloyedSubDenom)
 
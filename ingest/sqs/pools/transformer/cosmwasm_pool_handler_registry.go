@@ -0,0 +1,93 @@
+package poolstransformer
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	sqscosmwasmpool "github.com/osmosis-labs/osmosis/v30/ingest/types/cosmwasmpool"
+)
+
+// CosmWasmPoolHandlerFunc is an adapter to allow the use of ordinary functions as CosmWasm pool handlers.
+type CosmWasmPoolHandlerFunc func(ctx sdk.Context, wasmKeeper commondomain.WasmKeeper, poolId uint64, contractAddress sdk.AccAddress, model *sqscosmwasmpool.CosmWasmPoolModel, poolDenoms *[]string) error
+
+var _ domain.CosmWasmPoolHandler = CosmWasmPoolHandlerFunc(nil)
+
+// UpdateModel implements domain.CosmWasmPoolHandler.
+func (f CosmWasmPoolHandlerFunc) UpdateModel(ctx sdk.Context, wasmKeeper commondomain.WasmKeeper, poolId uint64, contractAddress sdk.AccAddress, model *sqscosmwasmpool.CosmWasmPoolModel, poolDenoms *[]string) error {
+	return f(ctx, wasmKeeper, poolId, contractAddress, model, poolDenoms)
+}
+
+// registeredCosmWasmPoolHandler is a handler together with the cw2 contract info it applies to.
+type registeredCosmWasmPoolHandler struct {
+	contract           string
+	versionConstraints *semver.Constraints
+	handler            domain.CosmWasmPoolHandler
+}
+
+// CosmWasmPoolHandlerRegistry maps cw2 contract names and version constraints
+// to the handlers that instrument the matching pools with contract specific data.
+// CosmWasm pools that match no handler are ingested with contract info only.
+type CosmWasmPoolHandlerRegistry struct {
+	handlers []registeredCosmWasmPoolHandler
+}
+
+// NewCosmWasmPoolHandlerRegistry returns an empty registry.
+func NewCosmWasmPoolHandlerRegistry() *CosmWasmPoolHandlerRegistry {
+	return &CosmWasmPoolHandlerRegistry{}
+}
+
+// DefaultCosmWasmPoolHandlerRegistry returns a registry with the handlers
+// for the natively supported contracts: alloyed transmuter and orderbook.
+func DefaultCosmWasmPoolHandlerRegistry() *CosmWasmPoolHandlerRegistry {
+	registry := NewCosmWasmPoolHandlerRegistry()
+
+	if err := registry.Register(sqscosmwasmpool.ALLOY_TRANSMUTER_CONTRACT_NAME, sqscosmwasmpool.ALLOY_TRANSMUTER_CONTRACT_VERSION_CONSTRAINT, alloyTransmuterHandler{}); err != nil {
+		panic(err)
+	}
+
+	if err := registry.Register(sqscosmwasmpool.ORDERBOOK_CONTRACT_NAME, sqscosmwasmpool.ORDERBOOK_CONTRACT_VERSION_CONSTRAINT, orderbookHandler{}); err != nil {
+		panic(err)
+	}
+
+	return registry
+}
+
+// Register registers the handler for pools whose cw2 contract name equals contract
+// and whose version satisfies versionConstraint (e.g. ">= 1.0.0, < 2.0.0").
+// If several handlers match a pool, the one registered last takes precedence.
+// This allows overriding the default handlers.
+// Returns error if the version constraint is invalid or the handler is nil.
+func (r *CosmWasmPoolHandlerRegistry) Register(contract string, versionConstraint string, handler domain.CosmWasmPoolHandler) error {
+	if handler == nil {
+		return fmt.Errorf("nil CosmWasm pool handler for contract (%s)", contract)
+	}
+
+	versionConstraints, err := semver.NewConstraint(versionConstraint)
+	if err != nil {
+		return fmt.Errorf("invalid version constraint (%s) for contract (%s): %w", versionConstraint, contract, err)
+	}
+
+	r.handlers = append(r.handlers, registeredCosmWasmPoolHandler{
+		contract:           contract,
+		versionConstraints: versionConstraints,
+		handler:            handler,
+	})
+
+	return nil
+}
+
+// Get returns the handler for the given contract info.
+// Returns false if no registered handler matches.
+func (r *CosmWasmPoolHandlerRegistry) Get(contractInfo sqscosmwasmpool.ContractInfo) (domain.CosmWasmPoolHandler, bool) {
+	for i := len(r.handlers) - 1; i >= 0; i-- {
+		if contractInfo.Matches(r.handlers[i].contract, r.handlers[i].versionConstraints) {
+			return r.handlers[i].handler, true
+		}
+	}
+
+	return nil, false
+}
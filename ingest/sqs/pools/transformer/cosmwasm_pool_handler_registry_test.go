@@ -0,0 +1,219 @@
+package poolstransformer_test
+
+import (
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	poolstransformer "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/transformer"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	sqscosmwasmpool "github.com/osmosis-labs/osmosis/v30/ingest/types/cosmwasmpool"
+)
+
+const (
+	transmuterContractName = "crates.io:transmuter"
+	testExtensionKey       = "test"
+)
+
+// namedCosmWasmPoolHandler is a no-op handler that is identified by its name.
+type namedCosmWasmPoolHandler struct {
+	name string
+}
+
+// UpdateModel implements domain.CosmWasmPoolHandler.
+func (namedCosmWasmPoolHandler) UpdateModel(ctx sdk.Context, wasmKeeper commondomain.WasmKeeper, poolId uint64, contractAddress sdk.AccAddress, model *sqscosmwasmpool.CosmWasmPoolModel, poolDenoms *[]string) error {
+	return nil
+}
+
+type testExtensionData struct {
+	PoolId  uint64 `json:"pool_id"`
+	Version string `json:"version"`
+}
+
+func (s *PoolTransformerTestSuite) TestCosmWasmPoolHandlerRegistry() {
+	type registration struct {
+		contract   string
+		constraint string
+		name       string
+	}
+
+	tests := []struct {
+		name          string
+		registrations []registration
+		contractInfo  sqscosmwasmpool.ContractInfo
+
+		expectedHandlerName string
+		expectedFound       bool
+	}{
+		{
+			name: "matches contract and version",
+			registrations: []registration{
+				{contract: transmuterContractName, constraint: ">= 3.0.0", name: "transmuter"},
+			},
+			contractInfo: sqscosmwasmpool.ContractInfo{Contract: transmuterContractName, Version: "3.1.0"},
+
+			expectedHandlerName: "transmuter",
+			expectedFound:       true,
+		},
+		{
+			name: "version does not satisfy constraint",
+			registrations: []registration{
+				{contract: transmuterContractName, constraint: ">= 3.0.0", name: "transmuter"},
+			},
+			contractInfo: sqscosmwasmpool.ContractInfo{Contract: transmuterContractName, Version: "2.0.0"},
+
+			expectedFound: false,
+		},
+		{
+			name: "contract does not match",
+			registrations: []registration{
+				{contract: transmuterContractName, constraint: ">= 3.0.0", name: "transmuter"},
+			},
+			contractInfo: sqscosmwasmpool.ContractInfo{Contract: "crates.io:other", Version: "3.0.0"},
+
+			expectedFound: false,
+		},
+		{
+			name: "invalid semver",
+			registrations: []registration{
+				{contract: transmuterContractName, constraint: ">= 3.0.0", name: "transmuter"},
+			},
+			contractInfo: sqscosmwasmpool.ContractInfo{Contract: transmuterContractName, Version: "invalid"},
+
+			expectedFound: false,
+		},
+		{
+			name: "selects by version range",
+			registrations: []registration{
+				{contract: transmuterContractName, constraint: "< 3.0.0", name: "v2"},
+				{contract: transmuterContractName, constraint: ">= 3.0.0", name: "v3"},
+			},
+			contractInfo: sqscosmwasmpool.ContractInfo{Contract: transmuterContractName, Version: "2.5.0"},
+
+			expectedHandlerName: "v2",
+			expectedFound:       true,
+		},
+		{
+			name: "last registered takes precedence",
+			registrations: []registration{
+				{contract: transmuterContractName, constraint: ">= 3.0.0", name: "default"},
+				{contract: transmuterContractName, constraint: ">= 3.1.0", name: "override"},
+			},
+			contractInfo: sqscosmwasmpool.ContractInfo{Contract: transmuterContractName, Version: "3.1.0"},
+
+			expectedHandlerName: "override",
+			expectedFound:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			registry := poolstransformer.NewCosmWasmPoolHandlerRegistry()
+			for _, r := range tc.registrations {
+				err := registry.Register(r.contract, r.constraint, namedCosmWasmPoolHandler{name: r.name})
+				s.Require().NoError(err)
+			}
+
+			handler, found := registry.Get(tc.contractInfo)
+
+			s.Require().Equal(tc.expectedFound, found)
+			if tc.expectedFound {
+				s.Require().Equal(namedCosmWasmPoolHandler{name: tc.expectedHandlerName}, handler)
+			}
+		})
+	}
+}
+
+func (s *PoolTransformerTestSuite) TestCosmWasmPoolHandlerRegistry_RegisterError() {
+	registry := poolstransformer.NewCosmWasmPoolHandlerRegistry()
+
+	err := registry.Register(transmuterContractName, "invalid", namedCosmWasmPoolHandler{})
+	s.Require().Error(err)
+
+	err = registry.Register(transmuterContractName, ">= 3.0.0", nil)
+	s.Require().Error(err)
+
+	_, found := registry.Get(sqscosmwasmpool.ContractInfo{Contract: transmuterContractName, Version: "3.0.0"})
+	s.Require().False(found)
+}
+
+func (s *PoolTransformerTestSuite) TestDefaultCosmWasmPoolHandlerRegistry() {
+	registry := poolstransformer.DefaultCosmWasmPoolHandlerRegistry()
+
+	_, found := registry.Get(sqscosmwasmpool.ContractInfo{Contract: sqscosmwasmpool.ALLOY_TRANSMUTER_CONTRACT_NAME, Version: sqscosmwasmpool.ALLOY_TRANSMUTER_MIN_CONTRACT_VERSION})
+	s.Require().True(found)
+
+	_, found = registry.Get(sqscosmwasmpool.ContractInfo{Contract: sqscosmwasmpool.ORDERBOOK_CONTRACT_NAME, Version: sqscosmwasmpool.ORDERBOOK_MIN_CONTRACT_VERSION})
+	s.Require().True(found)
+
+	// Pre-alloyed transmuter is not natively supported.
+	_, found = registry.Get(sqscosmwasmpool.ContractInfo{Contract: sqscosmwasmpool.ALLOY_TRANSMUTER_CONTRACT_NAME, Version: "0.1.0"})
+	s.Require().False(found)
+}
+
+// This test validates that converting a CosmWasm pool attaches the data supplied
+// by the handler registered for its contract.
+func (s *PoolTransformerTestSuite) TestConvertPool_CosmWasmPoolHandler() {
+	s.Setup()
+
+	// Create OSMO / USDC pool
+	// Note that spot price is 1 OSMO = 2 USDC
+	usdcOsmoPoolID := s.PrepareBalancerPoolWithCoins(sdk.NewCoin(USDC, defaultAmount), sdk.NewCoin(UOSMO, halfDefaultAmount))
+
+	// Transmuter v0.1.0 which has no default handler.
+	pool := s.PrepareCosmWasmPool()
+
+	registry := poolstransformer.DefaultCosmWasmPoolHandlerRegistry()
+	err := registry.Register(transmuterContractName, "< 3.0.0", poolstransformer.CosmWasmPoolHandlerFunc(
+		func(ctx sdk.Context, wasmKeeper commondomain.WasmKeeper, poolId uint64, contractAddress sdk.AccAddress, model *sqscosmwasmpool.CosmWasmPoolModel, poolDenoms *[]string) error {
+			return model.Data.SetExtension(testExtensionKey, testExtensionData{
+				PoolId:  poolId,
+				Version: model.ContractInfo.Version,
+			})
+		},
+	))
+	s.Require().NoError(err)
+
+	poolIngester := s.initializePoolIngesterWithCosmWasmPoolHandlers(usdcOsmoPoolID, registry)
+
+	// System under test
+	actualPool, err := poolIngester.ConvertPool(s.Ctx, pool, emptyDenomPriceInfoMap, ingesttypes.TakerFeeMap{})
+	s.Require().NoError(err)
+
+	cosmWasmPoolModel := actualPool.GetSQSPoolModel().CosmWasmPoolModel
+	s.Require().NotNil(cosmWasmPoolModel)
+	s.Require().Nil(cosmWasmPoolModel.Data.AlloyTransmuter)
+	s.Require().Nil(cosmWasmPoolModel.Data.Orderbook)
+
+	var actualExtension testExtensionData
+	found, err := cosmWasmPoolModel.Data.GetExtension(testExtensionKey, &actualExtension)
+	s.Require().NoError(err)
+	s.Require().True(found)
+	s.Require().Equal(testExtensionData{PoolId: pool.GetId(), Version: "0.1.0"}, actualExtension)
+}
+
+// This test validates that an error returned by the handler fails the conversion.
+func (s *PoolTransformerTestSuite) TestConvertPool_CosmWasmPoolHandler_Error() {
+	s.Setup()
+
+	usdcOsmoPoolID := s.PrepareBalancerPoolWithCoins(sdk.NewCoin(USDC, defaultAmount), sdk.NewCoin(UOSMO, halfDefaultAmount))
+
+	pool := s.PrepareCosmWasmPool()
+
+	expectedErr := errors.New("query failed")
+
+	registry := poolstransformer.NewCosmWasmPoolHandlerRegistry()
+	err := registry.Register(transmuterContractName, "< 3.0.0", poolstransformer.CosmWasmPoolHandlerFunc(
+		func(ctx sdk.Context, wasmKeeper commondomain.WasmKeeper, poolId uint64, contractAddress sdk.AccAddress, model *sqscosmwasmpool.CosmWasmPoolModel, poolDenoms *[]string) error {
+			return expectedErr
+		},
+	))
+	s.Require().NoError(err)
+
+	poolIngester := s.initializePoolIngesterWithCosmWasmPoolHandlers(usdcOsmoPoolID, registry)
+
+	// System under test
+	_, err = poolIngester.ConvertPool(s.Ctx, pool, emptyDenomPriceInfoMap, ingesttypes.TakerFeeMap{})
+	s.Require().ErrorIs(err, expectedErr)
+}
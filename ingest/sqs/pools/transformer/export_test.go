@@ -27,13 +27,21 @@ type (
 )
 
 func (pi *poolTransformer) ConvertPool(ctx sdk.Context, pool poolmanagertypes.PoolI, priceInfoMap map[string]osmomath.BigDec, denomPairToTakerFeeMap ingesttypes.TakerFeeMap) (ingesttypes.PoolI, error) {
-	return pi.convertPool(ctx, pool, priceInfoMap, denomPairToTakerFeeMap)
+	return pi.convertPool(ctx, pool, priceInfoMap, denomPairToTakerFeeMap, nil)
+}
+
+func (pi *poolTransformer) ConvertPoolWithChangedTicks(ctx sdk.Context, pool poolmanagertypes.PoolI, priceInfoMap map[string]osmomath.BigDec, denomPairToTakerFeeMap ingesttypes.TakerFeeMap, concentratedPoolIDChangedTicks map[uint64]map[int64]struct{}) (ingesttypes.PoolI, error) {
+	return pi.convertPool(ctx, pool, priceInfoMap, denomPairToTakerFeeMap, concentratedPoolIDChangedTicks)
 }
 
 func RetrieveTakerFeeToMapIfNotExists(ctx sdk.Context, denoms []string, denomPairToTakerFeeMap ingesttypes.TakerFeeMap, poolManagerKeeper commondomain.PoolManagerKeeper) error {
 	return retrieveTakerFeeToMapIfNotExists(ctx, denoms, denomPairToTakerFeeMap, poolManagerKeeper)
 }
 
+func RetrieveTakerFeeDenomPairsToMapIfNotExists(ctx sdk.Context, denomPairs map[ingesttypes.DenomPair]struct{}, denomPairToTakerFeeMap ingesttypes.TakerFeeMap, poolManagerKeeper commondomain.PoolManagerKeeper) error {
+	return retrieveTakerFeeDenomPairsToMapIfNotExists(ctx, denomPairs, denomPairToTakerFeeMap, poolManagerKeeper)
+}
+
 func (pi *poolTransformer) ComputeUOSMOPoolLiquidityCap(ctx sdk.Context, balances sdk.Coins, priceInfoMap map[string]osmomath.BigDec) (osmomath.Int, string) {
 	return pi.computeUOSMOPoolLiquidityCap(ctx, balances, priceInfoMap)
 }
@@ -50,19 +58,6 @@ func (pi *poolTransformer) ComputeUSDCPoolLiquidityCapFromUOSMO(ctx sdk.Context,
 	return pi.computeUSDCPoolLiquidityCapFromUOSMO(ctx, poolLiquidityCapUOSMO)
 }
 
-func (pi *poolTransformer) UpdateAlloyTransmuterInfo(ctx sdk.Context, poolId uint64, contractAddress sdk.AccAddress, cosmWasmPoolModel *sqscosmwasmpool.CosmWasmPoolModel, poolDenoms *[]string) error {
-	return pi.updateAlloyTransmuterInfo(ctx, poolId, contractAddress, cosmWasmPoolModel, poolDenoms)
-}
-
-func (pi *poolTransformer) UpdateOrderbookInfo(
-	ctx sdk.Context,
-	poolId uint64,
-	contractAddress sdk.AccAddress,
-	cosmWasmPoolModel *sqscosmwasmpool.CosmWasmPoolModel,
-) error {
-	return pi.updateOrderbookInfo(ctx, poolId, contractAddress, cosmWasmPoolModel)
-}
-
 func (pi *poolTransformer) InitCosmWasmPoolModel(
 	ctx sdk.Context,
 	pool poolmanagertypes.PoolI,
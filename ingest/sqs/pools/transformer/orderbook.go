@@ -10,6 +10,7 @@ import (
 
 	"github.com/osmosis-labs/osmosis/osmomath"
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
 )
 
 const (
@@ -49,20 +50,28 @@ type orderbook struct {
 	NextAskTick int64  `json:"next_ask_tick"`
 }
 
-// updateOrderbookInfo updates cosmwasmPoolModel with orderbook specific info.
-// - It queries all ticks of the pool and constructs `OrderbookData`.
-func (pi *poolTransformer) updateOrderbookInfo(
+// orderbookHandler is the CosmWasm pool handler for orderbook contracts.
+// It does not modify the pool denoms.
+type orderbookHandler struct{}
+
+var _ domain.CosmWasmPoolHandler = orderbookHandler{}
+
+// UpdateModel implements domain.CosmWasmPoolHandler.
+// It queries all ticks of the pool and constructs `OrderbookData`.
+func (orderbookHandler) UpdateModel(
 	ctx sdk.Context,
+	wasmKeeper commondomain.WasmKeeper,
 	poolId uint64,
 	contractAddress sdk.AccAddress,
 	cosmWasmPoolModel *sqscosmwasmpool.CosmWasmPoolModel,
+	_ *[]string,
 ) error {
-	orderbook, err := pi.getOrderbookRaw(ctx, pi.wasmKeeper, poolId, contractAddress)
+	orderbook, err := getOrderbookRaw(ctx, wasmKeeper, poolId, contractAddress)
 	if err != nil {
 		return err
 	}
 
-	ticks, err := pi.orderbookAllTicks(ctx, pi.wasmKeeper, poolId, contractAddress)
+	ticks, err := orderbookAllTicks(ctx, wasmKeeper, poolId, contractAddress)
 	if err != nil {
 		return err
 	}
@@ -93,7 +102,7 @@ func (pi *poolTransformer) updateOrderbookInfo(
 	return nil
 }
 
-func (pi *poolTransformer) orderbookAllTicks(
+func orderbookAllTicks(
 	ctx sdk.Context,
 	wasmKeeper commondomain.WasmKeeper,
 	poolId uint64,
@@ -131,7 +140,7 @@ func (pi *poolTransformer) orderbookAllTicks(
 	return ticks, nil
 }
 
-func (pi *poolTransformer) getOrderbookRaw(
+func getOrderbookRaw(
 	ctx sdk.Context,
 	wasmKeeper commondomain.WasmKeeper,
 	poolId uint64,
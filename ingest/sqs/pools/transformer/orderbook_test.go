@@ -39,16 +39,14 @@ func (s *PoolTransformerTestSuite) TestUpdateOrderbookInfo() {
 		QuoteDenom: USDC,
 	})
 
-	// Create OSMO / USDC pool
-	// Note that spot price is 1 OSMO = 2 USDC
-	usdcOsmoPoolID := s.PrepareBalancerPoolWithCoins(sdk.NewCoin(USDC, defaultAmount), sdk.NewCoin(UOSMO, halfDefaultAmount))
-
-	// Initialize the pool ingester
-	poolIngester := s.initializePoolIngester(usdcOsmoPoolID)
+	// The handler registered for orderbook pools by default.
+	handler, found := poolstransformer.DefaultCosmWasmPoolHandlerRegistry().Get(sqscosmwasmpool.ContractInfo{Contract: sqscosmwasmpool.ORDERBOOK_CONTRACT_NAME, Version: sqscosmwasmpool.ORDERBOOK_MIN_CONTRACT_VERSION})
+	s.Require().True(found)
 
 	cosmWasmPoolModel := sqscosmwasmpool.CosmWasmPoolModel{}
 
-	poolIngester.UpdateOrderbookInfo(s.Ctx, pool.GetId(), pool.GetAddress(), &cosmWasmPoolModel)
+	err := handler.UpdateModel(s.Ctx, s.App.WasmKeeper, pool.GetId(), pool.GetAddress(), &cosmWasmPoolModel, nil)
+	s.Require().NoError(err)
 
 	// Check if the pool has been updated
 	s.Equal(sqscosmwasmpool.CosmWasmPoolData{
@@ -79,7 +77,8 @@ func (s *PoolTransformerTestSuite) TestUpdateOrderbookInfo() {
 	_, err = s.App.ContractKeeper.Execute(s.Ctx, pool.GetAddress(), s.TestAccs[0], bz, sdk.NewCoins(sdk.NewCoin(USDC, osmomath.NewInt(10000))))
 	s.NoError(err)
 
-	poolIngester.UpdateOrderbookInfo(s.Ctx, pool.GetId(), pool.GetAddress(), &cosmWasmPoolModel)
+	err = handler.UpdateModel(s.Ctx, s.App.WasmKeeper, pool.GetId(), pool.GetAddress(), &cosmWasmPoolModel, nil)
+	s.Require().NoError(err)
 
 	// Check if the pool has been updated
 	s.Equal(sqscosmwasmpool.CosmWasmPoolData{
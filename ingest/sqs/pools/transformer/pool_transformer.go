@@ -43,6 +43,24 @@ type poolTransformer struct {
 
 	// Pool ID that is used for converting between USDC and UOSMO.
 	defaultUSDCUOSMOPoolID uint64
+
+	// Handlers instrumenting CosmWasm pools with contract specific data.
+	cosmWasmPoolHandlers *CosmWasmPoolHandlerRegistry
+
+	// Filter of the transformed pools. No pools are filtered if nil.
+	poolFilter domain.PoolFilter
+
+	// Computer of the pool APR and fees data. The data is not computed if nil.
+	poolAPRComputer domain.PoolAPRComputer
+
+	// Pools transformed so far by pool ID, without their tick and CosmWasm models.
+	// Used for recomputing the taker fees and the APR and fees data of all pools
+	// without transforming all pools.
+	knownPools map[uint64]ingesttypes.PoolI
+
+	// APR and fees data refreshed during the last Transform call for the known pools
+	// that were not part of the call.
+	aprUpdates []ingesttypes.PoolAPRUpdate
 }
 
 const (
@@ -105,7 +123,18 @@ var stablesOverwrite map[string]struct{} = map[string]struct{}{
 var _ domain.PoolsTransformer = &poolTransformer{}
 
 // NewPoolTransformer returns a new pool ingester.
-func NewPoolTransformer(keepers commondomain.PoolExtractorKeepers, defaultUSDCUOSMOPoolID uint64) domain.PoolsTransformer {
+// CosmWasm pools are instrumented using the default CosmWasm pool handlers.
+// The transformed pools are filtered by poolFilter and instrumented with the APR and fees data
+// by poolAPRComputer unless they are nil.
+func NewPoolTransformer(keepers commondomain.PoolExtractorKeepers, defaultUSDCUOSMOPoolID uint64, poolFilter domain.PoolFilter, poolAPRComputer domain.PoolAPRComputer) domain.PoolsTransformer {
+	return NewPoolTransformerWithCosmWasmPoolHandlers(keepers, defaultUSDCUOSMOPoolID, poolFilter, poolAPRComputer, DefaultCosmWasmPoolHandlerRegistry())
+}
+
+// NewPoolTransformerWithCosmWasmPoolHandlers returns a new pool ingester that instruments
+// CosmWasm pools using the handlers in the given registry.
+// The transformed pools are filtered by poolFilter and instrumented with the APR and fees data
+// by poolAPRComputer unless they are nil.
+func NewPoolTransformerWithCosmWasmPoolHandlers(keepers commondomain.PoolExtractorKeepers, defaultUSDCUOSMOPoolID uint64, poolFilter domain.PoolFilter, poolAPRComputer domain.PoolAPRComputer, cosmWasmPoolHandlers *CosmWasmPoolHandlerRegistry) domain.PoolsTransformer {
 	return &poolTransformer{
 		gammKeeper:         keepers.GammKeeper,
 		concentratedKeeper: keepers.ConcentratedKeeper,
@@ -116,11 +145,19 @@ func NewPoolTransformer(keepers commondomain.PoolExtractorKeepers, defaultUSDCUO
 		poolManagerKeeper:  keepers.PoolManagerKeeper,
 
 		defaultUSDCUOSMOPoolID: defaultUSDCUOSMOPoolID,
+
+		cosmWasmPoolHandlers: cosmWasmPoolHandlers,
+
+		poolFilter: poolFilter,
+
+		poolAPRComputer: poolAPRComputer,
+
+		knownPools: make(map[uint64]ingesttypes.PoolI),
 	}
 }
 
 // processPoolState processes the pool state. an
-func (pi *poolTransformer) Transform(ctx sdk.Context, blockPools commondomain.BlockPools) ([]ingesttypes.PoolI, ingesttypes.TakerFeeMap, error) {
+func (pi *poolTransformer) Transform(ctx sdk.Context, blockPools commondomain.BlockPools) ([]ingesttypes.PoolI, []uint64, ingesttypes.TakerFeeMap, error) {
 	// Create a map from denom to its price.
 	priceInfoMap := make(map[string]osmomath.BigDec)
 
@@ -133,43 +170,204 @@ func (pi *poolTransformer) Transform(ctx sdk.Context, blockPools commondomain.Bl
 
 	allPoolsParsed := make([]ingesttypes.PoolI, 0, len(cfmmPools)+len(concentratedPools)+len(cosmWasmPools))
 
+	// IDs of the pools that are removed by the pool filter.
+	removedPoolIDs := []uint64{}
+
 	// Parse CFMM pool to the standard SQS types.
 	for _, pool := range cfmmPools {
 		// Parse CFMM pool to the standard SQS types.
-		pool, err := pi.convertPool(ctx, pool, priceInfoMap, denomPairToTakerFeeMap)
+		pool, err := pi.convertPool(ctx, pool, priceInfoMap, denomPairToTakerFeeMap, nil)
 		if err != nil {
 			// Silently skip pools on error to avoid breaking ingest of all other pools.
 			continue
 		}
 
+		if pi.shouldRemove(pool) {
+			removedPoolIDs = append(removedPoolIDs, pool.GetId())
+			continue
+		}
+
 		allPoolsParsed = append(allPoolsParsed, pool)
 	}
 
 	for _, pool := range concentratedPools {
 		// Parse concentrated pool to the standard SQS types.
-		pool, err := pi.convertPool(ctx, pool, priceInfoMap, denomPairToTakerFeeMap)
+		pool, err := pi.convertPool(ctx, pool, priceInfoMap, denomPairToTakerFeeMap, blockPools.ConcentratedPoolIDChangedTicks)
 		if err != nil {
 			// Silently skip pools on error to avoid breaking ingest of all other pools.
 			continue
 		}
 
+		if pi.shouldRemove(pool) {
+			removedPoolIDs = append(removedPoolIDs, pool.GetId())
+			continue
+		}
+
 		allPoolsParsed = append(allPoolsParsed, pool)
 	}
 
 	for _, pool := range cosmWasmPools {
 		// Parse cosmwasm pool to the standard SQS types.
-		pool, err := pi.convertPool(ctx, pool, priceInfoMap, denomPairToTakerFeeMap)
+		pool, err := pi.convertPool(ctx, pool, priceInfoMap, denomPairToTakerFeeMap, nil)
 		if err != nil {
 			// Silently skip pools on error to avoid breaking ingest of all other pools.
 			continue
 		}
 
+		if pi.shouldRemove(pool) {
+			removedPoolIDs = append(removedPoolIDs, pool.GetId())
+			continue
+		}
+
 		allPoolsParsed = append(allPoolsParsed, pool)
 	}
 
-	ctx.Logger().Info("finish extracting pools", "height", ctx.BlockHeight(), "num_cfmm", len(cfmmPools), "num_concentrated", len(concentratedPools), "num_cosmwasm", len(cosmWasmPools))
+	pi.trackKnownPools(allPoolsParsed, removedPoolIDs)
+
+	// Mutates the transformed pools with the APR and fees data.
+	pi.attachAPRAndFeesData(ctx, allPoolsParsed, priceInfoMap)
+
+	// Mutates denomPairToTakerFeeMap with the taker fee for every denom pair whose taker fee override changed.
+	if err := retrieveTakerFeeDenomPairsToMapIfNotExists(ctx, blockPools.TakerFeeDenomPairs, denomPairToTakerFeeMap, pi.poolManagerKeeper); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// If the poolmanager params changed, the default taker fee might have changed.
+	// Mutates denomPairToTakerFeeMap with the taker fee for every denom pair of the pools pushed so far.
+	if blockPools.IsTakerFeeParamsChanged {
+		if err := retrieveTakerFeePoolsToMapIfNotExists(ctx, pi.knownPools, denomPairToTakerFeeMap, pi.poolManagerKeeper); err != nil {
+			return nil, nil, nil, err
+		}
+	}
 
-	return allPoolsParsed, denomPairToTakerFeeMap, nil
+	ctx.Logger().Info("finish extracting pools", "height", ctx.BlockHeight(), "num_cfmm", len(cfmmPools), "num_concentrated", len(concentratedPools), "num_cosmwasm", len(cosmWasmPools), "num_removed", len(removedPoolIDs))
+
+	return allPoolsParsed, removedPoolIDs, denomPairToTakerFeeMap, nil
+}
+
+// PopAPRUpdates implements domain.PoolsTransformer.
+func (pi *poolTransformer) PopAPRUpdates() []ingesttypes.PoolAPRUpdate {
+	aprUpdates := pi.aprUpdates
+	pi.aprUpdates = nil
+	return aprUpdates
+}
+
+// trackKnownPools records the transformed pools as known and forgets the removed ones.
+// The tick and CosmWasm models are not retained since they are not needed for
+// the taker fees or the APR and fees data.
+func (pi *poolTransformer) trackKnownPools(pools []ingesttypes.PoolI, removedPoolIDs []uint64) {
+	for _, pool := range pools {
+		sqsModel := pool.GetSQSPoolModel()
+		sqsModel.CosmWasmPoolModel = nil
+
+		pi.knownPools[pool.GetId()] = &ingesttypes.PoolWrapper{
+			ChainModel: pool.GetUnderlyingPool(),
+			SQSModel:   sqsModel,
+		}
+	}
+
+	for _, poolID := range removedPoolIDs {
+		delete(pi.knownPools, poolID)
+	}
+}
+
+// attachAPRAndFeesData attaches the APR and fees data to the transformed pools.
+// If the refresh is due, the data is recomputed first for all known pools, and the data of the known pools
+// that are not transformed is recorded as APR updates. Otherwise, the last computed data is attached.
+// Pools for which the data was never computed are left as is.
+func (pi *poolTransformer) attachAPRAndFeesData(ctx sdk.Context, pools []ingesttypes.PoolI, priceInfoMap map[string]osmomath.BigDec) {
+	pi.aprUpdates = nil
+
+	if pi.poolAPRComputer == nil {
+		return
+	}
+
+	if pi.poolAPRComputer.IsRefreshDue(ctx) {
+		knownPools := make([]ingesttypes.PoolI, 0, len(pi.knownPools))
+		for _, pool := range pi.knownPools {
+			knownPools = append(knownPools, pool)
+		}
+
+		pi.poolAPRComputer.Refresh(ctx, knownPools, func(ctx sdk.Context, coins sdk.Coins) (osmomath.Dec, error) {
+			return pi.computeUSDCValue(ctx, coins, priceInfoMap)
+		})
+
+		pi.aprUpdates = pi.getAPRUpdates(pools)
+	}
+
+	for _, pool := range pools {
+		poolWrapper, ok := pool.(*ingesttypes.PoolWrapper)
+		if !ok {
+			continue
+		}
+
+		aprData, feesData, ok := pi.poolAPRComputer.Get(pool.GetId())
+		if !ok {
+			continue
+		}
+
+		poolWrapper.APRData = aprData
+		poolWrapper.FeesData = feesData
+	}
+}
+
+// getAPRUpdates returns the last computed APR and fees data of the known pools
+// that are not part of the given pools, sorted by pool ID.
+func (pi *poolTransformer) getAPRUpdates(pools []ingesttypes.PoolI) []ingesttypes.PoolAPRUpdate {
+	transformedPoolIDs := make(map[uint64]struct{}, len(pools))
+	for _, pool := range pools {
+		transformedPoolIDs[pool.GetId()] = struct{}{}
+	}
+
+	aprUpdates := make([]ingesttypes.PoolAPRUpdate, 0, len(pi.knownPools)-len(transformedPoolIDs))
+	for poolID := range pi.knownPools {
+		if _, ok := transformedPoolIDs[poolID]; ok {
+			continue
+		}
+
+		aprData, feesData, ok := pi.poolAPRComputer.Get(poolID)
+		if !ok {
+			continue
+		}
+
+		aprUpdates = append(aprUpdates, ingesttypes.PoolAPRUpdate{
+			PoolID:   poolID,
+			APRData:  aprData,
+			FeesData: feesData,
+		})
+	}
+
+	sort.Slice(aprUpdates, func(i, j int) bool {
+		return aprUpdates[i].PoolID < aprUpdates[j].PoolID
+	})
+
+	return aprUpdates
+}
+
+// computeUSDCValue returns the value of the coins in USDC.
+// Unlike the pool liquidity capitalization, the value is not rounded to whole USDC.
+// Returns error if any of the coins cannot be valued.
+func (pi *poolTransformer) computeUSDCValue(ctx sdk.Context, coins sdk.Coins, priceInfoMap map[string]osmomath.BigDec) (osmomath.Dec, error) {
+	if coins.IsZero() {
+		return osmomath.ZeroDec(), nil
+	}
+
+	valueUOSMO, errorStr := pi.computeUOSMOPoolLiquidityCap(ctx, coins, priceInfoMap)
+	if errorStr != noPoolLiquidityCapError {
+		return osmomath.Dec{}, errors.New(errorStr)
+	}
+
+	usdcQuotePrice, err := pi.poolManagerKeeper.RouteCalculateSpotPrice(ctx, pi.defaultUSDCUOSMOPoolID, usdcDenom, UOSMO)
+	if err != nil {
+		return osmomath.Dec{}, err
+	}
+
+	return osmomath.BigDecFromSDKInt(valueUOSMO).MulMut(usdcQuotePrice).QuoMut(usdcPrecisionScalingFactor).Dec(), nil
+}
+
+// shouldRemove returns true if the transformed pool is removed by the pool filter.
+func (pi *poolTransformer) shouldRemove(pool ingesttypes.PoolI) bool {
+	return pi.poolFilter != nil && pi.poolFilter.ShouldRemove(pool)
 }
 
 // convertPool converts a pool to the standard SQS pool type.
@@ -180,11 +378,14 @@ func (pi *poolTransformer) Transform(ctx sdk.Context, blockPools commondomain.Bl
 // - TVL is calculated using spot price. TODO: use TWAP (https://app.clickup.com/t/86a182835)
 // - TVL does not account for token precision.
 // (https://app.clickup.com/t/86a18287v)
+// - For concentrated pools present in concentratedPoolIDChangedTicks, only the changed ticks
+// are read and attached as a tick delta model. Otherwise, the full tick model is attached.
 func (pi *poolTransformer) convertPool(
 	ctx sdk.Context,
 	pool poolmanagertypes.PoolI,
 	denomPriceInfoMap map[string]osmomath.BigDec,
 	denomPairToTakerFeeMap ingesttypes.TakerFeeMap,
+	concentratedPoolIDChangedTicks map[uint64]map[int64]struct{},
 ) (sqsPool ingesttypes.PoolI, err error) {
 	defer func() {
 		r := recover()
@@ -235,14 +436,9 @@ func (pi *poolTransformer) convertPool(
 		initedCosmWasmPoolModel := pi.initCosmWasmPoolModel(ctx, pool)
 		cosmWasmPoolModel = &initedCosmWasmPoolModel
 
-		// special transformation based on different cw pool
-		if cosmWasmPoolModel.IsAlloyTransmuter() {
-			err = pi.updateAlloyTransmuterInfo(ctx, poolId, poolAddress, cosmWasmPoolModel, &denoms)
-			if err != nil {
-				return nil, err
-			}
-		} else if cosmWasmPoolModel.IsOrderbook() {
-			err = pi.updateOrderbookInfo(ctx, poolId, poolAddress, cosmWasmPoolModel)
+		// special transformation based on the handler registered for the contract
+		if handler, ok := pi.cosmWasmPoolHandlers.Get(cosmWasmPoolModel.ContractInfo); ok {
+			err = handler.UpdateModel(ctx, pi.wasmKeeper, poolId, poolAddress, cosmWasmPoolModel, &denoms)
 			if err != nil {
 				return nil, err
 			}
@@ -275,10 +471,21 @@ func (pi *poolTransformer) convertPool(
 	}
 
 	// Get the tick model for concentrated pools
-	var tickModel *ingesttypes.TickModel
+	var (
+		tickModel      *ingesttypes.TickModel
+		tickDeltaModel *ingesttypes.TickDeltaModel
+	)
+
+	changedTicks, isTickDelta := concentratedPoolIDChangedTicks[pool.GetId()]
 
-	// For CL pools, get the tick data
-	if pool.GetType() == poolmanagertypes.Concentrated {
+	// For CL pools that only require the changed ticks, get the tick delta
+	if pool.GetType() == poolmanagertypes.Concentrated && isTickDelta {
+		tickDeltaModel, err = pi.getTickDeltaModel(ctx, pool, changedTicks)
+		if err != nil {
+			return nil, err
+		}
+		// For all other CL pools, get the tick data
+	} else if pool.GetType() == poolmanagertypes.Concentrated {
 		tickData, currentTickIndex, err := pi.concentratedKeeper.GetTickLiquidityForFullRange(ctx, pool.GetId())
 		// If there is no error, we set the tick model
 		if err == nil {
@@ -310,7 +517,41 @@ func (pi *poolTransformer) convertPool(
 			SpreadFactor:          spreadFactor,
 			CosmWasmPoolModel:     cosmWasmPoolModel,
 		},
-		TickModel: tickModel,
+		TickModel:      tickModel,
+		TickDeltaModel: tickDeltaModel,
+	}, nil
+}
+
+// getTickDeltaModel returns the tick delta model for the given concentrated pool.
+// It reads the liquidity net of every changed tick from the store. Ticks that were
+// removed within the block are returned with zero liquidity net.
+// The ticks are sorted by tick index in ascending order.
+func (pi *poolTransformer) getTickDeltaModel(ctx sdk.Context, pool poolmanagertypes.PoolI, changedTicks map[int64]struct{}) (*ingesttypes.TickDeltaModel, error) {
+	concentratedPool, ok := pool.(concentratedtypes.ConcentratedPoolExtension)
+	if !ok {
+		return nil, fmt.Errorf("pool (%d) with type (%d) is not a ConcentratedPoolExtension", pool.GetId(), pool.GetType())
+	}
+
+	ticks := make([]ingesttypes.TickDelta, 0, len(changedTicks))
+	for tickIndex := range changedTicks {
+		tickInfo, err := pi.concentratedKeeper.GetTickInfo(ctx, pool.GetId(), tickIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		ticks = append(ticks, ingesttypes.TickDelta{
+			TickIndex:    tickIndex,
+			LiquidityNet: tickInfo.LiquidityNet,
+		})
+	}
+
+	sort.Slice(ticks, func(i, j int) bool {
+		return ticks[i].TickIndex < ticks[j].TickIndex
+	})
+
+	return &ingesttypes.TickDeltaModel{
+		Ticks:       ticks,
+		CurrentTick: concentratedPool.GetCurrentTick(),
 	}, nil
 }
 
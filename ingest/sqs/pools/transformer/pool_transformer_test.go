@@ -9,11 +9,14 @@ import (
 
 	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
 	sqscosmwasmpool "github.com/osmosis-labs/osmosis/v30/ingest/types/cosmwasmpool"
+	"github.com/osmosis-labs/osmosis/v30/ingest/types/passthroughdomain"
 
 	"github.com/osmosis-labs/osmosis/osmomath"
 	"github.com/osmosis-labs/osmosis/osmoutils/osmoassert"
 	"github.com/osmosis-labs/osmosis/v30/app/apptesting"
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain/mocks"
+	poolsfilter "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/filter"
 	poolstransformer "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/transformer"
 	clqueryproto "github.com/osmosis-labs/osmosis/v30/x/concentrated-liquidity/client/queryproto"
 	cltypes "github.com/osmosis-labs/osmosis/v30/x/concentrated-liquidity/types"
@@ -362,6 +365,58 @@ func (s *PoolTransformerTestSuite) TestConvertPool_Concentrated() {
 	s.Require().Equal(expectedTick, actualModel.Ticks[0])
 }
 
+// This test validates that a concentrated pool with changed ticks is converted
+// with the tick delta model rather than the full tick model.
+func (s *PoolTransformerTestSuite) TestConvertPool_Concentrated_TickDelta() {
+	s.Setup()
+
+	// Create default pool for converting between UOSMO and USDC.
+	usdcUosmoPoolID := s.CreateDefaultQuoteDenomUOSMOPool()
+
+	concentratedPool := s.PrepareCustomConcentratedPool(s.TestAccs[0], USDT, UOSMO, 1, osmomath.ZeroDec())
+
+	initialLiquidity := sdk.NewCoins(sdk.NewCoin(USDT, defaultAmount), sdk.NewCoin(UOSMO, defaultAmount))
+	s.FundAcc(s.TestAccs[0], initialLiquidity)
+	fullRangePositionData, err := s.App.ConcentratedLiquidityKeeper.CreateFullRangePosition(s.Ctx, concentratedPool.GetId(), s.TestAccs[0], initialLiquidity)
+	s.Require().NoError(err)
+
+	// Refetch the pool from state.
+	concentratedPool, err = s.App.ConcentratedLiquidityKeeper.GetConcentratedPoolById(s.Ctx, concentratedPool.GetId())
+	s.Require().NoError(err)
+
+	// Note that tick 100 is not initialized. It signifies a tick removed within the block.
+	const uninitializedTick = int64(100)
+	concentratedPoolIDChangedTicks := map[uint64]map[int64]struct{}{
+		concentratedPool.GetId(): {
+			cltypes.MaxTick:            {},
+			uninitializedTick:          {},
+			cltypes.MinInitializedTick: {},
+		},
+	}
+
+	poolIngester := s.initializePoolIngester(usdcUosmoPoolID)
+
+	// System under test
+	actualPool, err := poolIngester.ConvertPoolWithChangedTicks(s.Ctx, concentratedPool, map[string]osmomath.BigDec{}, ingesttypes.TakerFeeMap{}, concentratedPoolIDChangedTicks)
+	s.Require().NoError(err)
+
+	// The full tick model is not set
+	_, err = actualPool.GetTickModel()
+	s.Require().Error(err)
+
+	actualDeltaModel := actualPool.GetTickDeltaModel()
+	s.Require().NotNil(actualDeltaModel)
+
+	s.Require().Equal(concentratedPool.GetCurrentTick(), actualDeltaModel.CurrentTick)
+
+	// Sorted by tick index in ascending order
+	s.Require().Equal([]ingesttypes.TickDelta{
+		{TickIndex: cltypes.MinInitializedTick, LiquidityNet: fullRangePositionData.Liquidity},
+		{TickIndex: uninitializedTick, LiquidityNet: osmomath.ZeroDec()},
+		{TickIndex: cltypes.MaxTick, LiquidityNet: fullRangePositionData.Liquidity.Neg()},
+	}, actualDeltaModel.Ticks)
+}
+
 // This test validates that CL pools with no liquidity are converted correctly.
 // The relevant no liquidity flag is set where applicable
 func (s *PoolTransformerTestSuite) TestConvertPool_Concentrated_NoLiquidity() {
@@ -462,7 +517,7 @@ func (s *PoolTransformerTestSuite) TestProcessBlock() {
 
 	// Create default pool for converting between UOSMO and USDC.
 	usdcUosmoPoolID := s.CreateDefaultQuoteDenomUOSMOPool()
-	poolTransformer := poolstransformer.NewPoolTransformer(sqsKeepers, usdcUosmoPoolID)
+	poolTransformer := poolstransformer.NewPoolTransformer(sqsKeepers, usdcUosmoPoolID, nil, nil)
 
 	blockPools := commondomain.BlockPools{
 		ConcentratedPools: []poolmanagertypes.PoolI{
@@ -478,8 +533,9 @@ func (s *PoolTransformerTestSuite) TestProcessBlock() {
 		},
 	}
 
-	allPools, takerFeesMap, err := poolTransformer.Transform(s.Ctx, blockPools)
+	allPools, removedPoolIDs, takerFeesMap, err := poolTransformer.Transform(s.Ctx, blockPools)
 	s.Require().NoError(err)
+	s.Require().Empty(removedPoolIDs)
 
 	s.Require().Len(allPools, 2+2+1)
 
@@ -509,6 +565,216 @@ func (s *PoolTransformerTestSuite) TestProcessBlock() {
 	s.Require().Equal(defaultPoolManagerTakerFee, actualTakerFee)
 }
 
+// This test validates that the pools removed by the pool filter are
+// not returned as transformed pools but as removed pool IDs instead.
+func (s *PoolTransformerTestSuite) TestProcessBlock_PoolFilter() {
+	s.Setup()
+
+	// Create one pool of each type.
+	poolsData := s.PrepareAllSupportedPools()
+
+	sqsKeepers := commondomain.PoolExtractorKeepers{
+		GammKeeper:         s.App.GAMMKeeper,
+		ConcentratedKeeper: s.App.ConcentratedLiquidityKeeper,
+		WasmKeeper:         s.App.WasmKeeper,
+		BankKeeper:         s.App.BankKeeper,
+		ProtorevKeeper:     s.App.ProtoRevKeeper,
+		PoolManagerKeeper:  s.App.PoolManagerKeeper,
+		CosmWasmPoolKeeper: s.App.CosmwasmPoolKeeper,
+	}
+
+	balancerPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, poolsData.BalancerPoolID)
+	s.Require().NoError(err)
+
+	stableSwapPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, poolsData.StableSwapPoolID)
+	s.Require().NoError(err)
+
+	concentratedPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, poolsData.ConcentratedPoolID)
+	s.Require().NoError(err)
+
+	// Deny the balancer pool by ID and only allow CFMM pool types.
+	poolFilter, err := poolsfilter.New(poolsfilter.Config{
+		DeniedPoolIDs:    []uint64{poolsData.BalancerPoolID},
+		AllowedPoolTypes: []string{"Balancer", "Stableswap"},
+	})
+	s.Require().NoError(err)
+
+	usdcUosmoPoolID := s.CreateDefaultQuoteDenomUOSMOPool()
+	poolTransformer := poolstransformer.NewPoolTransformer(sqsKeepers, usdcUosmoPoolID, poolFilter, nil)
+
+	blockPools := commondomain.BlockPools{
+		ConcentratedPools: []poolmanagertypes.PoolI{
+			concentratedPool,
+		},
+		CFMMPools: []poolmanagertypes.PoolI{
+			balancerPool,
+			stableSwapPool,
+		},
+	}
+
+	// System under test
+	allPools, removedPoolIDs, _, err := poolTransformer.Transform(s.Ctx, blockPools)
+	s.Require().NoError(err)
+
+	s.Require().Len(allPools, 1)
+	s.Require().Equal(poolsData.StableSwapPoolID, allPools[0].GetId())
+
+	s.Require().Equal([]uint64{poolsData.BalancerPoolID, poolsData.ConcentratedPoolID}, removedPoolIDs)
+}
+
+// This test validates that if the taker fee params changed, the taker fees of the denom pairs
+// of all pools transformed so far are returned rather than only the ones of the given pools.
+func (s *PoolTransformerTestSuite) TestProcessBlock_TakerFeeParamsChanged() {
+	s.Setup()
+
+	// Create one pool of each type.
+	poolsData := s.PrepareAllSupportedPools()
+
+	sqsKeepers := commondomain.PoolExtractorKeepers{
+		GammKeeper:         s.App.GAMMKeeper,
+		ConcentratedKeeper: s.App.ConcentratedLiquidityKeeper,
+		WasmKeeper:         s.App.WasmKeeper,
+		BankKeeper:         s.App.BankKeeper,
+		ProtorevKeeper:     s.App.ProtoRevKeeper,
+		PoolManagerKeeper:  s.App.PoolManagerKeeper,
+		CosmWasmPoolKeeper: s.App.CosmwasmPoolKeeper,
+	}
+
+	balancerPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, poolsData.BalancerPoolID)
+	s.Require().NoError(err)
+
+	concentratedPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, poolsData.ConcentratedPoolID)
+	s.Require().NoError(err)
+
+	usdcUosmoPoolID := s.CreateDefaultQuoteDenomUOSMOPool()
+	poolTransformer := poolstransformer.NewPoolTransformer(sqsKeepers, usdcUosmoPoolID, nil, nil)
+
+	// Transform the balancer pool so that it is known to the transformer.
+	_, _, _, err = poolTransformer.Transform(s.Ctx, commondomain.BlockPools{
+		CFMMPools: []poolmanagertypes.PoolI{
+			balancerPool,
+		},
+	})
+	s.Require().NoError(err)
+
+	balancerDenoms := balancerPool.GetPoolDenoms(s.Ctx)
+
+	// Change the default taker fee.
+	s.setDefaultPoolManagerTakerFee()
+
+	concentratedBlockPools := commondomain.BlockPools{
+		ConcentratedPools: []poolmanagertypes.PoolI{
+			concentratedPool,
+		},
+	}
+
+	// Without the taker fee params change, only the taker fees of the given pools are returned.
+	_, _, takerFeesMap, err := poolTransformer.Transform(s.Ctx, concentratedBlockPools)
+	s.Require().NoError(err)
+	s.Require().False(takerFeesMap.Has(balancerDenoms[0], balancerDenoms[1]))
+
+	// System under test
+	concentratedBlockPools.IsTakerFeeParamsChanged = true
+	allPools, _, takerFeesMap, err := poolTransformer.Transform(s.Ctx, concentratedBlockPools)
+	s.Require().NoError(err)
+
+	// Only the given pool is returned.
+	s.Require().Len(allPools, 1)
+	s.Require().Equal(poolsData.ConcentratedPoolID, allPools[0].GetId())
+
+	// The taker fees of the known pool are recomputed with the new default taker fee.
+	s.Require().Equal(defaultPoolManagerTakerFee, takerFeesMap.GetTakerFee(balancerDenoms[0], balancerDenoms[1]))
+	s.Require().Equal(defaultPoolManagerTakerFee, takerFeesMap.GetTakerFee(balancerDenoms[1], balancerDenoms[0]))
+}
+
+// This test validates that when the APR refresh is due, the APR and fees data is recomputed
+// for all pools transformed so far, and that the data of the pools that are not part of the block
+// is returned as APR updates rather than requiring the pools to be transformed.
+func (s *PoolTransformerTestSuite) TestProcessBlock_APRUpdates() {
+	s.Setup()
+
+	// Create one pool of each type.
+	poolsData := s.PrepareAllSupportedPools()
+
+	sqsKeepers := commondomain.PoolExtractorKeepers{
+		GammKeeper:         s.App.GAMMKeeper,
+		ConcentratedKeeper: s.App.ConcentratedLiquidityKeeper,
+		WasmKeeper:         s.App.WasmKeeper,
+		BankKeeper:         s.App.BankKeeper,
+		ProtorevKeeper:     s.App.ProtoRevKeeper,
+		PoolManagerKeeper:  s.App.PoolManagerKeeper,
+		CosmWasmPoolKeeper: s.App.CosmwasmPoolKeeper,
+	}
+
+	balancerPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, poolsData.BalancerPoolID)
+	s.Require().NoError(err)
+
+	concentratedPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, poolsData.ConcentratedPoolID)
+	s.Require().NoError(err)
+
+	balancerAPRData := passthroughdomain.PoolAPRDataStatusWrap{
+		PoolAPR: passthroughdomain.PoolAPR{
+			PoolID:   poolsData.BalancerPoolID,
+			TotalAPR: passthroughdomain.PoolDataRange{Lower: 1, Upper: 1},
+		},
+	}
+	concentratedAPRData := passthroughdomain.PoolAPRDataStatusWrap{
+		PoolAPR: passthroughdomain.PoolAPR{
+			PoolID:   poolsData.ConcentratedPoolID,
+			TotalAPR: passthroughdomain.PoolDataRange{Lower: 2, Upper: 2},
+		},
+	}
+
+	poolAPRComputer := &mocks.PoolAPRComputerMock{
+		APRData: map[uint64]passthroughdomain.PoolAPRDataStatusWrap{
+			poolsData.BalancerPoolID:     balancerAPRData,
+			poolsData.ConcentratedPoolID: concentratedAPRData,
+		},
+	}
+
+	usdcUosmoPoolID := s.CreateDefaultQuoteDenomUOSMOPool()
+	poolTransformer := poolstransformer.NewPoolTransformer(sqsKeepers, usdcUosmoPoolID, nil, poolAPRComputer)
+
+	// Transform the balancer pool so that it is known to the transformer.
+	_, _, _, err = poolTransformer.Transform(s.Ctx, commondomain.BlockPools{
+		CFMMPools: []poolmanagertypes.PoolI{
+			balancerPool,
+		},
+	})
+	s.Require().NoError(err)
+
+	// No refresh was due.
+	s.Require().Nil(poolAPRComputer.CalledWithPools)
+	s.Require().Empty(poolTransformer.PopAPRUpdates())
+
+	// System under test
+	poolAPRComputer.IsRefreshDueReturn = true
+	allPools, _, _, err := poolTransformer.Transform(s.Ctx, commondomain.BlockPools{
+		ConcentratedPools: []poolmanagertypes.PoolI{
+			concentratedPool,
+		},
+	})
+	s.Require().NoError(err)
+
+	// The data is recomputed for the known pools.
+	s.Require().Len(poolAPRComputer.CalledWithPools, 2)
+
+	// Only the given pool is returned with its data attached.
+	s.Require().Len(allPools, 1)
+	s.Require().Equal(concentratedAPRData, allPools[0].GetAPRData())
+
+	// The data of the other known pool is returned as an APR update.
+	s.Require().Equal([]ingesttypes.PoolAPRUpdate{
+		{
+			PoolID:  poolsData.BalancerPoolID,
+			APRData: balancerAPRData,
+		},
+	}, poolTransformer.PopAPRUpdates())
+
+	// The updates are cleared on return.
+	s.Require().Empty(poolTransformer.PopAPRUpdates())
+}
+
 // This tests validates that uosmo pool liquidity cap is computed correctly
 // by validating the happy path cases. Validates that if no computation method is found
 // the error string and zero is returned without error or panic
@@ -839,7 +1105,10 @@ func (s *PoolTransformerTestSuite) validatePoolConversion(expectedPool poolmanag
 }
 
 func (s *PoolTransformerTestSuite) initializePoolIngester(defaultUSDCUOSMOPoolID uint64) *poolstransformer.PoolTransformer {
+	return s.initializePoolIngesterWithCosmWasmPoolHandlers(defaultUSDCUOSMOPoolID, poolstransformer.DefaultCosmWasmPoolHandlerRegistry())
+}
 
+func (s *PoolTransformerTestSuite) initializePoolIngesterWithCosmWasmPoolHandlers(defaultUSDCUOSMOPoolID uint64, cosmWasmPoolHandlers *poolstransformer.CosmWasmPoolHandlerRegistry) *poolstransformer.PoolTransformer {
 	sqsKeepers := commondomain.PoolExtractorKeepers{
 		GammKeeper:         s.App.GAMMKeeper,
 		ConcentratedKeeper: s.App.ConcentratedLiquidityKeeper,
@@ -850,7 +1119,7 @@ func (s *PoolTransformerTestSuite) initializePoolIngester(defaultUSDCUOSMOPoolID
 		WasmKeeper:         s.App.WasmKeeper,
 	}
 
-	atomicIngester := poolstransformer.NewPoolTransformer(sqsKeepers, defaultUSDCUOSMOPoolID)
+	atomicIngester := poolstransformer.NewPoolTransformerWithCosmWasmPoolHandlers(sqsKeepers, defaultUSDCUOSMOPoolID, nil, nil, cosmWasmPoolHandlers)
 	poolIngester, ok := atomicIngester.(*poolstransformer.PoolTransformer)
 	s.Require().True(ok)
 	return poolIngester
@@ -33,3 +33,37 @@ func retrieveTakerFeeToMapIfNotExists(ctx sdk.Context, denoms []string, denomPai
 
 	return nil
 }
+
+// retrieveTakerFeeDenomPairsToMapIfNotExists retrieves the taker fee for every given denom pair if it does not exist in the map.
+// Unlike retrieveTakerFeeToMapIfNotExists, the taker fee is only retrieved in the direction of the denom pair
+// since a taker fee override only applies in one direction.
+// Returns error if fails to retrieve taker fee from chain. Nil otherwise
+func retrieveTakerFeeDenomPairsToMapIfNotExists(ctx sdk.Context, denomPairs map[ingesttypes.DenomPair]struct{}, denomPairToTakerFeeMap ingesttypes.TakerFeeMap, poolManagerKeeper commondomain.PoolManagerKeeper) error {
+	for denomPair := range denomPairs {
+		if denomPairToTakerFeeMap.Has(denomPair.Denom0, denomPair.Denom1) {
+			continue
+		}
+
+		takerFee, err := poolManagerKeeper.GetTradingPairTakerFee(ctx, denomPair.Denom0, denomPair.Denom1)
+		if err != nil {
+			return err
+		}
+
+		denomPairToTakerFeeMap.SetTakerFee(denomPair.Denom0, denomPair.Denom1, takerFee)
+	}
+
+	return nil
+}
+
+// retrieveTakerFeePoolsToMapIfNotExists retrieves the taker fee for every denom pair of the given pools
+// if it does not exist in the map.
+// Returns error if fails to retrieve taker fee from chain. Nil otherwise
+func retrieveTakerFeePoolsToMapIfNotExists(ctx sdk.Context, pools map[uint64]ingesttypes.PoolI, denomPairToTakerFeeMap ingesttypes.TakerFeeMap, poolManagerKeeper commondomain.PoolManagerKeeper) error {
+	for _, pool := range pools {
+		if err := retrieveTakerFeeToMapIfNotExists(ctx, pool.GetPoolDenoms(), denomPairToTakerFeeMap, poolManagerKeeper); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -193,3 +193,36 @@ func (s *PoolTransformerTestSuite) setDefaultPoolManagerTakerFee() {
 	poolmanagerParams.TakerFeeParams.DefaultTakerFee = defaultPoolManagerTakerFee
 	s.App.PoolManagerKeeper.SetParams(s.Ctx, poolmanagerParams)
 }
+
+// Tests that the taker fee is correctly retrieved for the given denom pairs
+// in their direction only and the map is correctly mutated.
+func (s *PoolTransformerTestSuite) TestRetrieveTakerFeeDenomPairsToMapIfNotExists() {
+	s.Setup()
+
+	// Set default poolmanager taker fee that is different from the default taker fee.
+	s.setDefaultPoolManagerTakerFee()
+
+	s.App.PoolManagerKeeper.SetDenomPairTakerFee(s.Ctx, USDC, USDT, defaultCustomTakerFee)
+
+	denomPairs := map[ingesttypes.DenomPair]struct{}{
+		// Pre-set taker fee
+		{Denom0: USDC, Denom1: USDT}: {},
+		// Taken from params
+		{Denom0: USDC, Denom1: USDW}: {},
+		// Already in the map
+		{Denom0: USDT, Denom1: USDW}: {},
+	}
+
+	denomPairToTakerFeeMap := ingesttypes.TakerFeeMap{
+		{Denom0: USDT, Denom1: USDW}: otherCustomTakerFee,
+	}
+
+	err := poolstransformer.RetrieveTakerFeeDenomPairsToMapIfNotExists(s.Ctx, denomPairs, denomPairToTakerFeeMap, s.App.PoolManagerKeeper)
+	s.Require().NoError(err)
+
+	s.Require().Equal(ingesttypes.TakerFeeMap{
+		{Denom0: USDC, Denom1: USDT}: defaultCustomTakerFee,
+		{Denom0: USDC, Denom1: USDW}: defaultPoolManagerTakerFee,
+		{Denom0: USDT, Denom1: USDW}: otherCustomTakerFee,
+	}, denomPairToTakerFeeMap)
+}
@@ -0,0 +1,3 @@
+package receiver
+
+type Receiver = *receiver
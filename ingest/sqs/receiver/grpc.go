@@ -0,0 +1,109 @@
+package receiver
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	prototypes "github.com/osmosis-labs/osmosis/v30/ingest/types/proto/types"
+)
+
+var _ prototypes.SQSReceiverQueryServer = &receiver{}
+
+// Pools implements prototypes.SQSReceiverQueryServer.
+func (r *receiver) Pools(ctx context.Context, req *prototypes.ReceiverPoolsRequest) (*prototypes.ReceiverPoolsReply, error) {
+	state, err := r.getStateFromHeight(req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &prototypes.ReceiverPoolsReply{
+		Height: state.Height,
+		Pools:  make([]*prototypes.PoolModel, 0, len(state.Pools)),
+	}
+	for _, pool := range state.GetPools() {
+		poolModel, err := r.newPoolModel(pool)
+		if err != nil {
+			return nil, err
+		}
+		reply.Pools = append(reply.Pools, poolModel)
+	}
+
+	return reply, nil
+}
+
+// Pool implements prototypes.SQSReceiverQueryServer.
+func (r *receiver) Pool(ctx context.Context, req *prototypes.ReceiverPoolRequest) (*prototypes.ReceiverPoolReply, error) {
+	state, err := r.getStateFromHeight(req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, ok := state.GetPool(req.PoolId)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "pool (%d) not found", req.PoolId)
+	}
+
+	poolModel, err := r.newPoolModel(pool)
+	if err != nil {
+		return nil, err
+	}
+
+	return &prototypes.ReceiverPoolReply{
+		Height: state.Height,
+		Pool:   poolModel,
+	}, nil
+}
+
+// TakerFee implements prototypes.SQSReceiverQueryServer.
+func (r *receiver) TakerFee(ctx context.Context, req *prototypes.ReceiverTakerFeeRequest) (*prototypes.ReceiverTakerFeeReply, error) {
+	if req.Denom0 == "" || req.Denom1 == "" {
+		return nil, status.Error(codes.InvalidArgument, "denom0 and denom1 are required")
+	}
+
+	state, err := r.getStateFromHeight(req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	takerFee, found := state.GetTakerFee(req.Denom0, req.Denom1)
+
+	return &prototypes.ReceiverTakerFeeReply{
+		Height: state.Height,
+		TakerFee: &prototypes.TakerFee{
+			Denom0:   req.Denom0,
+			Denom1:   req.Denom1,
+			TakerFee: takerFee.String(),
+		},
+		IsDefault: !found,
+	}, nil
+}
+
+// getStateFromHeight returns the state at the given height as a gRPC status error on failure.
+// If height is zero, returns the latest state.
+func (r *receiver) getStateFromHeight(height uint64) (*State, error) {
+	state, ok := r.GetState(height)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "state at height (%d) not found", height)
+	}
+
+	return state, nil
+}
+
+// newPoolModel converts the pool into its protobuf-encoded model.
+// A concentrated pool for which a tick snapshot was requested holds no tick model
+// until the next block, in which case Unavailable is returned.
+func (r *receiver) newPoolModel(pool *ingesttypes.PoolWrapper) (*prototypes.PoolModel, error) {
+	poolModel, err := ingesttypes.PoolToProto(r.appCodec, pool)
+	if errors.As(err, &ingesttypes.ConcentratedPoolNoTickModelError{}) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return poolModel, nil
+}
@@ -0,0 +1,161 @@
+package receiver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+)
+
+// PoolResponse is the JSON representation of a pool served by the read API.
+type PoolResponse struct {
+	ChainModel json.RawMessage        `json:"chain_model"`
+	SQSModel   ingesttypes.SQSPool    `json:"sqs_model"`
+	TickModel  *ingesttypes.TickModel `json:"tick_model,omitempty"`
+}
+
+// PoolsResponse is the response of the list pools endpoint.
+type PoolsResponse struct {
+	Height uint64         `json:"height"`
+	Pools  []PoolResponse `json:"pools"`
+}
+
+// TakerFeeResponse is the response of the taker fee endpoint.
+type TakerFeeResponse struct {
+	Height   uint64       `json:"height"`
+	Denom0   string       `json:"denom0"`
+	Denom1   string       `json:"denom1"`
+	TakerFee osmomath.Dec `json:"taker_fee"`
+	// IsDefault is true if the taker fee was not pushed and the default is returned.
+	IsDefault bool `json:"is_default"`
+}
+
+// NewHTTPHandler returns the HTTP handler of the JSON read API over the receiver state.
+// Every endpoint accepts an optional height query parameter and defaults to the latest height.
+//
+// GET /pools - list all pools
+// GET /pools/{id} - get a pool
+// GET /taker-fee?denom0=&denom1= - get the taker fee for a denom pair
+func (r *receiver) NewHTTPHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /pools", func(w http.ResponseWriter, req *http.Request) {
+		state, ok := r.getStateFromRequest(w, req)
+		if !ok {
+			return
+		}
+
+		response := PoolsResponse{
+			Height: state.Height,
+			Pools:  make([]PoolResponse, 0, len(state.Pools)),
+		}
+		for _, pool := range state.GetPools() {
+			poolResponse, err := newPoolResponse(r.appCodec, pool)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			response.Pools = append(response.Pools, poolResponse)
+		}
+
+		writeJSON(w, response)
+	})
+
+	mux.HandleFunc("GET /pools/{id}", func(w http.ResponseWriter, req *http.Request) {
+		poolID, err := strconv.ParseUint(req.PathValue("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid pool id", http.StatusBadRequest)
+			return
+		}
+
+		state, ok := r.getStateFromRequest(w, req)
+		if !ok {
+			return
+		}
+
+		pool, ok := state.GetPool(poolID)
+		if !ok {
+			http.Error(w, "pool not found", http.StatusNotFound)
+			return
+		}
+
+		poolResponse, err := newPoolResponse(r.appCodec, pool)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, poolResponse)
+	})
+
+	mux.HandleFunc("GET /taker-fee", func(w http.ResponseWriter, req *http.Request) {
+		denom0, denom1 := req.URL.Query().Get("denom0"), req.URL.Query().Get("denom1")
+		if denom0 == "" || denom1 == "" {
+			http.Error(w, "denom0 and denom1 are required", http.StatusBadRequest)
+			return
+		}
+
+		state, ok := r.getStateFromRequest(w, req)
+		if !ok {
+			return
+		}
+
+		takerFee, found := state.GetTakerFee(denom0, denom1)
+
+		writeJSON(w, TakerFeeResponse{
+			Height:    state.Height,
+			Denom0:    denom0,
+			Denom1:    denom1,
+			TakerFee:  takerFee,
+			IsDefault: !found,
+		})
+	})
+
+	return mux
+}
+
+// getStateFromRequest returns the state at the height from the request query.
+// Writes the error response and returns false on failure.
+func (r *receiver) getStateFromRequest(w http.ResponseWriter, req *http.Request) (*State, bool) {
+	var height uint64
+	if heightStr := req.URL.Query().Get("height"); heightStr != "" {
+		var err error
+		height, err = strconv.ParseUint(heightStr, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid height", http.StatusBadRequest)
+			return nil, false
+		}
+	}
+
+	state, ok := r.GetState(height)
+	if !ok {
+		http.Error(w, "state not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	return state, true
+}
+
+func newPoolResponse(appCodec codec.Codec, pool *ingesttypes.PoolWrapper) (PoolResponse, error) {
+	chainModelBz, err := appCodec.MarshalInterfaceJSON(pool.GetUnderlyingPool())
+	if err != nil {
+		return PoolResponse{}, err
+	}
+
+	return PoolResponse{
+		ChainModel: chainModelBz,
+		SQSModel:   pool.GetSQSPoolModel(),
+		TickModel:  pool.TickModel,
+	}, nil
+}
+
+func writeJSON(w http.ResponseWriter, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,292 @@
+package receiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	prototypes "github.com/osmosis-labs/osmosis/v30/ingest/types/proto/types"
+
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+// DefaultHistorySize is the default number of heights for which the pool state is retained.
+const DefaultHistorySize = 10
+
+// receiver is a reference implementation of the SQS ingester server.
+// It decodes the pushed data and keeps the pool state in memory for the most recent heights.
+// It is intended for local development and integration testing rather than production use.
+type receiver struct {
+	prototypes.UnimplementedSQSIngesterServer
+
+	appCodec codec.Codec
+
+	historySize int
+
+	mu sync.RWMutex
+	// states are the retained states ordered by height in ascending order.
+	states []*State
+}
+
+var _ prototypes.SQSIngesterServer = &receiver{}
+
+// New returns a new receiver retaining the state for historySize heights.
+// The codec must have the pool interfaces registered to decode the chain models.
+func New(appCodec codec.Codec, historySize int) *receiver {
+	if historySize < 1 {
+		historySize = 1
+	}
+
+	return &receiver{
+		appCodec:    appCodec,
+		historySize: historySize,
+		states:      []*State{},
+	}
+}
+
+// ProcessBlock implements prototypes.SQSIngesterServer.
+// The pools are JSON-encoded.
+func (r *receiver) ProcessBlock(ctx context.Context, req *prototypes.ProcessBlockRequest) (*prototypes.ProcessBlockReply, error) {
+	pools := make([]*ingesttypes.PoolWrapper, 0, len(req.Pools))
+	for _, poolData := range req.Pools {
+		pool, err := r.poolFromJSON(poolData)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		pools = append(pools, pool)
+	}
+
+	takerFeeMap := ingesttypes.TakerFeeMap{}
+	if len(req.TakerFeesMap) > 0 {
+		if err := takerFeeMap.UnmarshalJSON(req.TakerFeesMap); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	return r.apply(req.BlockHeight, req.BaseHeight, pools, req.RemovedPoolIds, takerFeeMap, ingesttypes.PoolAPRUpdatesFromProto(req.AprUpdates)), nil
+}
+
+// ProcessBlockStream implements prototypes.SQSIngesterServer.
+// The pools are protobuf-encoded and split into chunks. The block is applied
+// only after all chunks are received.
+func (r *receiver) ProcessBlockStream(stream prototypes.SQSIngester_ProcessBlockStreamServer) error {
+	var (
+		height, baseHeight uint64
+		numChunks          uint32
+		totalChunks        uint32
+
+		pools          = []*ingesttypes.PoolWrapper{}
+		removedPoolIDs = []uint64{}
+		takerFeeMap    = ingesttypes.TakerFeeMap{}
+		aprUpdates     = []ingesttypes.PoolAPRUpdate{}
+	)
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if numChunks == 0 {
+			height, baseHeight, totalChunks = chunk.BlockHeight, chunk.BaseHeight, chunk.TotalChunks
+		} else if chunk.BlockHeight != height {
+			return status.Errorf(codes.InvalidArgument, "chunk (%d) has height (%d), expected (%d)", chunk.ChunkIndex, chunk.BlockHeight, height)
+		}
+
+		if chunk.ChunkIndex != numChunks {
+			return status.Errorf(codes.InvalidArgument, "chunk has index (%d), expected (%d)", chunk.ChunkIndex, numChunks)
+		}
+		numChunks++
+
+		for _, poolModel := range chunk.Pools {
+			pool, err := ingesttypes.PoolFromProto(r.appCodec, poolModel)
+			if err != nil {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			pools = append(pools, pool)
+		}
+
+		removedPoolIDs = append(removedPoolIDs, chunk.RemovedPoolIds...)
+		aprUpdates = append(aprUpdates, ingesttypes.PoolAPRUpdatesFromProto(chunk.AprUpdates)...)
+
+		chunkTakerFeeMap, err := ingesttypes.TakerFeeMapFromProto(chunk.TakerFees)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		for denomPair, takerFee := range chunkTakerFeeMap {
+			takerFeeMap[denomPair] = takerFee
+		}
+	}
+
+	if numChunks == 0 || numChunks != totalChunks {
+		return status.Errorf(codes.InvalidArgument, "received (%d) chunks, expected (%d)", numChunks, totalChunks)
+	}
+
+	return stream.SendAndClose(r.apply(height, baseHeight, pools, removedPoolIDs, takerFeeMap, aprUpdates))
+}
+
+// GetState returns the state at the given height.
+// If height is zero, returns the latest state.
+// Returns false if no state is retained for the height.
+func (r *receiver) GetState(height uint64) (*State, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.states) == 0 {
+		return nil, false
+	}
+
+	if height == 0 {
+		return r.states[len(r.states)-1], true
+	}
+
+	for _, state := range r.states {
+		if state.Height == height {
+			return state, true
+		}
+	}
+
+	return nil, false
+}
+
+// apply applies the pools and taker fees pushed at the given height.
+// Zero base height signifies a full snapshot that replaces the state.
+// Otherwise, the pools are an update on top of the state at the base height.
+// If the base height is not the last applied height, the update is rejected and a resync is requested.
+// The removed pools are dropped from the state. The APR updates are applied on top of the pools
+// held in the state. APR updates of unknown pools are ignored.
+func (r *receiver) apply(height, baseHeight uint64, pools []*ingesttypes.PoolWrapper, removedPoolIDs []uint64, takerFeeMap ingesttypes.TakerFeeMap, aprUpdates []ingesttypes.PoolAPRUpdate) *prototypes.ProcessBlockReply {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var lastState *State
+	if len(r.states) > 0 {
+		lastState = r.states[len(r.states)-1]
+	}
+
+	var lastAppliedHeight uint64
+	if lastState != nil {
+		lastAppliedHeight = lastState.Height
+	}
+
+	isFullSnapshot := baseHeight == 0
+	if !isFullSnapshot && (lastState == nil || baseHeight != lastAppliedHeight) {
+		return &prototypes.ProcessBlockReply{
+			LastAppliedHeight: lastAppliedHeight,
+			Resync:            true,
+		}
+	}
+
+	newState := &State{
+		Height:      height,
+		Pools:       make(map[uint64]*ingesttypes.PoolWrapper, len(pools)),
+		TakerFeeMap: ingesttypes.TakerFeeMap{},
+	}
+
+	if !isFullSnapshot {
+		for poolID, pool := range lastState.Pools {
+			newState.Pools[poolID] = pool
+		}
+		for denomPair, takerFee := range lastState.TakerFeeMap {
+			newState.TakerFeeMap[denomPair] = takerFee
+		}
+	}
+
+	for denomPair, takerFee := range takerFeeMap {
+		newState.TakerFeeMap[denomPair] = takerFee
+	}
+
+	for _, poolID := range removedPoolIDs {
+		delete(newState.Pools, poolID)
+	}
+
+	tickSnapshotPoolIDs := []uint64{}
+	for _, pool := range pools {
+		if pool.TickDeltaModel != nil {
+			var tickModel *ingesttypes.TickModel
+			if previousPool, ok := newState.Pools[pool.GetId()]; ok {
+				tickModel = previousPool.TickModel
+			}
+
+			// The ticks cannot be patched without the full tick model.
+			// Request it in the next block.
+			if tickModel == nil {
+				tickSnapshotPoolIDs = append(tickSnapshotPoolIDs, pool.GetId())
+			} else {
+				pool.TickModel = ApplyTickDelta(tickModel, pool.TickDeltaModel)
+			}
+
+			pool.TickDeltaModel = nil
+		}
+
+		newState.Pools[pool.GetId()] = pool
+	}
+
+	for _, aprUpdate := range aprUpdates {
+		previousPool, ok := newState.Pools[aprUpdate.PoolID]
+		if !ok {
+			continue
+		}
+
+		// The pools are shared with the previous states, so they are copied before updating.
+		pool := *previousPool
+		pool.APRData = aprUpdate.APRData
+		pool.FeesData = aprUpdate.FeesData
+		newState.Pools[aprUpdate.PoolID] = &pool
+	}
+
+	r.states = append(r.states, newState)
+	if len(r.states) > r.historySize {
+		r.states = r.states[len(r.states)-r.historySize:]
+	}
+
+	return &prototypes.ProcessBlockReply{
+		TickSnapshotPoolIds: tickSnapshotPoolIDs,
+		LastAppliedHeight:   height,
+	}
+}
+
+// poolFromJSON decodes the JSON-encoded pool data pushed over the unary RPC.
+func (r *receiver) poolFromJSON(poolData *prototypes.PoolData) (*ingesttypes.PoolWrapper, error) {
+	var chainModel poolmanagertypes.PoolI
+	if err := r.appCodec.UnmarshalInterfaceJSON(poolData.ChainModel, &chainModel); err != nil {
+		return nil, err
+	}
+
+	pool := &ingesttypes.PoolWrapper{
+		ChainModel: chainModel,
+		APRData:    ingesttypes.PoolAPRDataFromProto(poolData.AprData),
+		FeesData:   ingesttypes.PoolFeesDataFromProto(poolData.FeesData),
+	}
+
+	if err := json.Unmarshal(poolData.SqsModel, &pool.SQSModel); err != nil {
+		return nil, fmt.Errorf("pool (%d): %w", chainModel.GetId(), err)
+	}
+
+	if len(poolData.TickModel) > 0 {
+		pool.TickModel = &ingesttypes.TickModel{}
+		if err := json.Unmarshal(poolData.TickModel, pool.TickModel); err != nil {
+			return nil, fmt.Errorf("pool (%d): %w", chainModel.GetId(), err)
+		}
+	}
+
+	if len(poolData.TickDeltaModel) > 0 {
+		pool.TickDeltaModel = &ingesttypes.TickDeltaModel{}
+		if err := json.Unmarshal(poolData.TickDeltaModel, pool.TickDeltaModel); err != nil {
+			return nil, fmt.Errorf("pool (%d): %w", chainModel.GetId(), err)
+		}
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,413 @@
+package receiver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v30/app/apptesting"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/receiver"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/service"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	"github.com/osmosis-labs/osmosis/v30/ingest/types/passthroughdomain"
+	prototypes "github.com/osmosis-labs/osmosis/v30/ingest/types/proto/types"
+)
+
+type ReceiverTestSuite struct {
+	apptesting.ConcentratedKeeperTestHelper
+}
+
+func TestReceiverTestSuite(t *testing.T) {
+	suite.Run(t, new(ReceiverTestSuite))
+}
+
+// startReceiver starts a receiver on a random local port and returns it
+// together with a client pushing to it.
+func (s *ReceiverTestSuite) startReceiver(config sqs.Config) (receiver.Receiver, *service.GRPCClient) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+
+	ingestReceiver := receiver.New(s.App.AppCodec(), receiver.DefaultHistorySize)
+
+	grpcServer := grpc.NewServer()
+	prototypes.RegisterSQSIngesterServer(grpcServer, ingestReceiver)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	s.T().Cleanup(grpcServer.Stop)
+
+	grpcClient, err := service.NewGRPCCLient(listener.Addr().String(), config, s.App.AppCodec(), nil)
+	s.Require().NoError(err)
+
+	return ingestReceiver, grpcClient
+}
+
+// preparePools creates the given number of balancer pools and wraps them for ingest.
+func (s *ReceiverTestSuite) preparePools(numPools int) []ingesttypes.PoolI {
+	pools := make([]ingesttypes.PoolI, 0, numPools)
+	for i := 0; i < numPools; i++ {
+		poolID := s.PrepareBalancerPool()
+
+		pool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, poolID)
+		s.Require().NoError(err)
+
+		balances := s.App.BankKeeper.GetAllBalances(s.Ctx, pool.GetAddress())
+
+		pools = append(pools, ingesttypes.NewPool(pool, osmomath.ZeroDec(), balances))
+	}
+	return pools
+}
+
+// This test validates that the pushed pools are decoded and applied on top of the previous state
+// over both the streaming and the unary transports.
+func (s *ReceiverTestSuite) TestReceiver_ProcessBlock() {
+	testCases := []struct {
+		name          string
+		streamEnabled bool
+	}{
+		{
+			name:          "stream",
+			streamEnabled: true,
+		},
+		{
+			name:          "unary",
+			streamEnabled: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Setup()
+
+			config := sqs.DefaultConfig
+			config.GRPCIngestStreamEnabled = tc.streamEnabled
+
+			ingestReceiver, grpcClient := s.startReceiver(config)
+
+			pools := s.preparePools(3)
+
+			takerFeeMap := ingesttypes.TakerFeeMap{}
+			takerFeeMap.SetTakerFee("uosmo", "uion", osmomath.MustNewDecFromStr("0.002"))
+
+			// Full snapshot with the first two pools.
+			err := grpcClient.PushData(context.Background(), 10, pools[:2], nil, takerFeeMap, nil, true)
+			s.Require().NoError(err)
+			s.Require().Equal(domain.SinkAck{LastAppliedHeight: 10}, grpcClient.GetSinkAck())
+
+			// Update with the last pool.
+			err = grpcClient.PushData(context.Background(), 11, pools[2:], nil, ingesttypes.TakerFeeMap{}, nil, false)
+			s.Require().NoError(err)
+			s.Require().Equal(domain.SinkAck{LastAppliedHeight: 11}, grpcClient.GetSinkAck())
+
+			// The previous height is retained.
+			state, ok := ingestReceiver.GetState(10)
+			s.Require().True(ok)
+			s.Require().Len(state.Pools, 2)
+
+			// The latest state contains all pools.
+			state, ok = ingestReceiver.GetState(0)
+			s.Require().True(ok)
+			s.Require().Equal(uint64(11), state.Height)
+
+			actualPools := state.GetPools()
+			s.Require().Len(actualPools, len(pools))
+			for i, pool := range pools {
+				s.Require().Equal(pool.GetId(), actualPools[i].GetId())
+				s.Require().Equal(pool.GetSQSPoolModel().Balances, actualPools[i].GetSQSPoolModel().Balances)
+			}
+
+			// The taker fees are carried over.
+			takerFee, found := state.GetTakerFee("uosmo", "uion")
+			s.Require().True(found)
+			s.Require().Equal(osmomath.MustNewDecFromStr("0.002"), takerFee)
+		})
+	}
+}
+
+// This test validates that the removed pools are dropped from the state
+// over both the streaming and the unary transports.
+func (s *ReceiverTestSuite) TestReceiver_ProcessBlock_RemovedPools() {
+	testCases := []struct {
+		name          string
+		streamEnabled bool
+	}{
+		{
+			name:          "stream",
+			streamEnabled: true,
+		},
+		{
+			name:          "unary",
+			streamEnabled: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Setup()
+
+			config := sqs.DefaultConfig
+			config.GRPCIngestStreamEnabled = tc.streamEnabled
+
+			ingestReceiver, grpcClient := s.startReceiver(config)
+
+			pools := s.preparePools(3)
+
+			err := grpcClient.PushData(context.Background(), 10, pools, nil, ingesttypes.TakerFeeMap{}, nil, true)
+			s.Require().NoError(err)
+
+			// Update the last pool and remove the first one.
+			err = grpcClient.PushData(context.Background(), 11, pools[2:], []uint64{pools[0].GetId()}, ingesttypes.TakerFeeMap{}, nil, false)
+			s.Require().NoError(err)
+			s.Require().Equal(domain.SinkAck{LastAppliedHeight: 11}, grpcClient.GetSinkAck())
+
+			state, ok := ingestReceiver.GetState(0)
+			s.Require().True(ok)
+
+			actualPools := state.GetPools()
+			s.Require().Len(actualPools, 2)
+			s.Require().Equal(pools[1].GetId(), actualPools[0].GetId())
+			s.Require().Equal(pools[2].GetId(), actualPools[1].GetId())
+
+			// The previous height still contains the removed pool.
+			state, ok = ingestReceiver.GetState(10)
+			s.Require().True(ok)
+			s.Require().Len(state.Pools, 3)
+		})
+	}
+}
+
+// This test validates that the APR updates are applied on top of the pools held in the state
+// over both the streaming and the unary transports.
+func (s *ReceiverTestSuite) TestReceiver_ProcessBlock_APRUpdates() {
+	testCases := []struct {
+		name          string
+		streamEnabled bool
+	}{
+		{
+			name:          "stream",
+			streamEnabled: true,
+		},
+		{
+			name:          "unary",
+			streamEnabled: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Setup()
+
+			config := sqs.DefaultConfig
+			config.GRPCIngestStreamEnabled = tc.streamEnabled
+
+			ingestReceiver, grpcClient := s.startReceiver(config)
+
+			pools := s.preparePools(2)
+
+			err := grpcClient.PushData(context.Background(), 10, pools, nil, ingesttypes.TakerFeeMap{}, nil, true)
+			s.Require().NoError(err)
+
+			aprUpdate := ingesttypes.PoolAPRUpdate{
+				PoolID: pools[0].GetId(),
+				APRData: passthroughdomain.PoolAPRDataStatusWrap{
+					PoolAPR: passthroughdomain.PoolAPR{
+						TotalAPR: passthroughdomain.PoolDataRange{Lower: 5, Upper: 5},
+					},
+				},
+				FeesData: passthroughdomain.PoolFeesDataStatusWrap{
+					PoolFee: passthroughdomain.PoolFee{
+						Volume24h: 1000,
+					},
+					IsStale: true,
+				},
+			}
+
+			// Update with the APR data of the first pool and of an unknown pool only.
+			err = grpcClient.PushData(context.Background(), 11, nil, nil, ingesttypes.TakerFeeMap{}, []ingesttypes.PoolAPRUpdate{aprUpdate, {PoolID: 1000}}, false)
+			s.Require().NoError(err)
+			s.Require().Equal(domain.SinkAck{LastAppliedHeight: 11}, grpcClient.GetSinkAck())
+
+			state, ok := ingestReceiver.GetState(0)
+			s.Require().True(ok)
+			s.Require().Len(state.Pools, 2)
+
+			pool, ok := state.GetPool(pools[0].GetId())
+			s.Require().True(ok)
+			s.Require().Equal(aprUpdate.APRData, pool.GetAPRData())
+			s.Require().Equal(aprUpdate.FeesData, pool.GetFeesData())
+			s.Require().Equal(pools[0].GetSQSPoolModel().Balances, pool.GetSQSPoolModel().Balances)
+
+			// The pool held in the previous height is not updated.
+			state, ok = ingestReceiver.GetState(10)
+			s.Require().True(ok)
+
+			pool, ok = state.GetPool(pools[0].GetId())
+			s.Require().True(ok)
+			s.Require().Equal(passthroughdomain.PoolAPRDataStatusWrap{}, pool.GetAPRData())
+		})
+	}
+}
+
+// This test validates that an update that is not on top of the last applied height
+// is rejected with a resync request.
+func (s *ReceiverTestSuite) TestReceiver_ProcessBlock_Resync() {
+	s.Setup()
+
+	ingestReceiver := receiver.New(s.App.AppCodec(), receiver.DefaultHistorySize)
+
+	// Nothing applied yet.
+	reply, err := ingestReceiver.ProcessBlock(context.Background(), &prototypes.ProcessBlockRequest{
+		BlockHeight: 5,
+		BaseHeight:  4,
+	})
+	s.Require().NoError(err)
+	s.Require().True(reply.Resync)
+	s.Require().Zero(reply.LastAppliedHeight)
+
+	// Full snapshot.
+	reply, err = ingestReceiver.ProcessBlock(context.Background(), &prototypes.ProcessBlockRequest{
+		BlockHeight: 5,
+	})
+	s.Require().NoError(err)
+	s.Require().False(reply.Resync)
+	s.Require().Equal(uint64(5), reply.LastAppliedHeight)
+
+	// Height 6 is missed.
+	reply, err = ingestReceiver.ProcessBlock(context.Background(), &prototypes.ProcessBlockRequest{
+		BlockHeight: 7,
+		BaseHeight:  6,
+	})
+	s.Require().NoError(err)
+	s.Require().True(reply.Resync)
+	s.Require().Equal(uint64(5), reply.LastAppliedHeight)
+
+	_, ok := ingestReceiver.GetState(7)
+	s.Require().False(ok)
+}
+
+// This test validates the JSON read API.
+func (s *ReceiverTestSuite) TestReceiver_HTTPHandler() {
+	s.Setup()
+
+	ingestReceiver, grpcClient := s.startReceiver(sqs.DefaultConfig)
+
+	pools := s.preparePools(2)
+
+	takerFeeMap := ingesttypes.TakerFeeMap{}
+	takerFeeMap.SetTakerFee("uosmo", "uion", osmomath.MustNewDecFromStr("0.002"))
+
+	err := grpcClient.PushData(context.Background(), 10, pools, nil, takerFeeMap, nil, true)
+	s.Require().NoError(err)
+
+	httpServer := httptest.NewServer(ingestReceiver.NewHTTPHandler())
+	defer httpServer.Close()
+
+	get := func(path string, response any) int {
+		resp, err := http.Get(httpServer.URL + path)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusOK {
+			s.Require().NoError(json.NewDecoder(resp.Body).Decode(response))
+		}
+		return resp.StatusCode
+	}
+
+	var poolsResponse receiver.PoolsResponse
+	s.Require().Equal(http.StatusOK, get("/pools", &poolsResponse))
+	s.Require().Equal(uint64(10), poolsResponse.Height)
+	s.Require().Len(poolsResponse.Pools, 2)
+
+	var poolResponse receiver.PoolResponse
+	s.Require().Equal(http.StatusOK, get("/pools/"+strconv.FormatUint(pools[1].GetId(), 10), &poolResponse))
+	s.Require().Equal(pools[1].GetSQSPoolModel().Balances, poolResponse.SQSModel.Balances)
+
+	var takerFeeResponse receiver.TakerFeeResponse
+	s.Require().Equal(http.StatusOK, get("/taker-fee?denom0=uosmo&denom1=uion", &takerFeeResponse))
+	s.Require().Equal(osmomath.MustNewDecFromStr("0.002"), takerFeeResponse.TakerFee)
+	s.Require().False(takerFeeResponse.IsDefault)
+
+	s.Require().Equal(http.StatusOK, get("/taker-fee?denom0=uion&denom1=uosmo", &takerFeeResponse))
+	s.Require().True(takerFeeResponse.IsDefault)
+
+	s.Require().Equal(http.StatusNotFound, get("/pools/1000", nil))
+	s.Require().Equal(http.StatusNotFound, get("/pools?height=9", nil))
+	s.Require().Equal(http.StatusBadRequest, get("/taker-fee?denom0=uosmo", nil))
+}
+
+// This test validates the gRPC read API over the applied state.
+func (s *ReceiverTestSuite) TestReceiver_GRPCQuery() {
+	s.Setup()
+
+	ingestReceiver, grpcClient := s.startReceiver(sqs.DefaultConfig)
+
+	pools := s.preparePools(2)
+
+	takerFeeMap := ingesttypes.TakerFeeMap{}
+	takerFeeMap.SetTakerFee("uosmo", "uion", osmomath.MustNewDecFromStr("0.002"))
+
+	err := grpcClient.PushData(context.Background(), 10, pools, nil, takerFeeMap, nil, true)
+	s.Require().NoError(err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+
+	grpcServer := grpc.NewServer()
+	prototypes.RegisterSQSReceiverQueryServer(grpcServer, ingestReceiver)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	queryClient := prototypes.NewSQSReceiverQueryClient(conn)
+	ctx := context.Background()
+
+	poolsReply, err := queryClient.Pools(ctx, &prototypes.ReceiverPoolsRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(10), poolsReply.Height)
+	s.Require().Len(poolsReply.Pools, 2)
+
+	poolReply, err := queryClient.Pool(ctx, &prototypes.ReceiverPoolRequest{PoolId: pools[1].GetId(), Height: 10})
+	s.Require().NoError(err)
+	pool, err := ingesttypes.PoolFromProto(s.App.AppCodec(), poolReply.Pool)
+	s.Require().NoError(err)
+	s.Require().Equal(pools[1].GetId(), pool.GetId())
+	s.Require().Equal(pools[1].GetSQSPoolModel().Balances, pool.GetSQSPoolModel().Balances)
+
+	takerFeeReply, err := queryClient.TakerFee(ctx, &prototypes.ReceiverTakerFeeRequest{Denom0: "uosmo", Denom1: "uion"})
+	s.Require().NoError(err)
+	s.Require().Equal("0.002000000000000000", takerFeeReply.TakerFee.TakerFee)
+	s.Require().False(takerFeeReply.IsDefault)
+
+	takerFeeReply, err = queryClient.TakerFee(ctx, &prototypes.ReceiverTakerFeeRequest{Denom0: "uion", Denom1: "uosmo"})
+	s.Require().NoError(err)
+	s.Require().True(takerFeeReply.IsDefault)
+
+	_, err = queryClient.Pool(ctx, &prototypes.ReceiverPoolRequest{PoolId: 1000})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+
+	_, err = queryClient.Pools(ctx, &prototypes.ReceiverPoolsRequest{Height: 9})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+
+	_, err = queryClient.TakerFee(ctx, &prototypes.ReceiverTakerFeeRequest{Denom0: "uosmo"})
+	s.Require().Equal(codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,43 @@
+package receiver
+
+import (
+	"sort"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+)
+
+// State is the pool state applied at a height.
+// It must not be mutated since it is shared with the subsequent heights.
+type State struct {
+	Height      uint64
+	Pools       map[uint64]*ingesttypes.PoolWrapper
+	TakerFeeMap ingesttypes.TakerFeeMap
+}
+
+// GetPools returns all pools sorted by ID.
+func (s *State) GetPools() []*ingesttypes.PoolWrapper {
+	pools := make([]*ingesttypes.PoolWrapper, 0, len(s.Pools))
+	for _, pool := range s.Pools {
+		pools = append(pools, pool)
+	}
+
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].GetId() < pools[j].GetId()
+	})
+
+	return pools
+}
+
+// GetPool returns the pool with the given ID.
+// Returns false if the pool is not found.
+func (s *State) GetPool(poolID uint64) (*ingesttypes.PoolWrapper, bool) {
+	pool, ok := s.Pools[poolID]
+	return pool, ok
+}
+
+// GetTakerFee returns the taker fee for the given denom pair.
+// Returns false if the taker fee was not pushed, in which case the default taker fee is returned.
+func (s *State) GetTakerFee(denom0, denom1 string) (osmomath.Dec, bool) {
+	return s.TakerFeeMap.GetTakerFee(denom0, denom1), s.TakerFeeMap.Has(denom0, denom1)
+}
@@ -0,0 +1,104 @@
+package receiver
+
+import (
+	"sort"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+)
+
+// ApplyTickDelta returns the tick model resulting from patching the given tick model with the tick delta.
+// The liquidity net of every initialized tick is recovered from the liquidity ranges,
+// replaced with the liquidity net from the delta and the ranges are rebuilt.
+// Only the ranges with positive liquidity are retained.
+// The given tick model is not mutated.
+func ApplyTickDelta(tickModel *ingesttypes.TickModel, tickDeltaModel *ingesttypes.TickDeltaModel) *ingesttypes.TickModel {
+	liquidityNetByTick := liquidityNetFromRanges(tickModel.Ticks)
+
+	for _, tick := range tickDeltaModel.Ticks {
+		if tick.LiquidityNet.IsZero() {
+			delete(liquidityNetByTick, tick.TickIndex)
+			continue
+		}
+		liquidityNetByTick[tick.TickIndex] = tick.LiquidityNet
+	}
+
+	ticks := rangesFromLiquidityNet(liquidityNetByTick)
+
+	currentTickIndex := int64(-1)
+	for i, tick := range ticks {
+		if tick.LowerTick <= tickDeltaModel.CurrentTick && tickDeltaModel.CurrentTick < tick.UpperTick {
+			currentTickIndex = int64(i)
+			break
+		}
+	}
+
+	return &ingesttypes.TickModel{
+		Ticks:            ticks,
+		CurrentTickIndex: currentTickIndex,
+		HasNoLiquidity:   len(ticks) == 0,
+	}
+}
+
+// liquidityNetFromRanges returns the liquidity net of every tick at which the liquidity changes
+// across the given ranges.
+func liquidityNetFromRanges(ranges []ingesttypes.LiquidityDepthsWithRange) map[int64]osmomath.Dec {
+	sortedRanges := make([]ingesttypes.LiquidityDepthsWithRange, len(ranges))
+	copy(sortedRanges, ranges)
+	sort.Slice(sortedRanges, func(i, j int) bool {
+		return sortedRanges[i].LowerTick < sortedRanges[j].LowerTick
+	})
+
+	liquidityNetByTick := map[int64]osmomath.Dec{}
+	addLiquidityNet := func(tick int64, liquidityNet osmomath.Dec) {
+		if existing, ok := liquidityNetByTick[tick]; ok {
+			liquidityNet = existing.Add(liquidityNet)
+		}
+
+		if liquidityNet.IsZero() {
+			delete(liquidityNetByTick, tick)
+			return
+		}
+		liquidityNetByTick[tick] = liquidityNet
+	}
+
+	for _, liquidityRange := range sortedRanges {
+		// The liquidity goes from zero to the range liquidity at the lower tick
+		// and back to zero at the upper tick. Adjacent ranges cancel out
+		// the corresponding parts at their shared tick.
+		addLiquidityNet(liquidityRange.LowerTick, liquidityRange.LiquidityAmount)
+		addLiquidityNet(liquidityRange.UpperTick, liquidityRange.LiquidityAmount.Neg())
+	}
+
+	return liquidityNetByTick
+}
+
+// rangesFromLiquidityNet returns the ranges with positive liquidity between the ticks
+// with the given liquidity net, sorted by the lower tick.
+func rangesFromLiquidityNet(liquidityNetByTick map[int64]osmomath.Dec) []ingesttypes.LiquidityDepthsWithRange {
+	tickIndexes := make([]int64, 0, len(liquidityNetByTick))
+	for tickIndex := range liquidityNetByTick {
+		tickIndexes = append(tickIndexes, tickIndex)
+	}
+	sort.Slice(tickIndexes, func(i, j int) bool {
+		return tickIndexes[i] < tickIndexes[j]
+	})
+
+	ranges := []ingesttypes.LiquidityDepthsWithRange{}
+	liquidity := osmomath.ZeroDec()
+	for i, tickIndex := range tickIndexes {
+		liquidity = liquidity.Add(liquidityNetByTick[tickIndex])
+
+		if i+1 == len(tickIndexes) || !liquidity.IsPositive() {
+			continue
+		}
+
+		ranges = append(ranges, ingesttypes.LiquidityDepthsWithRange{
+			LowerTick:       tickIndex,
+			UpperTick:       tickIndexes[i+1],
+			LiquidityAmount: liquidity,
+		})
+	}
+
+	return ranges
+}
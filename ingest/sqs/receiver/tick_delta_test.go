@@ -0,0 +1,120 @@
+package receiver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/receiver"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+)
+
+func TestApplyTickDelta(t *testing.T) {
+	liquidityRange := func(lowerTick, upperTick int64, liquidity int64) ingesttypes.LiquidityDepthsWithRange {
+		return ingesttypes.LiquidityDepthsWithRange{
+			LowerTick:       lowerTick,
+			UpperTick:       upperTick,
+			LiquidityAmount: osmomath.NewDec(liquidity),
+		}
+	}
+
+	tickDelta := func(tickIndex int64, liquidityNet int64) ingesttypes.TickDelta {
+		return ingesttypes.TickDelta{
+			TickIndex:    tickIndex,
+			LiquidityNet: osmomath.NewDec(liquidityNet),
+		}
+	}
+
+	// Two overlapping positions: [-100, 100) with 10 and [0, 200) with 5.
+	tickModel := &ingesttypes.TickModel{
+		Ticks: []ingesttypes.LiquidityDepthsWithRange{
+			liquidityRange(-100, 0, 10),
+			liquidityRange(0, 100, 15),
+			liquidityRange(100, 200, 5),
+		},
+		CurrentTickIndex: 1,
+	}
+
+	testCases := []struct {
+		name           string
+		tickDeltaModel *ingesttypes.TickDeltaModel
+
+		expectedTickModel *ingesttypes.TickModel
+	}{
+		{
+			name: "no changed ticks, current tick moves",
+			tickDeltaModel: &ingesttypes.TickDeltaModel{
+				CurrentTick: -50,
+			},
+
+			expectedTickModel: &ingesttypes.TickModel{
+				Ticks:            tickModel.Ticks,
+				CurrentTickIndex: 0,
+			},
+		},
+		{
+			name: "new position [50, 150) with 3",
+			tickDeltaModel: &ingesttypes.TickDeltaModel{
+				Ticks: []ingesttypes.TickDelta{
+					tickDelta(50, 3),
+					tickDelta(150, -3),
+				},
+				CurrentTick: 120,
+			},
+
+			expectedTickModel: &ingesttypes.TickModel{
+				Ticks: []ingesttypes.LiquidityDepthsWithRange{
+					liquidityRange(-100, 0, 10),
+					liquidityRange(0, 50, 15),
+					liquidityRange(50, 100, 18),
+					liquidityRange(100, 150, 8),
+					liquidityRange(150, 200, 5),
+				},
+				CurrentTickIndex: 3,
+			},
+		},
+		{
+			name: "position [-100, 100) is removed",
+			tickDeltaModel: &ingesttypes.TickDeltaModel{
+				Ticks: []ingesttypes.TickDelta{
+					tickDelta(-100, 0),
+					tickDelta(100, 0),
+				},
+				CurrentTick: -50,
+			},
+
+			expectedTickModel: &ingesttypes.TickModel{
+				Ticks: []ingesttypes.LiquidityDepthsWithRange{
+					liquidityRange(0, 200, 5),
+				},
+				CurrentTickIndex: -1,
+			},
+		},
+		{
+			name: "all positions are removed",
+			tickDeltaModel: &ingesttypes.TickDeltaModel{
+				Ticks: []ingesttypes.TickDelta{
+					tickDelta(-100, 0),
+					tickDelta(0, 0),
+					tickDelta(100, 0),
+					tickDelta(200, 0),
+				},
+			},
+
+			expectedTickModel: &ingesttypes.TickModel{
+				Ticks:            []ingesttypes.LiquidityDepthsWithRange{},
+				CurrentTickIndex: -1,
+				HasNoLiquidity:   true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualTickModel := receiver.ApplyTickDelta(tickModel, tc.tickDeltaModel)
+
+			require.Equal(t, tc.expectedTickModel, actualTickModel)
+		})
+	}
+}
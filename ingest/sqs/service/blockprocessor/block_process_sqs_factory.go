@@ -7,7 +7,7 @@ import (
 	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
 )
 
-type transformAndLoadFunc func(ctx sdk.Context, poolsTrasnformer domain.PoolsTransformer, sqsGRPCClient domain.SQSGRPClient, pools commondomain.BlockPools) error
+type transformAndLoadFunc func(ctx sdk.Context, poolsTrasnformer domain.PoolsTransformer, sqsGRPCClient domain.SQSGRPClient, pools commondomain.BlockPools, isFullSnapshot bool) error
 
 // NewBlockProcessor creates a new block process strategy.
 // If block process strategy manager should push all data, then it will return a full indexer block process strategy.
@@ -27,6 +27,9 @@ var _ commondomain.BlockProcessor = &blockUpdatesSQSBlockProcessStrategy{}
 
 // ProcessBlock implements commondomain.BlockProcessStrategy.
 // ProcessBlock extracts, transforms and loads the pools that were changed in the block.
+// Note that if the poolmanager params changed or the pool APR and fees data refresh is due,
+// only the changed pools are pushed in full. The transformer recomputes the taker fees and
+// the APR and fees data of the other pools, which are pushed on top of them.
 // Returns an error if any of the steps fail.
 func (f *blockUpdatesSQSBlockProcessStrategy) ProcessBlock(ctx types.Context) error {
 	// Due to new streaming service design, we need to process the writes in the change set all at once here.
@@ -42,7 +45,7 @@ func (f *blockUpdatesSQSBlockProcessStrategy) ProcessBlock(ctx types.Context) er
 	}
 
 	// Publish the pools
-	err = f.transformAndLoadFunc(ctx, f.poolsTransformer, f.sqsGRPCClient, pools)
+	err = f.transformAndLoadFunc(ctx, f.poolsTransformer, f.sqsGRPCClient, pools, false)
 	if err != nil {
 		return err
 	}
@@ -13,6 +13,8 @@ import (
 var (
 	emptyBlockPools = commondomain.BlockPools{}
 
+	takerFeeParamsChangedBlockPools = commondomain.BlockPools{IsTakerFeeParamsChanged: true}
+
 	// Create uninitialized mocks to be used where components are irrelevant for testing.
 	uninitializedTransformer = &mocks.PoolsTransformerMock{}
 	uninitialzedGRPClient    = &mocks.GRPCClientMock{}
@@ -71,6 +73,15 @@ func (s *SQSBlockProcessorTestSuite) TestProcessBlock_UpdatesOnlyStrategy() {
 
 			expectedError: defaultError,
 		},
+		{
+			name: "taker fee params changed, only changed pools are loaded",
+
+			exractorBlockPools:        takerFeeParamsChangedBlockPools,
+			extractChangedError:       nil,
+			transformAndLoadMockError: nil,
+
+			expectedError: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -104,9 +115,12 @@ func (s *SQSBlockProcessorTestSuite) TestProcessBlock_UpdatesOnlyStrategy() {
 			actualErr := newBlockProcessor.ProcessBlock(s.Ctx)
 			s.Require().Equal(tt.expectedError, actualErr)
 
+			// All pools are never extracted
+			s.Require().False(poolsExtracter.IsProcessAllBlockDataCalled)
+
 			// Validate the transformAndLoadFunc mock
 			expectPreTransformError := tt.extractChangedError != nil || tt.processChangeSetError != nil
-			s.validateTransformAndLoadFuncMock(expectPreTransformError, tt.exractorBlockPools, transformAndLoadMock, uninitializedTransformer, uninitialzedGRPClient)
+			s.validateTransformAndLoadFuncMock(expectPreTransformError, tt.exractorBlockPools, false, transformAndLoadMock, uninitializedTransformer, uninitialzedGRPClient)
 		})
 	}
 }
@@ -114,7 +128,7 @@ func (s *SQSBlockProcessorTestSuite) TestProcessBlock_UpdatesOnlyStrategy() {
 // validateTransformAndLoadFuncMock validates the transformAndLoadFunc mock
 // based on the expected inputs and outputs.
 // expectPreTransformError indicates if any component before the transformAndLoadFunc errored.
-func (s *SQSBlockProcessorTestSuite) validateTransformAndLoadFuncMock(expectPreTransformError bool, expectedBlockPools commondomain.BlockPools, transformAndLoadMock blockprocessor.TransformAndLoadFuncMock, expectedTransformer *mocks.PoolsTransformerMock, expectedSQSClient *mocks.GRPCClientMock) {
+func (s *SQSBlockProcessorTestSuite) validateTransformAndLoadFuncMock(expectPreTransformError bool, expectedBlockPools commondomain.BlockPools, expectedIsFullSnapshot bool, transformAndLoadMock blockprocessor.TransformAndLoadFuncMock, expectedTransformer *mocks.PoolsTransformerMock, expectedSQSClient *mocks.GRPCClientMock) {
 	// If extractor errored, we do not expect transformer mock to be called
 	if expectPreTransformError {
 		// Note: nil indicates that the method was not called
@@ -122,11 +136,14 @@ func (s *SQSBlockProcessorTestSuite) validateTransformAndLoadFuncMock(expectPreT
 		s.Require().Nil(transformAndLoadMock.CalledWithSQSClient)
 		// Note: this structure signifes nil pools
 		s.Require().Equal(commondomain.BlockPools{ConcentratedPools: []types.PoolI(nil)}, transformAndLoadMock.CalledWithPools)
+		s.Require().Nil(transformAndLoadMock.CalledWithIsFullSnapshot)
 		return
 	}
 
 	// Assert tranformAndLoadFunc is called with the correct inputs
-	s.Require().Equal(uninitializedTransformer, transformAndLoadMock.CalledWithTransformer)
+	s.Require().Equal(expectedTransformer, transformAndLoadMock.CalledWithTransformer)
 	s.Require().Equal(uninitialzedGRPClient, transformAndLoadMock.CalledWithSQSClient)
 	s.Require().Equal(expectedBlockPools, transformAndLoadMock.CalledWithPools)
+	s.Require().NotNil(transformAndLoadMock.CalledWithIsFullSnapshot)
+	s.Require().Equal(expectedIsFullSnapshot, *transformAndLoadMock.CalledWithIsFullSnapshot)
 }
@@ -43,14 +43,17 @@ type TransformAndLoadFuncMock struct {
 	CalledWithTransformer domain.PoolsTransformer
 	CalledWithSQSClient   domain.SQSGRPClient
 	CalledWithPools       commondomain.BlockPools
+	// CalledWithIsFullSnapshot is nil if the mock was not called.
+	CalledWithIsFullSnapshot *bool
 
 	Error error
 }
 
-func (m *TransformAndLoadFuncMock) TransformAndLoad(ctx sdk.Context, poolsTrasnformer domain.PoolsTransformer, sqsGRPCClient domain.SQSGRPClient, pools commondomain.BlockPools) error {
+func (m *TransformAndLoadFuncMock) TransformAndLoad(ctx sdk.Context, poolsTrasnformer domain.PoolsTransformer, sqsGRPCClient domain.SQSGRPClient, pools commondomain.BlockPools, isFullSnapshot bool) error {
 	m.CalledWithSQSClient = sqsGRPCClient
 	m.CalledWithTransformer = poolsTrasnformer
 	m.CalledWithPools = pools
+	m.CalledWithIsFullSnapshot = &isFullSnapshot
 
 	return m.Error
 }
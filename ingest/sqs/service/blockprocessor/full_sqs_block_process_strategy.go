@@ -50,7 +50,7 @@ func (f *fullSQSBlockProcessStrategy) ProcessBlock(ctx sdk.Context) (err error)
 	}
 
 	// Publish the pools
-	err = f.transformAndLoadFunc(ctx, f.poolsTransformer, f.sqsGRPCClient, pools)
+	err = f.transformAndLoadFunc(ctx, f.poolsTransformer, f.sqsGRPCClient, pools, true)
 	if err != nil {
 		return err
 	}
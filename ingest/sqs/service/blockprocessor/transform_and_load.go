@@ -7,17 +7,22 @@ import (
 	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
 )
 
-// transformAndLoad transforms the pools and loads them into the SQS.
-func transformAndLoad(ctx sdk.Context, poolsTransformer domain.PoolsTransformer, sqsGRPCClient domain.SQSGRPClient, pools commondomain.BlockPools) error {
+// transformAndLoad transforms the pools and loads them into the SQS together with
+// the APR and fees data refreshed for the other pools, if any.
+// isFullSnapshot signifies that the pools are all the pools in the chain.
+// Returns domain.PushDataError if loading fails.
+func transformAndLoad(ctx sdk.Context, poolsTransformer domain.PoolsTransformer, sqsGRPCClient domain.SQSGRPClient, pools commondomain.BlockPools, isFullSnapshot bool) error {
 	// Transform the pools
-	transformedPools, takerFeeMap, err := poolsTransformer.Transform(ctx, pools)
+	transformedPools, removedPoolIDs, takerFeeMap, err := poolsTransformer.Transform(ctx, pools)
 	if err != nil {
 		return err
 	}
 
+	aprUpdates := poolsTransformer.PopAPRUpdates()
+
 	// load the data
-	if err := sqsGRPCClient.PushData(ctx, uint64(ctx.BlockHeight()), transformedPools, takerFeeMap); err != nil {
-		return err
+	if err := sqsGRPCClient.PushData(ctx, uint64(ctx.BlockHeight()), transformedPools, removedPoolIDs, takerFeeMap, aprUpdates, isFullSnapshot); err != nil {
+		return &domain.PushDataError{Err: err}
 	}
 
 	return nil
@@ -7,3 +7,15 @@ type SQSStreamingService = sqsStreamingService
 func (s *sqsStreamingService) ProcessBlockRecoverError(ctx sdk.Context) error {
 	return s.processBlockRecoverError(ctx)
 }
+
+func (s *sqsStreamingService) SyncWithSink(ctx sdk.Context) {
+	s.syncWithSink(ctx)
+}
+
+func (s *sqsStreamingService) RecordReplayHistory(height uint64, poolIDs []uint64) {
+	s.replayHistory.record(height, poolIDs)
+}
+
+func (s *sqsStreamingService) LastPushedHeight() uint64 {
+	return s.lastPushedHeight.Load()
+}
@@ -1,9 +1,14 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/telemetry"
@@ -13,9 +18,13 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/compression"
+	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/grpcsecurity"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs"
 	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
 	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
 )
@@ -25,22 +34,83 @@ type GRPCClient struct {
 	grpcMaxCallSizeBytes int
 	grpcConn             *grpc.ClientConn
 	appCodec             codec.Codec
+
+	// transportCredentials are the credentials used for dialing.
+	// They are insecure if TLS is disabled.
+	transportCredentials credentials.TransportCredentials
+	// perRPCCredentials authenticate every request. Nil if authentication is disabled.
+	perRPCCredentials credentials.PerRPCCredentials
+
+	// streamEnabled defines if blocks are pushed in chunks over the client-streaming RPC.
+	// It is disabled if the receiver does not implement it.
+	streamEnabled bool
+	// chunkSizeBytes is the target size of a single streamed chunk in bytes.
+	chunkSizeBytes int
+	// compression is the name of the gRPC compressor used for ingest calls.
+	// It is set to compression.None if the receiver does not support it.
+	compression string
+	// configuredStreamEnabled and configuredCompression are the configured transport.
+	// It is restored on every new connection since the receiver may have been upgraded.
+	configuredStreamEnabled bool
+	configuredCompression   string
+
+	// tickSnapshotRequests are the concentrated pool IDs for which SQS
+	// requested a full tick model.
+	tickSnapshotRequests []uint64
+
+	// sinkAck is the acknowledgement from the last successful push.
+	sinkAck domain.SinkAck
+
+	// checkpointer persists the pushed pools for warm restart. Nil if disabled.
+	checkpointer commondomain.Checkpointer
+
+	// lastPayloadSizeBytes is the size of the data sent in the last successful push.
+	lastPayloadSizeBytes uint64
 }
 
 var (
 	_ domain.SQSGRPClient = &GRPCClient{}
+	_ io.Closer           = &GRPCClient{}
 )
 
-func NewGRPCCLient(grpcAddress string, grpxMaxCallSizeBytes int, appCodec codec.Codec) *GRPCClient {
+const (
+	transportStream = "stream"
+	transportUnary  = "unary"
+)
+
+// NewGRPCCLient returns a new gRPC client for the given address.
+// If checkpointer is not nil, the pushed pools are checkpointed so that the first full snapshot
+// after a restart only pushes the pools that changed since the checkpoint.
+// Returns error if the TLS or the authentication configuration is invalid.
+func NewGRPCCLient(grpcAddress string, config sqs.Config, appCodec codec.Codec, checkpointer commondomain.Checkpointer) (*GRPCClient, error) {
+	transportCredentials, err := grpcsecurity.NewClientCredentials(config.TLSConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	perRPCCredentials, err := grpcsecurity.NewPerRPCCredentials(config.AuthConfig())
+	if err != nil {
+		return nil, err
+	}
+
 	return &GRPCClient{
 		grpcAddress:          grpcAddress,
-		grpcMaxCallSizeBytes: grpxMaxCallSizeBytes,
+		grpcMaxCallSizeBytes: config.GRPCIngestMaxCallSizeBytes,
 		appCodec:             appCodec,
-	}
+		transportCredentials: transportCredentials,
+		perRPCCredentials:    perRPCCredentials,
+		streamEnabled:        config.GRPCIngestStreamEnabled,
+		chunkSizeBytes:       config.GRPCIngestChunkSizeBytes,
+		compression:          config.GRPCIngestCompression,
+		checkpointer:         checkpointer,
+
+		configuredStreamEnabled: config.GRPCIngestStreamEnabled,
+		configuredCompression:   config.GRPCIngestCompression,
+	}, nil
 }
 
 // PushData implements domain.GracefulSQSGRPClient.
-func (g *GRPCClient) PushData(ctx context.Context, height uint64, pools []ingesttypes.PoolI, takerFeesMap ingesttypes.TakerFeeMap) (err error) {
+func (g *GRPCClient) PushData(ctx context.Context, height uint64, pools []ingesttypes.PoolI, removedPoolIDs []uint64, takerFeesMap ingesttypes.TakerFeeMap, aprUpdates []ingesttypes.PoolAPRUpdate, isFullSnapshot bool) (err error) {
 	// If sqs service is unavailable, we should reset the connection
 	// and attempt to reconnect during the next block.
 	var shouldResetConnection bool
@@ -65,47 +135,389 @@ func (g *GRPCClient) PushData(ctx context.Context, height uint64, pools []ingest
 		// Using the built-in GRPC retry back-off logic is likely to halt the serial system.
 		// As a result, we opt in for simply continuing to attempting to process the next block
 		// and retrying the connection and ingest
-		g.grpcConn, err = grpc.NewClient(g.grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(g.grpcMaxCallSizeBytes)), grpc.WithDisableRetry(), grpc.WithDisableRetry(), grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
+		dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(g.transportCredentials), grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(g.grpcMaxCallSizeBytes)), grpc.WithDisableRetry(), grpc.WithStatsHandler(otelgrpc.NewClientHandler())}
+		if g.perRPCCredentials != nil {
+			dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(g.perRPCCredentials))
+		}
+
+		g.grpcConn, err = grpc.NewClient(g.grpcAddress, dialOptions...)
 		if err != nil {
 			shouldResetConnection = true
 			return err
 		}
+
+		// The receiver may have been upgraded since the transport fell back,
+		// e.g. during a rolling upgrade of SQS. As a result, the configured transport
+		// is attempted again on every new connection.
+		g.streamEnabled = g.configuredStreamEnabled
+		g.compression = g.configuredCompression
+	}
+
+	// Hashes of the pushed pools for the checkpoint.
+	// The protobuf-encoded pool models they are computed from are reused by the streamed chunks
+	// so that every pool is converted only once per push.
+	var (
+		poolHashes map[uint64][]byte
+		poolModels map[uint64]*prototypes.PoolModel
+	)
+	if g.checkpointer != nil {
+		poolModels, err = g.newPoolModels(pools)
+		if err != nil {
+			return err
+		}
+
+		poolHashes, err = hashPools(pools, poolModels)
+		if err != nil {
+			return err
+		}
+
+		// On warm restart, the full snapshot is replaced by an update on top of the checkpoint height
+		// that only contains the pools that changed since the checkpoint, and removes the checkpointed
+		// pools that are no longer part of the snapshot, e.g. dropped by the pool filters.
+		// If SQS did not retain the state at the checkpoint height, it requests a resync
+		// and a full snapshot is pushed in the next block.
+		if isFullSnapshot {
+			if checkpoint, ok := g.checkpointer.WarmRestart(height); ok {
+				pools = filterChangedPools(pools, poolHashes, checkpoint.PoolHashes)
+				removedPoolIDs = appendMissingPoolIDs(removedPoolIDs, poolHashes, checkpoint.PoolHashes)
+				isFullSnapshot = false
+				g.sinkAck = domain.SinkAck{LastAppliedHeight: checkpoint.Height}
+			}
+		}
 	}
 
+	// The pools are an update on top of the last height applied by SQS
+	// unless this is a full snapshot.
+	var baseHeight uint64
+	if !isFullSnapshot {
+		baseHeight = g.sinkAck.LastAppliedHeight
+	}
+
+	var (
+		reply            *prototypes.ProcessBlockReply
+		payloadSizeBytes uint64
+	)
+	for {
+		if g.streamEnabled {
+			reply, payloadSizeBytes, err = g.pushStream(ctx, height, baseHeight, pools, poolModels, removedPoolIDs, takerFeesMap, aprUpdates)
+		} else {
+			reply, payloadSizeBytes, err = g.pushUnary(ctx, height, baseHeight, pools, removedPoolIDs, takerFeesMap, aprUpdates)
+		}
+		if err == nil {
+			break
+		}
+
+		status, ok := status.FromError(err)
+
+		// If the receiver does not implement the RPC or the compression,
+		// we fall back to a more conservative transport and retry immediately.
+		if ok && status.Code() == codes.Unimplemented && g.fallback() {
+			continue
+		}
+
+		// If the connection is unavailable, we should reset the connection
+		// and attempt to reconnect during the next block.
+		// On any other error, we assume that the connection is still valid so we
+		// do no attempt to recreate it. However, we still return the error to the caller.
+		shouldResetConnection = ok && status.Code() == codes.Unavailable
+
+		return err
+	}
+
+	// Record the pools for which SQS requested a full tick model
+	// so that they are pushed in full during the next block.
+	g.tickSnapshotRequests = append(g.tickSnapshotRequests, reply.GetTickSnapshotPoolIds()...)
+
+	g.lastPayloadSizeBytes = payloadSizeBytes
+
+	// Receivers that predate acknowledgements reply with zero last applied height.
+	// In that case, we assume that the pushed height was applied.
+	lastAppliedHeight := reply.GetLastAppliedHeight()
+	if lastAppliedHeight == 0 && !reply.GetResync() {
+		lastAppliedHeight = height
+	}
+
+	g.sinkAck = domain.SinkAck{
+		LastAppliedHeight: lastAppliedHeight,
+		Resync:            reply.GetResync(),
+	}
+
+	// Only the pools applied by SQS are checkpointed.
+	if g.checkpointer != nil && !reply.GetResync() && lastAppliedHeight == height {
+		if err := g.checkpointer.Commit(height, poolHashes, removedPoolIDs, isFullSnapshot); err != nil {
+			return fmt.Errorf("failed to commit checkpoint: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetSinkAck implements domain.SQSGRPClient.
+func (g *GRPCClient) GetSinkAck() domain.SinkAck {
+	return g.sinkAck
+}
+
+// GetLastPayloadSizeBytes implements domain.SQSGRPClient.
+func (g *GRPCClient) GetLastPayloadSizeBytes() uint64 {
+	return g.lastPayloadSizeBytes
+}
+
+// Close closes the connection to SQS, if any.
+// It must not be called concurrently with PushData.
+func (g *GRPCClient) Close() error {
+	if g.grpcConn == nil {
+		return nil
+	}
+
+	err := g.grpcConn.Close()
+	g.grpcConn = nil
+	return err
+}
+
+// PopTickSnapshotRequests implements domain.SQSGRPClient.
+func (g *GRPCClient) PopTickSnapshotRequests() []uint64 {
+	tickSnapshotRequests := g.tickSnapshotRequests
+	g.tickSnapshotRequests = nil
+	return tickSnapshotRequests
+}
+
+// pushUnary pushes the block data in a single ProcessBlock call with JSON-encoded pool models.
+// This is the transport supported by older receivers.
+// Returns the reply and the size of the request in bytes.
+func (g *GRPCClient) pushUnary(ctx context.Context, height, baseHeight uint64, pools []ingesttypes.PoolI, removedPoolIDs []uint64, takerFeesMap ingesttypes.TakerFeeMap, aprUpdates []ingesttypes.PoolAPRUpdate) (*prototypes.ProcessBlockReply, uint64, error) {
 	// Marshal pools
 	poolData, err := g.marshalPools(pools)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	// Marshal taker fees
 	takerFeesBz, err := takerFeesMap.MarshalJSON()
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	ingesterClient := prototypes.NewSQSIngesterClient(g.grpcConn)
 
 	req := prototypes.ProcessBlockRequest{
-		BlockHeight:  height,
-		TakerFeesMap: takerFeesBz,
-		Pools:        poolData,
+		BlockHeight:    height,
+		TakerFeesMap:   takerFeesBz,
+		Pools:          poolData,
+		BaseHeight:     baseHeight,
+		RemovedPoolIds: removedPoolIDs,
+		AprUpdates:     ingesttypes.PoolAPRUpdatesToProto(aprUpdates),
 	}
 
-	_, err = ingesterClient.ProcessBlock(ctx, &req)
+	reply, err := ingesterClient.ProcessBlock(ctx, &req, g.callOptions()...)
 	if err != nil {
-		status, ok := status.FromError(err)
+		return nil, 0, err
+	}
 
-		// If the connection is unavailable, we should reset the connection
-		// and attempt to reconnect during the next block.
-		// On any other error, we assume that the connection is still valid so we
-		// do no attempt to recreate it. However, we still return the error to the caller.
-		shouldResetConnection = ok && status.Code() == codes.Unavailable
+	return reply, uint64(req.Size()), nil
+}
 
-		return err
+// pushStream pushes the block data in chunks of protobuf-encoded pool models
+// over the ProcessBlockStream client-streaming call.
+// The pool models that were already converted are reused.
+// Returns the reply and the total size of the chunks in bytes.
+func (g *GRPCClient) pushStream(ctx context.Context, height, baseHeight uint64, pools []ingesttypes.PoolI, poolModels map[uint64]*prototypes.PoolModel, removedPoolIDs []uint64, takerFeesMap ingesttypes.TakerFeeMap, aprUpdates []ingesttypes.PoolAPRUpdate) (*prototypes.ProcessBlockReply, uint64, error) {
+	chunks, err := g.chunkPools(height, baseHeight, pools, poolModels, removedPoolIDs, takerFeesMap, aprUpdates)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	return nil
+	ingesterClient := prototypes.NewSQSIngesterClient(g.grpcConn)
+
+	stream, err := ingesterClient.ProcessBlockStream(ctx, g.callOptions()...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var payloadSizeBytes uint64
+	for _, chunk := range chunks {
+		if err := stream.Send(chunk); err != nil {
+			// io.EOF signifies that the stream was aborted by the receiver.
+			// The actual status is returned by CloseAndRecv.
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, 0, err
+		}
+		payloadSizeBytes += uint64(chunk.Size())
+	}
+
+	reply, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reply, payloadSizeBytes, nil
+}
+
+// chunkPools marshals pools into protobuf-encoded models, unless already converted in poolModels,
+// and splits them into chunks of approximately chunkSizeBytes each. A pool that exceeds the chunk size on its own
+// is sent in a dedicated chunk. There is always at least one chunk so that
+// the block height, the taker fees, the removed pool IDs and the APR updates are sent even if no pool was updated.
+func (g *GRPCClient) chunkPools(height, baseHeight uint64, pools []ingesttypes.PoolI, poolModels map[uint64]*prototypes.PoolModel, removedPoolIDs []uint64, takerFeesMap ingesttypes.TakerFeeMap, aprUpdates []ingesttypes.PoolAPRUpdate) ([]*prototypes.ProcessBlockChunk, error) {
+	chunks := []*prototypes.ProcessBlockChunk{
+		{
+			BlockHeight:    height,
+			BaseHeight:     baseHeight,
+			TakerFees:      ingesttypes.TakerFeeMapToProto(takerFeesMap),
+			RemovedPoolIds: removedPoolIDs,
+			AprUpdates:     ingesttypes.PoolAPRUpdatesToProto(aprUpdates),
+		},
+	}
+	currentChunkSize := chunks[0].Size()
+
+	for _, pool := range pools {
+		poolModel, ok := poolModels[pool.GetId()]
+		if !ok {
+			var err error
+			poolModel, err = ingesttypes.PoolToProto(g.appCodec, pool)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		poolModelSize := poolModel.Size()
+
+		currentChunk := chunks[len(chunks)-1]
+		if len(currentChunk.Pools) > 0 && currentChunkSize+poolModelSize > g.chunkSizeBytes {
+			currentChunk = &prototypes.ProcessBlockChunk{
+				BlockHeight: height,
+				BaseHeight:  baseHeight,
+			}
+			chunks = append(chunks, currentChunk)
+			currentChunkSize = 0
+		}
+
+		currentChunk.Pools = append(currentChunk.Pools, poolModel)
+		currentChunkSize += poolModelSize
+	}
+
+	for i, chunk := range chunks {
+		chunk.ChunkIndex = uint32(i)
+		chunk.TotalChunks = uint32(len(chunks))
+	}
+
+	return chunks, nil
+}
+
+// newPoolModels converts the pools into protobuf-encoded models by pool ID.
+func (g *GRPCClient) newPoolModels(pools []ingesttypes.PoolI) (map[uint64]*prototypes.PoolModel, error) {
+	poolModels := make(map[uint64]*prototypes.PoolModel, len(pools))
+	for _, pool := range pools {
+		poolModel, err := ingesttypes.PoolToProto(g.appCodec, pool)
+		if err != nil {
+			return nil, err
+		}
+
+		poolModels[pool.GetId()] = poolModel
+	}
+
+	return poolModels, nil
+}
+
+// hashPools returns the hashes of the protobuf-encoded pool models by pool ID.
+// Pools with a tick delta model have nil hash since their full tick model is unknown.
+// As a result, they are always pushed on warm restart.
+func hashPools(pools []ingesttypes.PoolI, poolModels map[uint64]*prototypes.PoolModel) (map[uint64][]byte, error) {
+	poolHashes := make(map[uint64][]byte, len(pools))
+	for _, pool := range pools {
+		if pool.GetTickDeltaModel() != nil {
+			poolHashes[pool.GetId()] = nil
+			continue
+		}
+
+		poolModelBz, err := poolModels[pool.GetId()].Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		hash := sha256.Sum256(poolModelBz)
+		poolHashes[pool.GetId()] = hash[:]
+	}
+
+	return poolHashes, nil
+}
+
+// filterChangedPools returns the pools whose hash differs from the checkpointed one.
+func filterChangedPools(pools []ingesttypes.PoolI, poolHashes, checkpointPoolHashes map[uint64][]byte) []ingesttypes.PoolI {
+	changedPools := make([]ingesttypes.PoolI, 0, len(pools))
+	for _, pool := range pools {
+		hash := poolHashes[pool.GetId()]
+		if hash != nil && bytes.Equal(hash, checkpointPoolHashes[pool.GetId()]) {
+			continue
+		}
+
+		changedPools = append(changedPools, pool)
+	}
+
+	return changedPools
+}
+
+// appendMissingPoolIDs returns the removed pool IDs extended with the IDs of the checkpointed pools
+// that have no hash in the snapshot, in ascending order. The given removed pool IDs are not modified.
+func appendMissingPoolIDs(removedPoolIDs []uint64, poolHashes, checkpointPoolHashes map[uint64][]byte) []uint64 {
+	isRemoved := make(map[uint64]struct{}, len(removedPoolIDs))
+	for _, poolID := range removedPoolIDs {
+		isRemoved[poolID] = struct{}{}
+	}
+
+	missingPoolIDs := make([]uint64, 0)
+	for poolID := range checkpointPoolHashes {
+		if _, ok := poolHashes[poolID]; ok {
+			continue
+		}
+		if _, ok := isRemoved[poolID]; ok {
+			continue
+		}
+
+		missingPoolIDs = append(missingPoolIDs, poolID)
+	}
+
+	if len(missingPoolIDs) == 0 {
+		return removedPoolIDs
+	}
+
+	slices.Sort(missingPoolIDs)
+
+	return append(slices.Clone(removedPoolIDs), missingPoolIDs...)
+}
+
+// callOptions returns the gRPC call options for the ingest calls.
+func (g *GRPCClient) callOptions() []grpc.CallOption {
+	if g.compression == compression.None {
+		return nil
+	}
+	return []grpc.CallOption{grpc.UseCompressor(g.compression)}
+}
+
+// fallback switches to a more conservative transport after the receiver responded
+// with codes.Unimplemented. Compression is disabled first since a receiver that supports
+// streaming may still lack the configured decompressor. Streaming is disabled next.
+// The configured transport is restored once the connection is re-established.
+// Returns false if there is nothing left to fall back from.
+func (g *GRPCClient) fallback() bool {
+	var from, to string
+	switch {
+	case g.compression != compression.None:
+		from, to = g.compression, compression.None
+		g.compression = compression.None
+	case g.streamEnabled:
+		from, to = transportStream, transportUnary
+		g.streamEnabled = false
+	default:
+		return false
+	}
+
+	telemetry.IncrCounterWithLabels([]string{domain.SQSGRPCTransportFallbackMetricName}, 1, []metrics.Label{
+		telemetry.NewLabel("from", from),
+		telemetry.NewLabel("to", to),
+	})
+
+	return true
 }
 
 // marshalPools marshals pools into a format that can be sent over gRPC.
@@ -125,9 +537,14 @@ func (g *GRPCClient) marshalPools(pools []ingesttypes.PoolI) ([]*prototypes.Pool
 			return nil, err
 		}
 
-		// if the pool is concentrated, serialize tick model
-		var tickModelBz []byte
-		if pool.GetType() == poolmanagertypes.Concentrated {
+		// if the pool is concentrated, serialize either the tick delta or the full tick model
+		var tickModelBz, tickDeltaModelBz []byte
+		if tickDeltaModel := pool.GetTickDeltaModel(); pool.GetType() == poolmanagertypes.Concentrated && tickDeltaModel != nil {
+			tickDeltaModelBz, err = json.Marshal(tickDeltaModel)
+			if err != nil {
+				return nil, err
+			}
+		} else if pool.GetType() == poolmanagertypes.Concentrated {
 			tickModel, err := pool.GetTickModel()
 			if err != nil {
 				return nil, err
@@ -141,9 +558,12 @@ func (g *GRPCClient) marshalPools(pools []ingesttypes.PoolI) ([]*prototypes.Pool
 
 		// Append pool data to chunk
 		poolData = append(poolData, &prototypes.PoolData{
-			ChainModel: chainPoolBz,
-			SqsModel:   sqsPoolBz,
-			TickModel:  tickModelBz,
+			ChainModel:     chainPoolBz,
+			SqsModel:       sqsPoolBz,
+			TickModel:      tickModelBz,
+			TickDeltaModel: tickDeltaModelBz,
+			AprData:        ingesttypes.PoolAPRDataToProto(pool.GetAPRData()),
+			FeesData:       ingesttypes.PoolFeesDataToProto(pool.GetFeesData()),
 		})
 	}
 	return poolData, nil
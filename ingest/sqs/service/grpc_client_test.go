@@ -0,0 +1,396 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"google.golang.org/grpc"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/checkpoint"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/compression"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/service"
+	ingesttypes "github.com/osmosis-labs/osmosis/v30/ingest/types"
+	prototypes "github.com/osmosis-labs/osmosis/v30/ingest/types/proto/types"
+)
+
+// unaryIngesterServer is an ingester server that only supports the unary RPC,
+// mimicking older receivers.
+type unaryIngesterServer struct {
+	prototypes.UnimplementedSQSIngesterServer
+
+	requests []*prototypes.ProcessBlockRequest
+}
+
+func (s *unaryIngesterServer) ProcessBlock(_ context.Context, req *prototypes.ProcessBlockRequest) (*prototypes.ProcessBlockReply, error) {
+	s.requests = append(s.requests, req)
+	return &prototypes.ProcessBlockReply{}, nil
+}
+
+// streamIngesterServer is an ingester server that supports the client-streaming RPC.
+type streamIngesterServer struct {
+	prototypes.UnimplementedSQSIngesterServer
+
+	chunks []*prototypes.ProcessBlockChunk
+}
+
+func (s *streamIngesterServer) ProcessBlockStream(stream prototypes.SQSIngester_ProcessBlockStreamServer) error {
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&prototypes.ProcessBlockReply{
+				TickSnapshotPoolIds: []uint64{1},
+			})
+		}
+		if err != nil {
+			return err
+		}
+		s.chunks = append(s.chunks, chunk)
+	}
+}
+
+// startIngesterServer starts the given ingester server on a random local port
+// and returns its address.
+func (s *SQSServiceTestSuite) startIngesterServer(ingesterServer prototypes.SQSIngesterServer) string {
+	address, _ := s.startIngesterServerAt("127.0.0.1:0", ingesterServer)
+	return address
+}
+
+// startIngesterServerAt starts the given ingester server on the given address
+// and returns its address and the server.
+func (s *SQSServiceTestSuite) startIngesterServerAt(address string, ingesterServer prototypes.SQSIngesterServer) (string, *grpc.Server) {
+	listener, err := net.Listen("tcp", address)
+	s.Require().NoError(err)
+
+	grpcServer := grpc.NewServer()
+	prototypes.RegisterSQSIngesterServer(grpcServer, ingesterServer)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	s.T().Cleanup(grpcServer.Stop)
+
+	return listener.Addr().String(), grpcServer
+}
+
+// preparePools creates the given number of balancer pools and wraps them for ingest.
+func (s *SQSServiceTestSuite) preparePools(numPools int) []ingesttypes.PoolI {
+	pools := make([]ingesttypes.PoolI, 0, numPools)
+	for i := 0; i < numPools; i++ {
+		poolID := s.PrepareBalancerPool()
+
+		pool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, poolID)
+		s.Require().NoError(err)
+
+		balances := s.App.BankKeeper.GetAllBalances(s.Ctx, pool.GetAddress())
+
+		pools = append(pools, ingesttypes.NewPool(pool, osmomath.ZeroDec(), balances))
+	}
+	return pools
+}
+
+// This test validates that the client pushes the block in chunks
+// over the client-streaming RPC and that the pools round-trip.
+func (s *SQSServiceTestSuite) TestGRPCClient_PushData_Stream() {
+	s.Setup()
+
+	const numPools = 5
+	pools := s.preparePools(numPools)
+
+	takerFeeMap := ingesttypes.TakerFeeMap{}
+	takerFeeMap.SetTakerFee("uosmo", "uion", osmomath.MustNewDecFromStr("0.002"))
+
+	ingesterServer := &streamIngesterServer{}
+	address := s.startIngesterServer(ingesterServer)
+
+	config := sqs.DefaultConfig
+	// Small enough for every pool to be in its own chunk.
+	config.GRPCIngestChunkSizeBytes = 1
+
+	grpcClient, err := service.NewGRPCCLient(address, config, s.App.AppCodec(), nil)
+	s.Require().NoError(err)
+
+	// System under test
+	err = grpcClient.PushData(context.Background(), 10, pools, nil, takerFeeMap, nil, true)
+	s.Require().NoError(err)
+
+	s.Require().Len(ingesterServer.chunks, numPools)
+
+	for i, chunk := range ingesterServer.chunks {
+		s.Require().Equal(uint64(10), chunk.BlockHeight)
+		s.Require().Equal(uint32(i), chunk.ChunkIndex)
+		s.Require().Equal(uint32(numPools), chunk.TotalChunks)
+		s.Require().Len(chunk.Pools, 1)
+
+		// Taker fees are only sent in the first chunk.
+		if i == 0 {
+			actualTakerFeeMap, err := ingesttypes.TakerFeeMapFromProto(chunk.TakerFees)
+			s.Require().NoError(err)
+			s.Require().Equal(takerFeeMap, actualTakerFeeMap)
+		} else {
+			s.Require().Empty(chunk.TakerFees)
+		}
+
+		actualPool, err := ingesttypes.PoolFromProto(s.App.AppCodec(), chunk.Pools[0])
+		s.Require().NoError(err)
+
+		s.Require().Equal(pools[i].GetId(), actualPool.GetId())
+		s.Require().Equal(pools[i].GetSQSPoolModel().Balances, actualPool.GetSQSPoolModel().Balances)
+		s.Require().Equal(pools[i].GetSQSPoolModel().SpreadFactor, actualPool.GetSQSPoolModel().SpreadFactor)
+	}
+
+	// The reply is recorded.
+	s.Require().Equal([]uint64{1}, grpcClient.PopTickSnapshotRequests())
+
+	// The payload size is the total size of the chunks.
+	expectedPayloadSizeBytes := 0
+	for _, chunk := range ingesterServer.chunks {
+		expectedPayloadSizeBytes += chunk.Size()
+	}
+	s.Require().Equal(uint64(expectedPayloadSizeBytes), grpcClient.GetLastPayloadSizeBytes())
+}
+
+// This test validates that the client falls back to the unary RPC
+// if the receiver does not implement the client-streaming RPC.
+func (s *SQSServiceTestSuite) TestGRPCClient_PushData_UnaryFallback() {
+	s.Setup()
+
+	pools := s.preparePools(2)
+
+	ingesterServer := &unaryIngesterServer{}
+	address := s.startIngesterServer(ingesterServer)
+
+	config := sqs.DefaultConfig
+	config.GRPCIngestCompression = compression.Gzip
+
+	grpcClient, err := service.NewGRPCCLient(address, config, s.App.AppCodec(), nil)
+	s.Require().NoError(err)
+
+	// System under test
+	for height := uint64(1); height <= 2; height++ {
+		err = grpcClient.PushData(context.Background(), height, pools, nil, ingesttypes.TakerFeeMap{}, nil, true)
+		s.Require().NoError(err)
+	}
+
+	// Both blocks are received over the unary RPC.
+	s.Require().Len(ingesterServer.requests, 2)
+	s.Require().Len(ingesterServer.requests[0].Pools, 2)
+	s.Require().Equal(uint64(2), ingesterServer.requests[1].BlockHeight)
+
+	s.Require().Empty(grpcClient.PopTickSnapshotRequests())
+
+	// The payload size is the size of the last request.
+	s.Require().Equal(uint64(ingesterServer.requests[1].Size()), grpcClient.GetLastPayloadSizeBytes())
+}
+
+// This test validates that the configured transport is restored once the connection
+// is re-established, e.g. after the receiver was upgraded.
+func (s *SQSServiceTestSuite) TestGRPCClient_PushData_FallbackResetOnReconnect() {
+	s.Setup()
+
+	pools := s.preparePools(2)
+
+	unaryServer := &unaryIngesterServer{}
+	address, grpcServer := s.startIngesterServerAt("127.0.0.1:0", unaryServer)
+
+	grpcClient, err := service.NewGRPCCLient(address, sqs.DefaultConfig, s.App.AppCodec(), nil)
+	s.Require().NoError(err)
+
+	// The old receiver pins the client to the unary RPC.
+	err = grpcClient.PushData(context.Background(), 1, pools, nil, ingesttypes.TakerFeeMap{}, nil, true)
+	s.Require().NoError(err)
+	s.Require().Len(unaryServer.requests, 1)
+
+	// The receiver goes down for the upgrade and the connection is reset.
+	grpcServer.Stop()
+
+	err = grpcClient.PushData(context.Background(), 2, pools, nil, ingesttypes.TakerFeeMap{}, nil, true)
+	s.Require().Error(err)
+
+	// System under test: the upgraded receiver supports streaming.
+	streamServer := &streamIngesterServer{}
+	s.startIngesterServerAt(address, streamServer)
+
+	err = grpcClient.PushData(context.Background(), 3, pools, nil, ingesttypes.TakerFeeMap{}, nil, true)
+	s.Require().NoError(err)
+
+	s.Require().Len(unaryServer.requests, 1)
+	s.Require().NotEmpty(streamServer.chunks)
+	s.Require().Equal(uint64(3), streamServer.chunks[0].BlockHeight)
+}
+
+// This test validates that an empty block is still pushed in a single chunk.
+func (s *SQSServiceTestSuite) TestGRPCClient_PushData_Stream_NoPools() {
+	s.Setup()
+
+	ingesterServer := &streamIngesterServer{}
+	address := s.startIngesterServer(ingesterServer)
+
+	grpcClient, err := service.NewGRPCCLient(address, sqs.DefaultConfig, s.App.AppCodec(), nil)
+	s.Require().NoError(err)
+
+	// System under test
+	err = grpcClient.PushData(context.Background(), 3, []ingesttypes.PoolI{}, nil, ingesttypes.TakerFeeMap{}, nil, true)
+	s.Require().NoError(err)
+
+	s.Require().Len(ingesterServer.chunks, 1)
+	s.Require().Equal(uint64(3), ingesterServer.chunks[0].BlockHeight)
+	s.Require().Equal(uint32(1), ingesterServer.chunks[0].TotalChunks)
+	s.Require().Empty(ingesterServer.chunks[0].Pools)
+}
+
+// This test validates that the acknowledged height is recorded from the reply
+// and that updates are pushed on top of it.
+func (s *SQSServiceTestSuite) TestGRPCClient_PushData_SinkAck() {
+	s.Setup()
+
+	pools := s.preparePools(1)
+
+	// Older receivers do not set the last applied height in the reply.
+	ingesterServer := &unaryIngesterServer{}
+	address := s.startIngesterServer(ingesterServer)
+
+	grpcClient, err := service.NewGRPCCLient(address, sqs.DefaultConfig, s.App.AppCodec(), nil)
+	s.Require().NoError(err)
+
+	s.Require().Equal(domain.SinkAck{}, grpcClient.GetSinkAck())
+
+	// System under test
+	err = grpcClient.PushData(context.Background(), 5, pools, nil, ingesttypes.TakerFeeMap{}, nil, true)
+	s.Require().NoError(err)
+
+	// The pushed height is considered applied.
+	s.Require().Equal(domain.SinkAck{LastAppliedHeight: 5}, grpcClient.GetSinkAck())
+
+	err = grpcClient.PushData(context.Background(), 6, pools, nil, ingesttypes.TakerFeeMap{}, nil, false)
+	s.Require().NoError(err)
+
+	s.Require().Len(ingesterServer.requests, 2)
+
+	// Full snapshots have no base height while updates are on top of the acknowledged height.
+	s.Require().Zero(ingesterServer.requests[0].BaseHeight)
+	s.Require().Equal(uint64(5), ingesterServer.requests[1].BaseHeight)
+
+	s.Require().Equal(domain.SinkAck{LastAppliedHeight: 6}, grpcClient.GetSinkAck())
+}
+
+// This test validates that after a restart, the first full snapshot is replaced by an update
+// with the pools that changed since the checkpoint, unless the checkpoint is too old.
+func (s *SQSServiceTestSuite) TestGRPCClient_PushData_WarmRestart() {
+	s.Setup()
+
+	const (
+		sink         = "sqs/test"
+		maxAgeBlocks = 100
+	)
+
+	pools := s.preparePools(3)
+
+	ingesterServer := &unaryIngesterServer{}
+	address := s.startIngesterServer(ingesterServer)
+
+	checkpointStore := checkpoint.NewStore(dbm.NewMemDB())
+
+	// newGRPCClient mimics a node restart by creating a new client
+	// with a checkpointer loaded from the store.
+	newGRPCClient := func() *service.GRPCClient {
+		checkpointer, err := checkpoint.NewCheckpointer(checkpointStore, sink, maxAgeBlocks)
+		s.Require().NoError(err)
+
+		grpcClient, err := service.NewGRPCCLient(address, sqs.DefaultConfig, s.App.AppCodec(), checkpointer)
+		s.Require().NoError(err)
+		return grpcClient
+	}
+
+	// No checkpoint, all pools are pushed.
+	err := newGRPCClient().PushData(context.Background(), 5, pools, nil, ingesttypes.TakerFeeMap{}, nil, true)
+	s.Require().NoError(err)
+
+	s.Require().Len(ingesterServer.requests, 1)
+	s.Require().Zero(ingesterServer.requests[0].BaseHeight)
+	s.Require().Len(ingesterServer.requests[0].Pools, 3)
+
+	// Change one of the pools while the node is down.
+	pools[1].(*ingesttypes.PoolWrapper).SQSModel.SpreadFactor = osmomath.MustNewDecFromStr("0.01")
+
+	// System under test: warm restart.
+	grpcClient := newGRPCClient()
+	err = grpcClient.PushData(context.Background(), 8, pools, nil, ingesttypes.TakerFeeMap{}, nil, true)
+	s.Require().NoError(err)
+
+	// Only the changed pool is pushed on top of the checkpoint height.
+	s.Require().Len(ingesterServer.requests, 2)
+	s.Require().Equal(uint64(5), ingesterServer.requests[1].BaseHeight)
+	s.Require().Len(ingesterServer.requests[1].Pools, 1)
+	s.Require().Equal(domain.SinkAck{LastAppliedHeight: 8}, grpcClient.GetSinkAck())
+
+	// Subsequent full snapshots are not replaced.
+	err = grpcClient.PushData(context.Background(), 9, pools, nil, ingesttypes.TakerFeeMap{}, nil, true)
+	s.Require().NoError(err)
+
+	s.Require().Len(ingesterServer.requests, 3)
+	s.Require().Zero(ingesterServer.requests[2].BaseHeight)
+	s.Require().Len(ingesterServer.requests[2].Pools, 3)
+
+	// System under test: the checkpoint is too old.
+	err = newGRPCClient().PushData(context.Background(), 9+maxAgeBlocks+1, pools, nil, ingesttypes.TakerFeeMap{}, nil, true)
+	s.Require().NoError(err)
+
+	s.Require().Len(ingesterServer.requests, 4)
+	s.Require().Zero(ingesterServer.requests[3].BaseHeight)
+	s.Require().Len(ingesterServer.requests[3].Pools, 3)
+}
+
+// This test validates that on warm restart, the checkpointed pools that are absent from the snapshot
+// are pushed as removed and that their hashes are deleted from the checkpoint.
+func (s *SQSServiceTestSuite) TestGRPCClient_PushData_WarmRestart_RemovedPools() {
+	s.Setup()
+
+	const (
+		sink         = "sqs/test"
+		maxAgeBlocks = 100
+	)
+
+	pools := s.preparePools(3)
+
+	ingesterServer := &unaryIngesterServer{}
+	address := s.startIngesterServer(ingesterServer)
+
+	checkpointStore := checkpoint.NewStore(dbm.NewMemDB())
+
+	newGRPCClient := func() *service.GRPCClient {
+		checkpointer, err := checkpoint.NewCheckpointer(checkpointStore, sink, maxAgeBlocks)
+		s.Require().NoError(err)
+
+		grpcClient, err := service.NewGRPCCLient(address, sqs.DefaultConfig, s.App.AppCodec(), checkpointer)
+		s.Require().NoError(err)
+		return grpcClient
+	}
+
+	err := newGRPCClient().PushData(context.Background(), 5, pools, nil, ingesttypes.TakerFeeMap{}, nil, true)
+	s.Require().NoError(err)
+
+	// The last pool is deleted or filtered out while the node is down.
+	removedPoolID := pools[2].GetId()
+
+	// System under test: warm restart without the last pool.
+	err = newGRPCClient().PushData(context.Background(), 8, pools[:2], nil, ingesttypes.TakerFeeMap{}, nil, true)
+	s.Require().NoError(err)
+
+	s.Require().Len(ingesterServer.requests, 2)
+	s.Require().Equal(uint64(5), ingesterServer.requests[1].BaseHeight)
+	s.Require().Empty(ingesterServer.requests[1].Pools)
+	s.Require().Equal([]uint64{removedPoolID}, ingesterServer.requests[1].RemovedPoolIds)
+
+	cp, ok, err := checkpointStore.Load(sink)
+	s.Require().NoError(err)
+	s.Require().True(ok)
+	s.Require().Equal(uint64(8), cp.Height)
+	s.Require().Len(cp.PoolHashes, 2)
+	s.Require().NotContains(cp.PoolHashes, removedPoolID)
+}
@@ -0,0 +1,58 @@
+package service
+
+// defaultReplayHistorySize is the number of recent blocks for which the IDs of the updated pools
+// are retained. If SQS falls further behind, all data is pushed instead of replaying the updates.
+const defaultReplayHistorySize = 100
+
+// replayHistory retains the IDs of the pools updated in the recent blocks that were pushed as updates.
+// It is used to replay the updates that SQS missed.
+type replayHistory struct {
+	size            uint64
+	poolIDsByHeight map[uint64][]uint64
+}
+
+// newReplayHistory returns a new replay history retaining the given number of blocks.
+func newReplayHistory(size uint64) *replayHistory {
+	return &replayHistory{
+		size:            size,
+		poolIDsByHeight: make(map[uint64][]uint64, size),
+	}
+}
+
+// record records the IDs of the pools updated at the given height.
+// Evicts the heights that fall outside of the retained window.
+func (h *replayHistory) record(height uint64, poolIDs []uint64) {
+	h.poolIDsByHeight[height] = poolIDs
+
+	for recordedHeight := range h.poolIDsByHeight {
+		if recordedHeight+h.size <= height {
+			delete(h.poolIDsByHeight, recordedHeight)
+		}
+	}
+}
+
+// get returns the union of the IDs of the pools updated within the given inclusive height range.
+// Returns false if any of the heights is not retained, in which case the updates cannot be replayed.
+func (h *replayHistory) get(fromHeight, toHeight uint64) (map[uint64]struct{}, bool) {
+	if fromHeight > toHeight {
+		return map[uint64]struct{}{}, true
+	}
+
+	if toHeight-fromHeight >= h.size {
+		return nil, false
+	}
+
+	poolIDs := map[uint64]struct{}{}
+	for height := fromHeight; height <= toHeight; height++ {
+		heightPoolIDs, ok := h.poolIDsByHeight[height]
+		if !ok {
+			return nil, false
+		}
+
+		for _, poolID := range heightPoolIDs {
+			poolIDs[poolID] = struct{}{}
+		}
+	}
+
+	return poolIDs, true
+}
@@ -2,9 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"cosmossdk.io/log"
 	storetypes "cosmossdk.io/store/types"
 	"github.com/cometbft/cometbft/abci/types"
 	"github.com/cosmos/cosmos-sdk/telemetry"
@@ -13,11 +18,16 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/lifecycle"
 	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/domain"
 	"github.com/osmosis-labs/osmosis/v30/ingest/sqs/service/blockprocessor"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
 )
 
-var _ storetypes.ABCIListener = (*sqsStreamingService)(nil)
+var (
+	_ storetypes.ABCIListener = (*sqsStreamingService)(nil)
+	_ lifecycle.Shutdowner    = (*sqsStreamingService)(nil)
+)
 
 // sqsStreamingService is a streaming service that processes block data and ingests it into SQS.
 // It does so by either processing the entire block data or only the pools that were changed in the block.
@@ -32,6 +42,24 @@ type sqsStreamingService struct {
 	nodeStatusChecker domain.NodeStatusChecker
 
 	blockUpdatesProcessUtil commondomain.BlockUpdateProcessUtilsI
+
+	// replayHistory retains the pools updated in the recent blocks
+	// so that they can be replayed if SQS misses a block.
+	replayHistory *replayHistory
+
+	// statusRecorder records the ingest status of the sink. Nil if disabled.
+	statusRecorder commondomain.StatusRecorder
+
+	// lastPushedHeight is the last height whose pools were pushed to SQS.
+	// It is read on shutdown, possibly while a block is in flight.
+	lastPushedHeight atomic.Uint64
+
+	// drainer tracks the block in flight so that the shutdown waits for it.
+	drainer      lifecycle.Drainer
+	shutdownOnce sync.Once
+	shutdownErr  error
+
+	logger log.Logger
 }
 
 // New creates a new sqsStreamingService.
@@ -39,7 +67,8 @@ type sqsStreamingService struct {
 // sqsIngester is an ingester that ingests the block data into SQS.
 // poolTracker is a tracker that tracks the pools that were changed in the block.
 // nodeStatusChecker is a checker that checks if the node is syncing.
-func New(blockUpdatesProcessUtil commondomain.BlockUpdateProcessUtilsI, poolsExtractor commondomain.PoolExtractor, poolsTransformer domain.PoolsTransformer, poolTracker domain.BlockPoolUpdateTracker, grpcClient domain.SQSGRPClient, blockProcessStrategyManager commondomain.BlockProcessStrategyManager, nodeStatusChecker domain.NodeStatusChecker) *sqsStreamingService {
+// statusRecorder records the outcome of every block for the status server. Nil if disabled.
+func New(blockUpdatesProcessUtil commondomain.BlockUpdateProcessUtilsI, poolsExtractor commondomain.PoolExtractor, poolsTransformer domain.PoolsTransformer, poolTracker domain.BlockPoolUpdateTracker, grpcClient domain.SQSGRPClient, blockProcessStrategyManager commondomain.BlockProcessStrategyManager, nodeStatusChecker domain.NodeStatusChecker, statusRecorder commondomain.StatusRecorder, logger log.Logger) *sqsStreamingService {
 	return &sqsStreamingService{
 		blockUpdatesProcessUtil:     blockUpdatesProcessUtil,
 		poolsExtractor:              poolsExtractor,
@@ -48,11 +77,47 @@ func New(blockUpdatesProcessUtil commondomain.BlockUpdateProcessUtilsI, poolsExt
 		nodeStatusChecker:           nodeStatusChecker,
 		grpcClient:                  grpcClient,
 		blockProcessStrategyManager: blockProcessStrategyManager,
+		replayHistory:               newReplayHistory(defaultReplayHistorySize),
+		statusRecorder:              statusRecorder,
+		logger:                      logger,
 	}
 }
 
 // Close implements baseapp.StreamingService.
+// It shuts the service down within the default shutdown timeout.
 func (s *sqsStreamingService) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), lifecycle.DefaultConfig.ShutdownTimeout())
+	defer cancel()
+	return s.Shutdown(ctx)
+}
+
+// Shutdown implements lifecycle.Shutdowner.
+// Once the block in flight is pushed, the connection to SQS is closed.
+// The last pushed height is logged. On restart, the blocks after it are replayed from
+// the checkpoint if enabled, or all data is pushed. Subsequent calls return the result of the first one.
+func (s *sqsStreamingService) Shutdown(ctx context.Context) error {
+	s.shutdownOnce.Do(func() {
+		s.shutdownErr = s.shutdown(ctx)
+	})
+	return s.shutdownErr
+}
+
+func (s *sqsStreamingService) shutdown(ctx context.Context) error {
+	if err := s.drainer.Drain(ctx); err != nil {
+		s.logger.Error("SQS streaming service shut down with a block in flight", "last_pushed_height", s.lastPushedHeight.Load(), "err", err)
+		return err
+	}
+
+	lastPushedHeight := s.lastPushedHeight.Load()
+
+	if closer, ok := s.grpcClient.(io.Closer); ok {
+		if err := lifecycle.CloseWithin(ctx, closer); err != nil {
+			s.logger.Error("Error shutting down SQS streaming service", "last_pushed_height", lastPushedHeight, "err", err)
+			return err
+		}
+	}
+
+	s.logger.Info("SQS streaming service shut down", "last_pushed_height", lastPushedHeight)
 	return nil
 }
 
@@ -62,6 +127,12 @@ func (s *sqsStreamingService) ListenFinalizeBlock(goCtx context.Context, req typ
 }
 
 func (s *sqsStreamingService) ListenCommit(ctx context.Context, res types.ResponseCommit, changeSet []*storetypes.StoreKVPair) error {
+	// Once shut down, the blocks are no longer pushed.
+	if !s.drainer.Begin() {
+		return nil
+	}
+	defer s.drainer.End()
+
 	blockProcessStartTime := time.Now()
 	defer func() {
 		// Emit telemetry for the duration of processing the block.
@@ -73,12 +144,61 @@ func (s *sqsStreamingService) ListenCommit(ctx context.Context, res types.Respon
 	// Set the change set on the block update process utils.
 	s.blockUpdatesProcessUtil.SetChangeSet(changeSet)
 
+	// While paused by the operator, the block is skipped without pushing.
+	// All data is pushed once resumed.
 	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	if s.blockProcessStrategyManager.IsPaused() {
+		s.poolTracker.Reset()
+		s.recordPausedStatus(sdkCtx)
+		return nil
+	}
+
+	err := s.processBlockRecoverError(sdkCtx)
+	if err == nil {
+		s.lastPushedHeight.Store(uint64(sdkCtx.BlockHeight()))
+	}
+	s.recordStatus(sdkCtx, err)
+
 	// Always return nil to avoid making this consensus breaking.
-	_ = s.processBlockRecoverError(sdkCtx)
 	return nil
 }
 
+// recordStatus records the outcome of processing the block if the status recorder is enabled.
+// The last pushed height is the last height acknowledged by SQS.
+func (s *sqsStreamingService) recordStatus(ctx sdk.Context, err error) {
+	if s.statusRecorder == nil {
+		return
+	}
+
+	sinkAck := s.grpcClient.GetSinkAck()
+
+	blockStatus := commondomain.BlockStatus{
+		Height:              uint64(ctx.BlockHeight()),
+		LastPushedHeight:    sinkAck.LastAppliedHeight,
+		Err:                 err,
+		IsNextBlockFullPush: s.blockProcessStrategyManager.ShouldPushAllData() || sinkAck.Resync,
+	}
+	if err == nil {
+		blockStatus.PayloadSizeBytes = s.grpcClient.GetLastPayloadSizeBytes()
+	}
+
+	s.statusRecorder.RecordBlock(blockStatus)
+}
+
+// recordPausedStatus records that the block was skipped while paused if the status recorder is enabled.
+func (s *sqsStreamingService) recordPausedStatus(ctx sdk.Context) {
+	if s.statusRecorder == nil {
+		return
+	}
+
+	s.statusRecorder.RecordBlock(commondomain.BlockStatus{
+		Height:              uint64(ctx.BlockHeight()),
+		LastPushedHeight:    s.grpcClient.GetSinkAck().LastAppliedHeight,
+		IsNextBlockFullPush: s.blockProcessStrategyManager.ShouldPushAllData(),
+		IsPaused:            true,
+	})
+}
+
 // processBlockRecoverError processes the block data and ingests it into SQS. Recovers from panics and returns them as errors.
 // It utilizes blockProcessStrategyManager to determine if the block data should be processed in full.
 // It resets the pool tracker after processing the block data.
@@ -88,8 +208,12 @@ func (s *sqsStreamingService) ListenCommit(ctx context.Context, res types.Respon
 // - An error occurred while processing the block data in the previous block. To avoid data loss,
 // we reprocess the entire block data.
 //
+// - SQS requested a resync or missed more blocks than retained in the replay history.
+//
 // It processes only the pools that were changed in the block in the following cases:
 // - The node is not in cold start and the previous block was processed successfully.
+// - The previous block failed to be pushed or SQS missed blocks. In that case, the pools
+// updated in the blocks that SQS did not apply are replayed.
 func (s *sqsStreamingService) processBlockRecoverError(ctx sdk.Context) (err error) {
 	defer func() {
 		// Reset pool tracking for this block.
@@ -113,12 +237,34 @@ func (s *sqsStreamingService) processBlockRecoverError(ctx sdk.Context) (err err
 		}
 	}()
 
+	// Pools for which SQS requested a full tick model in the previous
+	// reply are pushed with all of their ticks.
+	for _, poolID := range s.grpcClient.PopTickSnapshotRequests() {
+		s.poolTracker.TrackConcentratedPoolIDTickSnapshot(poolID)
+	}
+
+	// Reconcile with the height acknowledged by SQS.
+	s.syncWithSink(ctx)
+
 	blockProcessor := blockprocessor.NewBlockProcessor(s.blockProcessStrategyManager, s.grpcClient, s.poolsExtractor, s.poolsTransformer, s.nodeStatusChecker, s.blockUpdatesProcessUtil)
 
-	if err := blockProcessor.ProcessBlock(ctx); err != nil {
+	err = blockProcessor.ProcessBlock(ctx)
+
+	// If the pools were extracted, record them so that they can be replayed
+	// should SQS not apply this block.
+	var pushDataErr *domain.PushDataError
+	isPushDataErr := errors.As(err, &pushDataErr)
+	if !blockProcessor.IsFullBlockProcessor() && (err == nil || isPushDataErr) {
+		s.replayHistory.record(uint64(ctx.BlockHeight()), s.getUpdatedPoolIDs())
+	}
+
+	if err != nil {
 		// Due to error, we set shouldProcessAllBlockData to true to reprocess the entire block.
+		// Failing to push is an exception since the updates are replayed in the next block.
 		// Be careful when changing this behavior.
-		s.blockProcessStrategyManager.MarkErrorObserved()
+		if !isPushDataErr {
+			s.blockProcessStrategyManager.MarkErrorObserved()
+		}
 
 		// Emit telemetry for the error.
 		emitFailureTelemetry(ctx, err, domain.SQSProcessBlockErrorMetricName)
@@ -129,6 +275,71 @@ func (s *sqsStreamingService) processBlockRecoverError(ctx sdk.Context) (err err
 	return nil
 }
 
+// syncWithSink reconciles the data pushed to SQS with the last height it acknowledged.
+// It is a no-op if all data is to be pushed in this block.
+// If SQS requested a resync or if the missed blocks are no longer retained in the replay history,
+// it switches to pushing all data. Otherwise, it tracks the pools updated in the missed blocks for replay.
+func (s *sqsStreamingService) syncWithSink(ctx sdk.Context) {
+	if s.blockProcessStrategyManager.ShouldPushAllData() {
+		return
+	}
+
+	sinkAck := s.grpcClient.GetSinkAck()
+	height := uint64(ctx.BlockHeight())
+
+	// No gap
+	if !sinkAck.Resync && sinkAck.LastAppliedHeight > 0 && sinkAck.LastAppliedHeight+1 >= height {
+		return
+	}
+
+	var replayedPoolIDs map[uint64]struct{}
+	canReplay := !sinkAck.Resync && sinkAck.LastAppliedHeight > 0
+	if canReplay {
+		replayedPoolIDs, canReplay = s.replayHistory.get(sinkAck.LastAppliedHeight+1, height-1)
+	}
+
+	telemetry.IncrCounterWithLabels([]string{domain.SQSSinkGapMetricName}, 1, []metrics.Label{
+		{Name: "height", Value: fmt.Sprintf("%d", height)},
+		{Name: "last_applied_height", Value: fmt.Sprintf("%d", sinkAck.LastAppliedHeight)},
+		{Name: "resync", Value: fmt.Sprintf("%t", sinkAck.Resync)},
+		{Name: "replayed", Value: fmt.Sprintf("%t", canReplay)},
+	})
+
+	if !canReplay {
+		s.blockProcessStrategyManager.MarkErrorObserved()
+		return
+	}
+
+	for poolID := range replayedPoolIDs {
+		s.poolTracker.TrackReplayedPoolID(poolID)
+	}
+}
+
+// getUpdatedPoolIDs returns the IDs of all pools tracked as updated in the block.
+func (s *sqsStreamingService) getUpdatedPoolIDs() []uint64 {
+	poolIDs := map[uint64]struct{}{}
+
+	for poolID := range s.poolTracker.GetConcentratedPoolIDTickChange() {
+		poolIDs[poolID] = struct{}{}
+	}
+
+	for _, pools := range [][]poolmanagertypes.PoolI{s.poolTracker.GetConcentratedPools(), s.poolTracker.GetCFMMPools(), s.poolTracker.GetCosmWasmPools()} {
+		for _, pool := range pools {
+			poolIDs[pool.GetId()] = struct{}{}
+		}
+	}
+
+	for poolID := range s.poolTracker.GetReplayedPoolIDs() {
+		poolIDs[poolID] = struct{}{}
+	}
+
+	result := make([]uint64, 0, len(poolIDs))
+	for poolID := range poolIDs {
+		result = append(result, poolID)
+	}
+	return result
+}
+
 // emitFailureTelemetry emits telemetry for panics or errors
 func emitFailureTelemetry(ctx sdk.Context, r interface{}, metricName string) {
 	// Panics are silently logged and ignored.
@@ -1,10 +1,13 @@
 package service_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
+	"cosmossdk.io/log"
 	storetypes "cosmossdk.io/store/types"
+	abci "github.com/cometbft/cometbft/abci/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/suite"
 
@@ -18,6 +21,7 @@ import (
 	commondomain "github.com/osmosis-labs/osmosis/v30/ingest/common/domain"
 	commonmocks "github.com/osmosis-labs/osmosis/v30/ingest/common/domain/mocks"
 	"github.com/osmosis-labs/osmosis/v30/ingest/common/pooltracker"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/status"
 )
 
 var (
@@ -151,7 +155,7 @@ func (s *SQSServiceTestSuite) TestProcessBlockRecoverError() {
 
 			for _, grpcClientMock := range grpcClientMocks {
 				// System under test.
-				sqsStreamingService := service.New(blockUpdatesProcessUtilsMock, poolExtractorMock, poolTransformerMock, poolTracker, grpcClientMock, blockProcessStrategyManager, nodeStatusCheckerMock)
+				sqsStreamingService := service.New(blockUpdatesProcessUtilsMock, poolExtractorMock, poolTransformerMock, poolTracker, grpcClientMock, blockProcessStrategyManager, nodeStatusCheckerMock, nil, log.NewNopLogger())
 				err = sqsStreamingService.ProcessBlockRecoverError(s.Ctx)
 
 				// We expect the pool tracker to always be reset
@@ -182,3 +186,299 @@ func (s *SQSServiceTestSuite) TestProcessBlockRecoverError() {
 		})
 	}
 }
+
+// This test validates that the service reconciles with the height acknowledged by SQS.
+// If the missed blocks are retained in the replay history, their pools are tracked for replay.
+// Otherwise, or if SQS requested a resync, all data is pushed.
+func (s *SQSServiceTestSuite) TestSyncWithSink() {
+	const blockHeight = 10
+
+	testCases := []struct {
+		name           string
+		sinkAck        domain.SinkAck
+		shouldPushAll  bool
+		recordedHeight []uint64
+
+		expectedReplayedPoolIDs map[uint64]struct{}
+		expectedShouldPushAll   bool
+	}{
+		{
+			name:           "no gap",
+			sinkAck:        domain.SinkAck{LastAppliedHeight: blockHeight - 1},
+			recordedHeight: []uint64{blockHeight - 1},
+
+			expectedReplayedPoolIDs: map[uint64]struct{}{},
+		},
+		{
+			name:           "gap within replay history",
+			sinkAck:        domain.SinkAck{LastAppliedHeight: blockHeight - 3},
+			recordedHeight: []uint64{blockHeight - 3, blockHeight - 2, blockHeight - 1},
+
+			expectedReplayedPoolIDs: map[uint64]struct{}{blockHeight - 2: {}, blockHeight - 1: {}},
+		},
+		{
+			name:           "gap outside of replay history",
+			sinkAck:        domain.SinkAck{LastAppliedHeight: blockHeight - 3},
+			recordedHeight: []uint64{blockHeight - 1},
+
+			expectedReplayedPoolIDs: map[uint64]struct{}{},
+			expectedShouldPushAll:   true,
+		},
+		{
+			name:           "resync requested",
+			sinkAck:        domain.SinkAck{LastAppliedHeight: blockHeight - 1, Resync: true},
+			recordedHeight: []uint64{blockHeight - 1},
+
+			expectedReplayedPoolIDs: map[uint64]struct{}{},
+			expectedShouldPushAll:   true,
+		},
+		{
+			name:          "already pushing all data",
+			sinkAck:       domain.SinkAck{Resync: true},
+			shouldPushAll: true,
+
+			expectedReplayedPoolIDs: map[uint64]struct{}{},
+			expectedShouldPushAll:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Setup()
+
+			ctx := s.Ctx.WithBlockHeight(blockHeight)
+
+			poolTracker := pooltracker.NewMemory()
+
+			blockProcessStrategyManager := commondomain.NewBlockProcessStrategyManager()
+			if !tc.shouldPushAll {
+				blockProcessStrategyManager.MarkInitialDataIngested()
+			}
+
+			grpcClientMock := &mocks.GRPCClientMock{
+				SinkAck: tc.sinkAck,
+			}
+
+			sqsStreamingService := service.New(&mocks.BlockUpdateProcessUtilsMock{}, &mocks.PoolsExtractorMock{}, &mocks.PoolsTransformerMock{}, poolTracker, grpcClientMock, blockProcessStrategyManager, &commonmocks.NodeStatusCheckerMock{}, nil, log.NewNopLogger())
+
+			// For simplicity, the pool updated at every height has the ID equal to the height.
+			for _, height := range tc.recordedHeight {
+				sqsStreamingService.RecordReplayHistory(height, []uint64{height})
+			}
+
+			// System under test.
+			sqsStreamingService.SyncWithSink(ctx)
+
+			s.Require().Equal(tc.expectedReplayedPoolIDs, poolTracker.GetReplayedPoolIDs())
+			s.Require().Equal(tc.expectedShouldPushAll, blockProcessStrategyManager.ShouldPushAllData())
+		})
+	}
+}
+
+// This test validates that failing to push data does not force pushing all data in the next block
+// and that the pools of the block are retained for replay.
+func (s *SQSServiceTestSuite) TestProcessBlockRecoverError_PushDataError() {
+	s.Setup()
+
+	const blockHeight = 10
+	ctx := s.Ctx.WithBlockHeight(blockHeight)
+
+	allPools := s.PrepareAllSupportedPools()
+
+	balancerPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, allPools.BalancerPoolID)
+	s.Require().NoError(err)
+
+	poolTracker := pooltracker.NewMemory()
+	poolTracker.TrackCFMM(balancerPool)
+
+	blockProcessStrategyManager := commondomain.NewBlockProcessStrategyManager()
+	blockProcessStrategyManager.MarkInitialDataIngested()
+
+	grpcClientMock := &mocks.GRPCClientMock{
+		Error:   mockError,
+		SinkAck: domain.SinkAck{LastAppliedHeight: blockHeight - 1},
+	}
+
+	poolTransformerMock := &mocks.PoolsTransformerMock{
+		PoolReturn: []ingesttypes.PoolI{ingesttypes.NewPool(balancerPool, balancerPool.GetSpreadFactor(s.Ctx), sdk.Coins{})},
+	}
+
+	sqsStreamingService := service.New(&mocks.BlockUpdateProcessUtilsMock{}, &mocks.PoolsExtractorMock{}, poolTransformerMock, poolTracker, grpcClientMock, blockProcessStrategyManager, &commonmocks.NodeStatusCheckerMock{}, nil, log.NewNopLogger())
+
+	// System under test.
+	err = sqsStreamingService.ProcessBlockRecoverError(ctx)
+	s.Require().ErrorIs(err, mockError)
+
+	// Updates are still pushed in the next block.
+	s.Require().False(blockProcessStrategyManager.ShouldPushAllData())
+	s.Require().False(grpcClientMock.CalledWithIsFullSnapshot)
+
+	// SQS did not apply the failed block, so its pools are replayed in the next block.
+	sqsStreamingService.SyncWithSink(ctx.WithBlockHeight(blockHeight + 1))
+	s.Require().Equal(map[uint64]struct{}{allPools.BalancerPoolID: {}}, poolTracker.GetReplayedPoolIDs())
+}
+
+// This test validates that the outcome of every block is recorded for the status server.
+func (s *SQSServiceTestSuite) TestListenCommit_RecordStatus() {
+	s.Setup()
+
+	const blockHeight = 10
+	ctx := s.Ctx.WithBlockHeight(blockHeight)
+
+	allPools := s.PrepareAllSupportedPools()
+
+	balancerPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, allPools.BalancerPoolID)
+	s.Require().NoError(err)
+
+	poolTracker := pooltracker.NewMemory()
+	poolTracker.TrackCFMM(balancerPool)
+
+	blockProcessStrategyManager := commondomain.NewBlockProcessStrategyManager()
+	blockProcessStrategyManager.MarkInitialDataIngested()
+
+	grpcClientMock := &mocks.GRPCClientMock{
+		Error:                mockError,
+		SinkAck:              domain.SinkAck{LastAppliedHeight: blockHeight - 1},
+		LastPayloadSizeBytes: 100,
+	}
+
+	poolTransformerMock := &mocks.PoolsTransformerMock{
+		PoolReturn: []ingesttypes.PoolI{ingesttypes.NewPool(balancerPool, balancerPool.GetSpreadFactor(s.Ctx), sdk.Coins{})},
+	}
+
+	registry := status.NewRegistry(status.DefaultConfig.StatusMaxLagBlocks, status.DefaultConfig.StatusMaxConsecutiveFailures)
+
+	sqsStreamingService := service.New(&mocks.BlockUpdateProcessUtilsMock{}, &mocks.PoolsExtractorMock{}, poolTransformerMock, poolTracker, grpcClientMock, blockProcessStrategyManager, &commonmocks.NodeStatusCheckerMock{}, registry.Sink("sqs/localhost:50051"), log.NewNopLogger())
+
+	// System under test: failed push.
+	err = sqsStreamingService.ListenCommit(ctx, abci.ResponseCommit{}, nil)
+	s.Require().NoError(err)
+
+	actualStatus := registry.Statuses()[0]
+	s.Require().Equal(uint64(blockHeight), actualStatus.Height)
+	s.Require().Equal(uint64(blockHeight-1), actualStatus.LastPushedHeight)
+	s.Require().Equal(uint64(1), actualStatus.QueueLag)
+	s.Require().Equal(uint64(1), actualStatus.ConsecutiveFailures)
+	s.Require().Contains(actualStatus.LastError, mockError.Error())
+	s.Require().False(actualStatus.IsNextBlockFullPush)
+	s.Require().Zero(actualStatus.AvgPayloadSizeBytes)
+
+	// System under test: successful push.
+	grpcClientMock.Error = nil
+	grpcClientMock.SinkAck = domain.SinkAck{LastAppliedHeight: blockHeight + 1}
+
+	err = sqsStreamingService.ListenCommit(ctx.WithBlockHeight(blockHeight+1), abci.ResponseCommit{}, nil)
+	s.Require().NoError(err)
+
+	actualStatus = registry.Statuses()[0]
+	s.Require().Equal(uint64(blockHeight+1), actualStatus.LastPushedHeight)
+	s.Require().Zero(actualStatus.QueueLag)
+	s.Require().Zero(actualStatus.ConsecutiveFailures)
+	s.Require().Equal(uint64(100), actualStatus.AvgPayloadSizeBytes)
+	s.Require().True(actualStatus.IsReady)
+}
+
+// This test validates that blocks are skipped while paused and that all data is pushed once resumed.
+func (s *SQSServiceTestSuite) TestListenCommit_Paused() {
+	s.Setup()
+
+	const blockHeight = 10
+	ctx := s.Ctx.WithBlockHeight(blockHeight)
+
+	allPools := s.PrepareAllSupportedPools()
+
+	balancerPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, allPools.BalancerPoolID)
+	s.Require().NoError(err)
+
+	poolTracker := pooltracker.NewMemory()
+	poolTracker.TrackCFMM(balancerPool)
+
+	blockProcessStrategyManager := commondomain.NewBlockProcessStrategyManager()
+	blockProcessStrategyManager.MarkInitialDataIngested()
+	blockProcessStrategyManager.Pause()
+
+	grpcClientMock := &mocks.GRPCClientMock{
+		SinkAck: domain.SinkAck{LastAppliedHeight: blockHeight - 1},
+	}
+
+	registry := status.NewRegistry(status.DefaultConfig.StatusMaxLagBlocks, status.DefaultConfig.StatusMaxConsecutiveFailures)
+
+	sqsStreamingService := service.New(&mocks.BlockUpdateProcessUtilsMock{}, &mocks.PoolsExtractorMock{}, &mocks.PoolsTransformerMock{}, poolTracker, grpcClientMock, blockProcessStrategyManager, &commonmocks.NodeStatusCheckerMock{}, registry.Sink("sqs/localhost:50051"), log.NewNopLogger())
+
+	// System under test: paused.
+	err = sqsStreamingService.ListenCommit(ctx, abci.ResponseCommit{}, nil)
+	s.Require().NoError(err)
+
+	// Nothing is pushed but the tracked pools are reset and the paused status is recorded.
+	s.Require().Empty(poolTracker.GetCFMMPools())
+
+	actualStatus := registry.Statuses()[0]
+	s.Require().Equal(uint64(blockHeight), actualStatus.Height)
+	s.Require().Equal(uint64(blockHeight-1), actualStatus.LastPushedHeight)
+	s.Require().Equal(uint64(1), actualStatus.QueueLag)
+	s.Require().Zero(actualStatus.ConsecutiveFailures)
+	s.Require().True(actualStatus.IsPaused)
+	s.Require().False(actualStatus.IsReady)
+
+	blockProcessStrategyManager.Resume()
+
+	// System under test: resumed.
+	err = sqsStreamingService.ListenCommit(ctx.WithBlockHeight(blockHeight+1), abci.ResponseCommit{}, nil)
+	s.Require().NoError(err)
+
+	s.Require().True(grpcClientMock.CalledWithIsFullSnapshot)
+
+	actualStatus = registry.Statuses()[0]
+	s.Require().Equal(uint64(blockHeight+1), actualStatus.Height)
+	s.Require().False(actualStatus.IsPaused)
+}
+
+// This test validates that the last pushed height is recorded, that the client is closed once on shutdown
+// and that no block is pushed afterwards.
+func (s *SQSServiceTestSuite) TestShutdown() {
+	s.Setup()
+
+	const blockHeight = 10
+	ctx := s.Ctx.WithBlockHeight(blockHeight)
+
+	allPools := s.PrepareAllSupportedPools()
+
+	balancerPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, allPools.BalancerPoolID)
+	s.Require().NoError(err)
+
+	poolTracker := pooltracker.NewMemory()
+	poolTracker.TrackCFMM(balancerPool)
+
+	blockProcessStrategyManager := commondomain.NewBlockProcessStrategyManager()
+	blockProcessStrategyManager.MarkInitialDataIngested()
+
+	grpcClientMock := &mocks.GRPCClientMock{
+		SinkAck: domain.SinkAck{LastAppliedHeight: blockHeight - 1},
+	}
+
+	poolTransformerMock := &mocks.PoolsTransformerMock{
+		PoolReturn: []ingesttypes.PoolI{ingesttypes.NewPool(balancerPool, balancerPool.GetSpreadFactor(s.Ctx), sdk.Coins{})},
+	}
+
+	sqsStreamingService := service.New(&mocks.BlockUpdateProcessUtilsMock{}, &mocks.PoolsExtractorMock{}, poolTransformerMock, poolTracker, grpcClientMock, blockProcessStrategyManager, &commonmocks.NodeStatusCheckerMock{}, nil, log.NewNopLogger())
+
+	err = sqsStreamingService.ListenCommit(ctx, abci.ResponseCommit{}, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(1, grpcClientMock.NumPushDataCalls)
+	s.Require().Equal(uint64(blockHeight), sqsStreamingService.LastPushedHeight())
+
+	// System under test.
+	err = sqsStreamingService.Shutdown(context.Background())
+	s.Require().NoError(err)
+	s.Require().Equal(1, grpcClientMock.NumCloseCalls)
+
+	// No block is pushed once shut down.
+	err = sqsStreamingService.ListenCommit(ctx.WithBlockHeight(blockHeight+1), abci.ResponseCommit{}, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(1, grpcClientMock.NumPushDataCalls)
+	s.Require().Equal(uint64(blockHeight), sqsStreamingService.LastPushedHeight())
+
+	// Closing again does not close the client again.
+	s.Require().NoError(sqsStreamingService.Close())
+	s.Require().Equal(1, grpcClientMock.NumCloseCalls)
+}
@@ -1,11 +1,17 @@
 package sqs
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 
 	"github.com/osmosis-labs/osmosis/osmoutils"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/compression"
+	"github.com/osmosis-labs/osmosis/v30/ingest/common/grpcsecurity"
+	ingestoptions "github.com/osmosis-labs/osmosis/v30/ingest/common/options"
+	poolsfilter "github.com/osmosis-labs/osmosis/v30/ingest/sqs/pools/filter"
 )
 
 // Config defines the config for the sidecar query server.
@@ -16,6 +22,60 @@ type Config struct {
 	GRPCIngestAddress []string `mapstructure:"grpc-ingest-address"`
 	// GRPCIngestMaxCallSizeBytes defines the maximum size of a gRPC ingest call in bytes.
 	GRPCIngestMaxCallSizeBytes int `mapstructure:"grpc-ingest-max-call-size-bytes"`
+	// GRPCIngestStreamEnabled defines if blocks are pushed in chunks over the client-streaming RPC.
+	// If the receiver does not support it, the unary RPC is used instead.
+	GRPCIngestStreamEnabled bool `mapstructure:"grpc-ingest-stream-enabled"`
+	// GRPCIngestChunkSizeBytes defines the target size of a single chunk of the client-streaming RPC in bytes.
+	GRPCIngestChunkSizeBytes int `mapstructure:"grpc-ingest-chunk-size-bytes"`
+	// GRPCIngestCompression defines the gRPC compressor used for ingest calls.
+	// One of "zstd", "gzip" or "none".
+	GRPCIngestCompression string `mapstructure:"grpc-ingest-compression"`
+
+	// GRPCIngestTLSEnabled defines if the gRPC ingest connection uses TLS.
+	GRPCIngestTLSEnabled bool `mapstructure:"grpc-ingest-tls-enabled"`
+	// GRPCIngestTLSCAFile defines the path to the CA bundle used to verify the sidecar query server.
+	// If empty, the system roots are used.
+	GRPCIngestTLSCAFile string `mapstructure:"grpc-ingest-tls-ca-file"`
+	// GRPCIngestTLSCertFile defines the path to the client certificate for mTLS.
+	GRPCIngestTLSCertFile string `mapstructure:"grpc-ingest-tls-cert-file"`
+	// GRPCIngestTLSKeyFile defines the path to the client key for mTLS.
+	GRPCIngestTLSKeyFile string `mapstructure:"grpc-ingest-tls-key-file"`
+	// GRPCIngestTLSServerName overrides the server name used to verify the sidecar query server certificate.
+	GRPCIngestTLSServerName string `mapstructure:"grpc-ingest-tls-server-name"`
+
+	// GRPCIngestAuthToken defines a static bearer token sent with every ingest request.
+	// Requires TLS.
+	GRPCIngestAuthToken string `mapstructure:"grpc-ingest-auth-token"`
+	// GRPCIngestAuthHMACSecret defines the secret used to sign every ingest request.
+	// Mutually exclusive with GRPCIngestAuthToken. Requires TLS.
+	GRPCIngestAuthHMACSecret string `mapstructure:"grpc-ingest-auth-hmac-secret"`
+
+	// PoolFilterMinLiquidityCap defines the minimum pool liquidity capitalization (in USDC) of the pushed pools.
+	// If zero, pools are not filtered by liquidity.
+	PoolFilterMinLiquidityCap uint64 `mapstructure:"pool-filter-min-liquidity-cap"`
+	// PoolFilterAllowedPoolIDs defines the IDs of the pools that are pushed regardless of the other filters
+	// except for the denied pool IDs.
+	PoolFilterAllowedPoolIDs []uint64 `mapstructure:"pool-filter-allowed-pool-ids"`
+	// PoolFilterDeniedPoolIDs defines the IDs of the pools that are never pushed.
+	PoolFilterDeniedPoolIDs []uint64 `mapstructure:"pool-filter-denied-pool-ids"`
+	// PoolFilterAllowedPoolTypes defines the types of the pushed pools.
+	// If empty, all pool types are pushed.
+	PoolFilterAllowedPoolTypes []string `mapstructure:"pool-filter-allowed-pool-types"`
+	// PoolFilterDeniedDenoms defines the denoms such that pools containing any of them are not pushed.
+	PoolFilterDeniedDenoms []string `mapstructure:"pool-filter-denied-denoms"`
+
+	// APREnabled defines if the APR and fees data of the pushed pools is computed on-node.
+	APREnabled bool `mapstructure:"apr-enabled"`
+	// APRRefreshIntervalBlocks defines the number of blocks between the recomputations of the APR and fees data.
+	// All pools are pushed on every recomputation.
+	APRRefreshIntervalBlocks uint64 `mapstructure:"apr-refresh-interval-blocks"`
+
+	// CheckpointEnabled defines if the pushed pools are checkpointed so that only the changed pools
+	// are pushed after a restart.
+	CheckpointEnabled bool `mapstructure:"checkpoint-enabled"`
+	// CheckpointMaxAgeBlocks defines the maximum number of blocks between the checkpoint and the restart height.
+	// All pools are pushed if the checkpoint is older.
+	CheckpointMaxAgeBlocks uint64 `mapstructure:"checkpoint-max-age-blocks"`
 }
 
 const (
@@ -36,6 +96,16 @@ var DefaultConfig = Config{
 	// During normal operation, we should not approach even 1 MB since we are to stream only
 	// modified pools.
 	GRPCIngestMaxCallSizeBytes: 50 * 1024 * 1024,
+	GRPCIngestStreamEnabled:    true,
+	// 2 MB by default. This is below the default 4 MB gRPC receive limit.
+	GRPCIngestChunkSizeBytes: 2 * 1024 * 1024,
+	GRPCIngestCompression:    compression.Zstd,
+	APREnabled:               false,
+	// 600 blocks is approximately an hour.
+	APRRefreshIntervalBlocks: 600,
+	CheckpointEnabled:        false,
+	// 6000 blocks is approximately 10 hours.
+	CheckpointMaxAgeBlocks: 6000,
 }
 
 // NewConfigFromOptions returns a new sidecar query server config from the given options.
@@ -52,9 +122,135 @@ func NewConfigFromOptions(opts servertypes.AppOptions) Config {
 
 	grpcIngestMaxCallSizeBytes := osmoutils.ParseInt(opts, groupOptName, "grpc-ingest-max-call-size-bytes")
 
-	return Config{
+	grpcIngestStreamEnabled := osmoutils.ParseBool(opts, groupOptName, "grpc-ingest-stream-enabled", DefaultConfig.GRPCIngestStreamEnabled)
+
+	grpcIngestChunkSizeBytes := osmoutils.ParseInt(opts, groupOptName, "grpc-ingest-chunk-size-bytes")
+	if grpcIngestChunkSizeBytes <= 0 {
+		grpcIngestChunkSizeBytes = DefaultConfig.GRPCIngestChunkSizeBytes
+	}
+
+	grpcIngestCompression := osmoutils.ParseString(opts, groupOptName, "grpc-ingest-compression")
+	if grpcIngestCompression == "" {
+		grpcIngestCompression = DefaultConfig.GRPCIngestCompression
+	}
+	if err := compression.Validate(grpcIngestCompression); err != nil {
+		panic(err)
+	}
+
+	poolFilterMinLiquidityCap := osmoutils.ParseInt(opts, groupOptName, "pool-filter-min-liquidity-cap")
+	if poolFilterMinLiquidityCap < 0 {
+		panic(fmt.Sprintf("negative pool-filter-min-liquidity-cap (%d)", poolFilterMinLiquidityCap))
+	}
+
+	aprRefreshIntervalBlocks := osmoutils.ParseInt(opts, groupOptName, "apr-refresh-interval-blocks")
+	if aprRefreshIntervalBlocks < 0 {
+		panic(fmt.Sprintf("negative apr-refresh-interval-blocks (%d)", aprRefreshIntervalBlocks))
+	}
+	if aprRefreshIntervalBlocks == 0 {
+		aprRefreshIntervalBlocks = int(DefaultConfig.APRRefreshIntervalBlocks)
+	}
+
+	checkpointMaxAgeBlocks := osmoutils.ParseInt(opts, groupOptName, "checkpoint-max-age-blocks")
+	if checkpointMaxAgeBlocks < 0 {
+		panic(fmt.Sprintf("negative checkpoint-max-age-blocks (%d)", checkpointMaxAgeBlocks))
+	}
+	if checkpointMaxAgeBlocks == 0 {
+		checkpointMaxAgeBlocks = int(DefaultConfig.CheckpointMaxAgeBlocks)
+	}
+
+	config := Config{
 		IsEnabled:                  isEnabled,
 		GRPCIngestAddress:          grpcIngestAddress,
 		GRPCIngestMaxCallSizeBytes: grpcIngestMaxCallSizeBytes,
+		GRPCIngestStreamEnabled:    grpcIngestStreamEnabled,
+		GRPCIngestChunkSizeBytes:   grpcIngestChunkSizeBytes,
+		GRPCIngestCompression:      grpcIngestCompression,
+
+		GRPCIngestTLSEnabled:    osmoutils.ParseBool(opts, groupOptName, "grpc-ingest-tls-enabled", false),
+		GRPCIngestTLSCAFile:     osmoutils.ParseString(opts, groupOptName, "grpc-ingest-tls-ca-file"),
+		GRPCIngestTLSCertFile:   osmoutils.ParseString(opts, groupOptName, "grpc-ingest-tls-cert-file"),
+		GRPCIngestTLSKeyFile:    osmoutils.ParseString(opts, groupOptName, "grpc-ingest-tls-key-file"),
+		GRPCIngestTLSServerName: osmoutils.ParseString(opts, groupOptName, "grpc-ingest-tls-server-name"),
+
+		GRPCIngestAuthToken:      osmoutils.ParseString(opts, groupOptName, "grpc-ingest-auth-token"),
+		GRPCIngestAuthHMACSecret: osmoutils.ParseString(opts, groupOptName, "grpc-ingest-auth-hmac-secret"),
+
+		PoolFilterMinLiquidityCap:  uint64(poolFilterMinLiquidityCap),
+		PoolFilterAllowedPoolIDs:   parsePoolIDList(osmoutils.ParseString(opts, groupOptName, "pool-filter-allowed-pool-ids")),
+		PoolFilterDeniedPoolIDs:    parsePoolIDList(osmoutils.ParseString(opts, groupOptName, "pool-filter-denied-pool-ids")),
+		PoolFilterAllowedPoolTypes: ingestoptions.ParseList(osmoutils.ParseString(opts, groupOptName, "pool-filter-allowed-pool-types")),
+		PoolFilterDeniedDenoms:     ingestoptions.ParseList(osmoutils.ParseString(opts, groupOptName, "pool-filter-denied-denoms")),
+
+		APREnabled:               osmoutils.ParseBool(opts, groupOptName, "apr-enabled", DefaultConfig.APREnabled),
+		APRRefreshIntervalBlocks: uint64(aprRefreshIntervalBlocks),
+
+		CheckpointEnabled:      osmoutils.ParseBool(opts, groupOptName, "checkpoint-enabled", DefaultConfig.CheckpointEnabled),
+		CheckpointMaxAgeBlocks: uint64(checkpointMaxAgeBlocks),
+	}
+
+	if err := config.TLSConfig().Validate(); err != nil {
+		panic(err)
+	}
+
+	if err := config.AuthConfig().Validate(); err != nil {
+		panic(err)
+	}
+
+	if config.GRPCIngestAuthToken != "" && !config.GRPCIngestTLSEnabled {
+		panic("grpc-ingest-auth-token requires grpc-ingest-tls-enabled")
+	}
+
+	if config.GRPCIngestAuthHMACSecret != "" && !config.GRPCIngestTLSEnabled {
+		panic("grpc-ingest-auth-hmac-secret requires grpc-ingest-tls-enabled")
+	}
+
+	if err := config.PoolFilterConfig().Validate(); err != nil {
+		panic(err)
+	}
+
+	return config
+}
+
+// TLSConfig returns the TLS configuration of the gRPC ingest connection.
+func (c Config) TLSConfig() grpcsecurity.TLSConfig {
+	return grpcsecurity.TLSConfig{
+		Enabled:    c.GRPCIngestTLSEnabled,
+		CAFile:     c.GRPCIngestTLSCAFile,
+		CertFile:   c.GRPCIngestTLSCertFile,
+		KeyFile:    c.GRPCIngestTLSKeyFile,
+		ServerName: c.GRPCIngestTLSServerName,
+	}
+}
+
+// AuthConfig returns the per-request authentication configuration of the gRPC ingest connection.
+func (c Config) AuthConfig() grpcsecurity.AuthConfig {
+	return grpcsecurity.AuthConfig{
+		Token:      c.GRPCIngestAuthToken,
+		HMACSecret: c.GRPCIngestAuthHMACSecret,
+	}
+}
+
+// PoolFilterConfig returns the configuration of the filter applied to the pools pushed to SQS.
+func (c Config) PoolFilterConfig() poolsfilter.Config {
+	return poolsfilter.Config{
+		MinPoolLiquidityCap: c.PoolFilterMinLiquidityCap,
+		AllowedPoolIDs:      c.PoolFilterAllowedPoolIDs,
+		DeniedPoolIDs:       c.PoolFilterDeniedPoolIDs,
+		AllowedPoolTypes:    c.PoolFilterAllowedPoolTypes,
+		DeniedDenoms:        c.PoolFilterDeniedDenoms,
+	}
+}
+
+// parsePoolIDList parses a comma-separated list of pool IDs.
+// Panics if any of the pool IDs is invalid.
+func parsePoolIDList(list string) []uint64 {
+	var result []uint64
+	for _, element := range ingestoptions.ParseList(list) {
+		poolID, err := strconv.ParseUint(element, 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("invalid pool ID (%s): %s", element, err))
+		}
+		result = append(result, poolID)
 	}
+	return result
 }
@@ -1,6 +1,9 @@
 package cosmwasmpool
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/Masterminds/semver"
 )
 
@@ -53,6 +56,42 @@ type CosmWasmPoolData struct {
 
 	// Data for Orderbook contract, must be present if and only if `IsOrderbook()` is true
 	Orderbook *OrderbookData `json:"orderbook,omitempty"`
+
+	// Extensions holds the data of contracts that are not natively supported,
+	// keyed by the name chosen by the handler that produced it.
+	Extensions map[string]json.RawMessage `json:"extensions,omitempty"`
+}
+
+// SetExtension marshals the given data and attaches it under the given key.
+// Overwrites any data previously attached under the same key.
+func (d *CosmWasmPoolData) SetExtension(key string, data any) error {
+	bz, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error marshalling extension (%s): %w", key, err)
+	}
+
+	if d.Extensions == nil {
+		d.Extensions = make(map[string]json.RawMessage)
+	}
+
+	d.Extensions[key] = bz
+
+	return nil
+}
+
+// GetExtension unmarshals the data attached under the given key into out.
+// Returns false if no data is attached under the key.
+func (d *CosmWasmPoolData) GetExtension(key string, out any) (bool, error) {
+	bz, ok := d.Extensions[key]
+	if !ok {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(bz, out); err != nil {
+		return true, fmt.Errorf("error unmarshalling extension (%s): %w", key, err)
+	}
+
+	return true, nil
 }
 
 func NewCWPoolModel(contract string, version string, data CosmWasmPoolData) *CosmWasmPoolModel {
@@ -0,0 +1,396 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v30/ingest/types/cosmwasmpool"
+	"github.com/osmosis-labs/osmosis/v30/ingest/types/passthroughdomain"
+	prototypes "github.com/osmosis-labs/osmosis/v30/ingest/types/proto/types"
+
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v30/x/poolmanager/types"
+)
+
+// PoolToProto converts the given pool into its protobuf-encoded model.
+// The chain model is encoded as a protobuf Any using the given codec.
+// For concentrated pools, the tick delta model takes precedence over the full tick model.
+func PoolToProto(cdc codec.Codec, pool PoolI) (*prototypes.PoolModel, error) {
+	chainModelBz, err := cdc.MarshalInterface(pool.GetUnderlyingPool())
+	if err != nil {
+		return nil, err
+	}
+
+	sqsModel, err := sqsPoolToProto(pool.GetSQSPoolModel())
+	if err != nil {
+		return nil, err
+	}
+
+	poolModel := &prototypes.PoolModel{
+		ChainModel: chainModelBz,
+		SqsModel:   sqsModel,
+		AprData:    PoolAPRDataToProto(pool.GetAPRData()),
+		FeesData:   PoolFeesDataToProto(pool.GetFeesData()),
+	}
+
+	if pool.GetType() != poolmanagertypes.Concentrated {
+		return poolModel, nil
+	}
+
+	if tickDeltaModel := pool.GetTickDeltaModel(); tickDeltaModel != nil {
+		poolModel.TickDeltaModel = tickDeltaModelToProto(tickDeltaModel)
+		return poolModel, nil
+	}
+
+	tickModel, err := pool.GetTickModel()
+	if err != nil {
+		return nil, err
+	}
+
+	poolModel.TickModel = tickModelToProto(tickModel)
+
+	return poolModel, nil
+}
+
+// PoolFromProto converts the given protobuf-encoded pool model back into a pool.
+// It is the inverse of PoolToProto.
+func PoolFromProto(cdc codec.Codec, poolModel *prototypes.PoolModel) (*PoolWrapper, error) {
+	var chainModel poolmanagertypes.PoolI
+	if err := cdc.UnmarshalInterface(poolModel.ChainModel, &chainModel); err != nil {
+		return nil, err
+	}
+
+	sqsModel, err := sqsPoolFromProto(poolModel.SqsModel)
+	if err != nil {
+		return nil, fmt.Errorf("pool (%d): %w", chainModel.GetId(), err)
+	}
+
+	pool := &PoolWrapper{
+		ChainModel: chainModel,
+		SQSModel:   sqsModel,
+		APRData:    PoolAPRDataFromProto(poolModel.AprData),
+		FeesData:   PoolFeesDataFromProto(poolModel.FeesData),
+	}
+
+	if poolModel.TickModel != nil {
+		pool.TickModel, err = tickModelFromProto(poolModel.TickModel)
+		if err != nil {
+			return nil, fmt.Errorf("pool (%d): %w", chainModel.GetId(), err)
+		}
+	}
+
+	if poolModel.TickDeltaModel != nil {
+		pool.TickDeltaModel, err = tickDeltaModelFromProto(poolModel.TickDeltaModel)
+		if err != nil {
+			return nil, fmt.Errorf("pool (%d): %w", chainModel.GetId(), err)
+		}
+	}
+
+	return pool, nil
+}
+
+// TakerFeeMapToProto converts the given taker fee map into its protobuf-encoded model.
+func TakerFeeMapToProto(takerFeeMap TakerFeeMap) []*prototypes.TakerFee {
+	takerFees := make([]*prototypes.TakerFee, 0, len(takerFeeMap))
+	for denomPair, takerFee := range takerFeeMap {
+		takerFees = append(takerFees, &prototypes.TakerFee{
+			Denom0:   denomPair.Denom0,
+			Denom1:   denomPair.Denom1,
+			TakerFee: takerFee.String(),
+		})
+	}
+	return takerFees
+}
+
+// TakerFeeMapFromProto converts the given protobuf-encoded taker fees back into a taker fee map.
+func TakerFeeMapFromProto(takerFees []*prototypes.TakerFee) (TakerFeeMap, error) {
+	takerFeeMap := make(TakerFeeMap, len(takerFees))
+	for _, takerFee := range takerFees {
+		takerFeeDec, err := osmomath.NewDecFromStr(takerFee.TakerFee)
+		if err != nil {
+			return nil, fmt.Errorf("invalid taker fee for denom pair (%s, %s): %w", takerFee.Denom0, takerFee.Denom1, err)
+		}
+
+		takerFeeMap.SetTakerFee(takerFee.Denom0, takerFee.Denom1, takerFeeDec)
+	}
+	return takerFeeMap, nil
+}
+
+// PoolAPRDataToProto converts the given APR data into its protobuf-encoded model.
+// Returns nil if the APR data is not computed.
+func PoolAPRDataToProto(aprData passthroughdomain.PoolAPRDataStatusWrap) *prototypes.PoolAPRData {
+	if aprData == (passthroughdomain.PoolAPRDataStatusWrap{}) {
+		return nil
+	}
+
+	return &prototypes.PoolAPRData{
+		SwapFees:   poolDataRangeToProto(aprData.SwapFees),
+		Superfluid: poolDataRangeToProto(aprData.SuperfluidAPR),
+		Osmosis:    poolDataRangeToProto(aprData.OsmosisAPR),
+		Boost:      poolDataRangeToProto(aprData.BoostAPR),
+		TotalApr:   poolDataRangeToProto(aprData.TotalAPR),
+		IsStale:    aprData.IsStale,
+		IsError:    aprData.IsError,
+	}
+}
+
+// PoolAPRDataFromProto converts the given protobuf-encoded APR data back into the APR data.
+// It is the inverse of PoolAPRDataToProto.
+func PoolAPRDataFromProto(aprData *prototypes.PoolAPRData) passthroughdomain.PoolAPRDataStatusWrap {
+	if aprData == nil {
+		return passthroughdomain.PoolAPRDataStatusWrap{}
+	}
+
+	return passthroughdomain.PoolAPRDataStatusWrap{
+		PoolAPR: passthroughdomain.PoolAPR{
+			SwapFees:      poolDataRangeFromProto(aprData.SwapFees),
+			SuperfluidAPR: poolDataRangeFromProto(aprData.Superfluid),
+			OsmosisAPR:    poolDataRangeFromProto(aprData.Osmosis),
+			BoostAPR:      poolDataRangeFromProto(aprData.Boost),
+			TotalAPR:      poolDataRangeFromProto(aprData.TotalApr),
+		},
+		IsStale: aprData.IsStale,
+		IsError: aprData.IsError,
+	}
+}
+
+// PoolFeesDataToProto converts the given fees data into its protobuf-encoded model.
+// Returns nil if the fees data is not computed.
+func PoolFeesDataToProto(feesData passthroughdomain.PoolFeesDataStatusWrap) *prototypes.PoolFeesData {
+	if feesData == (passthroughdomain.PoolFeesDataStatusWrap{}) {
+		return nil
+	}
+
+	return &prototypes.PoolFeesData{
+		Volume_24H:     feesData.Volume24h,
+		Volume_7D:      feesData.Volume7d,
+		FeesSpent_24H:  feesData.FeesSpent24h,
+		FeesSpent_7D:   feesData.FeesSpent7d,
+		FeesPercentage: feesData.FeesPercentage,
+		IsStale:        feesData.IsStale,
+		IsError:        feesData.IsError,
+	}
+}
+
+// PoolFeesDataFromProto converts the given protobuf-encoded fees data back into the fees data.
+// It is the inverse of PoolFeesDataToProto.
+func PoolFeesDataFromProto(feesData *prototypes.PoolFeesData) passthroughdomain.PoolFeesDataStatusWrap {
+	if feesData == nil {
+		return passthroughdomain.PoolFeesDataStatusWrap{}
+	}
+
+	return passthroughdomain.PoolFeesDataStatusWrap{
+		PoolFee: passthroughdomain.PoolFee{
+			Volume24h:      feesData.Volume_24H,
+			Volume7d:       feesData.Volume_7D,
+			FeesSpent24h:   feesData.FeesSpent_24H,
+			FeesSpent7d:    feesData.FeesSpent_7D,
+			FeesPercentage: feesData.FeesPercentage,
+		},
+		IsStale: feesData.IsStale,
+		IsError: feesData.IsError,
+	}
+}
+
+// PoolAPRUpdatesToProto converts the given APR updates into their protobuf-encoded models.
+func PoolAPRUpdatesToProto(aprUpdates []PoolAPRUpdate) []*prototypes.PoolAPRUpdate {
+	protoAPRUpdates := make([]*prototypes.PoolAPRUpdate, 0, len(aprUpdates))
+	for _, aprUpdate := range aprUpdates {
+		protoAPRUpdates = append(protoAPRUpdates, &prototypes.PoolAPRUpdate{
+			PoolId:   aprUpdate.PoolID,
+			AprData:  PoolAPRDataToProto(aprUpdate.APRData),
+			FeesData: PoolFeesDataToProto(aprUpdate.FeesData),
+		})
+	}
+	return protoAPRUpdates
+}
+
+// PoolAPRUpdatesFromProto converts the given protobuf-encoded APR updates back into the APR updates.
+// It is the inverse of PoolAPRUpdatesToProto.
+func PoolAPRUpdatesFromProto(protoAPRUpdates []*prototypes.PoolAPRUpdate) []PoolAPRUpdate {
+	aprUpdates := make([]PoolAPRUpdate, 0, len(protoAPRUpdates))
+	for _, aprUpdate := range protoAPRUpdates {
+		aprUpdates = append(aprUpdates, PoolAPRUpdate{
+			PoolID:   aprUpdate.PoolId,
+			APRData:  PoolAPRDataFromProto(aprUpdate.AprData),
+			FeesData: PoolFeesDataFromProto(aprUpdate.FeesData),
+		})
+	}
+	return aprUpdates
+}
+
+func poolDataRangeToProto(dataRange passthroughdomain.PoolDataRange) *prototypes.PoolDataRange {
+	return &prototypes.PoolDataRange{
+		Lower: dataRange.Lower,
+		Upper: dataRange.Upper,
+	}
+}
+
+func poolDataRangeFromProto(dataRange *prototypes.PoolDataRange) passthroughdomain.PoolDataRange {
+	if dataRange == nil {
+		return passthroughdomain.PoolDataRange{}
+	}
+
+	return passthroughdomain.PoolDataRange{
+		Lower: dataRange.Lower,
+		Upper: dataRange.Upper,
+	}
+}
+
+func sqsPoolToProto(sqsPool SQSPool) (*prototypes.SQSPoolModel, error) {
+	balances := make([]*prototypes.Coin, 0, len(sqsPool.Balances))
+	for _, balance := range sqsPool.Balances {
+		balances = append(balances, &prototypes.Coin{
+			Denom:  balance.Denom,
+			Amount: balance.Amount.String(),
+		})
+	}
+
+	var cosmWasmPoolModelBz []byte
+	if sqsPool.CosmWasmPoolModel != nil {
+		var err error
+		cosmWasmPoolModelBz, err = json.Marshal(sqsPool.CosmWasmPoolModel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sqsPoolModel := &prototypes.SQSPoolModel{
+		PoolLiquidityCapError: sqsPool.PoolLiquidityCapError,
+		Balances:              balances,
+		PoolDenoms:            sqsPool.PoolDenoms,
+		CosmwasmPoolModel:     cosmWasmPoolModelBz,
+	}
+
+	if !sqsPool.PoolLiquidityCap.IsNil() {
+		sqsPoolModel.PoolLiquidityCap = sqsPool.PoolLiquidityCap.String()
+	}
+
+	if !sqsPool.SpreadFactor.IsNil() {
+		sqsPoolModel.SpreadFactor = sqsPool.SpreadFactor.String()
+	}
+
+	return sqsPoolModel, nil
+}
+
+func sqsPoolFromProto(sqsPoolModel *prototypes.SQSPoolModel) (SQSPool, error) {
+	if sqsPoolModel == nil {
+		return SQSPool{}, fmt.Errorf("sqs model is not set")
+	}
+
+	sqsPool := SQSPool{
+		PoolLiquidityCapError: sqsPoolModel.PoolLiquidityCapError,
+		PoolDenoms:            sqsPoolModel.PoolDenoms,
+	}
+
+	if sqsPoolModel.PoolLiquidityCap != "" {
+		poolLiquidityCap, ok := osmomath.NewIntFromString(sqsPoolModel.PoolLiquidityCap)
+		if !ok {
+			return SQSPool{}, fmt.Errorf("invalid pool liquidity cap (%s)", sqsPoolModel.PoolLiquidityCap)
+		}
+		sqsPool.PoolLiquidityCap = poolLiquidityCap
+	}
+
+	if sqsPoolModel.SpreadFactor != "" {
+		spreadFactor, err := osmomath.NewDecFromStr(sqsPoolModel.SpreadFactor)
+		if err != nil {
+			return SQSPool{}, fmt.Errorf("invalid spread factor: %w", err)
+		}
+		sqsPool.SpreadFactor = spreadFactor
+	}
+
+	sqsPool.Balances = make(sdk.Coins, 0, len(sqsPoolModel.Balances))
+	for _, balance := range sqsPoolModel.Balances {
+		amount, ok := osmomath.NewIntFromString(balance.Amount)
+		if !ok {
+			return SQSPool{}, fmt.Errorf("invalid balance amount (%s) for denom (%s)", balance.Amount, balance.Denom)
+		}
+		sqsPool.Balances = append(sqsPool.Balances, sdk.Coin{Denom: balance.Denom, Amount: amount})
+	}
+
+	if len(sqsPoolModel.CosmwasmPoolModel) > 0 {
+		sqsPool.CosmWasmPoolModel = &cosmwasmpool.CosmWasmPoolModel{}
+		if err := json.Unmarshal(sqsPoolModel.CosmwasmPoolModel, sqsPool.CosmWasmPoolModel); err != nil {
+			return SQSPool{}, err
+		}
+	}
+
+	return sqsPool, nil
+}
+
+func tickModelToProto(tickModel *TickModel) *prototypes.TickModel {
+	ticks := make([]*prototypes.TickLiquidity, 0, len(tickModel.Ticks))
+	for _, tick := range tickModel.Ticks {
+		ticks = append(ticks, &prototypes.TickLiquidity{
+			LowerTick:       tick.LowerTick,
+			UpperTick:       tick.UpperTick,
+			LiquidityAmount: tick.LiquidityAmount.String(),
+		})
+	}
+
+	return &prototypes.TickModel{
+		Ticks:            ticks,
+		CurrentTickIndex: tickModel.CurrentTickIndex,
+		HasNoLiquidity:   tickModel.HasNoLiquidity,
+	}
+}
+
+func tickModelFromProto(tickModel *prototypes.TickModel) (*TickModel, error) {
+	ticks := make([]LiquidityDepthsWithRange, 0, len(tickModel.Ticks))
+	for _, tick := range tickModel.Ticks {
+		liquidityAmount, err := osmomath.NewDecFromStr(tick.LiquidityAmount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid liquidity amount for tick range (%d, %d): %w", tick.LowerTick, tick.UpperTick, err)
+		}
+
+		ticks = append(ticks, LiquidityDepthsWithRange{
+			LowerTick:       tick.LowerTick,
+			UpperTick:       tick.UpperTick,
+			LiquidityAmount: liquidityAmount,
+		})
+	}
+
+	return &TickModel{
+		Ticks:            ticks,
+		CurrentTickIndex: tickModel.CurrentTickIndex,
+		HasNoLiquidity:   tickModel.HasNoLiquidity,
+	}, nil
+}
+
+func tickDeltaModelToProto(tickDeltaModel *TickDeltaModel) *prototypes.TickDeltaModel {
+	ticks := make([]*prototypes.TickDelta, 0, len(tickDeltaModel.Ticks))
+	for _, tick := range tickDeltaModel.Ticks {
+		ticks = append(ticks, &prototypes.TickDelta{
+			TickIndex:    tick.TickIndex,
+			LiquidityNet: tick.LiquidityNet.String(),
+		})
+	}
+
+	return &prototypes.TickDeltaModel{
+		Ticks:       ticks,
+		CurrentTick: tickDeltaModel.CurrentTick,
+	}
+}
+
+func tickDeltaModelFromProto(tickDeltaModel *prototypes.TickDeltaModel) (*TickDeltaModel, error) {
+	ticks := make([]TickDelta, 0, len(tickDeltaModel.Ticks))
+	for _, tick := range tickDeltaModel.Ticks {
+		liquidityNet, err := osmomath.NewDecFromStr(tick.LiquidityNet)
+		if err != nil {
+			return nil, fmt.Errorf("invalid liquidity net for tick (%d): %w", tick.TickIndex, err)
+		}
+
+		ticks = append(ticks, TickDelta{
+			TickIndex:    tick.TickIndex,
+			LiquidityNet: liquidityNet,
+		})
+	}
+
+	return &TickDeltaModel{
+		Ticks:       ticks,
+		CurrentTick: tickDeltaModel.CurrentTick,
+	}, nil
+}
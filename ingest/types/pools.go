@@ -34,6 +34,19 @@ type PoolI interface {
 	// Also errors if this is a concentrated pool but
 	// the tick model is not set
 	GetTickModel() (*TickModel, error)
+
+	// GetTickDeltaModel returns the tick delta model for the pool.
+	// Returns nil if the pool is not concentrated or if the full
+	// tick model is set instead.
+	GetTickDeltaModel() *TickDeltaModel
+
+	// GetAPRData returns the APR data of the pool.
+	// Returns the zero value if the APR data is not computed.
+	GetAPRData() passthroughdomain.PoolAPRDataStatusWrap
+
+	// GetFeesData returns the volume and fees data of the pool.
+	// Returns the zero value if the fees data is not computed.
+	GetFeesData() passthroughdomain.PoolFeesDataStatusWrap
 }
 
 type LiquidityDepthsWithRange = clqueryproto.LiquidityDepthWithRange
@@ -44,6 +57,22 @@ type TickModel struct {
 	HasNoLiquidity   bool                       `json:"has_no_liquidity,omitempty"`
 }
 
+// TickDelta is the new liquidity net of a tick that was written to in the block.
+// Zero liquidity net signifies that the tick is no longer initialized.
+type TickDelta struct {
+	TickIndex    int64        `json:"tick_index"`
+	LiquidityNet osmomath.Dec `json:"liquidity_net"`
+}
+
+// TickDeltaModel is the incremental counterpart of TickModel.
+// It contains only the ticks that changed in the block together with
+// the current tick of the pool so that the receiver can patch the tick
+// model it already holds rather than replacing it.
+type TickDeltaModel struct {
+	Ticks       []TickDelta `json:"ticks,omitempty"`
+	CurrentTick int64       `json:"current_tick"`
+}
+
 type SQSPool struct {
 	PoolLiquidityCap      osmomath.Int `json:"pool_liquidity_cap"`
 	PoolLiquidityCapError string       `json:"pool_liquidity_error,omitempty"`
@@ -62,10 +91,21 @@ type PoolWrapper struct {
 	APRData    passthroughdomain.PoolAPRDataStatusWrap  `json:"apr_data,omitempty"`
 	FeesData   passthroughdomain.PoolFeesDataStatusWrap `json:"fees_data,omitempty"`
 	TickModel  *TickModel                               `json:"tick_model,omitempty"`
+	// TickDeltaModel is set instead of TickModel for concentrated pools
+	// whose ticks are pushed incrementally.
+	TickDeltaModel *TickDeltaModel `json:"tick_delta_model,omitempty"`
 }
 
 var _ PoolI = &PoolWrapper{}
 
+// PoolAPRUpdate is the refreshed APR and fees data of a pool that is pushed
+// without the rest of the pool model.
+type PoolAPRUpdate struct {
+	PoolID   uint64
+	APRData  passthroughdomain.PoolAPRDataStatusWrap
+	FeesData passthroughdomain.PoolFeesDataStatusWrap
+}
+
 func NewPool(model poolmanagertypes.PoolI, spreadFactor osmomath.Dec, balances sdk.Coins) *PoolWrapper {
 	return &PoolWrapper{
 		ChainModel: model,
@@ -120,3 +160,18 @@ func (p *PoolWrapper) GetTickModel() (*TickModel, error) {
 
 	return p.TickModel, nil
 }
+
+// GetTickDeltaModel implements PoolI.
+func (p *PoolWrapper) GetTickDeltaModel() *TickDeltaModel {
+	return p.TickDeltaModel
+}
+
+// GetAPRData implements PoolI.
+func (p *PoolWrapper) GetAPRData() passthroughdomain.PoolAPRDataStatusWrap {
+	return p.APRData
+}
+
+// GetFeesData implements PoolI.
+func (p *PoolWrapper) GetFeesData() passthroughdomain.PoolFeesDataStatusWrap {
+	return p.FeesData
+}
@@ -5,6 +5,7 @@ package types
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	grpc1 "github.com/cosmos/gogoproto/grpc"
 	proto "github.com/cosmos/gogoproto/proto"
@@ -37,6 +38,17 @@ type PoolData struct {
 	// This field is only valid and set for concentrated pools. It is nil
 	// otherwise.
 	TickModel []byte `protobuf:"bytes,3,opt,name=tick_model,json=tickModel,proto3" json:"tick_model,omitempty"`
+	// TickDeltaModel contains only the ticks of a concentrated liquidity pool
+	// that changed in the block together with its current tick.
+	// It is set instead of TickModel when the pool ticks are pushed incrementally.
+	// The receiver is expected to apply it on top of the tick model it holds.
+	TickDeltaModel []byte `protobuf:"bytes,4,opt,name=tick_delta_model,json=tickDeltaModel,proto3" json:"tick_delta_model,omitempty"`
+	// AprData is the APR data of the pool computed from chain state.
+	// It is not set if the APR computation is disabled.
+	AprData *PoolAPRData `protobuf:"bytes,5,opt,name=apr_data,json=aprData,proto3" json:"apr_data,omitempty"`
+	// FeesData is the volume and fees data of the pool computed from chain state.
+	// It is not set if the APR computation is disabled.
+	FeesData *PoolFeesData `protobuf:"bytes,6,opt,name=fees_data,json=feesData,proto3" json:"fees_data,omitempty"`
 }
 
 func (m *PoolData) Reset()         { *m = PoolData{} }
@@ -93,6 +105,27 @@ func (m *PoolData) GetTickModel() []byte {
 	return nil
 }
 
+func (m *PoolData) GetTickDeltaModel() []byte {
+	if m != nil {
+		return m.TickDeltaModel
+	}
+	return nil
+}
+
+func (m *PoolData) GetAprData() *PoolAPRData {
+	if m != nil {
+		return m.AprData
+	}
+	return nil
+}
+
+func (m *PoolData) GetFeesData() *PoolFeesData {
+	if m != nil {
+		return m.FeesData
+	}
+	return nil
+}
+
 // The block process request.
 // Sends taker fees, block height and pools.
 type ProcessBlockRequest struct {
@@ -102,6 +135,17 @@ type ProcessBlockRequest struct {
 	TakerFeesMap []byte `protobuf:"bytes,2,opt,name=taker_fees_map,json=takerFeesMap,proto3" json:"taker_fees_map,omitempty"`
 	// pools in the block.
 	Pools []*PoolData `protobuf:"bytes,3,rep,name=pools,proto3" json:"pools,omitempty"`
+	// base_height is the last height applied by the receiver that the pools
+	// are an update on top of. Zero if the request is a full snapshot.
+	// The receiver is expected to request a resync if it does not match.
+	BaseHeight uint64 `protobuf:"varint,4,opt,name=base_height,json=baseHeight,proto3" json:"base_height,omitempty"`
+	// removed_pool_ids are the IDs of the pools that the receiver must drop.
+	// For example, pools that no longer pass the configured pool filters.
+	RemovedPoolIds []uint64 `protobuf:"varint,5,rep,packed,name=removed_pool_ids,json=removedPoolIds,proto3" json:"removed_pool_ids,omitempty"`
+	// apr_updates are the refreshed APR and fees data of the pools that are
+	// not part of pools. The receiver is expected to apply them on top of the
+	// pools it holds.
+	AprUpdates []*PoolAPRUpdate `protobuf:"bytes,6,rep,name=apr_updates,json=aprUpdates,proto3" json:"apr_updates,omitempty"`
 }
 
 func (m *ProcessBlockRequest) Reset()         { *m = ProcessBlockRequest{} }
@@ -158,8 +202,40 @@ func (m *ProcessBlockRequest) GetPools() []*PoolData {
 	return nil
 }
 
+func (m *ProcessBlockRequest) GetBaseHeight() uint64 {
+	if m != nil {
+		return m.BaseHeight
+	}
+	return 0
+}
+
+func (m *ProcessBlockRequest) GetRemovedPoolIds() []uint64 {
+	if m != nil {
+		return m.RemovedPoolIds
+	}
+	return nil
+}
+
+func (m *ProcessBlockRequest) GetAprUpdates() []*PoolAPRUpdate {
+	if m != nil {
+		return m.AprUpdates
+	}
+	return nil
+}
+
 // The response after completing the block processing.
 type ProcessBlockReply struct {
+	// tick_snapshot_pool_ids are the IDs of the concentrated pools for which
+	// the receiver requests the full tick model in the next block. For example,
+	// when it receives a tick delta for a pool it holds no tick model for.
+	TickSnapshotPoolIds []uint64 `protobuf:"varint,1,rep,packed,name=tick_snapshot_pool_ids,json=tickSnapshotPoolIds,proto3" json:"tick_snapshot_pool_ids,omitempty"`
+	// last_applied_height is the last height the receiver applied.
+	// If it is behind the last pushed height, the sender replays the missing
+	// updates or sends a full snapshot.
+	LastAppliedHeight uint64 `protobuf:"varint,2,opt,name=last_applied_height,json=lastAppliedHeight,proto3" json:"last_applied_height,omitempty"`
+	// resync is true if the receiver requests a full snapshot in the next block.
+	// For example, after a restart or on a base height mismatch.
+	Resync bool `protobuf:"varint,3,opt,name=resync,proto3" json:"resync,omitempty"`
 }
 
 func (m *ProcessBlockReply) Reset()         { *m = ProcessBlockReply{} }
@@ -195,310 +271,7062 @@ func (m *ProcessBlockReply) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ProcessBlockReply proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*PoolData)(nil), "osmosis.ingest.v1beta1.PoolData")
-	proto.RegisterType((*ProcessBlockRequest)(nil), "osmosis.ingest.v1beta1.ProcessBlockRequest")
-	proto.RegisterType((*ProcessBlockReply)(nil), "osmosis.ingest.v1beta1.ProcessBlockReply")
+func (m *ProcessBlockReply) GetTickSnapshotPoolIds() []uint64 {
+	if m != nil {
+		return m.TickSnapshotPoolIds
+	}
+	return nil
 }
 
-func init() {
-	proto.RegisterFile("osmosis/ingest/v1beta1/ingest.proto", fileDescriptor_1fc800754937f999)
+func (m *ProcessBlockReply) GetLastAppliedHeight() uint64 {
+	if m != nil {
+		return m.LastAppliedHeight
+	}
+	return 0
 }
 
-var fileDescriptor_1fc800754937f999 = []byte{
-	// 351 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x41, 0x4b, 0xeb, 0x40,
-	0x14, 0x85, 0x93, 0xd7, 0xf7, 0x1e, 0xed, 0x24, 0x08, 0x4e, 0x41, 0x8a, 0x62, 0xac, 0xd1, 0x45,
-	0x45, 0x4c, 0x6c, 0x0b, 0xae, 0x5c, 0x15, 0x11, 0x5d, 0x14, 0x6a, 0x0a, 0x2e, 0xdc, 0x84, 0x49,
-	0xbc, 0x26, 0xa1, 0x49, 0x27, 0xcd, 0x9d, 0x56, 0xfa, 0x2f, 0x5c, 0xf9, 0x9b, 0x5c, 0x76, 0xe9,
-	0x52, 0xda, 0x3f, 0x22, 0x9d, 0x4e, 0x41, 0x51, 0xc1, 0x5d, 0xce, 0x77, 0x4e, 0xee, 0x9d, 0xc3,
-	0x0c, 0x39, 0xe0, 0x98, 0x71, 0x4c, 0xd0, 0x4d, 0x86, 0x11, 0xa0, 0x70, 0x27, 0xcd, 0x00, 0x04,
-	0x6b, 0x2a, 0xe9, 0xe4, 0x05, 0x17, 0x9c, 0x6e, 0xa9, 0x90, 0xa3, 0xa8, 0x0a, 0xd9, 0x11, 0x29,
-	0xf7, 0x38, 0x4f, 0x2f, 0x98, 0x60, 0x74, 0x8f, 0x18, 0x61, 0xcc, 0x92, 0xa1, 0x9f, 0xf1, 0x7b,
-	0x48, 0x6b, 0x7a, 0x5d, 0x6f, 0x98, 0x1e, 0x91, 0xa8, 0xbb, 0x24, 0x74, 0x87, 0x54, 0x70, 0x84,
-	0xca, 0xfe, 0x23, 0xed, 0x32, 0x8e, 0x70, 0x65, 0xee, 0x12, 0x22, 0x92, 0x70, 0xa0, 0xdc, 0x92,
-	0x74, 0x2b, 0x4b, 0x22, 0x6d, 0xfb, 0x59, 0x27, 0xd5, 0x5e, 0xc1, 0x43, 0x40, 0xec, 0xa4, 0x3c,
-	0x1c, 0x78, 0x30, 0x1a, 0x03, 0x0a, 0xba, 0x4f, 0xcc, 0x60, 0xa9, 0xfd, 0x18, 0x92, 0x28, 0x16,
-	0x72, 0xeb, 0x5f, 0xcf, 0x90, 0xec, 0x4a, 0x22, 0x7a, 0x48, 0x36, 0x04, 0x1b, 0x40, 0xe1, 0x3f,
-	0x00, 0xa0, 0x9f, 0xb1, 0x5c, 0xed, 0x36, 0x25, 0xbd, 0x04, 0xc0, 0x2e, 0xcb, 0xe9, 0x19, 0xf9,
-	0x97, 0x73, 0x9e, 0x62, 0xad, 0x54, 0x2f, 0x35, 0x8c, 0x56, 0xdd, 0xf9, 0xbe, 0xb1, 0xb3, 0xae,
-	0xeb, 0xad, 0xe2, 0x76, 0x95, 0x6c, 0x7e, 0x3e, 0x57, 0x9e, 0x4e, 0x5b, 0x8f, 0xc4, 0xe8, 0xdf,
-	0xf4, 0xaf, 0xe5, 0x9f, 0x50, 0xd0, 0x98, 0x98, 0x1f, 0x33, 0xf4, 0xf8, 0xc7, 0xe1, 0x5f, 0x1b,
-	0x6e, 0x1f, 0xfd, 0x2e, 0x9c, 0xa7, 0x53, 0x5b, 0xeb, 0xdc, 0xbe, 0xcc, 0x2d, 0x7d, 0x36, 0xb7,
-	0xf4, 0xb7, 0xb9, 0xa5, 0x3f, 0x2d, 0x2c, 0x6d, 0xb6, 0xb0, 0xb4, 0xd7, 0x85, 0xa5, 0xdd, 0x9d,
-	0x47, 0x89, 0x88, 0xc7, 0x81, 0x13, 0xf2, 0xcc, 0x55, 0x03, 0x4f, 0x52, 0x16, 0xe0, 0x5a, 0xb8,
-	0x93, 0xf6, 0xe9, 0xfa, 0x09, 0x88, 0x69, 0x0e, 0xe8, 0xca, 0x9b, 0x5f, 0x7d, 0x07, 0xff, 0xa5,
-	0x68, 0xbf, 0x07, 0x00, 0x00, 0xff, 0xff, 0x98, 0x14, 0xd2, 0xb8, 0x2d, 0x02, 0x00, 0x00,
+func (m *ProcessBlockReply) GetResync() bool {
+	if m != nil {
+		return m.Resync
+	}
+	return false
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+// Coin is a denom and amount pair. The amount is an integer encoded as a string.
+type Coin struct {
+	Denom  string `protobuf:"bytes,1,opt,name=denom,proto3" json:"denom,omitempty"`
+	Amount string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *Coin) Reset()         { *m = Coin{} }
+func (m *Coin) String() string { return proto.CompactTextString(m) }
+func (*Coin) ProtoMessage()    {}
+func (*Coin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{3}
+}
+func (m *Coin) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Coin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Coin.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Coin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Coin.Merge(m, src)
+}
+func (m *Coin) XXX_Size() int {
+	return m.Size()
+}
+func (m *Coin) XXX_DiscardUnknown() {
+	xxx_messageInfo_Coin.DiscardUnknown(m)
+}
 
-// SQSIngesterClient is the client API for SQSIngester service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SQSIngesterClient interface {
-	// ProcessBlock processes a block from the Osmosis node.
-	ProcessBlock(ctx context.Context, in *ProcessBlockRequest, opts ...grpc.CallOption) (*ProcessBlockReply, error)
+var xxx_messageInfo_Coin proto.InternalMessageInfo
+
+func (m *Coin) GetDenom() string {
+	if m != nil {
+		return m.Denom
+	}
+	return ""
 }
 
-type sQSIngesterClient struct {
-	cc grpc1.ClientConn
+func (m *Coin) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
 }
 
-func NewSQSIngesterClient(cc grpc1.ClientConn) SQSIngesterClient {
-	return &sQSIngesterClient{cc}
+// SQSPoolModel is the protobuf counterpart of the JSON-encoded sqs_model in PoolData.
+type SQSPoolModel struct {
+	// pool_liquidity_cap is an integer encoded as a string.
+	PoolLiquidityCap      string   `protobuf:"bytes,1,opt,name=pool_liquidity_cap,json=poolLiquidityCap,proto3" json:"pool_liquidity_cap,omitempty"`
+	PoolLiquidityCapError string   `protobuf:"bytes,2,opt,name=pool_liquidity_cap_error,json=poolLiquidityCapError,proto3" json:"pool_liquidity_cap_error,omitempty"`
+	Balances              []*Coin  `protobuf:"bytes,3,rep,name=balances,proto3" json:"balances,omitempty"`
+	PoolDenoms            []string `protobuf:"bytes,4,rep,name=pool_denoms,json=poolDenoms,proto3" json:"pool_denoms,omitempty"`
+	// spread_factor is a decimal encoded as a string.
+	SpreadFactor string `protobuf:"bytes,5,opt,name=spread_factor,json=spreadFactor,proto3" json:"spread_factor,omitempty"`
+	// cosmwasm_pool_model is the JSON-encoded CosmWasm pool model.
+	// It is only set for CosmWasm pools.
+	CosmwasmPoolModel []byte `protobuf:"bytes,6,opt,name=cosmwasm_pool_model,json=cosmwasmPoolModel,proto3" json:"cosmwasm_pool_model,omitempty"`
 }
 
-func (c *sQSIngesterClient) ProcessBlock(ctx context.Context, in *ProcessBlockRequest, opts ...grpc.CallOption) (*ProcessBlockReply, error) {
-	out := new(ProcessBlockReply)
-	err := c.cc.Invoke(ctx, "/osmosis.ingest.v1beta1.SQSIngester/ProcessBlock", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SQSPoolModel) Reset()         { *m = SQSPoolModel{} }
+func (m *SQSPoolModel) String() string { return proto.CompactTextString(m) }
+func (*SQSPoolModel) ProtoMessage()    {}
+func (*SQSPoolModel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{4}
+}
+func (m *SQSPoolModel) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SQSPoolModel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SQSPoolModel.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *SQSPoolModel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SQSPoolModel.Merge(m, src)
+}
+func (m *SQSPoolModel) XXX_Size() int {
+	return m.Size()
+}
+func (m *SQSPoolModel) XXX_DiscardUnknown() {
+	xxx_messageInfo_SQSPoolModel.DiscardUnknown(m)
 }
 
-// SQSIngesterServer is the server API for SQSIngester service.
-type SQSIngesterServer interface {
-	// ProcessBlock processes a block from the Osmosis node.
-	ProcessBlock(context.Context, *ProcessBlockRequest) (*ProcessBlockReply, error)
+var xxx_messageInfo_SQSPoolModel proto.InternalMessageInfo
+
+func (m *SQSPoolModel) GetPoolLiquidityCap() string {
+	if m != nil {
+		return m.PoolLiquidityCap
+	}
+	return ""
 }
 
-// UnimplementedSQSIngesterServer can be embedded to have forward compatible implementations.
-type UnimplementedSQSIngesterServer struct {
+func (m *SQSPoolModel) GetPoolLiquidityCapError() string {
+	if m != nil {
+		return m.PoolLiquidityCapError
+	}
+	return ""
 }
 
-func (*UnimplementedSQSIngesterServer) ProcessBlock(ctx context.Context, req *ProcessBlockRequest) (*ProcessBlockReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ProcessBlock not implemented")
+func (m *SQSPoolModel) GetBalances() []*Coin {
+	if m != nil {
+		return m.Balances
+	}
+	return nil
 }
 
-func RegisterSQSIngesterServer(s grpc1.Server, srv SQSIngesterServer) {
-	s.RegisterService(&_SQSIngester_serviceDesc, srv)
+func (m *SQSPoolModel) GetPoolDenoms() []string {
+	if m != nil {
+		return m.PoolDenoms
+	}
+	return nil
 }
 
-func _SQSIngester_ProcessBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ProcessBlockRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *SQSPoolModel) GetSpreadFactor() string {
+	if m != nil {
+		return m.SpreadFactor
 	}
-	if interceptor == nil {
-		return srv.(SQSIngesterServer).ProcessBlock(ctx, in)
+	return ""
+}
+
+func (m *SQSPoolModel) GetCosmwasmPoolModel() []byte {
+	if m != nil {
+		return m.CosmwasmPoolModel
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/osmosis.ingest.v1beta1.SQSIngester/ProcessBlock",
+	return nil
+}
+
+// TickLiquidity is the liquidity within a tick range.
+type TickLiquidity struct {
+	LowerTick int64 `protobuf:"varint,1,opt,name=lower_tick,json=lowerTick,proto3" json:"lower_tick,omitempty"`
+	UpperTick int64 `protobuf:"varint,2,opt,name=upper_tick,json=upperTick,proto3" json:"upper_tick,omitempty"`
+	// liquidity_amount is a decimal encoded as a string.
+	LiquidityAmount string `protobuf:"bytes,3,opt,name=liquidity_amount,json=liquidityAmount,proto3" json:"liquidity_amount,omitempty"`
+}
+
+func (m *TickLiquidity) Reset()         { *m = TickLiquidity{} }
+func (m *TickLiquidity) String() string { return proto.CompactTextString(m) }
+func (*TickLiquidity) ProtoMessage()    {}
+func (*TickLiquidity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{5}
+}
+func (m *TickLiquidity) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TickLiquidity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TickLiquidity.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SQSIngesterServer).ProcessBlock(ctx, req.(*ProcessBlockRequest))
+}
+func (m *TickLiquidity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TickLiquidity.Merge(m, src)
+}
+func (m *TickLiquidity) XXX_Size() int {
+	return m.Size()
+}
+func (m *TickLiquidity) XXX_DiscardUnknown() {
+	xxx_messageInfo_TickLiquidity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TickLiquidity proto.InternalMessageInfo
+
+func (m *TickLiquidity) GetLowerTick() int64 {
+	if m != nil {
+		return m.LowerTick
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-var _SQSIngester_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "osmosis.ingest.v1beta1.SQSIngester",
-	HandlerType: (*SQSIngesterServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ProcessBlock",
-			Handler:    _SQSIngester_ProcessBlock_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "osmosis/ingest/v1beta1/ingest.proto",
+func (m *TickLiquidity) GetUpperTick() int64 {
+	if m != nil {
+		return m.UpperTick
+	}
+	return 0
 }
 
-func (m *PoolData) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *TickLiquidity) GetLiquidityAmount() string {
+	if m != nil {
+		return m.LiquidityAmount
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *PoolData) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// TickModel is the protobuf counterpart of the JSON-encoded tick_model in PoolData.
+type TickModel struct {
+	Ticks            []*TickLiquidity `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty"`
+	CurrentTickIndex int64            `protobuf:"varint,2,opt,name=current_tick_index,json=currentTickIndex,proto3" json:"current_tick_index,omitempty"`
+	HasNoLiquidity   bool             `protobuf:"varint,3,opt,name=has_no_liquidity,json=hasNoLiquidity,proto3" json:"has_no_liquidity,omitempty"`
 }
 
-func (m *PoolData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.TickModel) > 0 {
-		i -= len(m.TickModel)
-		copy(dAtA[i:], m.TickModel)
-		i = encodeVarintIngest(dAtA, i, uint64(len(m.TickModel)))
-		i--
-		dAtA[i] = 0x1a
+func (m *TickModel) Reset()         { *m = TickModel{} }
+func (m *TickModel) String() string { return proto.CompactTextString(m) }
+func (*TickModel) ProtoMessage()    {}
+func (*TickModel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{6}
+}
+func (m *TickModel) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TickModel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TickModel.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.SqsModel) > 0 {
-		i -= len(m.SqsModel)
-		copy(dAtA[i:], m.SqsModel)
-		i = encodeVarintIngest(dAtA, i, uint64(len(m.SqsModel)))
-		i--
-		dAtA[i] = 0x12
+}
+func (m *TickModel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TickModel.Merge(m, src)
+}
+func (m *TickModel) XXX_Size() int {
+	return m.Size()
+}
+func (m *TickModel) XXX_DiscardUnknown() {
+	xxx_messageInfo_TickModel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TickModel proto.InternalMessageInfo
+
+func (m *TickModel) GetTicks() []*TickLiquidity {
+	if m != nil {
+		return m.Ticks
 	}
-	if len(m.ChainModel) > 0 {
-		i -= len(m.ChainModel)
-		copy(dAtA[i:], m.ChainModel)
-		i = encodeVarintIngest(dAtA, i, uint64(len(m.ChainModel)))
-		i--
-		dAtA[i] = 0xa
+	return nil
+}
+
+func (m *TickModel) GetCurrentTickIndex() int64 {
+	if m != nil {
+		return m.CurrentTickIndex
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *ProcessBlockRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *TickModel) GetHasNoLiquidity() bool {
+	if m != nil {
+		return m.HasNoLiquidity
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *ProcessBlockRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// TickDelta is the new liquidity net of a tick that was written to in the block.
+type TickDelta struct {
+	TickIndex int64 `protobuf:"varint,1,opt,name=tick_index,json=tickIndex,proto3" json:"tick_index,omitempty"`
+	// liquidity_net is a decimal encoded as a string.
+	// Zero signifies that the tick is no longer initialized.
+	LiquidityNet string `protobuf:"bytes,2,opt,name=liquidity_net,json=liquidityNet,proto3" json:"liquidity_net,omitempty"`
 }
 
-func (m *ProcessBlockRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Pools) > 0 {
-		for iNdEx := len(m.Pools) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Pools[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngest(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
+func (m *TickDelta) Reset()         { *m = TickDelta{} }
+func (m *TickDelta) String() string { return proto.CompactTextString(m) }
+func (*TickDelta) ProtoMessage()    {}
+func (*TickDelta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{7}
+}
+func (m *TickDelta) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TickDelta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TickDelta.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.TakerFeesMap) > 0 {
-		i -= len(m.TakerFeesMap)
-		copy(dAtA[i:], m.TakerFeesMap)
-		i = encodeVarintIngest(dAtA, i, uint64(len(m.TakerFeesMap)))
-		i--
-		dAtA[i] = 0x12
+}
+func (m *TickDelta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TickDelta.Merge(m, src)
+}
+func (m *TickDelta) XXX_Size() int {
+	return m.Size()
+}
+func (m *TickDelta) XXX_DiscardUnknown() {
+	xxx_messageInfo_TickDelta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TickDelta proto.InternalMessageInfo
+
+func (m *TickDelta) GetTickIndex() int64 {
+	if m != nil {
+		return m.TickIndex
 	}
-	if m.BlockHeight != 0 {
-		i = encodeVarintIngest(dAtA, i, uint64(m.BlockHeight))
-		i--
-		dAtA[i] = 0x8
+	return 0
+}
+
+func (m *TickDelta) GetLiquidityNet() string {
+	if m != nil {
+		return m.LiquidityNet
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *ProcessBlockReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+// TickDeltaModel is the protobuf counterpart of the JSON-encoded tick_delta_model in PoolData.
+type TickDeltaModel struct {
+	Ticks       []*TickDelta `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty"`
+	CurrentTick int64        `protobuf:"varint,2,opt,name=current_tick,json=currentTick,proto3" json:"current_tick,omitempty"`
+}
+
+func (m *TickDeltaModel) Reset()         { *m = TickDeltaModel{} }
+func (m *TickDeltaModel) String() string { return proto.CompactTextString(m) }
+func (*TickDeltaModel) ProtoMessage()    {}
+func (*TickDeltaModel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{8}
+}
+func (m *TickDeltaModel) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TickDeltaModel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TickDeltaModel.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *TickDeltaModel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TickDeltaModel.Merge(m, src)
+}
+func (m *TickDeltaModel) XXX_Size() int {
+	return m.Size()
+}
+func (m *TickDeltaModel) XXX_DiscardUnknown() {
+	xxx_messageInfo_TickDeltaModel.DiscardUnknown(m)
 }
 
-func (m *ProcessBlockReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_TickDeltaModel proto.InternalMessageInfo
+
+func (m *TickDeltaModel) GetTicks() []*TickDelta {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
 }
 
-func (m *ProcessBlockReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func (m *TickDeltaModel) GetCurrentTick() int64 {
+	if m != nil {
+		return m.CurrentTick
+	}
+	return 0
 }
 
-func encodeVarintIngest(dAtA []byte, offset int, v uint64) int {
-	offset -= sovIngest(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+// PoolModel is the protobuf-encoded counterpart of PoolData.
+type PoolModel struct {
+	// chain_model is the chain representation model of the pool
+	// encoded as a protobuf Any.
+	ChainModel []byte `protobuf:"bytes,1,opt,name=chain_model,json=chainModel,proto3" json:"chain_model,omitempty"`
+	// sqs_model is additional pool data used by the sidecar query server.
+	SqsModel *SQSPoolModel `protobuf:"bytes,2,opt,name=sqs_model,json=sqsModel,proto3" json:"sqs_model,omitempty"`
+	// tick_model is the full tick data of a concentrated liquidity pool.
+	// At most one of tick_model and tick_delta_model is set, and only for
+	// concentrated pools.
+	TickModel *TickModel `protobuf:"bytes,3,opt,name=tick_model,json=tickModel,proto3" json:"tick_model,omitempty"`
+	// tick_delta_model is the incremental tick data of a concentrated liquidity pool.
+	TickDeltaModel *TickDeltaModel `protobuf:"bytes,4,opt,name=tick_delta_model,json=tickDeltaModel,proto3" json:"tick_delta_model,omitempty"`
+	// apr_data is the APR data of the pool computed from chain state.
+	AprData *PoolAPRData `protobuf:"bytes,5,opt,name=apr_data,json=aprData,proto3" json:"apr_data,omitempty"`
+	// fees_data is the volume and fees data of the pool computed from chain state.
+	FeesData *PoolFeesData `protobuf:"bytes,6,opt,name=fees_data,json=feesData,proto3" json:"fees_data,omitempty"`
+}
+
+func (m *PoolModel) Reset()         { *m = PoolModel{} }
+func (m *PoolModel) String() string { return proto.CompactTextString(m) }
+func (*PoolModel) ProtoMessage()    {}
+func (*PoolModel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{9}
+}
+func (m *PoolModel) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoolModel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoolModel.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	dAtA[offset] = uint8(v)
-	return base
 }
-func (m *PoolData) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PoolModel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolModel.Merge(m, src)
+}
+func (m *PoolModel) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoolModel) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolModel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolModel proto.InternalMessageInfo
+
+func (m *PoolModel) GetChainModel() []byte {
+	if m != nil {
+		return m.ChainModel
 	}
-	var l int
-	_ = l
-	l = len(m.ChainModel)
-	if l > 0 {
-		n += 1 + l + sovIngest(uint64(l))
+	return nil
+}
+
+func (m *PoolModel) GetSqsModel() *SQSPoolModel {
+	if m != nil {
+		return m.SqsModel
 	}
-	l = len(m.SqsModel)
-	if l > 0 {
-		n += 1 + l + sovIngest(uint64(l))
+	return nil
+}
+
+func (m *PoolModel) GetTickModel() *TickModel {
+	if m != nil {
+		return m.TickModel
 	}
-	l = len(m.TickModel)
-	if l > 0 {
-		n += 1 + l + sovIngest(uint64(l))
+	return nil
+}
+
+func (m *PoolModel) GetTickDeltaModel() *TickDeltaModel {
+	if m != nil {
+		return m.TickDeltaModel
 	}
-	return n
+	return nil
 }
 
-func (m *ProcessBlockRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PoolModel) GetAprData() *PoolAPRData {
+	if m != nil {
+		return m.AprData
 	}
-	var l int
-	_ = l
-	if m.BlockHeight != 0 {
-		n += 1 + sovIngest(uint64(m.BlockHeight))
+	return nil
+}
+
+func (m *PoolModel) GetFeesData() *PoolFeesData {
+	if m != nil {
+		return m.FeesData
 	}
-	l = len(m.TakerFeesMap)
-	if l > 0 {
-		n += 1 + l + sovIngest(uint64(l))
+	return nil
+}
+
+// PoolDataRange is the range of a pool APR component in percent.
+type PoolDataRange struct {
+	Lower float64 `protobuf:"fixed64,1,opt,name=lower,proto3" json:"lower,omitempty"`
+	Upper float64 `protobuf:"fixed64,2,opt,name=upper,proto3" json:"upper,omitempty"`
+}
+
+func (m *PoolDataRange) Reset()         { *m = PoolDataRange{} }
+func (m *PoolDataRange) String() string { return proto.CompactTextString(m) }
+func (*PoolDataRange) ProtoMessage()    {}
+func (*PoolDataRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{10}
+}
+func (m *PoolDataRange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoolDataRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoolDataRange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.Pools) > 0 {
-		for _, e := range m.Pools {
-			l = e.Size()
-			n += 1 + l + sovIngest(uint64(l))
+}
+func (m *PoolDataRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolDataRange.Merge(m, src)
+}
+func (m *PoolDataRange) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoolDataRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolDataRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolDataRange proto.InternalMessageInfo
+
+func (m *PoolDataRange) GetLower() float64 {
+	if m != nil {
+		return m.Lower
+	}
+	return 0
+}
+
+func (m *PoolDataRange) GetUpper() float64 {
+	if m != nil {
+		return m.Upper
+	}
+	return 0
+}
+
+// PoolAPRData is the APR data of a pool in percent.
+type PoolAPRData struct {
+	SwapFees   *PoolDataRange `protobuf:"bytes,1,opt,name=swap_fees,json=swapFees,proto3" json:"swap_fees,omitempty"`
+	Superfluid *PoolDataRange `protobuf:"bytes,2,opt,name=superfluid,proto3" json:"superfluid,omitempty"`
+	// osmosis is the APR from the internal OSMO incentives.
+	Osmosis *PoolDataRange `protobuf:"bytes,3,opt,name=osmosis,proto3" json:"osmosis,omitempty"`
+	// boost is the APR from the external incentives.
+	Boost    *PoolDataRange `protobuf:"bytes,4,opt,name=boost,proto3" json:"boost,omitempty"`
+	TotalApr *PoolDataRange `protobuf:"bytes,5,opt,name=total_apr,json=totalApr,proto3" json:"total_apr,omitempty"`
+	// is_stale is true if the data is computed from incomplete history.
+	IsStale bool `protobuf:"varint,6,opt,name=is_stale,json=isStale,proto3" json:"is_stale,omitempty"`
+	// is_error is true if some of the components failed to compute.
+	IsError bool `protobuf:"varint,7,opt,name=is_error,json=isError,proto3" json:"is_error,omitempty"`
+}
+
+func (m *PoolAPRData) Reset()         { *m = PoolAPRData{} }
+func (m *PoolAPRData) String() string { return proto.CompactTextString(m) }
+func (*PoolAPRData) ProtoMessage()    {}
+func (*PoolAPRData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{11}
+}
+func (m *PoolAPRData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoolAPRData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoolAPRData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PoolAPRData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolAPRData.Merge(m, src)
+}
+func (m *PoolAPRData) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoolAPRData) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolAPRData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolAPRData proto.InternalMessageInfo
+
+func (m *PoolAPRData) GetSwapFees() *PoolDataRange {
+	if m != nil {
+		return m.SwapFees
+	}
+	return nil
+}
+
+func (m *PoolAPRData) GetSuperfluid() *PoolDataRange {
+	if m != nil {
+		return m.Superfluid
+	}
+	return nil
+}
+
+func (m *PoolAPRData) GetOsmosis() *PoolDataRange {
+	if m != nil {
+		return m.Osmosis
+	}
+	return nil
+}
+
+func (m *PoolAPRData) GetBoost() *PoolDataRange {
+	if m != nil {
+		return m.Boost
+	}
+	return nil
+}
+
+func (m *PoolAPRData) GetTotalApr() *PoolDataRange {
+	if m != nil {
+		return m.TotalApr
+	}
+	return nil
+}
+
+func (m *PoolAPRData) GetIsStale() bool {
+	if m != nil {
+		return m.IsStale
+	}
+	return false
+}
+
+func (m *PoolAPRData) GetIsError() bool {
+	if m != nil {
+		return m.IsError
+	}
+	return false
+}
+
+// PoolFeesData is the volume and fees data of a pool in USDC.
+type PoolFeesData struct {
+	Volume_24H    float64 `protobuf:"fixed64,1,opt,name=volume_24h,json=volume24h,proto3" json:"volume_24h,omitempty"`
+	Volume_7D     float64 `protobuf:"fixed64,2,opt,name=volume_7d,json=volume7d,proto3" json:"volume_7d,omitempty"`
+	FeesSpent_24H float64 `protobuf:"fixed64,3,opt,name=fees_spent_24h,json=feesSpent24h,proto3" json:"fees_spent_24h,omitempty"`
+	FeesSpent_7D  float64 `protobuf:"fixed64,4,opt,name=fees_spent_7d,json=feesSpent7d,proto3" json:"fees_spent_7d,omitempty"`
+	// fees_percentage is the spread factor of the pool in percent, e.g. "0.2%".
+	FeesPercentage string `protobuf:"bytes,5,opt,name=fees_percentage,json=feesPercentage,proto3" json:"fees_percentage,omitempty"`
+	// is_stale is true if the data is computed from incomplete history.
+	IsStale bool `protobuf:"varint,6,opt,name=is_stale,json=isStale,proto3" json:"is_stale,omitempty"`
+	// is_error is true if the volume failed to be valued.
+	IsError bool `protobuf:"varint,7,opt,name=is_error,json=isError,proto3" json:"is_error,omitempty"`
+}
+
+func (m *PoolFeesData) Reset()         { *m = PoolFeesData{} }
+func (m *PoolFeesData) String() string { return proto.CompactTextString(m) }
+func (*PoolFeesData) ProtoMessage()    {}
+func (*PoolFeesData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{12}
+}
+func (m *PoolFeesData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoolFeesData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoolFeesData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PoolFeesData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolFeesData.Merge(m, src)
+}
+func (m *PoolFeesData) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoolFeesData) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolFeesData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolFeesData proto.InternalMessageInfo
+
+func (m *PoolFeesData) GetVolume_24H() float64 {
+	if m != nil {
+		return m.Volume_24H
+	}
+	return 0
+}
+
+func (m *PoolFeesData) GetVolume_7D() float64 {
+	if m != nil {
+		return m.Volume_7D
+	}
+	return 0
+}
+
+func (m *PoolFeesData) GetFeesSpent_24H() float64 {
+	if m != nil {
+		return m.FeesSpent_24H
+	}
+	return 0
+}
+
+func (m *PoolFeesData) GetFeesSpent_7D() float64 {
+	if m != nil {
+		return m.FeesSpent_7D
+	}
+	return 0
+}
+
+func (m *PoolFeesData) GetFeesPercentage() string {
+	if m != nil {
+		return m.FeesPercentage
+	}
+	return ""
+}
+
+func (m *PoolFeesData) GetIsStale() bool {
+	if m != nil {
+		return m.IsStale
+	}
+	return false
+}
+
+func (m *PoolFeesData) GetIsError() bool {
+	if m != nil {
+		return m.IsError
+	}
+	return false
+}
+
+// TakerFee is the taker fee for a denom pair.
+type TakerFee struct {
+	Denom0 string `protobuf:"bytes,1,opt,name=denom0,proto3" json:"denom0,omitempty"`
+	Denom1 string `protobuf:"bytes,2,opt,name=denom1,proto3" json:"denom1,omitempty"`
+	// taker_fee is a decimal encoded as a string.
+	TakerFee string `protobuf:"bytes,3,opt,name=taker_fee,json=takerFee,proto3" json:"taker_fee,omitempty"`
+}
+
+func (m *TakerFee) Reset()         { *m = TakerFee{} }
+func (m *TakerFee) String() string { return proto.CompactTextString(m) }
+func (*TakerFee) ProtoMessage()    {}
+func (*TakerFee) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{13}
+}
+func (m *TakerFee) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TakerFee) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TakerFee.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TakerFee) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TakerFee.Merge(m, src)
+}
+func (m *TakerFee) XXX_Size() int {
+	return m.Size()
+}
+func (m *TakerFee) XXX_DiscardUnknown() {
+	xxx_messageInfo_TakerFee.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TakerFee proto.InternalMessageInfo
+
+func (m *TakerFee) GetDenom0() string {
+	if m != nil {
+		return m.Denom0
+	}
+	return ""
+}
+
+func (m *TakerFee) GetDenom1() string {
+	if m != nil {
+		return m.Denom1
+	}
+	return ""
+}
+
+func (m *TakerFee) GetTakerFee() string {
+	if m != nil {
+		return m.TakerFee
+	}
+	return ""
+}
+
+// ProcessBlockChunk is a single message of the ProcessBlockStream client stream.
+// A block is split into one or more chunks so that no single message
+// exceeds the gRPC message size limits.
+type ProcessBlockChunk struct {
+	// block_height is the height of the block being processed.
+	// It is set on every chunk.
+	BlockHeight uint64 `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	// taker_fees are the taker fees for the block.
+	// They are only set on the first chunk.
+	TakerFees []*TakerFee `protobuf:"bytes,2,rep,name=taker_fees,json=takerFees,proto3" json:"taker_fees,omitempty"`
+	// pools in the chunk.
+	Pools []*PoolModel `protobuf:"bytes,3,rep,name=pools,proto3" json:"pools,omitempty"`
+	// chunk_index is the zero-based index of the chunk within the block.
+	ChunkIndex uint32 `protobuf:"varint,4,opt,name=chunk_index,json=chunkIndex,proto3" json:"chunk_index,omitempty"`
+	// total_chunks is the number of chunks the block is split into.
+	TotalChunks uint32 `protobuf:"varint,5,opt,name=total_chunks,json=totalChunks,proto3" json:"total_chunks,omitempty"`
+	// base_height is the last height applied by the receiver that the pools
+	// are an update on top of. Zero if the block is a full snapshot.
+	// It is set on every chunk.
+	BaseHeight uint64 `protobuf:"varint,6,opt,name=base_height,json=baseHeight,proto3" json:"base_height,omitempty"`
+	// removed_pool_ids are the IDs of the pools that the receiver must drop.
+	// They are only set on the first chunk.
+	RemovedPoolIds []uint64 `protobuf:"varint,7,rep,packed,name=removed_pool_ids,json=removedPoolIds,proto3" json:"removed_pool_ids,omitempty"`
+	// apr_updates are the refreshed APR and fees data of the pools that are
+	// not part of pools. They are only set on the first chunk.
+	AprUpdates []*PoolAPRUpdate `protobuf:"bytes,8,rep,name=apr_updates,json=aprUpdates,proto3" json:"apr_updates,omitempty"`
+}
+
+func (m *ProcessBlockChunk) Reset()         { *m = ProcessBlockChunk{} }
+func (m *ProcessBlockChunk) String() string { return proto.CompactTextString(m) }
+func (*ProcessBlockChunk) ProtoMessage()    {}
+func (*ProcessBlockChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{14}
+}
+func (m *ProcessBlockChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProcessBlockChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProcessBlockChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProcessBlockChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcessBlockChunk.Merge(m, src)
+}
+func (m *ProcessBlockChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProcessBlockChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcessBlockChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProcessBlockChunk proto.InternalMessageInfo
+
+func (m *ProcessBlockChunk) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *ProcessBlockChunk) GetTakerFees() []*TakerFee {
+	if m != nil {
+		return m.TakerFees
+	}
+	return nil
+}
+
+func (m *ProcessBlockChunk) GetPools() []*PoolModel {
+	if m != nil {
+		return m.Pools
+	}
+	return nil
+}
+
+func (m *ProcessBlockChunk) GetChunkIndex() uint32 {
+	if m != nil {
+		return m.ChunkIndex
+	}
+	return 0
+}
+
+func (m *ProcessBlockChunk) GetTotalChunks() uint32 {
+	if m != nil {
+		return m.TotalChunks
+	}
+	return 0
+}
+
+func (m *ProcessBlockChunk) GetBaseHeight() uint64 {
+	if m != nil {
+		return m.BaseHeight
+	}
+	return 0
+}
+
+func (m *ProcessBlockChunk) GetRemovedPoolIds() []uint64 {
+	if m != nil {
+		return m.RemovedPoolIds
+	}
+	return nil
+}
+
+func (m *ProcessBlockChunk) GetAprUpdates() []*PoolAPRUpdate {
+	if m != nil {
+		return m.AprUpdates
+	}
+	return nil
+}
+
+// IngestAdminRequest is a request of an operator to the ingest admin service of a node.
+type IngestAdminRequest struct {
+	// target is the ingest service the request applies to: "sqs" or "indexer".
+	// If empty, the request applies to all ingest services.
+	Target string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (m *IngestAdminRequest) Reset()         { *m = IngestAdminRequest{} }
+func (m *IngestAdminRequest) String() string { return proto.CompactTextString(m) }
+func (*IngestAdminRequest) ProtoMessage()    {}
+func (*IngestAdminRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{15}
+}
+func (m *IngestAdminRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IngestAdminRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IngestAdminRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *IngestAdminRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IngestAdminRequest.Merge(m, src)
+}
+func (m *IngestAdminRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *IngestAdminRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IngestAdminRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IngestAdminRequest proto.InternalMessageInfo
+
+func (m *IngestAdminRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+// IngestAdminReply is the reply of the ingest admin service.
+type IngestAdminReply struct {
+	// sinks are the names of the sinks the request was applied to.
+	Sinks []string `protobuf:"bytes,1,rep,name=sinks,proto3" json:"sinks,omitempty"`
+}
+
+func (m *IngestAdminReply) Reset()         { *m = IngestAdminReply{} }
+func (m *IngestAdminReply) String() string { return proto.CompactTextString(m) }
+func (*IngestAdminReply) ProtoMessage()    {}
+func (*IngestAdminReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{16}
+}
+func (m *IngestAdminReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IngestAdminReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IngestAdminReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *IngestAdminReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IngestAdminReply.Merge(m, src)
+}
+func (m *IngestAdminReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *IngestAdminReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_IngestAdminReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IngestAdminReply proto.InternalMessageInfo
+
+func (m *IngestAdminReply) GetSinks() []string {
+	if m != nil {
+		return m.Sinks
+	}
+	return nil
+}
+
+// PoolAPRUpdate is the refreshed APR and fees data of a pool that is pushed
+// without the rest of the pool model.
+type PoolAPRUpdate struct {
+	PoolId   uint64        `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	AprData  *PoolAPRData  `protobuf:"bytes,2,opt,name=apr_data,json=aprData,proto3" json:"apr_data,omitempty"`
+	FeesData *PoolFeesData `protobuf:"bytes,3,opt,name=fees_data,json=feesData,proto3" json:"fees_data,omitempty"`
+}
+
+func (m *PoolAPRUpdate) Reset()         { *m = PoolAPRUpdate{} }
+func (m *PoolAPRUpdate) String() string { return proto.CompactTextString(m) }
+func (*PoolAPRUpdate) ProtoMessage()    {}
+func (*PoolAPRUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{17}
+}
+func (m *PoolAPRUpdate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoolAPRUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoolAPRUpdate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PoolAPRUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolAPRUpdate.Merge(m, src)
+}
+func (m *PoolAPRUpdate) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoolAPRUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolAPRUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolAPRUpdate proto.InternalMessageInfo
+
+func (m *PoolAPRUpdate) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
+	}
+	return 0
+}
+
+func (m *PoolAPRUpdate) GetAprData() *PoolAPRData {
+	if m != nil {
+		return m.AprData
+	}
+	return nil
+}
+
+func (m *PoolAPRUpdate) GetFeesData() *PoolFeesData {
+	if m != nil {
+		return m.FeesData
+	}
+	return nil
+}
+
+// ReceiverPoolsRequest is the request of SQSReceiverQuery.Pools.
+type ReceiverPoolsRequest struct {
+	// height is the height of the state. Zero for the latest height.
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *ReceiverPoolsRequest) Reset()         { *m = ReceiverPoolsRequest{} }
+func (m *ReceiverPoolsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReceiverPoolsRequest) ProtoMessage()    {}
+func (*ReceiverPoolsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{18}
+}
+func (m *ReceiverPoolsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReceiverPoolsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReceiverPoolsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReceiverPoolsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiverPoolsRequest.Merge(m, src)
+}
+func (m *ReceiverPoolsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReceiverPoolsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiverPoolsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiverPoolsRequest proto.InternalMessageInfo
+
+func (m *ReceiverPoolsRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// ReceiverPoolsReply is the reply of SQSReceiverQuery.Pools.
+type ReceiverPoolsReply struct {
+	// height is the height of the state.
+	Height uint64       `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Pools  []*PoolModel `protobuf:"bytes,2,rep,name=pools,proto3" json:"pools,omitempty"`
+}
+
+func (m *ReceiverPoolsReply) Reset()         { *m = ReceiverPoolsReply{} }
+func (m *ReceiverPoolsReply) String() string { return proto.CompactTextString(m) }
+func (*ReceiverPoolsReply) ProtoMessage()    {}
+func (*ReceiverPoolsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{19}
+}
+func (m *ReceiverPoolsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReceiverPoolsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReceiverPoolsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReceiverPoolsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiverPoolsReply.Merge(m, src)
+}
+func (m *ReceiverPoolsReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReceiverPoolsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiverPoolsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiverPoolsReply proto.InternalMessageInfo
+
+func (m *ReceiverPoolsReply) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReceiverPoolsReply) GetPools() []*PoolModel {
+	if m != nil {
+		return m.Pools
+	}
+	return nil
+}
+
+// ReceiverPoolRequest is the request of SQSReceiverQuery.Pool.
+type ReceiverPoolRequest struct {
+	PoolId uint64 `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	// height is the height of the state. Zero for the latest height.
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *ReceiverPoolRequest) Reset()         { *m = ReceiverPoolRequest{} }
+func (m *ReceiverPoolRequest) String() string { return proto.CompactTextString(m) }
+func (*ReceiverPoolRequest) ProtoMessage()    {}
+func (*ReceiverPoolRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{20}
+}
+func (m *ReceiverPoolRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReceiverPoolRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReceiverPoolRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReceiverPoolRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiverPoolRequest.Merge(m, src)
+}
+func (m *ReceiverPoolRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReceiverPoolRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiverPoolRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiverPoolRequest proto.InternalMessageInfo
+
+func (m *ReceiverPoolRequest) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
+	}
+	return 0
+}
+
+func (m *ReceiverPoolRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// ReceiverPoolReply is the reply of SQSReceiverQuery.Pool.
+type ReceiverPoolReply struct {
+	// height is the height of the state.
+	Height uint64     `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Pool   *PoolModel `protobuf:"bytes,2,opt,name=pool,proto3" json:"pool,omitempty"`
+}
+
+func (m *ReceiverPoolReply) Reset()         { *m = ReceiverPoolReply{} }
+func (m *ReceiverPoolReply) String() string { return proto.CompactTextString(m) }
+func (*ReceiverPoolReply) ProtoMessage()    {}
+func (*ReceiverPoolReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{21}
+}
+func (m *ReceiverPoolReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReceiverPoolReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReceiverPoolReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReceiverPoolReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiverPoolReply.Merge(m, src)
+}
+func (m *ReceiverPoolReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReceiverPoolReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiverPoolReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiverPoolReply proto.InternalMessageInfo
+
+func (m *ReceiverPoolReply) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReceiverPoolReply) GetPool() *PoolModel {
+	if m != nil {
+		return m.Pool
+	}
+	return nil
+}
+
+// ReceiverTakerFeeRequest is the request of SQSReceiverQuery.TakerFee.
+type ReceiverTakerFeeRequest struct {
+	Denom0 string `protobuf:"bytes,1,opt,name=denom0,proto3" json:"denom0,omitempty"`
+	Denom1 string `protobuf:"bytes,2,opt,name=denom1,proto3" json:"denom1,omitempty"`
+	// height is the height of the state. Zero for the latest height.
+	Height uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *ReceiverTakerFeeRequest) Reset()         { *m = ReceiverTakerFeeRequest{} }
+func (m *ReceiverTakerFeeRequest) String() string { return proto.CompactTextString(m) }
+func (*ReceiverTakerFeeRequest) ProtoMessage()    {}
+func (*ReceiverTakerFeeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{22}
+}
+func (m *ReceiverTakerFeeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReceiverTakerFeeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReceiverTakerFeeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReceiverTakerFeeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiverTakerFeeRequest.Merge(m, src)
+}
+func (m *ReceiverTakerFeeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReceiverTakerFeeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiverTakerFeeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiverTakerFeeRequest proto.InternalMessageInfo
+
+func (m *ReceiverTakerFeeRequest) GetDenom0() string {
+	if m != nil {
+		return m.Denom0
+	}
+	return ""
+}
+
+func (m *ReceiverTakerFeeRequest) GetDenom1() string {
+	if m != nil {
+		return m.Denom1
+	}
+	return ""
+}
+
+func (m *ReceiverTakerFeeRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// ReceiverTakerFeeReply is the reply of SQSReceiverQuery.TakerFee.
+type ReceiverTakerFeeReply struct {
+	// height is the height of the state.
+	Height   uint64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	TakerFee *TakerFee `protobuf:"bytes,2,opt,name=taker_fee,json=takerFee,proto3" json:"taker_fee,omitempty"`
+	// is_default is true if the taker fee was not pushed and the default is returned.
+	IsDefault bool `protobuf:"varint,3,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+}
+
+func (m *ReceiverTakerFeeReply) Reset()         { *m = ReceiverTakerFeeReply{} }
+func (m *ReceiverTakerFeeReply) String() string { return proto.CompactTextString(m) }
+func (*ReceiverTakerFeeReply) ProtoMessage()    {}
+func (*ReceiverTakerFeeReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1fc800754937f999, []int{23}
+}
+func (m *ReceiverTakerFeeReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReceiverTakerFeeReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReceiverTakerFeeReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReceiverTakerFeeReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiverTakerFeeReply.Merge(m, src)
+}
+func (m *ReceiverTakerFeeReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReceiverTakerFeeReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiverTakerFeeReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiverTakerFeeReply proto.InternalMessageInfo
+
+func (m *ReceiverTakerFeeReply) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReceiverTakerFeeReply) GetTakerFee() *TakerFee {
+	if m != nil {
+		return m.TakerFee
+	}
+	return nil
+}
+
+func (m *ReceiverTakerFeeReply) GetIsDefault() bool {
+	if m != nil {
+		return m.IsDefault
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*PoolData)(nil), "osmosis.ingest.v1beta1.PoolData")
+	proto.RegisterType((*ProcessBlockRequest)(nil), "osmosis.ingest.v1beta1.ProcessBlockRequest")
+	proto.RegisterType((*ProcessBlockReply)(nil), "osmosis.ingest.v1beta1.ProcessBlockReply")
+	proto.RegisterType((*Coin)(nil), "osmosis.ingest.v1beta1.Coin")
+	proto.RegisterType((*SQSPoolModel)(nil), "osmosis.ingest.v1beta1.SQSPoolModel")
+	proto.RegisterType((*TickLiquidity)(nil), "osmosis.ingest.v1beta1.TickLiquidity")
+	proto.RegisterType((*TickModel)(nil), "osmosis.ingest.v1beta1.TickModel")
+	proto.RegisterType((*TickDelta)(nil), "osmosis.ingest.v1beta1.TickDelta")
+	proto.RegisterType((*TickDeltaModel)(nil), "osmosis.ingest.v1beta1.TickDeltaModel")
+	proto.RegisterType((*PoolModel)(nil), "osmosis.ingest.v1beta1.PoolModel")
+	proto.RegisterType((*PoolDataRange)(nil), "osmosis.ingest.v1beta1.PoolDataRange")
+	proto.RegisterType((*PoolAPRData)(nil), "osmosis.ingest.v1beta1.PoolAPRData")
+	proto.RegisterType((*PoolFeesData)(nil), "osmosis.ingest.v1beta1.PoolFeesData")
+	proto.RegisterType((*TakerFee)(nil), "osmosis.ingest.v1beta1.TakerFee")
+	proto.RegisterType((*ProcessBlockChunk)(nil), "osmosis.ingest.v1beta1.ProcessBlockChunk")
+	proto.RegisterType((*IngestAdminRequest)(nil), "osmosis.ingest.v1beta1.IngestAdminRequest")
+	proto.RegisterType((*IngestAdminReply)(nil), "osmosis.ingest.v1beta1.IngestAdminReply")
+	proto.RegisterType((*PoolAPRUpdate)(nil), "osmosis.ingest.v1beta1.PoolAPRUpdate")
+	proto.RegisterType((*ReceiverPoolsRequest)(nil), "osmosis.ingest.v1beta1.ReceiverPoolsRequest")
+	proto.RegisterType((*ReceiverPoolsReply)(nil), "osmosis.ingest.v1beta1.ReceiverPoolsReply")
+	proto.RegisterType((*ReceiverPoolRequest)(nil), "osmosis.ingest.v1beta1.ReceiverPoolRequest")
+	proto.RegisterType((*ReceiverPoolReply)(nil), "osmosis.ingest.v1beta1.ReceiverPoolReply")
+	proto.RegisterType((*ReceiverTakerFeeRequest)(nil), "osmosis.ingest.v1beta1.ReceiverTakerFeeRequest")
+	proto.RegisterType((*ReceiverTakerFeeReply)(nil), "osmosis.ingest.v1beta1.ReceiverTakerFeeReply")
+}
+
+func init() {
+	proto.RegisterFile("osmosis/ingest/v1beta1/ingest.proto", fileDescriptor_1fc800754937f999)
+}
+
+var fileDescriptor_1fc800754937f999 = []byte{
+	// 1598 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x18, 0x4d, 0x73, 0xdb, 0xd4,
+	0x36, 0xf2, 0x57, 0xec, 0x63, 0x3b, 0x4d, 0x6e, 0xda, 0xd4, 0x2f, 0xef, 0xbd, 0xbc, 0x44, 0xe9,
+	0x03, 0xf7, 0x2b, 0x69, 0xdd, 0x42, 0x98, 0x29, 0x50, 0xd2, 0xa6, 0x19, 0x3a, 0x43, 0x4b, 0x22,
+	0x07, 0x98, 0x61, 0x23, 0xae, 0xa5, 0x9b, 0x58, 0x13, 0x59, 0x52, 0x74, 0xa5, 0xb4, 0xf9, 0x03,
+	0xb0, 0x62, 0xa6, 0x0b, 0x7e, 0x00, 0x1b, 0x16, 0x0c, 0x3b, 0xfe, 0x04, 0x2c, 0xbb, 0x64, 0xc9,
+	0xb4, 0x0b, 0x7e, 0x00, 0x7f, 0x80, 0xb9, 0xe7, 0x5e, 0xc9, 0x72, 0xd2, 0xd8, 0x4e, 0x99, 0xce,
+	0xb0, 0xd3, 0xf9, 0xfe, 0xbc, 0xe7, 0x1c, 0x1b, 0x96, 0x7d, 0xde, 0xf3, 0xb9, 0xc3, 0x57, 0x1d,
+	0x6f, 0x8f, 0xf1, 0x68, 0xf5, 0xf0, 0x66, 0x87, 0x45, 0xf4, 0xa6, 0x02, 0x57, 0x82, 0xd0, 0x8f,
+	0x7c, 0x32, 0xa7, 0x98, 0x56, 0x14, 0x56, 0x31, 0xe9, 0xdf, 0xe5, 0xa0, 0xbc, 0xe5, 0xfb, 0xee,
+	0x06, 0x8d, 0x28, 0xf9, 0x1f, 0x54, 0xad, 0x2e, 0x75, 0x3c, 0xb3, 0xe7, 0xdb, 0xcc, 0x6d, 0x68,
+	0x8b, 0x5a, 0xb3, 0x66, 0x00, 0xa2, 0x1e, 0x09, 0x0c, 0xf9, 0x37, 0x54, 0xf8, 0x01, 0x57, 0xe4,
+	0x1c, 0x92, 0xcb, 0xfc, 0x80, 0x4b, 0xe2, 0x7f, 0x01, 0x22, 0xc7, 0xda, 0x57, 0xd4, 0x3c, 0x52,
+	0x2b, 0x02, 0x23, 0xc9, 0x4d, 0x98, 0x46, 0xb2, 0xcd, 0xdc, 0x88, 0x2a, 0xa6, 0x02, 0x32, 0x4d,
+	0x09, 0xfc, 0x86, 0x40, 0x4b, 0xce, 0x0f, 0xa1, 0x4c, 0x83, 0xd0, 0xb4, 0x69, 0x44, 0x1b, 0xc5,
+	0x45, 0xad, 0x59, 0x6d, 0x2d, 0xaf, 0xbc, 0xda, 0xfd, 0x15, 0xe1, 0xfa, 0xfa, 0x96, 0x21, 0xbc,
+	0x37, 0x26, 0x69, 0x10, 0x62, 0x18, 0xeb, 0x50, 0xd9, 0x65, 0x8c, 0x4b, 0x05, 0x25, 0x54, 0x70,
+	0x69, 0x98, 0x82, 0x4d, 0xc6, 0x38, 0x6a, 0x28, 0xef, 0xaa, 0x2f, 0xfd, 0xc7, 0x1c, 0xcc, 0x6e,
+	0x85, 0xbe, 0xc5, 0x38, 0xbf, 0xe7, 0xfa, 0xd6, 0xbe, 0xc1, 0x0e, 0x62, 0xc6, 0x23, 0xb2, 0x04,
+	0xb5, 0x8e, 0x80, 0xcd, 0x2e, 0x73, 0xf6, 0xba, 0x11, 0xa6, 0xa8, 0x60, 0x54, 0x11, 0xf7, 0x31,
+	0xa2, 0xc8, 0x25, 0x98, 0x8a, 0xe8, 0x3e, 0x0b, 0x4d, 0xf4, 0xa1, 0x47, 0x03, 0x95, 0xa8, 0x1a,
+	0x62, 0x85, 0xad, 0x47, 0x34, 0x20, 0xef, 0x42, 0x31, 0xf0, 0x7d, 0x97, 0x37, 0xf2, 0x8b, 0xf9,
+	0x66, 0xb5, 0xb5, 0x38, 0xcc, 0x3f, 0xf4, 0x4d, 0xb2, 0x8b, 0x12, 0x75, 0x28, 0x67, 0x89, 0xfd,
+	0x02, 0xda, 0x07, 0x81, 0x52, 0xe6, 0x9b, 0x30, 0x1d, 0xb2, 0x9e, 0x7f, 0xc8, 0x6c, 0x53, 0x48,
+	0x98, 0x8e, 0xcd, 0x1b, 0xc5, 0xc5, 0x7c, 0xb3, 0x60, 0x4c, 0x29, 0xbc, 0x50, 0xf9, 0xd0, 0xe6,
+	0x64, 0x13, 0xaa, 0x22, 0xcd, 0x71, 0x60, 0xd3, 0x88, 0xf1, 0x46, 0x09, 0x1d, 0xf9, 0xff, 0x88,
+	0x4c, 0x7f, 0x86, 0xdc, 0x06, 0xd0, 0x20, 0x94, 0x9f, 0x5c, 0x7f, 0xa6, 0xc1, 0xcc, 0x60, 0xae,
+	0x02, 0xf7, 0x88, 0xdc, 0x82, 0x39, 0x2c, 0x37, 0xf7, 0x68, 0xc0, 0xbb, 0x7e, 0xd4, 0xf7, 0x46,
+	0x43, 0x6f, 0x66, 0x05, 0xb5, 0xad, 0x88, 0x89, 0x4b, 0x2b, 0x30, 0xeb, 0x52, 0x1e, 0x99, 0x34,
+	0x08, 0x5c, 0x87, 0xd9, 0x49, 0x94, 0x39, 0x8c, 0x72, 0x46, 0x90, 0xd6, 0x25, 0x45, 0x05, 0x3b,
+	0x07, 0xa5, 0x90, 0xf1, 0x23, 0xcf, 0xc2, 0x76, 0x2b, 0x1b, 0x0a, 0xd2, 0x6f, 0x43, 0xe1, 0xbe,
+	0xef, 0x78, 0xe4, 0x3c, 0x14, 0x6d, 0xe6, 0xf9, 0x3d, 0xac, 0x53, 0xc5, 0x90, 0x80, 0x90, 0xa2,
+	0x3d, 0x3f, 0xf6, 0xa4, 0xe2, 0x8a, 0xa1, 0x20, 0xfd, 0x87, 0x1c, 0xd4, 0xda, 0xdb, 0x6d, 0xe1,
+	0x8c, 0x6c, 0xc4, 0x6b, 0x40, 0xd0, 0x6b, 0xd7, 0x39, 0x88, 0x1d, 0xdb, 0x89, 0x8e, 0x4c, 0x8b,
+	0x06, 0x4a, 0xd7, 0xb4, 0xa0, 0x7c, 0x92, 0x10, 0xee, 0xd3, 0x80, 0xac, 0x41, 0xe3, 0x24, 0xb7,
+	0xc9, 0xc2, 0xd0, 0x0f, 0x95, 0xa1, 0x0b, 0xc7, 0x65, 0x1e, 0x08, 0x22, 0x79, 0x0f, 0xca, 0x1d,
+	0xea, 0x52, 0xcf, 0x62, 0x49, 0x3b, 0xfc, 0xe7, 0xb4, 0x2a, 0x88, 0xa8, 0x8c, 0x94, 0x5b, 0x74,
+	0x03, 0x9a, 0xc4, 0xb8, 0x78, 0xa3, 0xb0, 0x98, 0x6f, 0x56, 0x0c, 0x10, 0xa8, 0x0d, 0xc4, 0x90,
+	0x65, 0xa8, 0xf3, 0x20, 0x64, 0xd4, 0x36, 0x77, 0xa9, 0x15, 0xf9, 0x21, 0xbe, 0xa7, 0x8a, 0x51,
+	0x93, 0xc8, 0x4d, 0xc4, 0x89, 0xac, 0x5b, 0x3e, 0xef, 0x3d, 0xa1, 0xbc, 0x27, 0xab, 0x24, 0x1f,
+	0x67, 0x09, 0xdb, 0x76, 0x26, 0x21, 0xa5, 0x69, 0xd1, 0x9f, 0x42, 0x7d, 0xc7, 0xb1, 0xf6, 0xd3,
+	0x40, 0xc4, 0xcb, 0x77, 0xfd, 0x27, 0x2c, 0x34, 0x45, 0x4d, 0x31, 0x3f, 0x79, 0xa3, 0x82, 0x18,
+	0xc1, 0x27, 0xc8, 0x71, 0x10, 0x24, 0xe4, 0x9c, 0x24, 0x23, 0x06, 0xc9, 0x97, 0x61, 0xba, 0x9f,
+	0x32, 0x55, 0x98, 0x3c, 0xba, 0x79, 0x2e, 0xc5, 0xaf, 0xcb, 0x0a, 0x7d, 0xaf, 0x41, 0x65, 0x27,
+	0x9d, 0x28, 0x77, 0xa0, 0x28, 0x34, 0xca, 0x8e, 0x1a, 0xd2, 0xba, 0x03, 0xce, 0x1a, 0x52, 0x46,
+	0xd4, 0xd6, 0x8a, 0xc3, 0x90, 0x79, 0x11, 0xba, 0x65, 0x3a, 0x9e, 0xcd, 0x9e, 0x2a, 0xe7, 0xa6,
+	0x15, 0x45, 0x08, 0x3e, 0x14, 0x78, 0xf1, 0xaa, 0xba, 0x94, 0x9b, 0x9e, 0xdf, 0xaf, 0xae, 0x6a,
+	0xb9, 0xa9, 0x2e, 0xe5, 0x8f, 0xfd, 0x54, 0xbd, 0xfe, 0xa9, 0xf4, 0x10, 0xc7, 0x59, 0x3a, 0x12,
+	0xa5, 0x72, 0x95, 0x98, 0x28, 0xd5, 0xba, 0x0c, 0xf5, 0x7e, 0xe4, 0x1e, 0x4b, 0xfa, 0xb1, 0x96,
+	0x22, 0x1f, 0xb3, 0x48, 0x77, 0x61, 0x6a, 0x67, 0x70, 0x3e, 0xae, 0x0d, 0xc6, 0xbd, 0x34, 0x2c,
+	0x6e, 0x14, 0x4b, 0x62, 0x5e, 0x82, 0x5a, 0x36, 0x66, 0x15, 0x6d, 0x35, 0x13, 0xad, 0xfe, 0x75,
+	0x1e, 0x2a, 0xfd, 0x07, 0x30, 0x72, 0x21, 0xac, 0x1f, 0x5f, 0x08, 0x43, 0x46, 0x6d, 0xf6, 0x69,
+	0x65, 0xd6, 0xc6, 0x47, 0x27, 0xd6, 0xc6, 0x88, 0x90, 0xa4, 0x82, 0xcc, 0x66, 0xd9, 0x3a, 0x65,
+	0xb3, 0x54, 0x5b, 0x6f, 0x8d, 0x4c, 0x8d, 0x54, 0xf6, 0x0f, 0xdc, 0x40, 0x77, 0xa0, 0x9e, 0xce,
+	0x7e, 0xea, 0xed, 0x31, 0x31, 0xcb, 0xf0, 0x49, 0x61, 0x15, 0x34, 0x43, 0x02, 0x02, 0x8b, 0x2f,
+	0x09, 0x93, 0xaf, 0x19, 0x12, 0xd0, 0x9f, 0xe5, 0xa1, 0x9a, 0x71, 0x8c, 0xdc, 0x83, 0x0a, 0x7f,
+	0x42, 0x03, 0x5c, 0x49, 0x28, 0x3f, 0x62, 0xd0, 0xa7, 0x56, 0x8d, 0xb2, 0x90, 0x13, 0xee, 0x91,
+	0x07, 0x00, 0x3c, 0x0e, 0x58, 0xb8, 0xeb, 0xc6, 0x8e, 0xdd, 0xc8, 0x9d, 0x45, 0x49, 0x46, 0x90,
+	0xdc, 0x85, 0x49, 0x25, 0xd3, 0xc8, 0x9f, 0x45, 0x47, 0x22, 0x25, 0x5e, 0x7d, 0xc7, 0xf7, 0x79,
+	0xd4, 0x28, 0x9c, 0x45, 0x5c, 0xca, 0x88, 0x44, 0x44, 0x7e, 0x44, 0x5d, 0x93, 0x06, 0x61, 0xa3,
+	0x78, 0x16, 0x05, 0x65, 0x94, 0x5b, 0x0f, 0x42, 0xf2, 0x2f, 0x28, 0x3b, 0xdc, 0xe4, 0x11, 0x75,
+	0x19, 0xd6, 0xb6, 0x6c, 0x4c, 0x3a, 0xbc, 0x2d, 0x40, 0x45, 0x92, 0x23, 0x7f, 0x32, 0x21, 0xe1,
+	0x90, 0xd7, 0xff, 0xd4, 0xa0, 0x96, 0x2d, 0xb5, 0x98, 0x0d, 0x87, 0xbe, 0x1b, 0xf7, 0x98, 0xd9,
+	0xba, 0xdd, 0x55, 0x45, 0xad, 0x48, 0x4c, 0xeb, 0x76, 0x57, 0x9c, 0x5a, 0x8a, 0xbc, 0x66, 0xab,
+	0xe2, 0x96, 0x25, 0x62, 0xcd, 0x16, 0x37, 0x06, 0xf6, 0x17, 0x0f, 0xc4, 0x5b, 0x16, 0xf2, 0x79,
+	0xe4, 0xa8, 0x09, 0x6c, 0x5b, 0x20, 0x85, 0x0a, 0x1d, 0xea, 0x19, 0xae, 0x35, 0x1b, 0x33, 0xa6,
+	0x19, 0xd5, 0x94, 0x69, 0xcd, 0x26, 0x6f, 0xc3, 0x39, 0xe4, 0x09, 0x58, 0x68, 0x31, 0x2f, 0xa2,
+	0x7b, 0x4c, 0xad, 0x08, 0x34, 0xb0, 0x95, 0x62, 0x5f, 0x33, 0xea, 0x2f, 0xa0, 0xbc, 0xa3, 0xce,
+	0x1e, 0xb1, 0x76, 0x71, 0x4f, 0xdd, 0x50, 0x1b, 0x54, 0x41, 0x29, 0xfe, 0x66, 0xb2, 0x8e, 0x25,
+	0x24, 0x32, 0x90, 0x1e, 0x52, 0x6a, 0x21, 0x94, 0x93, 0x1b, 0x4a, 0xff, 0x26, 0x3f, 0x78, 0x74,
+	0xdc, 0xef, 0xc6, 0xde, 0xfe, 0x38, 0xe7, 0xd9, 0x5d, 0x80, 0x54, 0x2b, 0x6f, 0xe4, 0x86, 0x5f,
+	0x5f, 0x89, 0xef, 0x46, 0x25, 0x31, 0xcc, 0xc5, 0xf4, 0xcd, 0x5e, 0x6e, 0x4b, 0xc3, 0xda, 0x47,
+	0x4e, 0x97, 0xfe, 0xe9, 0x66, 0x09, 0x2f, 0xd5, 0x36, 0x10, 0xc5, 0xa8, 0x8b, 0x61, 0x1a, 0x7b,
+	0x6a, 0x1d, 0x2c, 0x41, 0x4d, 0x36, 0x27, 0xe2, 0x38, 0x16, 0xa2, 0x6e, 0x54, 0x11, 0x87, 0xf1,
+	0x9d, 0x38, 0xff, 0x4a, 0x63, 0x9d, 0x7f, 0x93, 0xe3, 0x9c, 0x7f, 0xe5, 0xd7, 0x3d, 0xff, 0xae,
+	0x01, 0x79, 0x88, 0xbc, 0xeb, 0x76, 0xcf, 0xf1, 0x92, 0x43, 0x79, 0x0e, 0x4a, 0x11, 0x0d, 0xf7,
+	0x58, 0x94, 0x14, 0x5b, 0x42, 0x7a, 0x13, 0xa6, 0x07, 0xb8, 0xc5, 0xa9, 0x78, 0x1e, 0x8a, 0xdc,
+	0xf1, 0xd4, 0x3e, 0xab, 0x18, 0x12, 0xd0, 0x7f, 0xd2, 0xa0, 0x3e, 0x60, 0x95, 0x5c, 0x84, 0x49,
+	0x15, 0x93, 0x2a, 0x6c, 0x29, 0xc0, 0x58, 0x06, 0xc6, 0x75, 0xee, 0xef, 0x8e, 0xeb, 0xfc, 0x6b,
+	0x8d, 0xeb, 0x15, 0x38, 0x6f, 0x30, 0x8b, 0x39, 0x87, 0x2c, 0x14, 0x1c, 0x3c, 0x93, 0x87, 0x81,
+	0x5e, 0x54, 0x90, 0xce, 0x80, 0x1c, 0xe3, 0x17, 0x99, 0x38, 0x85, 0xbb, 0xdf, 0x73, 0xb9, 0xb3,
+	0xf5, 0x9c, 0xbe, 0x09, 0xb3, 0x59, 0x33, 0x89, 0x57, 0xa7, 0x66, 0xb2, 0xef, 0x40, 0x6e, 0xc0,
+	0xdd, 0x0e, 0xcc, 0x0c, 0xea, 0x19, 0xe6, 0xed, 0x3b, 0x50, 0x10, 0xea, 0x54, 0x29, 0xc6, 0x70,
+	0x16, 0xd9, 0x75, 0x0a, 0x17, 0x13, 0x1b, 0xe9, 0xbb, 0xeb, 0x67, 0xf1, 0x4c, 0xa3, 0xa3, 0xef,
+	0x59, 0x7e, 0x20, 0x8c, 0x6f, 0x35, 0xb8, 0x70, 0xd2, 0xc6, 0xb0, 0x58, 0x3e, 0xc8, 0x0e, 0x21,
+	0x19, 0xd0, 0xe8, 0x69, 0x91, 0x8e, 0x29, 0x31, 0xe4, 0x1d, 0x6e, 0xda, 0x6c, 0x97, 0xc6, 0x6e,
+	0xa4, 0x2e, 0xc6, 0x8a, 0xc3, 0x37, 0x24, 0xa2, 0xf5, 0x87, 0x06, 0xd5, 0xf6, 0x76, 0x5b, 0xbe,
+	0x08, 0x16, 0x92, 0x2e, 0xd4, 0xb2, 0x43, 0x8d, 0x5c, 0x3d, 0x35, 0x77, 0x27, 0x7f, 0x9b, 0xce,
+	0x5f, 0x1e, 0x8f, 0x39, 0x70, 0x8f, 0xf4, 0x09, 0xe2, 0x01, 0xc9, 0xa2, 0xdb, 0x51, 0xc8, 0x68,
+	0x8f, 0x8c, 0xa5, 0x02, 0x47, 0xd1, 0x99, 0xac, 0x35, 0xb5, 0xd6, 0xcf, 0x39, 0xa8, 0x66, 0x1e,
+	0x3e, 0xf9, 0x0a, 0x4a, 0x06, 0xfe, 0x56, 0x23, 0x57, 0x4e, 0x53, 0x74, 0x72, 0xaa, 0xcc, 0x37,
+	0xc7, 0xe2, 0x95, 0x11, 0x9a, 0x50, 0xdc, 0xa2, 0x31, 0x67, 0x6f, 0xcc, 0x80, 0x0c, 0x21, 0xee,
+	0xbd, 0x31, 0x0b, 0xad, 0x5f, 0x72, 0x30, 0xdd, 0xde, 0x6e, 0x27, 0x1d, 0xbb, 0x1d, 0xb3, 0xf0,
+	0x88, 0x58, 0x50, 0xc4, 0x89, 0x41, 0xae, 0x9d, 0xa6, 0xe9, 0x55, 0x83, 0x68, 0xfe, 0xca, 0x98,
+	0xdc, 0x49, 0x6c, 0x05, 0x01, 0x93, 0xab, 0xe3, 0x48, 0x8d, 0x6c, 0xc0, 0x13, 0xa3, 0x43, 0x9f,
+	0x20, 0x6e, 0xe6, 0x32, 0x58, 0x1d, 0x25, 0x78, 0x6c, 0x1e, 0xcc, 0x5f, 0x1f, 0x5f, 0x00, 0xad,
+	0xdd, 0xfb, 0xfc, 0xd7, 0x17, 0x0b, 0xda, 0xf3, 0x17, 0x0b, 0xda, 0xef, 0x2f, 0x16, 0xb4, 0x67,
+	0x2f, 0x17, 0x26, 0x9e, 0xbf, 0x5c, 0x98, 0xf8, 0xed, 0xe5, 0xc2, 0xc4, 0x97, 0xef, 0xef, 0x39,
+	0x51, 0x37, 0xee, 0xac, 0x58, 0x7e, 0x6f, 0x55, 0x29, 0xbd, 0xee, 0xd2, 0x0e, 0x4f, 0x80, 0xd5,
+	0xc3, 0x5b, 0x37, 0x92, 0x7f, 0xd6, 0xa2, 0xa3, 0x80, 0xf1, 0x55, 0xfc, 0x43, 0x4d, 0x7e, 0x77,
+	0x4a, 0x08, 0xdc, 0xfa, 0x6b, 0x00, 0x29, 0x0c, 0xaf, 0xcb, 0x84, 0x13, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SQSIngesterClient is the client API for SQSIngester service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SQSIngesterClient interface {
+	// ProcessBlock processes a block from the Osmosis node.
+	ProcessBlock(ctx context.Context, in *ProcessBlockRequest, opts ...grpc.CallOption) (*ProcessBlockReply, error)
+	// ProcessBlockStream processes a block from the Osmosis node that is sent
+	// in chunks of protobuf-encoded pool models. The reply is sent once all
+	// chunks are received.
+	ProcessBlockStream(ctx context.Context, opts ...grpc.CallOption) (SQSIngester_ProcessBlockStreamClient, error)
+}
+
+type sQSIngesterClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewSQSIngesterClient(cc grpc1.ClientConn) SQSIngesterClient {
+	return &sQSIngesterClient{cc}
+}
+
+func (c *sQSIngesterClient) ProcessBlock(ctx context.Context, in *ProcessBlockRequest, opts ...grpc.CallOption) (*ProcessBlockReply, error) {
+	out := new(ProcessBlockReply)
+	err := c.cc.Invoke(ctx, "/osmosis.ingest.v1beta1.SQSIngester/ProcessBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sQSIngesterClient) ProcessBlockStream(ctx context.Context, opts ...grpc.CallOption) (SQSIngester_ProcessBlockStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SQSIngester_serviceDesc.Streams[0], "/osmosis.ingest.v1beta1.SQSIngester/ProcessBlockStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sQSIngesterProcessBlockStreamClient{stream}
+	return x, nil
+}
+
+type SQSIngester_ProcessBlockStreamClient interface {
+	Send(*ProcessBlockChunk) error
+	CloseAndRecv() (*ProcessBlockReply, error)
+	grpc.ClientStream
+}
+
+type sQSIngesterProcessBlockStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *sQSIngesterProcessBlockStreamClient) Send(m *ProcessBlockChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *sQSIngesterProcessBlockStreamClient) CloseAndRecv() (*ProcessBlockReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ProcessBlockReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SQSIngesterServer is the server API for SQSIngester service.
+type SQSIngesterServer interface {
+	// ProcessBlock processes a block from the Osmosis node.
+	ProcessBlock(context.Context, *ProcessBlockRequest) (*ProcessBlockReply, error)
+	// ProcessBlockStream processes a block from the Osmosis node that is sent
+	// in chunks of protobuf-encoded pool models. The reply is sent once all
+	// chunks are received.
+	ProcessBlockStream(SQSIngester_ProcessBlockStreamServer) error
+}
+
+// UnimplementedSQSIngesterServer can be embedded to have forward compatible implementations.
+type UnimplementedSQSIngesterServer struct {
+}
+
+func (*UnimplementedSQSIngesterServer) ProcessBlock(ctx context.Context, req *ProcessBlockRequest) (*ProcessBlockReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessBlock not implemented")
+}
+func (*UnimplementedSQSIngesterServer) ProcessBlockStream(srv SQSIngester_ProcessBlockStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ProcessBlockStream not implemented")
+}
+
+func RegisterSQSIngesterServer(s grpc1.Server, srv SQSIngesterServer) {
+	s.RegisterService(&_SQSIngester_serviceDesc, srv)
+}
+
+func _SQSIngester_ProcessBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQSIngesterServer).ProcessBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/osmosis.ingest.v1beta1.SQSIngester/ProcessBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQSIngesterServer).ProcessBlock(ctx, req.(*ProcessBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SQSIngester_ProcessBlockStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SQSIngesterServer).ProcessBlockStream(&sQSIngesterProcessBlockStreamServer{stream})
+}
+
+type SQSIngester_ProcessBlockStreamServer interface {
+	SendAndClose(*ProcessBlockReply) error
+	Recv() (*ProcessBlockChunk, error)
+	grpc.ServerStream
+}
+
+type sQSIngesterProcessBlockStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *sQSIngesterProcessBlockStreamServer) SendAndClose(m *ProcessBlockReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *sQSIngesterProcessBlockStreamServer) Recv() (*ProcessBlockChunk, error) {
+	m := new(ProcessBlockChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var SQSIngester_serviceDesc = _SQSIngester_serviceDesc
+var _SQSIngester_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "osmosis.ingest.v1beta1.SQSIngester",
+	HandlerType: (*SQSIngesterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProcessBlock",
+			Handler:    _SQSIngester_ProcessBlock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ProcessBlockStream",
+			Handler:       _SQSIngester_ProcessBlockStream_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "osmosis/ingest/v1beta1/ingest.proto",
+}
+
+// IngestAdminClient is the client API for IngestAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IngestAdminClient interface {
+	// Resync requests pushing all data in the next block.
+	Resync(ctx context.Context, in *IngestAdminRequest, opts ...grpc.CallOption) (*IngestAdminReply, error)
+	// Pause pauses pushing. Blocks are skipped until resumed.
+	Pause(ctx context.Context, in *IngestAdminRequest, opts ...grpc.CallOption) (*IngestAdminReply, error)
+	// Resume resumes pushing. All data is pushed in the next block.
+	Resume(ctx context.Context, in *IngestAdminRequest, opts ...grpc.CallOption) (*IngestAdminReply, error)
+}
+
+type ingestAdminClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewIngestAdminClient(cc grpc1.ClientConn) IngestAdminClient {
+	return &ingestAdminClient{cc}
+}
+
+func (c *ingestAdminClient) Resync(ctx context.Context, in *IngestAdminRequest, opts ...grpc.CallOption) (*IngestAdminReply, error) {
+	out := new(IngestAdminReply)
+	err := c.cc.Invoke(ctx, "/osmosis.ingest.v1beta1.IngestAdmin/Resync", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingestAdminClient) Pause(ctx context.Context, in *IngestAdminRequest, opts ...grpc.CallOption) (*IngestAdminReply, error) {
+	out := new(IngestAdminReply)
+	err := c.cc.Invoke(ctx, "/osmosis.ingest.v1beta1.IngestAdmin/Pause", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingestAdminClient) Resume(ctx context.Context, in *IngestAdminRequest, opts ...grpc.CallOption) (*IngestAdminReply, error) {
+	out := new(IngestAdminReply)
+	err := c.cc.Invoke(ctx, "/osmosis.ingest.v1beta1.IngestAdmin/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IngestAdminServer is the server API for IngestAdmin service.
+type IngestAdminServer interface {
+	// Resync requests pushing all data in the next block.
+	Resync(context.Context, *IngestAdminRequest) (*IngestAdminReply, error)
+	// Pause pauses pushing. Blocks are skipped until resumed.
+	Pause(context.Context, *IngestAdminRequest) (*IngestAdminReply, error)
+	// Resume resumes pushing. All data is pushed in the next block.
+	Resume(context.Context, *IngestAdminRequest) (*IngestAdminReply, error)
+}
+
+// UnimplementedIngestAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedIngestAdminServer struct {
+}
+
+func (*UnimplementedIngestAdminServer) Resync(ctx context.Context, req *IngestAdminRequest) (*IngestAdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resync not implemented")
+}
+func (*UnimplementedIngestAdminServer) Pause(ctx context.Context, req *IngestAdminRequest) (*IngestAdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (*UnimplementedIngestAdminServer) Resume(ctx context.Context, req *IngestAdminRequest) (*IngestAdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+
+func RegisterIngestAdminServer(s grpc1.Server, srv IngestAdminServer) {
+	s.RegisterService(&_IngestAdmin_serviceDesc, srv)
+}
+
+func _IngestAdmin_Resync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestAdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngestAdminServer).Resync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/osmosis.ingest.v1beta1.IngestAdmin/Resync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngestAdminServer).Resync(ctx, req.(*IngestAdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngestAdmin_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestAdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngestAdminServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/osmosis.ingest.v1beta1.IngestAdmin/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngestAdminServer).Pause(ctx, req.(*IngestAdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngestAdmin_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestAdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngestAdminServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/osmosis.ingest.v1beta1.IngestAdmin/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngestAdminServer).Resume(ctx, req.(*IngestAdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var IngestAdmin_serviceDesc = _IngestAdmin_serviceDesc
+var _IngestAdmin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "osmosis.ingest.v1beta1.IngestAdmin",
+	HandlerType: (*IngestAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Resync",
+			Handler:    _IngestAdmin_Resync_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _IngestAdmin_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _IngestAdmin_Resume_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "osmosis/ingest/v1beta1/ingest.proto",
+}
+
+// SQSReceiverQueryClient is the client API for SQSReceiverQuery service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SQSReceiverQueryClient interface {
+	// Pools returns all pools sorted by ID.
+	Pools(ctx context.Context, in *ReceiverPoolsRequest, opts ...grpc.CallOption) (*ReceiverPoolsReply, error)
+	// Pool returns a pool.
+	Pool(ctx context.Context, in *ReceiverPoolRequest, opts ...grpc.CallOption) (*ReceiverPoolReply, error)
+	// TakerFee returns the taker fee for a denom pair.
+	TakerFee(ctx context.Context, in *ReceiverTakerFeeRequest, opts ...grpc.CallOption) (*ReceiverTakerFeeReply, error)
+}
+
+type sQSReceiverQueryClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewSQSReceiverQueryClient(cc grpc1.ClientConn) SQSReceiverQueryClient {
+	return &sQSReceiverQueryClient{cc}
+}
+
+func (c *sQSReceiverQueryClient) Pools(ctx context.Context, in *ReceiverPoolsRequest, opts ...grpc.CallOption) (*ReceiverPoolsReply, error) {
+	out := new(ReceiverPoolsReply)
+	err := c.cc.Invoke(ctx, "/osmosis.ingest.v1beta1.SQSReceiverQuery/Pools", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sQSReceiverQueryClient) Pool(ctx context.Context, in *ReceiverPoolRequest, opts ...grpc.CallOption) (*ReceiverPoolReply, error) {
+	out := new(ReceiverPoolReply)
+	err := c.cc.Invoke(ctx, "/osmosis.ingest.v1beta1.SQSReceiverQuery/Pool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sQSReceiverQueryClient) TakerFee(ctx context.Context, in *ReceiverTakerFeeRequest, opts ...grpc.CallOption) (*ReceiverTakerFeeReply, error) {
+	out := new(ReceiverTakerFeeReply)
+	err := c.cc.Invoke(ctx, "/osmosis.ingest.v1beta1.SQSReceiverQuery/TakerFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SQSReceiverQueryServer is the server API for SQSReceiverQuery service.
+type SQSReceiverQueryServer interface {
+	// Pools returns all pools sorted by ID.
+	Pools(context.Context, *ReceiverPoolsRequest) (*ReceiverPoolsReply, error)
+	// Pool returns a pool.
+	Pool(context.Context, *ReceiverPoolRequest) (*ReceiverPoolReply, error)
+	// TakerFee returns the taker fee for a denom pair.
+	TakerFee(context.Context, *ReceiverTakerFeeRequest) (*ReceiverTakerFeeReply, error)
+}
+
+// UnimplementedSQSReceiverQueryServer can be embedded to have forward compatible implementations.
+type UnimplementedSQSReceiverQueryServer struct {
+}
+
+func (*UnimplementedSQSReceiverQueryServer) Pools(ctx context.Context, req *ReceiverPoolsRequest) (*ReceiverPoolsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pools not implemented")
+}
+func (*UnimplementedSQSReceiverQueryServer) Pool(ctx context.Context, req *ReceiverPoolRequest) (*ReceiverPoolReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pool not implemented")
+}
+func (*UnimplementedSQSReceiverQueryServer) TakerFee(ctx context.Context, req *ReceiverTakerFeeRequest) (*ReceiverTakerFeeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakerFee not implemented")
+}
+
+func RegisterSQSReceiverQueryServer(s grpc1.Server, srv SQSReceiverQueryServer) {
+	s.RegisterService(&_SQSReceiverQuery_serviceDesc, srv)
+}
+
+func _SQSReceiverQuery_Pools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiverPoolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQSReceiverQueryServer).Pools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/osmosis.ingest.v1beta1.SQSReceiverQuery/Pools",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQSReceiverQueryServer).Pools(ctx, req.(*ReceiverPoolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SQSReceiverQuery_Pool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiverPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQSReceiverQueryServer).Pool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/osmosis.ingest.v1beta1.SQSReceiverQuery/Pool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQSReceiverQueryServer).Pool(ctx, req.(*ReceiverPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SQSReceiverQuery_TakerFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiverTakerFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQSReceiverQueryServer).TakerFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/osmosis.ingest.v1beta1.SQSReceiverQuery/TakerFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQSReceiverQueryServer).TakerFee(ctx, req.(*ReceiverTakerFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var SQSReceiverQuery_serviceDesc = _SQSReceiverQuery_serviceDesc
+var _SQSReceiverQuery_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "osmosis.ingest.v1beta1.SQSReceiverQuery",
+	HandlerType: (*SQSReceiverQueryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Pools",
+			Handler:    _SQSReceiverQuery_Pools_Handler,
+		},
+		{
+			MethodName: "Pool",
+			Handler:    _SQSReceiverQuery_Pool_Handler,
+		},
+		{
+			MethodName: "TakerFee",
+			Handler:    _SQSReceiverQuery_TakerFee_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "osmosis/ingest/v1beta1/ingest.proto",
+}
+
+func (m *PoolData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoolData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoolData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.FeesData != nil {
+		{
+			size, err := m.FeesData.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.AprData != nil {
+		{
+			size, err := m.AprData.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.TickDeltaModel) > 0 {
+		i -= len(m.TickDeltaModel)
+		copy(dAtA[i:], m.TickDeltaModel)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.TickDeltaModel)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.TickModel) > 0 {
+		i -= len(m.TickModel)
+		copy(dAtA[i:], m.TickModel)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.TickModel)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.SqsModel) > 0 {
+		i -= len(m.SqsModel)
+		copy(dAtA[i:], m.SqsModel)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.SqsModel)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ChainModel) > 0 {
+		i -= len(m.ChainModel)
+		copy(dAtA[i:], m.ChainModel)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.ChainModel)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProcessBlockRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProcessBlockRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProcessBlockRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AprUpdates) > 0 {
+		for iNdEx := len(m.AprUpdates) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AprUpdates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngest(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.RemovedPoolIds) > 0 {
+		dAtA4 := make([]byte, len(m.RemovedPoolIds)*10)
+		var j3 int
+		for _, num := range m.RemovedPoolIds {
+			for num >= 1<<7 {
+				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA4[j3] = uint8(num)
+			j3++
+		}
+		i -= j3
+		copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintIngest(dAtA, i, uint64(j3))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.BaseHeight != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.BaseHeight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Pools) > 0 {
+		for iNdEx := len(m.Pools) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Pools[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngest(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.TakerFeesMap) > 0 {
+		i -= len(m.TakerFeesMap)
+		copy(dAtA[i:], m.TakerFeesMap)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.TakerFeesMap)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.BlockHeight != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProcessBlockReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProcessBlockReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProcessBlockReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Resync {
+		i--
+		if m.Resync {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.LastAppliedHeight != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.LastAppliedHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.TickSnapshotPoolIds) > 0 {
+		dAtA6 := make([]byte, len(m.TickSnapshotPoolIds)*10)
+		var j5 int
+		for _, num := range m.TickSnapshotPoolIds {
+			for num >= 1<<7 {
+				dAtA6[j5] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j5++
+			}
+			dAtA6[j5] = uint8(num)
+			j5++
+		}
+		i -= j5
+		copy(dAtA[i:], dAtA6[:j5])
+		i = encodeVarintIngest(dAtA, i, uint64(j5))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Coin) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Coin) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Coin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Amount) > 0 {
+		i -= len(m.Amount)
+		copy(dAtA[i:], m.Amount)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.Amount)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Denom) > 0 {
+		i -= len(m.Denom)
+		copy(dAtA[i:], m.Denom)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.Denom)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SQSPoolModel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SQSPoolModel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SQSPoolModel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.CosmwasmPoolModel) > 0 {
+		i -= len(m.CosmwasmPoolModel)
+		copy(dAtA[i:], m.CosmwasmPoolModel)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.CosmwasmPoolModel)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.SpreadFactor) > 0 {
+		i -= len(m.SpreadFactor)
+		copy(dAtA[i:], m.SpreadFactor)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.SpreadFactor)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.PoolDenoms) > 0 {
+		for iNdEx := len(m.PoolDenoms) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PoolDenoms[iNdEx])
+			copy(dAtA[i:], m.PoolDenoms[iNdEx])
+			i = encodeVarintIngest(dAtA, i, uint64(len(m.PoolDenoms[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Balances) > 0 {
+		for iNdEx := len(m.Balances) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Balances[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngest(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.PoolLiquidityCapError) > 0 {
+		i -= len(m.PoolLiquidityCapError)
+		copy(dAtA[i:], m.PoolLiquidityCapError)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.PoolLiquidityCapError)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.PoolLiquidityCap) > 0 {
+		i -= len(m.PoolLiquidityCap)
+		copy(dAtA[i:], m.PoolLiquidityCap)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.PoolLiquidityCap)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TickLiquidity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TickLiquidity) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TickLiquidity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LiquidityAmount) > 0 {
+		i -= len(m.LiquidityAmount)
+		copy(dAtA[i:], m.LiquidityAmount)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.LiquidityAmount)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.UpperTick != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.UpperTick))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.LowerTick != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.LowerTick))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TickModel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TickModel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TickModel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.HasNoLiquidity {
+		i--
+		if m.HasNoLiquidity {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.CurrentTickIndex != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.CurrentTickIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Ticks) > 0 {
+		for iNdEx := len(m.Ticks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ticks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngest(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TickDelta) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TickDelta) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TickDelta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LiquidityNet) > 0 {
+		i -= len(m.LiquidityNet)
+		copy(dAtA[i:], m.LiquidityNet)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.LiquidityNet)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.TickIndex != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.TickIndex))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TickDeltaModel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TickDeltaModel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TickDeltaModel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CurrentTick != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.CurrentTick))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Ticks) > 0 {
+		for iNdEx := len(m.Ticks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ticks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngest(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PoolModel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoolModel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoolModel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.FeesData != nil {
+		{
+			size, err := m.FeesData.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.AprData != nil {
+		{
+			size, err := m.AprData.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.TickDeltaModel != nil {
+		{
+			size, err := m.TickDeltaModel.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.TickModel != nil {
+		{
+			size, err := m.TickModel.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.SqsModel != nil {
+		{
+			size, err := m.SqsModel.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ChainModel) > 0 {
+		i -= len(m.ChainModel)
+		copy(dAtA[i:], m.ChainModel)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.ChainModel)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PoolDataRange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoolDataRange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoolDataRange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Upper != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Upper))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.Lower != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Lower))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PoolAPRData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoolAPRData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoolAPRData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.IsError {
+		i--
+		if m.IsError {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.IsStale {
+		i--
+		if m.IsStale {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.TotalApr != nil {
+		{
+			size, err := m.TotalApr.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Boost != nil {
+		{
+			size, err := m.Boost.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Osmosis != nil {
+		{
+			size, err := m.Osmosis.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Superfluid != nil {
+		{
+			size, err := m.Superfluid.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.SwapFees != nil {
+		{
+			size, err := m.SwapFees.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PoolFeesData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoolFeesData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoolFeesData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.IsError {
+		i--
+		if m.IsError {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.IsStale {
+		i--
+		if m.IsStale {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.FeesPercentage) > 0 {
+		i -= len(m.FeesPercentage)
+		copy(dAtA[i:], m.FeesPercentage)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.FeesPercentage)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.FeesSpent_7D != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.FeesSpent_7D))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.FeesSpent_24H != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.FeesSpent_24H))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.Volume_7D != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Volume_7D))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.Volume_24H != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Volume_24H))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TakerFee) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TakerFee) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TakerFee) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TakerFee) > 0 {
+		i -= len(m.TakerFee)
+		copy(dAtA[i:], m.TakerFee)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.TakerFee)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Denom1) > 0 {
+		i -= len(m.Denom1)
+		copy(dAtA[i:], m.Denom1)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.Denom1)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Denom0) > 0 {
+		i -= len(m.Denom0)
+		copy(dAtA[i:], m.Denom0)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.Denom0)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProcessBlockChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProcessBlockChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProcessBlockChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AprUpdates) > 0 {
+		for iNdEx := len(m.AprUpdates) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AprUpdates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngest(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.RemovedPoolIds) > 0 {
+		dAtA18 := make([]byte, len(m.RemovedPoolIds)*10)
+		var j17 int
+		for _, num := range m.RemovedPoolIds {
+			for num >= 1<<7 {
+				dAtA18[j17] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j17++
+			}
+			dAtA18[j17] = uint8(num)
+			j17++
+		}
+		i -= j17
+		copy(dAtA[i:], dAtA18[:j17])
+		i = encodeVarintIngest(dAtA, i, uint64(j17))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.BaseHeight != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.BaseHeight))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.TotalChunks != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.TotalChunks))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.ChunkIndex != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.ChunkIndex))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Pools) > 0 {
+		for iNdEx := len(m.Pools) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Pools[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngest(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.TakerFees) > 0 {
+		for iNdEx := len(m.TakerFees) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TakerFees[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngest(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.BlockHeight != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IngestAdminRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IngestAdminRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IngestAdminRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Target) > 0 {
+		i -= len(m.Target)
+		copy(dAtA[i:], m.Target)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.Target)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IngestAdminReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IngestAdminReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IngestAdminReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sinks) > 0 {
+		for iNdEx := len(m.Sinks) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Sinks[iNdEx])
+			copy(dAtA[i:], m.Sinks[iNdEx])
+			i = encodeVarintIngest(dAtA, i, uint64(len(m.Sinks[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PoolAPRUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoolAPRUpdate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoolAPRUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.FeesData != nil {
+		{
+			size, err := m.FeesData.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.AprData != nil {
+		{
+			size, err := m.AprData.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.PoolId != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReceiverPoolsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReceiverPoolsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReceiverPoolsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReceiverPoolsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReceiverPoolsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReceiverPoolsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Pools) > 0 {
+		for iNdEx := len(m.Pools) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Pools[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngest(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Height != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReceiverPoolRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReceiverPoolRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReceiverPoolRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.PoolId != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReceiverPoolReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReceiverPoolReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReceiverPoolReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Pool != nil {
+		{
+			size, err := m.Pool.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Height != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReceiverTakerFeeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReceiverTakerFeeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReceiverTakerFeeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Denom1) > 0 {
+		i -= len(m.Denom1)
+		copy(dAtA[i:], m.Denom1)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.Denom1)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Denom0) > 0 {
+		i -= len(m.Denom0)
+		copy(dAtA[i:], m.Denom0)
+		i = encodeVarintIngest(dAtA, i, uint64(len(m.Denom0)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReceiverTakerFeeReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReceiverTakerFeeReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReceiverTakerFeeReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.IsDefault {
+		i--
+		if m.IsDefault {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.TakerFee != nil {
+		{
+			size, err := m.TakerFee.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngest(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Height != 0 {
+		i = encodeVarintIngest(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintIngest(dAtA []byte, offset int, v uint64) int {
+	offset -= sovIngest(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *PoolData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainModel)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	l = len(m.SqsModel)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	l = len(m.TickModel)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	l = len(m.TickDeltaModel)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.AprData != nil {
+		l = m.AprData.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.FeesData != nil {
+		l = m.FeesData.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	return n
+}
+
+func (m *ProcessBlockRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		n += 1 + sovIngest(uint64(m.BlockHeight))
+	}
+	l = len(m.TakerFeesMap)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if len(m.Pools) > 0 {
+		for _, e := range m.Pools {
+			l = e.Size()
+			n += 1 + l + sovIngest(uint64(l))
+		}
+	}
+	if m.BaseHeight != 0 {
+		n += 1 + sovIngest(uint64(m.BaseHeight))
+	}
+	if len(m.RemovedPoolIds) > 0 {
+		l = 0
+		for _, e := range m.RemovedPoolIds {
+			l += sovIngest(uint64(e))
+		}
+		n += 1 + sovIngest(uint64(l)) + l
+	}
+	if len(m.AprUpdates) > 0 {
+		for _, e := range m.AprUpdates {
+			l = e.Size()
+			n += 1 + l + sovIngest(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ProcessBlockReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.TickSnapshotPoolIds) > 0 {
+		l = 0
+		for _, e := range m.TickSnapshotPoolIds {
+			l += sovIngest(uint64(e))
+		}
+		n += 1 + sovIngest(uint64(l)) + l
+	}
+	if m.LastAppliedHeight != 0 {
+		n += 1 + sovIngest(uint64(m.LastAppliedHeight))
+	}
+	if m.Resync {
+		n += 2
+	}
+	return n
+}
+
+func (m *Coin) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Denom)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	l = len(m.Amount)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	return n
+}
+
+func (m *SQSPoolModel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PoolLiquidityCap)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	l = len(m.PoolLiquidityCapError)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if len(m.Balances) > 0 {
+		for _, e := range m.Balances {
+			l = e.Size()
+			n += 1 + l + sovIngest(uint64(l))
+		}
+	}
+	if len(m.PoolDenoms) > 0 {
+		for _, s := range m.PoolDenoms {
+			l = len(s)
+			n += 1 + l + sovIngest(uint64(l))
+		}
+	}
+	l = len(m.SpreadFactor)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	l = len(m.CosmwasmPoolModel)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	return n
+}
+
+func (m *TickLiquidity) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LowerTick != 0 {
+		n += 1 + sovIngest(uint64(m.LowerTick))
+	}
+	if m.UpperTick != 0 {
+		n += 1 + sovIngest(uint64(m.UpperTick))
+	}
+	l = len(m.LiquidityAmount)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	return n
+}
+
+func (m *TickModel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ticks) > 0 {
+		for _, e := range m.Ticks {
+			l = e.Size()
+			n += 1 + l + sovIngest(uint64(l))
+		}
+	}
+	if m.CurrentTickIndex != 0 {
+		n += 1 + sovIngest(uint64(m.CurrentTickIndex))
+	}
+	if m.HasNoLiquidity {
+		n += 2
+	}
+	return n
+}
+
+func (m *TickDelta) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TickIndex != 0 {
+		n += 1 + sovIngest(uint64(m.TickIndex))
+	}
+	l = len(m.LiquidityNet)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	return n
+}
+
+func (m *TickDeltaModel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ticks) > 0 {
+		for _, e := range m.Ticks {
+			l = e.Size()
+			n += 1 + l + sovIngest(uint64(l))
+		}
+	}
+	if m.CurrentTick != 0 {
+		n += 1 + sovIngest(uint64(m.CurrentTick))
+	}
+	return n
+}
+
+func (m *PoolModel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainModel)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.SqsModel != nil {
+		l = m.SqsModel.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.TickModel != nil {
+		l = m.TickModel.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.TickDeltaModel != nil {
+		l = m.TickDeltaModel.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.AprData != nil {
+		l = m.AprData.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.FeesData != nil {
+		l = m.FeesData.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	return n
+}
+
+func (m *PoolDataRange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Lower != 0 {
+		n += 9
+	}
+	if m.Upper != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *PoolAPRData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SwapFees != nil {
+		l = m.SwapFees.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.Superfluid != nil {
+		l = m.Superfluid.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.Osmosis != nil {
+		l = m.Osmosis.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.Boost != nil {
+		l = m.Boost.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.TotalApr != nil {
+		l = m.TotalApr.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.IsStale {
+		n += 2
+	}
+	if m.IsError {
+		n += 2
+	}
+	return n
+}
+
+func (m *PoolFeesData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Volume_24H != 0 {
+		n += 9
+	}
+	if m.Volume_7D != 0 {
+		n += 9
+	}
+	if m.FeesSpent_24H != 0 {
+		n += 9
+	}
+	if m.FeesSpent_7D != 0 {
+		n += 9
+	}
+	l = len(m.FeesPercentage)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.IsStale {
+		n += 2
+	}
+	if m.IsError {
+		n += 2
+	}
+	return n
+}
+
+func (m *TakerFee) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Denom0)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	l = len(m.Denom1)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	l = len(m.TakerFee)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	return n
+}
+
+func (m *ProcessBlockChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		n += 1 + sovIngest(uint64(m.BlockHeight))
+	}
+	if len(m.TakerFees) > 0 {
+		for _, e := range m.TakerFees {
+			l = e.Size()
+			n += 1 + l + sovIngest(uint64(l))
+		}
+	}
+	if len(m.Pools) > 0 {
+		for _, e := range m.Pools {
+			l = e.Size()
+			n += 1 + l + sovIngest(uint64(l))
+		}
+	}
+	if m.ChunkIndex != 0 {
+		n += 1 + sovIngest(uint64(m.ChunkIndex))
+	}
+	if m.TotalChunks != 0 {
+		n += 1 + sovIngest(uint64(m.TotalChunks))
+	}
+	if m.BaseHeight != 0 {
+		n += 1 + sovIngest(uint64(m.BaseHeight))
+	}
+	if len(m.RemovedPoolIds) > 0 {
+		l = 0
+		for _, e := range m.RemovedPoolIds {
+			l += sovIngest(uint64(e))
+		}
+		n += 1 + sovIngest(uint64(l)) + l
+	}
+	if len(m.AprUpdates) > 0 {
+		for _, e := range m.AprUpdates {
+			l = e.Size()
+			n += 1 + l + sovIngest(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *IngestAdminRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Target)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	return n
+}
+
+func (m *IngestAdminReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Sinks) > 0 {
+		for _, s := range m.Sinks {
+			l = len(s)
+			n += 1 + l + sovIngest(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PoolAPRUpdate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PoolId != 0 {
+		n += 1 + sovIngest(uint64(m.PoolId))
+	}
+	if m.AprData != nil {
+		l = m.AprData.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.FeesData != nil {
+		l = m.FeesData.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	return n
+}
+
+func (m *ReceiverPoolsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovIngest(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *ReceiverPoolsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovIngest(uint64(m.Height))
+	}
+	if len(m.Pools) > 0 {
+		for _, e := range m.Pools {
+			l = e.Size()
+			n += 1 + l + sovIngest(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ReceiverPoolRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PoolId != 0 {
+		n += 1 + sovIngest(uint64(m.PoolId))
+	}
+	if m.Height != 0 {
+		n += 1 + sovIngest(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *ReceiverPoolReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovIngest(uint64(m.Height))
+	}
+	if m.Pool != nil {
+		l = m.Pool.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	return n
+}
+
+func (m *ReceiverTakerFeeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Denom0)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	l = len(m.Denom1)
+	if l > 0 {
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovIngest(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *ReceiverTakerFeeReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovIngest(uint64(m.Height))
+	}
+	if m.TakerFee != nil {
+		l = m.TakerFee.Size()
+		n += 1 + l + sovIngest(uint64(l))
+	}
+	if m.IsDefault {
+		n += 2
+	}
+	return n
+}
+
+func sovIngest(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozIngest(x uint64) (n int) {
+	return sovIngest(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *PoolData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PoolData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PoolData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainModel", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainModel = append(m.ChainModel[:0], dAtA[iNdEx:postIndex]...)
+			if m.ChainModel == nil {
+				m.ChainModel = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SqsModel", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SqsModel = append(m.SqsModel[:0], dAtA[iNdEx:postIndex]...)
+			if m.SqsModel == nil {
+				m.SqsModel = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TickModel", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TickModel = append(m.TickModel[:0], dAtA[iNdEx:postIndex]...)
+			if m.TickModel == nil {
+				m.TickModel = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TickDeltaModel", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TickDeltaModel = append(m.TickDeltaModel[:0], dAtA[iNdEx:postIndex]...)
+			if m.TickDeltaModel == nil {
+				m.TickDeltaModel = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AprData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AprData == nil {
+				m.AprData = &PoolAPRData{}
+			}
+			if err := m.AprData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeesData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FeesData == nil {
+				m.FeesData = &PoolFeesData{}
+			}
+			if err := m.FeesData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProcessBlockRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProcessBlockRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProcessBlockRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			}
+			m.BlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TakerFeesMap", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TakerFeesMap = append(m.TakerFeesMap[:0], dAtA[iNdEx:postIndex]...)
+			if m.TakerFeesMap == nil {
+				m.TakerFeesMap = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pools", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pools = append(m.Pools, &PoolData{})
+			if err := m.Pools[len(m.Pools)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHeight", wireType)
+			}
+			m.BaseHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngest
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RemovedPoolIds = append(m.RemovedPoolIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngest
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthIngest
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthIngest
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RemovedPoolIds) == 0 {
+					m.RemovedPoolIds = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngest
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RemovedPoolIds = append(m.RemovedPoolIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedPoolIds", wireType)
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AprUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AprUpdates = append(m.AprUpdates, &PoolAPRUpdate{})
+			if err := m.AprUpdates[len(m.AprUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProcessBlockReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProcessBlockReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProcessBlockReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngest
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.TickSnapshotPoolIds = append(m.TickSnapshotPoolIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngest
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthIngest
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthIngest
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.TickSnapshotPoolIds) == 0 {
+					m.TickSnapshotPoolIds = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngest
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.TickSnapshotPoolIds = append(m.TickSnapshotPoolIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field TickSnapshotPoolIds", wireType)
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastAppliedHeight", wireType)
+			}
+			m.LastAppliedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastAppliedHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resync", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Resync = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Coin) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Coin: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Coin: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Amount = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SQSPoolModel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SQSPoolModel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SQSPoolModel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolLiquidityCap", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PoolLiquidityCap = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolLiquidityCapError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PoolLiquidityCapError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Balances", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Balances = append(m.Balances, &Coin{})
+			if err := m.Balances[len(m.Balances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolDenoms", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PoolDenoms = append(m.PoolDenoms, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpreadFactor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SpreadFactor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CosmwasmPoolModel", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CosmwasmPoolModel = append(m.CosmwasmPoolModel[:0], dAtA[iNdEx:postIndex]...)
+			if m.CosmwasmPoolModel == nil {
+				m.CosmwasmPoolModel = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TickLiquidity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TickLiquidity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TickLiquidity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LowerTick", wireType)
+			}
+			m.LowerTick = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LowerTick |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpperTick", wireType)
+			}
+			m.UpperTick = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UpperTick |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LiquidityAmount", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LiquidityAmount = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TickModel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TickModel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TickModel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ticks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ticks = append(m.Ticks, &TickLiquidity{})
+			if err := m.Ticks[len(m.Ticks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrentTickIndex", wireType)
+			}
+			m.CurrentTickIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CurrentTickIndex |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasNoLiquidity", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HasNoLiquidity = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TickDelta) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TickDelta: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TickDelta: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TickIndex", wireType)
+			}
+			m.TickIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TickIndex |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LiquidityNet", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LiquidityNet = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TickDeltaModel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TickDeltaModel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TickDeltaModel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ticks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ticks = append(m.Ticks, &TickDelta{})
+			if err := m.Ticks[len(m.Ticks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrentTick", wireType)
+			}
+			m.CurrentTick = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CurrentTick |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PoolModel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PoolModel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PoolModel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainModel", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainModel = append(m.ChainModel[:0], dAtA[iNdEx:postIndex]...)
+			if m.ChainModel == nil {
+				m.ChainModel = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SqsModel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SqsModel == nil {
+				m.SqsModel = &SQSPoolModel{}
+			}
+			if err := m.SqsModel.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TickModel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TickModel == nil {
+				m.TickModel = &TickModel{}
+			}
+			if err := m.TickModel.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TickDeltaModel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TickDeltaModel == nil {
+				m.TickDeltaModel = &TickDeltaModel{}
+			}
+			if err := m.TickDeltaModel.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AprData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AprData == nil {
+				m.AprData = &PoolAPRData{}
+			}
+			if err := m.AprData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeesData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FeesData == nil {
+				m.FeesData = &PoolFeesData{}
+			}
+			if err := m.FeesData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PoolDataRange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PoolDataRange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PoolDataRange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lower", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Lower = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upper", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Upper = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PoolAPRData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PoolAPRData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PoolAPRData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SwapFees", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SwapFees == nil {
+				m.SwapFees = &PoolDataRange{}
+			}
+			if err := m.SwapFees.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Superfluid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Superfluid == nil {
+				m.Superfluid = &PoolDataRange{}
+			}
+			if err := m.Superfluid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Osmosis", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Osmosis == nil {
+				m.Osmosis = &PoolDataRange{}
+			}
+			if err := m.Osmosis.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Boost", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Boost == nil {
+				m.Boost = &PoolDataRange{}
+			}
+			if err := m.Boost.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalApr", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TotalApr == nil {
+				m.TotalApr = &PoolDataRange{}
+			}
+			if err := m.TotalApr.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsStale", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsStale = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsError", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsError = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PoolFeesData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PoolFeesData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PoolFeesData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Volume_24H", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Volume_24H = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Volume_7D", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Volume_7D = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeesSpent_24H", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.FeesSpent_24H = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeesSpent_7D", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.FeesSpent_7D = float64(math.Float64frombits(v))
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeesPercentage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FeesPercentage = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsStale", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsStale = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsError", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsError = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TakerFee) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TakerFee: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TakerFee: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom0", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denom0 = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom1", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denom1 = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TakerFee", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TakerFee = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProcessBlockChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProcessBlockChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProcessBlockChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			}
+			m.BlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TakerFees", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TakerFees = append(m.TakerFees, &TakerFee{})
+			if err := m.TakerFees[len(m.TakerFees)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pools", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pools = append(m.Pools, &PoolModel{})
+			if err := m.Pools[len(m.Pools)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkIndex", wireType)
+			}
+			m.ChunkIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkIndex |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalChunks", wireType)
+			}
+			m.TotalChunks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalChunks |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHeight", wireType)
+			}
+			m.BaseHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngest
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RemovedPoolIds = append(m.RemovedPoolIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngest
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthIngest
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthIngest
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RemovedPoolIds) == 0 {
+					m.RemovedPoolIds = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngest
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RemovedPoolIds = append(m.RemovedPoolIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedPoolIds", wireType)
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AprUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AprUpdates = append(m.AprUpdates, &PoolAPRUpdate{})
+			if err := m.AprUpdates[len(m.AprUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IngestAdminRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IngestAdminRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IngestAdminRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Target = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IngestAdminReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IngestAdminReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IngestAdminReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sinks", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sinks = append(m.Sinks, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PoolAPRUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PoolAPRUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PoolAPRUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
+			}
+			m.PoolId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PoolId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AprData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AprData == nil {
+				m.AprData = &PoolAPRData{}
+			}
+			if err := m.AprData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeesData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FeesData == nil {
+				m.FeesData = &PoolFeesData{}
+			}
+			if err := m.FeesData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReceiverPoolsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReceiverPoolsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReceiverPoolsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReceiverPoolsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReceiverPoolsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReceiverPoolsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pools", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pools = append(m.Pools, &PoolModel{})
+			if err := m.Pools[len(m.Pools)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
 
-func (m *ProcessBlockReply) Size() (n int) {
-	if m == nil {
-		return 0
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	var l int
-	_ = l
-	return n
-}
-
-func sovIngest(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozIngest(x uint64) (n int) {
-	return sovIngest(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *PoolData) Unmarshal(dAtA []byte) error {
+func (m *ReceiverPoolRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -521,17 +7349,17 @@ func (m *PoolData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PoolData: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReceiverPoolRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PoolData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReceiverPoolRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChainModel", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
 			}
-			var byteLen int
+			m.PoolId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngest
@@ -541,31 +7369,16 @@ func (m *PoolData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.PoolId |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthIngest
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthIngest
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ChainModel = append(m.ChainModel[:0], dAtA[iNdEx:postIndex]...)
-			if m.ChainModel == nil {
-				m.ChainModel = []byte{}
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SqsModel", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			var byteLen int
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngest
@@ -575,31 +7388,85 @@ func (m *PoolData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Height |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthIngest
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngest(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIngest
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SqsModel = append(m.SqsModel[:0], dAtA[iNdEx:postIndex]...)
-			if m.SqsModel == nil {
-				m.SqsModel = []byte{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReceiverPoolReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngest
 			}
-			iNdEx = postIndex
-		case 3:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReceiverPoolReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReceiverPoolReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TickModel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngest
@@ -609,24 +7476,26 @@ func (m *PoolData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthIngest
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthIngest
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TickModel = append(m.TickModel[:0], dAtA[iNdEx:postIndex]...)
-			if m.TickModel == nil {
-				m.TickModel = []byte{}
+			if m.Pool == nil {
+				m.Pool = &PoolModel{}
+			}
+			if err := m.Pool.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -650,7 +7519,7 @@ func (m *PoolData) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProcessBlockRequest) Unmarshal(dAtA []byte) error {
+func (m *ReceiverTakerFeeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -673,17 +7542,17 @@ func (m *ProcessBlockRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProcessBlockRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReceiverTakerFeeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProcessBlockRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReceiverTakerFeeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom0", wireType)
 			}
-			m.BlockHeight = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngest
@@ -693,16 +7562,29 @@ func (m *ProcessBlockRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BlockHeight |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denom0 = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TakerFeesMap", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom1", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngest
@@ -712,31 +7594,29 @@ func (m *ProcessBlockRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthIngest
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthIngest
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TakerFeesMap = append(m.TakerFeesMap[:0], dAtA[iNdEx:postIndex]...)
-			if m.TakerFeesMap == nil {
-				m.TakerFeesMap = []byte{}
-			}
+			m.Denom1 = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pools", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			var msglen int
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngest
@@ -746,26 +7626,11 @@ func (m *ProcessBlockRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Height |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthIngest
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthIngest
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Pools = append(m.Pools, &PoolData{})
-			if err := m.Pools[len(m.Pools)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIngest(dAtA[iNdEx:])
@@ -787,7 +7652,7 @@ func (m *ProcessBlockRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProcessBlockReply) Unmarshal(dAtA []byte) error {
+func (m *ReceiverTakerFeeReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -810,12 +7675,87 @@ func (m *ProcessBlockReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProcessBlockReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReceiverTakerFeeReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProcessBlockReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReceiverTakerFeeReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TakerFee", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngest
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TakerFee == nil {
+				m.TakerFee = &TakerFee{}
+			}
+			if err := m.TakerFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsDefault", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsDefault = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIngest(dAtA[iNdEx:])
@@ -0,0 +1,14 @@
+#!/usr/bin/env bash
+
+# Generates the Go types of the indexer messages from the protobuf schema embedded in ingest/indexer/encoding.
+
+set -eo pipefail
+
+echo "Generating indexer proto code"
+buf generate \
+  --template '{"version":"v1","plugins":[{"name":"gocosmos","out":".","opt":"plugins=grpc,Mgoogle/protobuf/timestamp.proto=github.com/cosmos/gogoproto/types"}]}' \
+  ingest/indexer/encoding/schemas
+
+# move proto files to the right places
+cp -r github.com/osmosis-labs/osmosis/v30/* ./
+rm -rf github.com
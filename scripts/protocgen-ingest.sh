@@ -0,0 +1,14 @@
+#!/usr/bin/env bash
+
+# Generates the Go types of the SQS ingest and ingest admin services from the protobuf schema in ingest/types/proto.
+
+set -eo pipefail
+
+echo "Generating ingest proto code"
+buf generate \
+  --template '{"version":"v1","plugins":[{"name":"gocosmos","out":".","opt":"plugins=grpc"}]}' \
+  ingest/types/proto
+
+# move proto files to the right places
+cp -r github.com/osmosis-labs/osmosis/v30/* ./
+rm -rf github.com